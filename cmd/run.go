@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"flag"
@@ -11,7 +12,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/peterbourgon/ff/v3/ffcli"
+
 	"github.com/tamtom/play-console-cli/internal/audit"
+	"github.com/tamtom/play-console-cli/internal/cli/schema"
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
 	"github.com/tamtom/play-console-cli/internal/cli/shared/errfmt"
 )
@@ -33,12 +37,22 @@ func Run(args []string, versionInfo string) int {
 
 	// Parse flags and subcommands
 	if err := root.Parse(args); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, shared.MaskErrorText(err.Error()))
 		return ExitCodeFromError(err)
 	}
 
 	ctx, err := rt.ApplyRootContext(ctx)
 	if err != nil {
+		fmt.Fprintln(os.Stderr, shared.MaskErrorText(errfmt.FormatStderr(err)))
+		return ExitUsage
+	}
+
+	if rt.RootFlags != nil && rt.RootFlags.Schema != nil && strings.TrimSpace(*rt.RootFlags.Schema) != "" {
+		return printSchema(*rt.RootFlags.Schema)
+	}
+
+	if err := shared.ApplyConfiguredOutputDefaults(selectedCommand(root, args).FlagSet); err != nil {
+		fmt.Fprintln(os.Stderr, shared.MaskErrorText(errfmt.FormatStderr(err)))
 		return ExitUsage
 	}
 
@@ -49,10 +63,14 @@ func Run(args []string, versionInfo string) int {
 	commandName := getCommandName(args)
 
 	// Execute
-	runErr := root.Run(ctx)
+	runErr := shared.ExplainTimeout(root.Run(ctx))
 
 	elapsed := time.Since(startTime)
 
+	if tracer := shared.TracerFromContext(ctx); tracer != nil {
+		tracer.Report(os.Stderr)
+	}
+
 	logAudit(commandName, args, runErr, elapsed)
 
 	// Write JUnit report if requested
@@ -72,7 +90,11 @@ func Run(args []string, versionInfo string) int {
 			return ExitUsage
 		}
 		if !shared.IsReportedError(runErr) {
-			fmt.Fprintln(os.Stderr, errfmt.FormatStderr(runErr))
+			if jsonErrorsRequested(root, args) {
+				_ = shared.WriteErrorJSON(os.Stderr, runErr)
+			} else {
+				fmt.Fprintln(os.Stderr, shared.MaskErrorText(errfmt.FormatStderr(runErr)))
+			}
 		}
 		return ExitCodeFromError(runErr)
 	}
@@ -80,11 +102,103 @@ func Run(args []string, versionInfo string) int {
 	return ExitSuccess
 }
 
+// printSchema writes the output schema for the named command to stdout.
+func printSchema(command string) int {
+	s, err := schema.ForCommand(command)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, errfmt.FormatStderr(err))
+		return ExitUsage
+	}
+	// Encoded directly so --fields projection never trims the schema.
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		fmt.Fprintln(os.Stderr, errfmt.FormatStderr(err))
+		return ExitError
+	}
+	return ExitSuccess
+}
+
 // isVersionOnlyInvocation returns true if the args are exactly ["--version"].
 func isVersionOnlyInvocation(args []string) bool {
 	return len(args) == 1 && (args[0] == "--version" || args[0] == "-version")
 }
 
+// jsonErrorsRequested reports whether the command selected by args was asked
+// for JSON output, either with --output json or GPLAY_DEFAULT_OUTPUT=json.
+// Commands without an --output flag always get plain-text errors.
+func jsonErrorsRequested(root *ffcli.Command, args []string) bool {
+	cmd := selectedCommand(root, args)
+	if cmd.FlagSet == nil || cmd.FlagSet.Lookup("output") == nil {
+		return false
+	}
+	explicit := false
+	cmd.FlagSet.Visit(func(f *flag.Flag) {
+		if f.Name == "output" {
+			explicit = true
+		}
+	})
+	if explicit {
+		return strings.EqualFold(strings.TrimSpace(cmd.FlagSet.Lookup("output").Value.String()), "json")
+	}
+	return shared.OutputFormatFromEnv() == "json"
+}
+
+// selectedCommand returns the subcommand args select, or root. Like ffcli,
+// each level's flags (and their values) are skipped before the next arg is
+// matched against subcommand names; descent stops at the first positional
+// arg that is not a subcommand, or at a flag the level does not define.
+func selectedCommand(root *ffcli.Command, args []string) *ffcli.Command {
+	cmd := root
+	for {
+		rest, ok := skipFlags(cmd.FlagSet, args)
+		if !ok || len(rest) == 0 {
+			return cmd
+		}
+		var next *ffcli.Command
+		for _, sub := range cmd.Subcommands {
+			if sub.Name == rest[0] {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			return cmd
+		}
+		cmd, args = next, rest[1:]
+	}
+}
+
+// skipFlags drops the leading flags defined on fs, with their values, and
+// returns the remaining args. It reports false at a flag fs does not define.
+func skipFlags(fs *flag.FlagSet, args []string) ([]string, bool) {
+	for len(args) > 0 {
+		arg := args[0]
+		if arg == "--" {
+			return args[1:], true
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			return args, true
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if fs == nil {
+			return args, false
+		}
+		f := fs.Lookup(name)
+		if f == nil {
+			return args, false
+		}
+		args = args[1:]
+		if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); hasValue || (ok && boolFlag.IsBoolFlag()) {
+			continue
+		}
+		if len(args) > 0 {
+			args = args[1:]
+		}
+	}
+	return args, true
+}
+
 // getCommandName extracts a human-readable command name from the args.
 func getCommandName(args []string) string {
 	var parts []string
@@ -118,7 +232,7 @@ func logAudit(commandName string, args []string, runErr error, elapsed time.Dura
 	}
 	if runErr != nil && !errors.Is(runErr, flag.ErrHelp) {
 		entry.Status = "error"
-		entry.Error = runErr.Error()
+		entry.Error = shared.MaskErrorText(runErr.Error())
 	}
 	_ = audit.Write(entry)
 }
@@ -191,8 +305,8 @@ func writeJUnitReport(reportFile, commandName string, runErr error, elapsed time
 			Message string `xml:"message,attr"`
 			Text    string `xml:",chardata"`
 		}{
-			Message: runErr.Error(),
-			Text:    runErr.Error(),
+			Message: shared.MaskErrorText(runErr.Error()),
+			Text:    shared.MaskErrorText(runErr.Error()),
 		}
 	}
 
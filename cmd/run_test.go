@@ -2,8 +2,16 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"flag"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
 )
 
 func TestRun_VersionFlag(t *testing.T) {
@@ -52,6 +60,35 @@ func TestRun_UnknownCommand(t *testing.T) {
 	}
 }
 
+func TestRun_AppliesConfiguredOutputDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"default_output":"yaml"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GPLAY_CONFIG_PATH", path)
+	t.Setenv("GPLAY_DEFAULT_OUTPUT", "")
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	code := Run([]string{"validate", "phases", "--json", `[{"duration":"P7D","recurrenceCount":1,"regionalConfigs":[{"regionCode":"US","free":{}}]}]`}, "1.0.0")
+
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		t.Fatalf("reading pipe: %v", err)
+	}
+	if code != ExitSuccess {
+		t.Fatalf("expected exit code 0, got %d: %s", code, buf.String())
+	}
+	if !strings.Contains(buf.String(), "valid: true") {
+		t.Fatalf("expected YAML output from default_output, got %q", buf.String())
+	}
+}
+
 func TestIsVersionOnlyInvocation(t *testing.T) {
 	tests := []struct {
 		name string
@@ -76,3 +113,94 @@ func TestIsVersionOnlyInvocation(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONErrorsRequested(t *testing.T) {
+	newTree := func() *ffcli.Command {
+		leafFS := flag.NewFlagSet("tracks list", flag.ContinueOnError)
+		leafFS.String("output", "json", "")
+		leafFS.String("package", "", "")
+		plainFS := flag.NewFlagSet("tracks open", flag.ContinueOnError)
+		noop := func(context.Context, []string) error { return nil }
+		return &ffcli.Command{
+			Name:    "gplay",
+			FlagSet: flag.NewFlagSet("gplay", flag.ContinueOnError),
+			Subcommands: []*ffcli.Command{{
+				Name:    "tracks",
+				FlagSet: flag.NewFlagSet("tracks", flag.ContinueOnError),
+				Subcommands: []*ffcli.Command{
+					{Name: "list", FlagSet: leafFS, Exec: noop},
+					{Name: "open", FlagSet: plainFS, Exec: noop},
+				},
+			}},
+		}
+	}
+
+	tests := []struct {
+		args []string
+		env  string
+		want bool
+	}{
+		{[]string{"tracks", "list", "--output", "json"}, "", true},
+		{[]string{"tracks", "list", "--output", "table"}, "json", false},
+		{[]string{"tracks", "list", "--package", "com.example.app"}, "", false},
+		{[]string{"tracks", "list"}, "json", true},
+		{[]string{"tracks", "open"}, "json", false},
+	}
+	for _, tt := range tests {
+		t.Setenv("GPLAY_DEFAULT_OUTPUT", tt.env)
+		root := newTree()
+		if err := root.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		if got := jsonErrorsRequested(root, tt.args); got != tt.want {
+			t.Errorf("args %v env %q: got %v, want %v", tt.args, tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestSelectedCommand_SkipsFlagValues(t *testing.T) {
+	rootFS := flag.NewFlagSet("gplay", flag.ContinueOnError)
+	rootFS.String("profile", "", "")
+	rootFS.Bool("pretty", false, "")
+	listFS := flag.NewFlagSet("tracks list", flag.ContinueOnError)
+	listFS.String("track", "", "")
+	list := &ffcli.Command{Name: "list", FlagSet: listFS}
+	tracks := &ffcli.Command{Name: "tracks", FlagSet: flag.NewFlagSet("tracks", flag.ContinueOnError), Subcommands: []*ffcli.Command{list}}
+	root := &ffcli.Command{Name: "gplay", FlagSet: rootFS, Subcommands: []*ffcli.Command{tracks}}
+
+	tests := []struct {
+		args []string
+		want *ffcli.Command
+	}{
+		{[]string{"tracks", "list"}, list},
+		{[]string{"--profile", "tracks"}, root},
+		{[]string{"--profile=ci", "--pretty", "tracks", "list"}, list},
+		{[]string{"--profile", "ci", "tracks", "list", "--track", "list"}, list},
+		{[]string{"tracks", "--track", "list"}, tracks},
+		{[]string{"tracks", "extra", "list"}, tracks},
+	}
+	for _, tt := range tests {
+		if got := selectedCommand(root, tt.args); got != tt.want {
+			t.Errorf("args %v: got %q, want %q", tt.args, got.Name, tt.want.Name)
+		}
+	}
+}
+
+func TestWriteJUnitReport_MasksSecrets(t *testing.T) {
+	t.Setenv("GPLAY_MASK_SECRETS_IN_ERRORS", "")
+	reportFile := filepath.Join(t.TempDir(), "report.xml")
+	runErr := errors.New("request failed: Authorization: Bearer ya29.topsecret")
+	if err := writeJUnitReport(reportFile, "gplay tracks list", runErr, time.Second); err != nil {
+		t.Fatalf("writeJUnitReport: %v", err)
+	}
+	data, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "topsecret") {
+		t.Fatalf("token leaked into JUnit report: %s", data)
+	}
+	if !strings.Contains(string(data), "Bearer ***") {
+		t.Fatalf("expected masked failure message, got %s", data)
+	}
+}
@@ -8,6 +8,7 @@ require (
 	golang.org/x/oauth2 v0.36.0
 	golang.org/x/term v0.42.0
 	google.golang.org/api v0.276.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -42,7 +42,7 @@ func UploadCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	filePath := fs.String("file", "", "Path to .apk file")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -58,6 +58,9 @@ func UploadCommand() *ffcli.Command {
 			if strings.TrimSpace(*filePath) == "" {
 				return fmt.Errorf("--file is required")
 			}
+			if err := shared.CheckUploadFile(*filePath, ".apk", os.Stderr); err != nil {
+				return err
+			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
 				return err
@@ -83,6 +86,11 @@ func UploadCommand() *ffcli.Command {
 			if err != nil {
 				return shared.WrapGoogleAPIError("failed to upload APK", err)
 			}
+			if resp.Binary != nil {
+				fmt.Fprintf(os.Stderr, "Uploaded APK version code %d (sha256 %s)\n", resp.VersionCode, resp.Binary.Sha256)
+			} else {
+				fmt.Fprintf(os.Stderr, "Uploaded APK version code %d\n", resp.VersionCode)
+			}
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
@@ -92,7 +100,7 @@ func ListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("apks list", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -134,8 +142,8 @@ func AddExternallyHostedCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("apks addexternallyhosted", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	jsonFlag := fs.String("json", "", "ExternallyHostedApk JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "ExternallyHostedApk JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -3,6 +3,9 @@ package apps
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +14,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
 	"github.com/tamtom/play-console-cli/internal/reportingclient"
 )
 
@@ -104,6 +108,81 @@ func TestListCommand_PaginatesReportingAppsSearch(t *testing.T) {
 	}
 }
 
+func TestListCommand_BucketIDListsPackagesFromStats(t *testing.T) {
+	objects := map[string][]string{
+		"stats/installs/": {
+			"stats/installs/installs_com.example.one_202401_overview.csv",
+			"stats/installs/installs_com.example.one_202401_country.csv",
+			"stats/installs/installs_com.example.my_app_202402_overview.csv",
+		},
+		"stats/ratings/": {
+			"stats/ratings/ratings_com.example.one_202401_overview.csv",
+			"stats/ratings/README.txt",
+		},
+	}
+	var gotBucket string
+	installMockGCSService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotBucket = strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/storage/v1/b/"), "/o")
+		type item struct {
+			Name string `json:"name"`
+		}
+		var items []item
+		for _, name := range objects[r.URL.Query().Get("prefix")] {
+			items = append(items, item{Name: name})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"kind": "storage#objects", "items": items})
+	})
+
+	cmd := ListCommand(nil)
+	if err := cmd.FlagSet.Parse([]string{"--bucket-id", "12345"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureAppsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotBucket != "pubsite_prod_rev_12345" {
+		t.Fatalf("bucket = %q, want pubsite_prod_rev_12345", gotBucket)
+	}
+	var result ReportPackages
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, stdout)
+	}
+	want := []string{"com.example.my_app", "com.example.one"}
+	if strings.Join(result.Packages, ",") != strings.Join(want, ",") {
+		t.Fatalf("packages = %v, want %v", result.Packages, want)
+	}
+}
+
+func TestListCommand_BucketIDRejectsPaginate(t *testing.T) {
+	cmd := ListCommand(nil)
+	if err := cmd.FlagSet.Parse([]string{"--bucket-id", "12345", "--paginate"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func installMockGCSService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newGCSService
+	newGCSService = func(ctx context.Context) (*gcsclient.Service, error) {
+		return gcsclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/storage/v1/")
+	}
+	t.Cleanup(func() {
+		newGCSService = original
+	})
+}
+
 func installMockReportingService(t *testing.T, handler http.HandlerFunc) {
 	t.Helper()
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
@@ -11,25 +13,65 @@ import (
 
 	cliruntime "github.com/tamtom/play-console-cli/internal/cli/runtime"
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
 	"github.com/tamtom/play-console-cli/internal/reportingclient"
 )
 
-var newReportingService = reportingclient.NewService
+var (
+	newReportingService = reportingclient.NewService
+	newGCSService       = gcsclient.NewService
+)
+
+// statsReportPrefixes are the report bucket folders whose object names carry
+// a package name, e.g. stats/installs/installs_com.example.app_202401_overview.csv.
+var statsReportPrefixes = []string{
+	"stats/installs/",
+	"stats/ratings/",
+	"stats/crashes/",
+	"stats/store_performance/",
+}
+
+// statsObjectPattern extracts the package name from a stats report file name.
+// Package names may contain underscores, so the match stops at the first
+// _YYYYMM_ segment.
+var statsObjectPattern = regexp.MustCompile(`^(?:installs|ratings|crashes|store_performance)_(.+?)_\d{6}_`)
+
+// ReportPackages is the output of apps list --bucket-id.
+type ReportPackages struct {
+	Bucket   string   `json:"bucket"`
+	Packages []string `json:"packages"`
+}
 
 // ListCommand returns the apps list subcommand.
 func ListCommand(rt *cliruntime.Runtime) *ffcli.Command {
 	fs := flag.NewFlagSet("apps list", flag.ExitOnError)
 	pageSize := fs.Int("page-size", 50, "Page size (1-1000)")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	bucketID := fs.String("bucket-id", "", "List packages found in this report bucket's stats files instead of calling the Reporting API (ID or gs:// URI)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
 		ShortUsage: "gplay apps list [flags]",
 		ShortHelp:  "List all apps accessible by the service account.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `List all apps accessible by the service account.
+
+By default apps come from the Play Developer Reporting API, which requires
+the Reporting API to be enabled for the credential's project.
+
+With --bucket-id, packages are instead inferred from the names of the
+monthly stats reports (installs, ratings, crashes, store performance) in
+the developer's Cloud Storage report bucket, and a sorted, deduplicated
+list is printed. This only needs read access to the bucket, but an app
+appears only once Play has exported stats for it. Find the bucket ID in
+Play Console > Download reports > Copy Cloud Storage URI.
+
+Examples:
+  gplay apps list --paginate
+  gplay apps list --bucket-id gs://pubsite_prod_rev_01234567890987654321/`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
@@ -37,6 +79,12 @@ func ListCommand(rt *cliruntime.Runtime) *ffcli.Command {
 			if *pageSize < 1 || *pageSize > 1000 {
 				return fmt.Errorf("--page-size must be between 1 and 1000")
 			}
+			if strings.TrimSpace(*bucketID) != "" {
+				if *paginate {
+					return shared.UsageError("--paginate cannot be used with --bucket-id")
+				}
+				return listReportPackages(ctx, gcsclient.ParseBucket(*bucketID), *outputFlag, *pretty)
+			}
 
 			service, err := newReportingService(ctx)
 			if err != nil {
@@ -75,3 +123,47 @@ func ListCommand(rt *cliruntime.Runtime) *ffcli.Command {
 		},
 	}
 }
+
+// listReportPackages prints the packages that have stats reports in bucket.
+func listReportPackages(ctx context.Context, bucket, outputFormat string, pretty bool) error {
+	svc, err := newGCSService(ctx)
+	if err != nil {
+		return fmt.Errorf("creating service: %w", err)
+	}
+
+	ctx, cancel := shared.ContextWithTimeout(ctx, svc.Cfg)
+	defer cancel()
+
+	seen := map[string]bool{}
+	result := &ReportPackages{Bucket: bucket, Packages: []string{}}
+	for _, prefix := range statsReportPrefixes {
+		objects, err := svc.ListObjects(ctx, bucket, prefix)
+		if err != nil {
+			return fmt.Errorf("list %s in bucket %s: %w", prefix, bucket, err)
+		}
+		for _, obj := range objects {
+			pkg := packageFromStatsObject(obj.Name)
+			if pkg == "" || seen[pkg] {
+				continue
+			}
+			seen[pkg] = true
+			result.Packages = append(result.Packages, pkg)
+		}
+	}
+	sort.Strings(result.Packages)
+	return shared.PrintOutput(result, outputFormat, pretty)
+}
+
+// packageFromStatsObject returns the package name in a stats report object
+// name, or "" when the name does not follow the report naming scheme.
+func packageFromStatsObject(name string) string {
+	base := name
+	if idx := strings.LastIndex(base, "/"); idx >= 0 {
+		base = base[idx+1:]
+	}
+	m := statsObjectPattern.FindStringSubmatch(base)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
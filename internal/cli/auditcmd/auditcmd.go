@@ -49,7 +49,7 @@ func listCommand() *ffcli.Command {
 	limit := fs.Int("limit", 50, "Maximum number of entries to show (0 = all)")
 	since := fs.String("since", "", "Only include entries newer than this (RFC3339 or duration like 24h)")
 	status := fs.String("status", "", "Filter by status (ok, error, started)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -92,7 +92,7 @@ func searchCommand() *ffcli.Command {
 	command := fs.String("command", "", "Substring to match against command name")
 	status := fs.String("status", "", "Filter by status")
 	limit := fs.Int("limit", 100, "Maximum number of results")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
+	"golang.org/x/term"
 
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
 	"github.com/tamtom/play-console-cli/internal/config"
@@ -31,7 +32,10 @@ func AuthCommand() *ffcli.Command {
 			AuthLoginCommand(),
 			AuthSwitchCommand(),
 			AuthLogoutCommand(),
+			AuthExportCommand(),
+			AuthImportCommand(),
 			AuthStatusCommand(),
+			AuthWhoamiCommand(),
 			AuthDoctorCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
@@ -99,63 +103,127 @@ func AuthInitCommand() *ffcli.Command {
 func AuthLoginCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("auth login", flag.ExitOnError)
 	profile := fs.String("profile", "default", "Profile name")
-	serviceAccount := fs.String("service-account", "", "Path to service account JSON (required)")
+	serviceAccount := fs.String("service-account", "", "Path to service account JSON (required unless --no-browser)")
+	noBrowser := fs.Bool("no-browser", false, "Log in with an OAuth client by pasting an authorization code instead of using a service account")
+	clientID := fs.String("client-id", "", "OAuth client ID for --no-browser (or GPLAY_OAUTH_CLIENT_ID)")
+	clientSecret := fs.String("client-secret", "", "OAuth client secret for --no-browser (or GPLAY_OAUTH_CLIENT_SECRET)")
+	tokenPath := fs.String("token-path", "", "Where --no-browser stores the OAuth token (default: tokens/<profile>.json next to the config)")
 	setDefault := fs.Bool("set-default", true, "Set as default profile")
 	local := fs.Bool("local", false, "Write to local repo config")
+	packageName := fs.String("package", "", "Default package name for this profile")
+	developerID := fs.String("developer", "", "Default developer ID for this profile")
 
 	return &ffcli.Command{
 		Name:       "login",
-		ShortUsage: "gplay auth login --service-account <path> [flags]",
+		ShortUsage: "gplay auth login --service-account <path> [flags] | --no-browser --client-id <id> --client-secret <secret> [flags]",
 		ShortHelp:  "Authenticate with Google Play Console using a service account.",
 		LongHelp: `Authenticate with Google Play Console using a service account.
 
 Service accounts are required for the Google Play Android Developer API.
 See README.md for setup instructions.
 
+--package and --developer store profile defaults that are used instead of
+the global package_name/developer_id while the profile is active. Logging
+in again without them keeps the profile's existing defaults.
+
+--no-browser logs in as a user through an OAuth client instead, for
+headless machines: it prints a consent URL to open in a browser anywhere,
+then waits for the authorization code (or the full URL the browser was
+redirected to) on stdin. The token is saved to --token-path and the profile
+is stored with type oauth. Set GPLAY_OAUTH_REDIRECT_URI when the client uses
+a redirect other than urn:ietf:wg:oauth:2.0:oob.
+
 Examples:
   gplay auth login --service-account /path/to/key.json
   gplay auth login --service-account key.json --profile work
-  gplay auth login --service-account key.json --local`,
+  gplay auth login --service-account key.json --profile work --package com.work.app --developer 1234567890
+  gplay auth login --service-account key.json --local
+  gplay auth login --no-browser --client-id ID.apps.googleusercontent.com --client-secret SECRET --profile me`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if strings.TrimSpace(*profile) == "" {
 				return fmt.Errorf("--profile is required")
 			}
-			if strings.TrimSpace(*serviceAccount) == "" {
+			if *noBrowser && strings.TrimSpace(*serviceAccount) != "" {
+				return fmt.Errorf("--no-browser cannot be combined with --service-account")
+			}
+			if !*noBrowser && strings.TrimSpace(*serviceAccount) == "" {
 				return fmt.Errorf("--service-account is required")
 			}
 
+			path, err := resolveConfigPath(*local)
+			if err != nil {
+				return err
+			}
+
 			newProfile := config.Profile{
-				Name:    *profile,
-				Type:    "service_account",
-				KeyPath: *serviceAccount,
+				Name:             *profile,
+				Type:             "service_account",
+				KeyPath:          *serviceAccount,
+				DefaultPackage:   strings.TrimSpace(*packageName),
+				DefaultDeveloper: strings.TrimSpace(*developerID),
+			}
+			if *noBrowser {
+				oauthCfg, err := newLoginOAuthConfig(*clientID, *clientSecret)
+				if err != nil {
+					return err
+				}
+				target := strings.TrimSpace(*tokenPath)
+				if target == "" {
+					if target, err = profileSecretPath(path, "tokens", *profile); err != nil {
+						return fmt.Errorf("%w; pass --token-path", err)
+					}
+				}
+				token, err := runManualOAuthFlow(ctx, oauthCfg, loginCodeInput, loginPromptOutput)
+				if err != nil {
+					return err
+				}
+				if err := writeOAuthToken(target, token, *local); err != nil {
+					return err
+				}
+				newProfile.Type = "oauth"
+				newProfile.KeyPath = ""
+				newProfile.TokenPath = target
+				newProfile.ClientID = oauthCfg.ClientID
+				newProfile.ClientSecret = oauthCfg.ClientSecret
 			}
 
 			cfg, _ := config.Load()
 			if cfg == nil {
 				cfg = &config.Config{}
 			}
+			for _, p := range cfg.Profiles {
+				if p.Name != newProfile.Name {
+					continue
+				}
+				if newProfile.DefaultPackage == "" {
+					newProfile.DefaultPackage = p.DefaultPackage
+				}
+				if newProfile.DefaultDeveloper == "" {
+					newProfile.DefaultDeveloper = p.DefaultDeveloper
+				}
+			}
 
 			cfg.Profiles = upsertProfile(cfg.Profiles, newProfile)
 			if *setDefault {
 				cfg.DefaultProfile = newProfile.Name
 			}
 
-			path, err := resolveConfigPath(*local)
-			if err != nil {
-				return err
-			}
 			if err := config.SaveAt(path, cfg); err != nil {
 				return err
 			}
 
+			printed := newProfile
+			if printed.ClientSecret != "" {
+				printed.ClientSecret = "***"
+			}
 			result := struct {
 				ConfigPath string         `json:"config_path"`
 				Profile    config.Profile `json:"profile"`
 			}{
 				ConfigPath: path,
-				Profile:    newProfile,
+				Profile:    printed,
 			}
 			return output.PrintJSON(result)
 		},
@@ -254,20 +322,34 @@ func AuthLogoutCommand() *ffcli.Command {
 
 func AuthStatusCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("auth status", flag.ExitOnError)
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	listScopes := fs.Bool("list-scopes", false, "Show the OAuth scope each feature area needs and whether the active credential has it")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "status",
-		ShortUsage: "gplay auth status [flags]",
+		ShortUsage: "gplay auth status [--list-scopes] [flags]",
 		ShortHelp:  "Show authentication status.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Show authentication status.
+
+With --list-scopes, print the OAuth scope required by each feature area
+(publishing needs androidpublisher, vitals needs playdeveloperreporting,
+reports need devstorage.read_only) and whether the active credential has it,
+with guidance on re-authorizing for missing scopes.
+
+Examples:
+  gplay auth status
+  gplay auth status --list-scopes --pretty`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
 			cfg, _ := config.Load()
+			if *listScopes {
+				return shared.PrintOutput(buildScopesReport(cfg), *outputFlag, *pretty)
+			}
 			configPath, _ := config.Path()
 			profileName := shared.ResolveProfileName(cfg)
 			result := struct {
@@ -289,10 +371,15 @@ func AuthStatusCommand() *ffcli.Command {
 	}
 }
 
+// doctorStdoutIsTerminal reports whether auth doctor writes to a terminal;
+// tests swap it out.
+var doctorStdoutIsTerminal = func() bool { return term.IsTerminal(int(os.Stdout.Fd())) }
+
 func AuthDoctorCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("auth doctor", flag.ExitOnError)
 	outputFlag := fs.String("output", "text", "Output format: text (default), json")
-	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+	shared.DeclareOutputFormats(fs, "text", "json")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output (default when stdout is a terminal; --pretty=false for compact)")
 	fix := fs.Bool("fix", false, "Attempt to auto-fix detected issues")
 	confirm := fs.Bool("confirm", false, "Required with --fix to apply changes (without it, --fix does a dry run)")
 
@@ -300,8 +387,17 @@ func AuthDoctorCommand() *ffcli.Command {
 		Name:       "doctor",
 		ShortUsage: "gplay auth doctor [flags]",
 		ShortHelp:  "Diagnose authentication configuration issues.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Diagnose authentication configuration issues.
+
+With --output json the report is indented when stdout is a terminal and
+compact when it is piped; --pretty or --pretty=false overrides this.
+
+Examples:
+  gplay auth doctor
+  gplay auth doctor --output json | jq .errors
+  gplay auth doctor --fix --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			normalized := strings.ToLower(strings.TrimSpace(*outputFlag))
 			if normalized != "text" && normalized != "json" {
@@ -310,6 +406,15 @@ func AuthDoctorCommand() *ffcli.Command {
 			if normalized != "json" && *pretty {
 				return fmt.Errorf("--pretty is only valid with JSON output")
 			}
+			explicitPretty := false
+			fs.Visit(func(f *flag.Flag) {
+				if f.Name == "pretty" {
+					explicitPretty = true
+				}
+			})
+			if normalized == "json" && !explicitPretty {
+				*pretty = doctorStdoutIsTerminal()
+			}
 			if *confirm && !*fix {
 				return fmt.Errorf("--confirm requires --fix")
 			}
@@ -368,6 +473,7 @@ func buildAuthReport() authReport {
 		report.Checks = append(report.Checks, "no profiles configured")
 	} else {
 		report.Checks = append(report.Checks, fmt.Sprintf("profiles configured: %d", len(cfg.Profiles)))
+		checkProfileCredentials(&report, cfg.Profiles)
 	}
 
 	if envAuthPresent() {
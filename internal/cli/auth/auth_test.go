@@ -3,8 +3,10 @@ package auth
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -49,7 +51,10 @@ func TestAuthCommand_SubcommandNames(t *testing.T) {
 		"login":  false,
 		"switch": false,
 		"logout": false,
+		"export": false,
+		"import": false,
 		"status": false,
+		"whoami": false,
 		"doctor": false,
 	}
 	for _, sub := range cmd.Subcommands {
@@ -151,6 +156,44 @@ func TestAuthLoginCommand_WhitespaceProfile(t *testing.T) {
 	}
 }
 
+func TestAuthLoginCommand_SeedsProfileDefaults(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("GPLAY_CONFIG_PATH", configPath)
+
+	login := func(args ...string) {
+		t.Helper()
+		cmd := AuthLoginCommand()
+		if err := cmd.FlagSet.Parse(append([]string{"--service-account", "/keys/work.json", "--profile", "work"}, args...)); err != nil {
+			t.Fatal(err)
+		}
+		oldStdout := os.Stdout
+		_, w, _ := os.Pipe()
+		os.Stdout = w
+		err := cmd.Exec(context.Background(), nil)
+		w.Close()
+		os.Stdout = oldStdout
+		if err != nil {
+			t.Fatalf("auth login: %v", err)
+		}
+	}
+
+	login("--package", "com.work.app", "--developer", "1234")
+	// Logging in again without the flags keeps the stored defaults.
+	login()
+
+	cfg, err := config.LoadAt(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Profiles) != 1 {
+		t.Fatalf("expected one profile, got %+v", cfg.Profiles)
+	}
+	p := cfg.Profiles[0]
+	if p.DefaultPackage != "com.work.app" || p.DefaultDeveloper != "1234" {
+		t.Fatalf("profile defaults not stored: %+v", p)
+	}
+}
+
 // --- auth logout ---
 
 func TestAuthLogoutCommand_Name(t *testing.T) {
@@ -313,6 +356,52 @@ func TestAuthDoctorCommand_PrettyWithText(t *testing.T) {
 	}
 }
 
+func TestAuthDoctorCommand_JSONPrettyOnTerminal(t *testing.T) {
+	t.Setenv("GPLAY_CONFIG_PATH", filepath.Join(t.TempDir(), "config.json"))
+
+	tests := []struct {
+		name     string
+		terminal bool
+		args     []string
+		indented bool
+	}{
+		{"terminal", true, []string{"--output", "json"}, true},
+		{"pipe", false, []string{"--output", "json"}, false},
+		{"terminal with --pretty=false", true, []string{"--output", "json", "--pretty=false"}, false},
+		{"pipe with --pretty", false, []string{"--output", "json", "--pretty"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := doctorStdoutIsTerminal
+			doctorStdoutIsTerminal = func() bool { return tt.terminal }
+			t.Cleanup(func() { doctorStdoutIsTerminal = original })
+
+			cmd := AuthDoctorCommand()
+			if err := cmd.FlagSet.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			old := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+			err := cmd.Exec(context.Background(), nil)
+			w.Close()
+			os.Stdout = old
+			out, _ := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("exec: %v", err)
+			}
+
+			var report authReport
+			if err := json.Unmarshal(out, &report); err != nil {
+				t.Fatalf("invalid JSON %q: %v", out, err)
+			}
+			if got := strings.Contains(string(out), "\n  \""); got != tt.indented {
+				t.Fatalf("indented = %v, want %v: %s", got, tt.indented, out)
+			}
+		})
+	}
+}
+
 // --- auth init ---
 
 func TestAuthInitCommand_Name(t *testing.T) {
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+// doctorNow is the clock used for token expiry checks.
+var doctorNow = time.Now
+
+// checkProfileCredentials validates the credential file referenced by each
+// profile and records the findings on report.
+func checkProfileCredentials(report *authReport, profiles []config.Profile) {
+	for _, p := range profiles {
+		switch strings.ToLower(strings.TrimSpace(p.Type)) {
+		case "service_account", "service-account", "serviceaccount":
+			checkServiceAccountProfile(report, p)
+		case "oauth":
+			checkOAuthProfile(report, p)
+		default:
+			report.Errors++
+			report.Checks = append(report.Checks, fmt.Sprintf("profile %s: unknown type %q", p.Name, p.Type))
+		}
+	}
+}
+
+func checkServiceAccountProfile(report *authReport, p config.Profile) {
+	if strings.TrimSpace(p.KeyPath) == "" {
+		report.Errors++
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: service account key_path is not set", p.Name))
+		return
+	}
+	data, err := os.ReadFile(p.KeyPath)
+	if err != nil {
+		report.Errors++
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: cannot read key file %s: %v", p.Name, p.KeyPath, err))
+		return
+	}
+	var key struct {
+		ClientEmail string `json:"client_email"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		report.Errors++
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: key file %s is not valid JSON: %v", p.Name, p.KeyPath, err))
+		return
+	}
+	if strings.TrimSpace(key.ClientEmail) == "" {
+		report.Errors++
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: key file %s has no client_email", p.Name, p.KeyPath))
+		return
+	}
+	report.Checks = append(report.Checks, fmt.Sprintf("profile %s: service account key OK (%s)", p.Name, key.ClientEmail))
+}
+
+func checkOAuthProfile(report *authReport, p config.Profile) {
+	if strings.TrimSpace(p.TokenPath) == "" {
+		report.Errors++
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: oauth token_path is not set", p.Name))
+		return
+	}
+	data, err := os.ReadFile(p.TokenPath)
+	if err != nil {
+		report.Errors++
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: cannot read token file %s: %v", p.Name, p.TokenPath, err))
+		return
+	}
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		report.Errors++
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: token file %s is not valid JSON: %v", p.Name, p.TokenPath, err))
+		return
+	}
+
+	hasRefresh := strings.TrimSpace(token.RefreshToken) != ""
+	hasAccess := strings.TrimSpace(token.AccessToken) != ""
+	expired := !token.Expiry.IsZero() && !token.Expiry.After(doctorNow())
+
+	switch {
+	case token.Expiry.IsZero():
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: access token has no recorded expiry", p.Name))
+	case expired:
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: access token expired at %s", p.Name, token.Expiry.UTC().Format(time.RFC3339)))
+	default:
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: access token expires at %s", p.Name, token.Expiry.UTC().Format(time.RFC3339)))
+	}
+
+	if hasRefresh {
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: refresh token present", p.Name))
+		if strings.TrimSpace(p.ClientID) == "" || strings.TrimSpace(p.ClientSecret) == "" {
+			report.Errors++
+			report.Checks = append(report.Checks, fmt.Sprintf("profile %s: client_id/client_secret missing; token cannot be refreshed", p.Name))
+		}
+		return
+	}
+
+	if !hasAccess || expired {
+		report.Errors++
+		report.Checks = append(report.Checks, fmt.Sprintf("profile %s: no usable credential (no refresh token and access token is missing or expired)", p.Name))
+		return
+	}
+	report.Warnings++
+	report.Checks = append(report.Checks, fmt.Sprintf("profile %s: no refresh token; re-run `gplay auth login` after the access token expires", p.Name))
+}
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+func writeDoctorFile(t *testing.T, name string, v interface{}) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	var data []byte
+	switch val := v.(type) {
+	case string:
+		data = []byte(val)
+	default:
+		var err error
+		data, err = json.Marshal(val)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func fixDoctorNow(t *testing.T, now time.Time) {
+	t.Helper()
+	orig := doctorNow
+	doctorNow = func() time.Time { return now }
+	t.Cleanup(func() { doctorNow = orig })
+}
+
+func hasCheck(report authReport, substr string) bool {
+	for _, c := range report.Checks {
+		if strings.Contains(c, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckProfileCredentials_ServiceAccountValid(t *testing.T) {
+	keyPath := writeDoctorFile(t, "sa.json", map[string]string{"type": "service_account", "client_email": "ci@proj.iam.gserviceaccount.com"})
+	var report authReport
+	checkProfileCredentials(&report, []config.Profile{{Name: "ci", Type: "service_account", KeyPath: keyPath}})
+
+	if report.Errors != 0 || report.Warnings != 0 {
+		t.Fatalf("expected clean report, got %+v", report)
+	}
+	if !hasCheck(report, "ci@proj.iam.gserviceaccount.com") {
+		t.Errorf("expected client_email in checks, got %v", report.Checks)
+	}
+}
+
+func TestCheckProfileCredentials_ServiceAccountProblems(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyPath func(t *testing.T) string
+		want    string
+	}{
+		{"missing key_path", func(t *testing.T) string { return "" }, "key_path is not set"},
+		{"missing file", func(t *testing.T) string { return filepath.Join(t.TempDir(), "nope.json") }, "cannot read key file"},
+		{"invalid json", func(t *testing.T) string { return writeDoctorFile(t, "sa.json", "{not json") }, "not valid JSON"},
+		{"no client_email", func(t *testing.T) string {
+			return writeDoctorFile(t, "sa.json", map[string]string{"type": "service_account"})
+		}, "no client_email"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var report authReport
+			checkProfileCredentials(&report, []config.Profile{{Name: "ci", Type: "service_account", KeyPath: tt.keyPath(t)}})
+			if report.Errors != 1 {
+				t.Errorf("Errors = %d, want 1", report.Errors)
+			}
+			if !hasCheck(report, tt.want) {
+				t.Errorf("expected check containing %q, got %v", tt.want, report.Checks)
+			}
+		})
+	}
+}
+
+func TestCheckProfileCredentials_OAuthValidWithRefresh(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fixDoctorNow(t, now)
+	tokenPath := writeDoctorFile(t, "token.json", map[string]interface{}{
+		"access_token":  "ya29.abc",
+		"refresh_token": "1//refresh",
+		"expiry":        now.Add(time.Hour).Format(time.RFC3339),
+	})
+	var report authReport
+	checkProfileCredentials(&report, []config.Profile{{Name: "me", Type: "oauth", TokenPath: tokenPath, ClientID: "id", ClientSecret: "secret"}})
+
+	if report.Errors != 0 || report.Warnings != 0 {
+		t.Fatalf("expected clean report, got %+v", report)
+	}
+	if !hasCheck(report, "expires at 2026-03-01T13:00:00Z") {
+		t.Errorf("expected expiry check, got %v", report.Checks)
+	}
+	if !hasCheck(report, "refresh token present") {
+		t.Errorf("expected refresh token check, got %v", report.Checks)
+	}
+}
+
+func TestCheckProfileCredentials_OAuthExpiredWithRefreshIsFine(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fixDoctorNow(t, now)
+	tokenPath := writeDoctorFile(t, "token.json", map[string]interface{}{
+		"access_token":  "ya29.abc",
+		"refresh_token": "1//refresh",
+		"expiry":        now.Add(-time.Hour).Format(time.RFC3339),
+	})
+	var report authReport
+	checkProfileCredentials(&report, []config.Profile{{Name: "me", Type: "oauth", TokenPath: tokenPath, ClientID: "id", ClientSecret: "secret"}})
+
+	if report.Errors != 0 {
+		t.Errorf("Errors = %d, want 0", report.Errors)
+	}
+	if !hasCheck(report, "expired at") {
+		t.Errorf("expected expired check, got %v", report.Checks)
+	}
+}
+
+func TestCheckProfileCredentials_OAuthExpiredWithoutRefresh(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fixDoctorNow(t, now)
+	tokenPath := writeDoctorFile(t, "token.json", map[string]interface{}{
+		"access_token": "ya29.abc",
+		"expiry":       now.Add(-time.Minute).Format(time.RFC3339),
+	})
+	var report authReport
+	checkProfileCredentials(&report, []config.Profile{{Name: "me", Type: "oauth", TokenPath: tokenPath}})
+
+	if report.Errors != 1 {
+		t.Errorf("Errors = %d, want 1", report.Errors)
+	}
+	if !hasCheck(report, "no usable credential") {
+		t.Errorf("expected no usable credential check, got %v", report.Checks)
+	}
+}
+
+func TestCheckProfileCredentials_OAuthNoRefreshWarns(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fixDoctorNow(t, now)
+	tokenPath := writeDoctorFile(t, "token.json", map[string]interface{}{
+		"access_token": "ya29.abc",
+		"expiry":       now.Add(time.Hour).Format(time.RFC3339),
+	})
+	var report authReport
+	checkProfileCredentials(&report, []config.Profile{{Name: "me", Type: "oauth", TokenPath: tokenPath}})
+
+	if report.Errors != 0 || report.Warnings != 1 {
+		t.Errorf("expected 0 errors and 1 warning, got %+v", report)
+	}
+}
+
+func TestCheckProfileCredentials_OAuthRefreshWithoutClient(t *testing.T) {
+	tokenPath := writeDoctorFile(t, "token.json", map[string]interface{}{"refresh_token": "1//refresh"})
+	var report authReport
+	checkProfileCredentials(&report, []config.Profile{{Name: "me", Type: "oauth", TokenPath: tokenPath}})
+
+	if report.Errors != 1 {
+		t.Errorf("Errors = %d, want 1", report.Errors)
+	}
+	if !hasCheck(report, "cannot be refreshed") {
+		t.Errorf("expected client credential check, got %v", report.Checks)
+	}
+}
+
+func TestCheckProfileCredentials_OAuthMissingTokenFile(t *testing.T) {
+	var report authReport
+	checkProfileCredentials(&report, []config.Profile{{Name: "me", Type: "oauth", TokenPath: filepath.Join(t.TempDir(), "token.json")}})
+	if report.Errors != 1 {
+		t.Errorf("Errors = %d, want 1", report.Errors)
+	}
+	if !hasCheck(report, "cannot read token file") {
+		t.Errorf("expected read error check, got %v", report.Checks)
+	}
+}
+
+func TestBuildAuthReport_IncludesCredentialChecks(t *testing.T) {
+	keyPath := writeDoctorFile(t, "sa.json", map[string]string{"client_email": "ci@proj.iam.gserviceaccount.com"})
+	cfg := &config.Config{
+		DefaultProfile: "ci",
+		Profiles: []config.Profile{
+			{Name: "ci", Type: "service_account", KeyPath: keyPath},
+			{Name: "broken", Type: "oauth"},
+		},
+	}
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := config.SaveAt(configPath, cfg); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GPLAY_CONFIG_PATH", configPath)
+
+	report := buildAuthReport()
+	if report.Errors != 1 {
+		t.Errorf("Errors = %d, want 1 (checks: %v)", report.Errors, report.Checks)
+	}
+	if !hasCheck(report, "service account key OK") {
+		t.Errorf("expected service account check, got %v", report.Checks)
+	}
+	if !hasCheck(report, "token_path is not set") {
+		t.Errorf("expected oauth check, got %v", report.Checks)
+	}
+}
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+// profileExportVersion is the format version written by auth export.
+const profileExportVersion = 1
+
+// profileExport is the file written by auth export and read by auth import.
+// KeyData and TokenData hold the base64 contents of the profile's key and
+// token files when --inline-secrets is set.
+type profileExport struct {
+	Version   int            `json:"version"`
+	Profile   config.Profile `json:"profile"`
+	KeyData   string         `json:"key_data,omitempty"`
+	TokenData string         `json:"token_data,omitempty"`
+}
+
+func AuthExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("auth export", flag.ExitOnError)
+	profile := fs.String("profile", "", "Profile name")
+	out := fs.String("out", "", "File to write the exported profile to")
+	inline := fs.Bool("inline-secrets", false, "Embed the key and token file contents (base64) so the export works without them")
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "gplay auth export --profile <name> --out <file> [--inline-secrets]",
+		ShortHelp:  "Export an auth profile to a file for another machine.",
+		LongHelp: `Export an auth profile to a file for another machine.
+
+The export holds the profile as stored in config.json, including any OAuth
+client secret. With --inline-secrets it also embeds the service account
+key or OAuth token file, so the profile works on a machine without those
+files. Treat the export like a password: it is written readable only by
+the current user, and should be deleted once imported.
+
+Examples:
+  gplay auth export --profile work --out work-profile.json
+  gplay auth export --profile ci --out ci-profile.json --inline-secrets`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if strings.TrimSpace(*profile) == "" {
+				return fmt.Errorf("--profile is required")
+			}
+			if strings.TrimSpace(*out) == "" {
+				return fmt.Errorf("--out is required")
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			var found *config.Profile
+			for i := range cfg.Profiles {
+				if cfg.Profiles[i].Name == *profile {
+					found = &cfg.Profiles[i]
+					break
+				}
+			}
+			if found == nil {
+				return fmt.Errorf("profile not found: %s", *profile)
+			}
+
+			export := profileExport{Version: profileExportVersion, Profile: *found}
+			if *inline {
+				if export.KeyData, err = readSecretFile(found.KeyPath); err != nil {
+					return fmt.Errorf("read key file: %w", err)
+				}
+				if export.TokenData, err = readSecretFile(found.TokenPath); err != nil {
+					return fmt.Errorf("read token file: %w", err)
+				}
+			}
+			data, err := json.MarshalIndent(export, "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := shared.AtomicWrite(*out, append(data, '\n'), 0o600); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, "WARNING: %s contains credentials for profile %q. Store it like a password and delete it after importing.\n", *out, *profile)
+			result := struct {
+				File          string `json:"file"`
+				Profile       string `json:"profile"`
+				InlineSecrets bool   `json:"inline_secrets"`
+			}{
+				File:          *out,
+				Profile:       *profile,
+				InlineSecrets: *inline,
+			}
+			return output.PrintJSON(result)
+		},
+	}
+}
+
+func AuthImportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("auth import", flag.ExitOnError)
+	file := fs.String("file", "", "Profile export written by gplay auth export")
+	setDefault := fs.Bool("set-default", false, "Set the imported profile as the default profile")
+	local := fs.Bool("local", false, "Write to local repo config")
+
+	return &ffcli.Command{
+		Name:       "import",
+		ShortUsage: "gplay auth import --file <file> [--set-default] [--local]",
+		ShortHelp:  "Import an auth profile exported with gplay auth export.",
+		LongHelp: `Import an auth profile exported with gplay auth export.
+
+The profile is merged into the config, replacing a profile of the same
+name. Key and token contents embedded with --inline-secrets are written
+next to the config, as keys/<profile>.json and tokens/<profile>.json,
+readable only by the current user, and the profile is pointed at them.
+With --local those files go under ./.gplay in the repo, so a warning is
+printed; keep .gplay/ out of version control.
+
+Examples:
+  gplay auth import --file work-profile.json
+  gplay auth import --file ci-profile.json --set-default`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if strings.TrimSpace(*file) == "" {
+				return fmt.Errorf("--file is required")
+			}
+			var export profileExport
+			if err := shared.LoadJSONArgStrict("@"+*file, &export); err != nil {
+				return fmt.Errorf("invalid profile export: %w", err)
+			}
+			if export.Version != profileExportVersion {
+				return fmt.Errorf("unsupported profile export version %d (expected %d)", export.Version, profileExportVersion)
+			}
+			profile := export.Profile
+			if strings.TrimSpace(profile.Name) == "" {
+				return fmt.Errorf("profile export has no profile name")
+			}
+
+			path, err := resolveConfigPath(*local)
+			if err != nil {
+				return err
+			}
+			secrets := []struct {
+				kind, label, data string
+				target            *string
+			}{
+				{"keys", "key", export.KeyData, &profile.KeyPath},
+				{"tokens", "token", export.TokenData, &profile.TokenPath},
+			}
+			for _, secret := range secrets {
+				if secret.data == "" {
+					continue
+				}
+				data, err := base64.StdEncoding.DecodeString(secret.data)
+				if err != nil {
+					return fmt.Errorf("invalid %s data: %w", secret.label, err)
+				}
+				target, err := profileSecretPath(path, secret.kind, profile.Name)
+				if err != nil {
+					return err
+				}
+				if err := writeSecretFile(target, data, *local); err != nil {
+					return fmt.Errorf("write %s file: %w", secret.label, err)
+				}
+				*secret.target = target
+			}
+
+			cfg, _ := config.Load()
+			if cfg == nil {
+				cfg = &config.Config{}
+			}
+			cfg.Profiles = upsertProfile(cfg.Profiles, profile)
+			if *setDefault {
+				cfg.DefaultProfile = profile.Name
+			}
+			if err := config.SaveAt(path, cfg); err != nil {
+				return err
+			}
+
+			printed := profile
+			if printed.ClientSecret != "" {
+				printed.ClientSecret = "***"
+			}
+			result := struct {
+				ConfigPath string         `json:"config_path"`
+				Profile    config.Profile `json:"profile"`
+			}{
+				ConfigPath: path,
+				Profile:    printed,
+			}
+			return output.PrintJSON(result)
+		},
+	}
+}
+
+// readSecretFile returns the base64 contents of path, or "" when path is
+// unset.
+func readSecretFile(path string) (string, error) {
+	if strings.TrimSpace(path) == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
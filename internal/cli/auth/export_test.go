@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+func runAuthCommandQuiet(t *testing.T, cmd *ffcli.Command) error {
+	t.Helper()
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	_, w, _ := os.Pipe()
+	os.Stdout, os.Stderr = w, w
+	err := cmd.Exec(context.Background(), nil)
+	w.Close()
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+	return err
+}
+
+func TestAuthExportImport_RoundTripsProfile(t *testing.T) {
+	srcDir := t.TempDir()
+	keyPath := filepath.Join(srcDir, "ci-key.json")
+	keyJSON := `{"type":"service_account","client_email":"ci@example.iam.gserviceaccount.com"}`
+	if err := os.WriteFile(keyPath, []byte(keyJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	srcConfig := filepath.Join(srcDir, "config.json")
+	if err := config.SaveAt(srcConfig, &config.Config{Profiles: []config.Profile{
+		{Name: "ci", Type: "service_account", KeyPath: keyPath, DefaultPackage: "com.example.app"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GPLAY_CONFIG_PATH", srcConfig)
+
+	exportPath := filepath.Join(t.TempDir(), "ci-profile.json")
+	export := AuthExportCommand()
+	if err := export.FlagSet.Parse([]string{"--profile", "ci", "--out", exportPath, "--inline-secrets"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runAuthCommandQuiet(t, export); err != nil {
+		t.Fatalf("auth export: %v", err)
+	}
+	info, err := os.Stat(exportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("export mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A fresh machine: new config, and the original key file is gone.
+	if err := os.Remove(keyPath); err != nil {
+		t.Fatal(err)
+	}
+	dstDir := t.TempDir()
+	dstConfig := filepath.Join(dstDir, "config.json")
+	t.Setenv("GPLAY_CONFIG_PATH", dstConfig)
+
+	imp := AuthImportCommand()
+	if err := imp.FlagSet.Parse([]string{"--file", exportPath, "--set-default"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runAuthCommandQuiet(t, imp); err != nil {
+		t.Fatalf("auth import: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load imported config: %v", err)
+	}
+	if cfg.DefaultProfile != "ci" || len(cfg.Profiles) != 1 {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	p := cfg.Profiles[0]
+	if p.Type != "service_account" || p.DefaultPackage != "com.example.app" {
+		t.Fatalf("profile not carried over: %+v", p)
+	}
+	if want := filepath.Join(dstDir, "keys", "ci.json"); p.KeyPath != want {
+		t.Fatalf("key path = %q, want %q", p.KeyPath, want)
+	}
+	data, err := os.ReadFile(p.KeyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != keyJSON {
+		t.Fatalf("key contents = %s, want %s", data, keyJSON)
+	}
+}
+
+func TestAuthImport_MergesIntoExistingConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	if err := config.SaveAt(configPath, &config.Config{DefaultProfile: "work", Profiles: []config.Profile{
+		{Name: "work", Type: "service_account", KeyPath: "/keys/work.json"},
+		{Name: "ci", Type: "service_account", KeyPath: "/keys/old.json"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GPLAY_CONFIG_PATH", configPath)
+	exportPath := filepath.Join(dir, "ci-profile.json")
+	if err := os.WriteFile(exportPath, []byte(`{"version":1,"profile":{"name":"ci","type":"service_account","key_path":"/keys/new.json"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	imp := AuthImportCommand()
+	if err := imp.FlagSet.Parse([]string{"--file", exportPath}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runAuthCommandQuiet(t, imp); err != nil {
+		t.Fatalf("auth import: %v", err)
+	}
+
+	cfg, err := config.LoadAt(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultProfile != "work" || len(cfg.Profiles) != 2 || cfg.Profiles[1].KeyPath != "/keys/new.json" {
+		t.Fatalf("unexpected merged config: %+v", cfg)
+	}
+}
+
+func TestAuthExport_UnknownProfile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := config.SaveAt(configPath, &config.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GPLAY_CONFIG_PATH", configPath)
+
+	cmd := AuthExportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--profile", "missing", "--out", filepath.Join(t.TempDir(), "out.json")}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "profile not found") {
+		t.Fatalf("expected profile not found error, got %v", err)
+	}
+}
+
+func TestAuthImport_RejectsUnknownVersion(t *testing.T) {
+	exportPath := filepath.Join(t.TempDir(), "profile.json")
+	if err := os.WriteFile(exportPath, []byte(`{"version":9,"profile":{"name":"ci"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := AuthImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--file", exportPath}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "version") {
+		t.Fatalf("expected version error, got %v", err)
+	}
+}
+
+func TestWriteSecretFile_WarnsForLocalConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gplay", "keys", "ci.json")
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	err := writeSecretFile(path, []byte("{}"), true)
+	w.Close()
+	os.Stderr = oldStderr
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "Warning: writing "+path) {
+		t.Fatalf("expected a working tree warning, got %q", out)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+}
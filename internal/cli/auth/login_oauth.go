@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	oauthClientIDEnvVar     = "GPLAY_OAUTH_CLIENT_ID"
+	oauthClientSecretEnvVar = "GPLAY_OAUTH_CLIENT_SECRET"
+	oauthRedirectEnvVar     = "GPLAY_OAUTH_REDIRECT_URI"
+
+	// defaultOAuthRedirectURI matches the redirect playclient uses when
+	// refreshing OAuth tokens.
+	defaultOAuthRedirectURI = "urn:ietf:wg:oauth:2.0:oob"
+)
+
+// oauthEndpoint, loginCodeInput, and loginPromptOutput are swapped out by
+// tests.
+var (
+	oauthEndpoint               = google.Endpoint
+	loginCodeInput    io.Reader = os.Stdin
+	loginPromptOutput io.Writer = os.Stderr
+)
+
+// newLoginOAuthConfig builds the OAuth client config for auth login
+// --no-browser, falling back to the GPLAY_OAUTH_* environment variables.
+func newLoginOAuthConfig(clientID, clientSecret string) (*oauth2.Config, error) {
+	clientID = firstNonEmpty(clientID, os.Getenv(oauthClientIDEnvVar))
+	clientSecret = firstNonEmpty(clientSecret, os.Getenv(oauthClientSecretEnvVar))
+	if clientID == "" {
+		return nil, fmt.Errorf("--client-id is required with --no-browser (or set %s)", oauthClientIDEnvVar)
+	}
+	if clientSecret == "" {
+		return nil, fmt.Errorf("--client-secret is required with --no-browser (or set %s)", oauthClientSecretEnvVar)
+	}
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint:     oauthEndpoint,
+		Scopes:       []string{scopeAndroidPublisher},
+		RedirectURL:  firstNonEmpty(os.Getenv(oauthRedirectEnvVar), defaultOAuthRedirectURI),
+	}, nil
+}
+
+// runManualOAuthFlow prints the consent URL to out, reads the authorization
+// code (or the URL the browser was redirected to) from in, and exchanges it
+// for a token. The exchange is protected with PKCE.
+func runManualOAuthFlow(ctx context.Context, cfg *oauth2.Config, in io.Reader, out io.Writer) (*oauth2.Token, error) {
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+	authURL := cfg.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce, oauth2.S256ChallengeOption(verifier))
+
+	fmt.Fprintf(out, "Open this URL in a browser on any machine and approve access:\n\n  %s\n\n", authURL)
+	fmt.Fprint(out, "Paste the authorization code (or the full redirect URL): ")
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("read authorization code: %w", err)
+	}
+	code, err := parseAuthorizationCode(line, state)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := cfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+	if token.RefreshToken == "" {
+		fmt.Fprintln(out, "Warning: no refresh token was returned; you will need to log in again when the access token expires.")
+	}
+	return token, nil
+}
+
+// parseAuthorizationCode accepts either a bare code or a redirect URL
+// carrying code and state query parameters. A state that does not match is
+// rejected.
+func parseAuthorizationCode(input, state string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("no authorization code entered")
+	}
+	if !strings.Contains(input, "code=") {
+		return input, nil
+	}
+	u, err := url.Parse(input)
+	if err != nil {
+		return "", fmt.Errorf("parse redirect URL: %w", err)
+	}
+	query := u.Query()
+	if msg := query.Get("error"); msg != "" {
+		return "", fmt.Errorf("authorization denied: %s", msg)
+	}
+	if got := query.Get("state"); got != "" && got != state {
+		return "", fmt.Errorf("redirect URL state does not match this login; start again")
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", fmt.Errorf("redirect URL has no code parameter")
+	}
+	return code, nil
+}
+
+// profileSecretPath returns where a profile's key or token file is stored:
+// <kind>/<profile>.json next to the config file, kind being keys or tokens.
+func profileSecretPath(configPath, kind, profile string) (string, error) {
+	name := profile + ".json"
+	if !filepath.IsLocal(name) || strings.ContainsAny(profile, `/\`) {
+		return "", fmt.Errorf("profile name %q cannot be used as a file name", profile)
+	}
+	return filepath.Join(filepath.Dir(configPath), kind, name), nil
+}
+
+// writeSecretFile writes data readable only by the current user, creating
+// its directory. With local set the file lands in the repo's .gplay
+// directory, so it warns that the file must stay out of version control.
+func writeSecretFile(path string, data []byte, local bool) error {
+	if local {
+		fmt.Fprintf(os.Stderr, "Warning: writing %s inside the working tree; keep .gplay/ in .gitignore so it is never committed\n", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// writeOAuthToken saves token as JSON, readable only by the current user.
+func writeOAuthToken(path string, token *oauth2.Token, local bool) error {
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeSecretFile(path, data, local); err != nil {
+		return fmt.Errorf("write token: %w", err)
+	}
+	return nil
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate state: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
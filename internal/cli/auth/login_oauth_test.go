@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+// installMockOAuth points the login flow at a mock token endpoint and feeds
+// it input as the pasted code. It returns the prompt output.
+func installMockOAuth(t *testing.T, input string) *bytes.Buffer {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parse form: %v", err)
+		}
+		if r.Form.Get("code") != "4/abc" {
+			t.Errorf("code = %q, want 4/abc", r.Form.Get("code"))
+		}
+		if r.Form.Get("code_verifier") == "" {
+			t.Error("expected a PKCE code_verifier")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"access_token":"access","refresh_token":"refresh","token_type":"Bearer","expires_in":3600}`)
+	}))
+	t.Cleanup(server.Close)
+
+	originalEndpoint, originalInput, originalOutput := oauthEndpoint, loginCodeInput, loginPromptOutput
+	prompt := &bytes.Buffer{}
+	oauthEndpoint = oauth2.Endpoint{AuthURL: server.URL + "/auth", TokenURL: server.URL + "/token", AuthStyle: oauth2.AuthStyleInParams}
+	loginCodeInput = strings.NewReader(input)
+	loginPromptOutput = prompt
+	t.Cleanup(func() {
+		oauthEndpoint, loginCodeInput, loginPromptOutput = originalEndpoint, originalInput, originalOutput
+	})
+	return prompt
+}
+
+func TestAuthLoginCommand_NoBrowser(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("GPLAY_CONFIG_PATH", configPath)
+	prompt := installMockOAuth(t, "4/abc\n")
+
+	cmd := AuthLoginCommand()
+	if err := cmd.FlagSet.Parse([]string{"--no-browser", "--client-id", "id.apps.googleusercontent.com", "--client-secret", "secret", "--profile", "me"}); err != nil {
+		t.Fatal(err)
+	}
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := cmd.Exec(context.Background(), nil)
+	w.Close()
+	os.Stdout = oldStdout
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("auth login: %v", err)
+	}
+
+	if !strings.Contains(prompt.String(), "/auth?") || !strings.Contains(prompt.String(), "client_id=id.apps.googleusercontent.com") {
+		t.Fatalf("expected the consent URL in the prompt, got %q", prompt.String())
+	}
+	if strings.Contains(string(out), `"secret"`) {
+		t.Fatalf("client secret printed: %s", out)
+	}
+
+	cfg, err := config.LoadAt(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Profiles) != 1 || cfg.DefaultProfile != "me" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	p := cfg.Profiles[0]
+	wantTokenPath := filepath.Join(filepath.Dir(configPath), "tokens", "me.json")
+	if p.Type != "oauth" || p.TokenPath != wantTokenPath || p.ClientID != "id.apps.googleusercontent.com" || p.ClientSecret != "secret" {
+		t.Fatalf("unexpected profile: %+v", p)
+	}
+
+	data, err := os.ReadFile(wantTokenPath)
+	if err != nil {
+		t.Fatalf("token not written: %v", err)
+	}
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "access" || token.RefreshToken != "refresh" {
+		t.Fatalf("unexpected token: %+v", token)
+	}
+	if info, err := os.Stat(wantTokenPath); err == nil && info.Mode().Perm() != 0o600 {
+		t.Errorf("token mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestAuthLoginCommand_NoBrowserRequiresClient(t *testing.T) {
+	t.Setenv("GPLAY_CONFIG_PATH", filepath.Join(t.TempDir(), "config.json"))
+	t.Setenv(oauthClientIDEnvVar, "")
+	t.Setenv(oauthClientSecretEnvVar, "")
+
+	cmd := AuthLoginCommand()
+	if err := cmd.FlagSet.Parse([]string{"--no-browser"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--client-id") {
+		t.Fatalf("expected --client-id error, got %v", err)
+	}
+
+	cmd = AuthLoginCommand()
+	if err := cmd.FlagSet.Parse([]string{"--no-browser", "--service-account", "key.json"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err == nil {
+		t.Fatal("expected error combining --no-browser and --service-account")
+	}
+}
+
+func TestParseAuthorizationCode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr string
+	}{
+		{input: " 4/abc \n", want: "4/abc"},
+		{input: "http://localhost/?code=4%2Fabc&state=s1", want: "4/abc"},
+		{input: "http://localhost/?code=4%2Fabc", want: "4/abc"},
+		{input: "http://localhost/?code=4%2Fabc&state=other", wantErr: "state"},
+		{input: "http://localhost/?error=access_denied&code=", wantErr: "access_denied"},
+		{input: "\n", wantErr: "no authorization code"},
+	}
+	for _, tt := range tests {
+		got, err := parseAuthorizationCode(tt.input, "s1")
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseAuthorizationCode(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseAuthorizationCode(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+const (
+	scopeAndroidPublisher = "https://www.googleapis.com/auth/androidpublisher"
+	scopeReporting        = "https://www.googleapis.com/auth/playdeveloperreporting"
+	scopeStorageRead      = "https://www.googleapis.com/auth/devstorage.read_only"
+)
+
+// featureScope maps a feature area to the OAuth scope its API calls need.
+type featureScope struct {
+	Area     string   `json:"area"`
+	Commands []string `json:"commands"`
+	Scope    string   `json:"scope"`
+}
+
+var featureScopes = []featureScope{
+	{Area: "publishing", Commands: []string{"edits", "tracks", "bundles", "apks", "release", "publish", "promote", "rollout"}, Scope: scopeAndroidPublisher},
+	{Area: "store listing", Commands: []string{"listings", "images", "details", "metadata", "sync"}, Scope: scopeAndroidPublisher},
+	{Area: "monetization", Commands: []string{"iap", "subscriptions", "baseplans", "offers", "onetimeproducts", "pricing", "regions"}, Scope: scopeAndroidPublisher},
+	{Area: "orders and purchases", Commands: []string{"orders", "purchases", "externaltx"}, Scope: scopeAndroidPublisher},
+	{Area: "reviews", Commands: []string{"reviews"}, Scope: scopeAndroidPublisher},
+	{Area: "users and grants", Commands: []string{"users", "grants"}, Scope: scopeAndroidPublisher},
+	{Area: "vitals", Commands: []string{"vitals"}, Scope: scopeReporting},
+	{Area: "reports", Commands: []string{"reports financial", "reports stats"}, Scope: scopeStorageRead},
+}
+
+// requiredScope returns the scope needed by a feature area.
+func requiredScope(area string) (string, bool) {
+	for _, f := range featureScopes {
+		if strings.EqualFold(f.Area, area) {
+			return f.Scope, true
+		}
+	}
+	return "", false
+}
+
+type scopeStatus struct {
+	featureScope
+	// Granted is "yes", "no", or "unknown" when the credential does not record
+	// its scopes.
+	Granted string `json:"granted"`
+}
+
+type scopesReport struct {
+	Credential string        `json:"credential"`
+	Features   []scopeStatus `json:"features"`
+	Guidance   []string      `json:"guidance,omitempty"`
+}
+
+// buildScopesReport checks the active credential against featureScopes.
+// Service accounts request scopes per token, so every scope is available to
+// them; OAuth tokens are limited to the scopes granted at consent time.
+func buildScopesReport(cfg *config.Config) scopesReport {
+	kind, tokenPath := activeCredential(cfg)
+	report := scopesReport{Credential: kind}
+
+	var granted map[string]bool
+	if kind == "oauth" {
+		granted = grantedOAuthScopes(tokenPath)
+	}
+
+	var missing []string
+	seen := map[string]bool{}
+	for _, f := range featureScopes {
+		status := scopeStatus{featureScope: f}
+		switch {
+		case kind == "service_account":
+			status.Granted = "yes"
+		case kind == "none":
+			status.Granted = "no"
+		case granted == nil:
+			status.Granted = "unknown"
+		case granted[f.Scope]:
+			status.Granted = "yes"
+		default:
+			status.Granted = "no"
+			if !seen[f.Scope] {
+				seen[f.Scope] = true
+				missing = append(missing, f.Scope)
+			}
+		}
+		report.Features = append(report.Features, status)
+	}
+
+	switch {
+	case kind == "none":
+		report.Guidance = append(report.Guidance, "No credential configured. Run `gplay auth login --service-account <key.json>`.")
+	case kind == "service_account":
+		report.Guidance = append(report.Guidance, "Service accounts request scopes per call; if a feature still fails, grant the account access in Play Console (and the bucket for reports).")
+	case len(missing) > 0:
+		report.Guidance = append(report.Guidance, "Re-authorize your OAuth client with these additional scopes and save the new token to "+tokenPath+":")
+		report.Guidance = append(report.Guidance, missing...)
+	case granted == nil:
+		report.Guidance = append(report.Guidance, "The OAuth token file does not record its scopes; if a feature fails with 403, re-authorize with the scope listed for it.")
+	}
+	return report
+}
+
+// activeCredential mirrors playclient's resolution order: the selected
+// profile first, then environment credentials.
+func activeCredential(cfg *config.Config) (kind, tokenPath string) {
+	if cfg != nil {
+		if name := shared.ResolveProfileName(cfg); name != "" {
+			for _, p := range cfg.Profiles {
+				if p.Name != name {
+					continue
+				}
+				if strings.EqualFold(p.Type, "oauth") {
+					return "oauth", p.TokenPath
+				}
+				return "service_account", ""
+			}
+		}
+	}
+	if strings.TrimSpace(os.Getenv("GPLAY_SERVICE_ACCOUNT_JSON")) != "" {
+		return "service_account", ""
+	}
+	if path := strings.TrimSpace(os.Getenv("GPLAY_OAUTH_TOKEN_PATH")); path != "" {
+		return "oauth", path
+	}
+	return "none", ""
+}
+
+// grantedOAuthScopes reads the space-separated "scope" field that Google's
+// token endpoint returns. It returns nil when the token file has none.
+func grantedOAuthScopes(tokenPath string) map[string]bool {
+	data, err := os.ReadFile(tokenPath) // #nosec G304 -- path comes from the user's auth config
+	if err != nil {
+		return nil
+	}
+	var token struct {
+		Scope string `json:"scope"`
+	}
+	if err := json.Unmarshal(data, &token); err != nil || strings.TrimSpace(token.Scope) == "" {
+		return nil
+	}
+	granted := map[string]bool{}
+	for _, s := range strings.Fields(token.Scope) {
+		granted[s] = true
+	}
+	return granted
+}
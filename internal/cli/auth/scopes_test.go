@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+func clearScopeEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("GPLAY_PROFILE", "")
+	t.Setenv("GPLAY_SERVICE_ACCOUNT_JSON", "")
+	t.Setenv("GPLAY_OAUTH_TOKEN_PATH", "")
+}
+
+func TestRequiredScope_FeatureAreas(t *testing.T) {
+	tests := map[string]string{
+		"publishing":    scopeAndroidPublisher,
+		"monetization":  scopeAndroidPublisher,
+		"reviews":       scopeAndroidPublisher,
+		"vitals":        scopeReporting,
+		"reports":       scopeStorageRead,
+		"Store Listing": scopeAndroidPublisher,
+	}
+	for area, want := range tests {
+		got, ok := requiredScope(area)
+		if !ok {
+			t.Errorf("requiredScope(%q) not found", area)
+			continue
+		}
+		if got != want {
+			t.Errorf("requiredScope(%q) = %q, want %q", area, got, want)
+		}
+	}
+	if _, ok := requiredScope("teleportation"); ok {
+		t.Error("expected unknown area to be reported as missing")
+	}
+}
+
+func TestBuildScopesReport_ServiceAccountHasAllScopes(t *testing.T) {
+	clearScopeEnv(t)
+	cfg := &config.Config{Profiles: []config.Profile{{Name: "default", Type: "service_account", KeyPath: "key.json"}}}
+
+	report := buildScopesReport(cfg)
+	if report.Credential != "service_account" {
+		t.Fatalf("credential = %q", report.Credential)
+	}
+	for _, f := range report.Features {
+		if f.Granted != "yes" {
+			t.Errorf("%s: granted = %q, want yes", f.Area, f.Granted)
+		}
+	}
+}
+
+func TestBuildScopesReport_OAuthMissingStorageScope(t *testing.T) {
+	clearScopeEnv(t)
+	tokenPath := writeDoctorFile(t, "token.json", map[string]interface{}{
+		"access_token":  "abc",
+		"refresh_token": "def",
+		"scope":         scopeAndroidPublisher + " " + scopeReporting,
+	})
+	cfg := &config.Config{Profiles: []config.Profile{{Name: "me", Type: "oauth", TokenPath: tokenPath}}}
+
+	report := buildScopesReport(cfg)
+	if report.Credential != "oauth" {
+		t.Fatalf("credential = %q", report.Credential)
+	}
+	for _, f := range report.Features {
+		want := "yes"
+		if f.Scope == scopeStorageRead {
+			want = "no"
+		}
+		if f.Granted != want {
+			t.Errorf("%s: granted = %q, want %q", f.Area, f.Granted, want)
+		}
+	}
+	guidance := strings.Join(report.Guidance, "\n")
+	if !strings.Contains(guidance, scopeStorageRead) || !strings.Contains(guidance, tokenPath) {
+		t.Errorf("guidance should name the missing scope and token path, got:\n%s", guidance)
+	}
+}
+
+func TestBuildScopesReport_OAuthWithoutRecordedScopes(t *testing.T) {
+	clearScopeEnv(t)
+	tokenPath := writeDoctorFile(t, "token.json", map[string]interface{}{"access_token": "abc"})
+	t.Setenv("GPLAY_OAUTH_TOKEN_PATH", tokenPath)
+
+	report := buildScopesReport(nil)
+	for _, f := range report.Features {
+		if f.Granted != "unknown" {
+			t.Errorf("%s: granted = %q, want unknown", f.Area, f.Granted)
+		}
+	}
+}
+
+func TestBuildScopesReport_NoCredential(t *testing.T) {
+	clearScopeEnv(t)
+	report := buildScopesReport(nil)
+	if report.Credential != "none" {
+		t.Fatalf("credential = %q", report.Credential)
+	}
+	if len(report.Guidance) == 0 || !strings.Contains(report.Guidance[0], "auth login") {
+		t.Errorf("expected login guidance, got %v", report.Guidance)
+	}
+}
@@ -59,6 +59,7 @@ func AuthSetupCommand() *ffcli.Command {
 	dryRun := fs.Bool("dry-run", false, "Print the gcloud commands without executing them")
 	setDefault := fs.Bool("set-default", true, "Set as default profile in config")
 	output := fs.String("output", "text", "Output format: text (default), json")
+	shared.DeclareOutputFormats(fs, "text", "json")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
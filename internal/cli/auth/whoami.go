@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+	"github.com/tamtom/play-console-cli/internal/reportingclient"
+)
+
+// newReportingService builds the client used by whoami --check. It is
+// overridden in tests.
+var newReportingService = reportingclient.NewService
+
+// whoamiResult is the identity the active credentials resolve to.
+type whoamiResult struct {
+	Profile             string `json:"profile,omitempty"`
+	Source              string `json:"source"`
+	Type                string `json:"type"`
+	ServiceAccountEmail string `json:"service_account_email,omitempty"`
+	ProjectID           string `json:"project_id,omitempty"`
+	OAuthClientID       string `json:"oauth_client_id,omitempty"`
+	KeyPath             string `json:"key_path,omitempty"`
+	TokenPath           string `json:"token_path,omitempty"`
+	Authenticated       *bool  `json:"authenticated,omitempty"`
+	AuthError           string `json:"auth_error,omitempty"`
+}
+
+func AuthWhoamiCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("auth whoami", flag.ExitOnError)
+	check := fs.Bool("check", false, "Confirm the credentials work with a lightweight authenticated call (lists one app)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "whoami",
+		ShortUsage: "gplay auth whoami [--check] [flags]",
+		ShortHelp:  "Show the identity the active credentials authenticate as.",
+		LongHelp: `Show the identity the active credentials authenticate as.
+
+Credentials are resolved the same way API commands resolve them: the
+selected profile first, then GPLAY_SERVICE_ACCOUNT_JSON or
+GPLAY_OAUTH_TOKEN_PATH. Service accounts report the client_email and
+project_id from the key file; OAuth credentials report the client ID.
+
+With --check, one app is listed through the Play Developer Reporting API and
+the result reports authenticated: true or false.
+
+Examples:
+  gplay auth whoami
+  gplay auth whoami --check --pretty`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			cfg, err := config.Load()
+			if err != nil && !errors.Is(err, config.ErrNotFound) {
+				return shared.NewActionableError(
+					"failed to load config",
+					err,
+					"Check that your config file is valid JSON and readable. Use `gplay auth init` to recreate it.",
+				)
+			}
+			result, err := resolveWhoami(cfg)
+			if err != nil {
+				return err
+			}
+			if *check {
+				authenticated := true
+				if err := checkAuthenticated(ctx); err != nil {
+					authenticated = false
+					result.AuthError = err.Error()
+				}
+				result.Authenticated = &authenticated
+			}
+			return shared.PrintOutput(result, *outputFlag, *pretty)
+		},
+	}
+}
+
+// resolveWhoami mirrors playclient's resolution order: the selected profile
+// first, then environment credentials.
+func resolveWhoami(cfg *config.Config) (*whoamiResult, error) {
+	if name := shared.ResolveProfileName(cfg); name != "" {
+		if cfg != nil {
+			for _, p := range cfg.Profiles {
+				if p.Name != name {
+					continue
+				}
+				result := &whoamiResult{Profile: name, Source: "profile"}
+				switch strings.ToLower(strings.TrimSpace(p.Type)) {
+				case "service_account", "service-account", "serviceaccount":
+					return serviceAccountIdentity(result, p.KeyPath)
+				case "oauth":
+					result.Type = "oauth"
+					result.OAuthClientID = strings.TrimSpace(p.ClientID)
+					result.TokenPath = p.TokenPath
+					return result, nil
+				default:
+					return nil, shared.NewAuthError(
+						"invalid auth profile",
+						fmt.Errorf("unknown profile type: %s", p.Type),
+						"Use type service_account or oauth.",
+					)
+				}
+			}
+		}
+		return nil, shared.NewAuthError(
+			"no active identity",
+			fmt.Errorf("profile not found: %s", name),
+			"Run `gplay auth login --profile <name>` or set GPLAY_PROFILE to an existing profile.",
+		)
+	}
+
+	if keyPath := strings.TrimSpace(os.Getenv("GPLAY_SERVICE_ACCOUNT_JSON")); keyPath != "" {
+		return serviceAccountIdentity(&whoamiResult{Source: "env"}, keyPath)
+	}
+	if tokenPath := strings.TrimSpace(os.Getenv("GPLAY_OAUTH_TOKEN_PATH")); tokenPath != "" {
+		return &whoamiResult{
+			Source:        "env",
+			Type:          "oauth",
+			OAuthClientID: strings.TrimSpace(os.Getenv("GPLAY_OAUTH_CLIENT_ID")),
+			TokenPath:     tokenPath,
+		}, nil
+	}
+	return nil, shared.NewAuthError(
+		"no active identity",
+		errors.New("no profile selected and no environment credentials set"),
+		"Run `gplay auth login` or set GPLAY_SERVICE_ACCOUNT_JSON / GPLAY_OAUTH_TOKEN_PATH.",
+	)
+}
+
+// serviceAccountIdentity fills result from the service account key file.
+func serviceAccountIdentity(result *whoamiResult, keyPath string) (*whoamiResult, error) {
+	result.Type = "service_account"
+	result.KeyPath = keyPath
+	if strings.TrimSpace(keyPath) == "" {
+		return nil, shared.NewAuthError(
+			"invalid auth profile",
+			errors.New("service account profile missing key_path"),
+			"Set key_path in config.json or re-run `gplay auth login` with --service-account.",
+		)
+	}
+	data, err := os.ReadFile(keyPath) // #nosec G304 -- path comes from the user's auth config
+	if err != nil {
+		return nil, shared.NewAuthError(
+			"failed to read service account file",
+			err,
+			fmt.Sprintf("Check that %s exists and is readable.", keyPath),
+		)
+	}
+	var key struct {
+		ClientEmail string `json:"client_email"`
+		ProjectID   string `json:"project_id"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, shared.NewAuthError(
+			"failed to parse service account JSON",
+			err,
+			"Ensure the file is a valid service account JSON key.",
+		)
+	}
+	if strings.TrimSpace(key.ClientEmail) == "" {
+		return nil, shared.NewAuthError(
+			"failed to parse service account JSON",
+			fmt.Errorf("%s has no client_email", keyPath),
+			"Ensure the file is a service account key downloaded from Google Cloud.",
+		)
+	}
+	result.ServiceAccountEmail = key.ClientEmail
+	result.ProjectID = key.ProjectID
+	return result, nil
+}
+
+// checkAuthenticated makes the cheapest authenticated call available, listing
+// a single accessible app.
+func checkAuthenticated(ctx context.Context) error {
+	service, err := newReportingService(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	defer cancel()
+	if _, err := service.API.Apps.Search().Context(ctx).PageSize(1).Do(); err != nil {
+		return shared.WrapGoogleAPIError("list accessible apps", err)
+	}
+	return nil
+}
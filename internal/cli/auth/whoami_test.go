@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+	"github.com/tamtom/play-console-cli/internal/reportingclient"
+)
+
+func runWhoami(t *testing.T, args ...string) (whoamiResult, error) {
+	t.Helper()
+	cmd := AuthWhoamiCommand()
+	if err := cmd.FlagSet.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := cmd.Exec(context.Background(), nil)
+	w.Close()
+	os.Stdout = oldStdout
+	out, _ := io.ReadAll(r)
+
+	var result whoamiResult
+	if err == nil {
+		if decodeErr := json.Unmarshal(out, &result); decodeErr != nil {
+			t.Fatalf("decode %q: %v", out, decodeErr)
+		}
+	}
+	return result, err
+}
+
+func TestAuthWhoami_ServiceAccountProfileReadsKeyFile(t *testing.T) {
+	clearScopeEnv(t)
+	keyPath := writeDoctorFile(t, "key.json", map[string]interface{}{
+		"type":         "service_account",
+		"client_email": "publisher@example-project.iam.gserviceaccount.com",
+		"project_id":   "example-project",
+	})
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("GPLAY_CONFIG_PATH", configPath)
+	cfg := &config.Config{
+		DefaultProfile: "ci",
+		Profiles:       []config.Profile{{Name: "ci", Type: "service_account", KeyPath: keyPath}},
+	}
+	if err := config.SaveAt(configPath, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := runWhoami(t)
+	if err != nil {
+		t.Fatalf("whoami: %v", err)
+	}
+	if result.Profile != "ci" || result.Source != "profile" || result.Type != "service_account" {
+		t.Fatalf("unexpected identity: %+v", result)
+	}
+	if result.ServiceAccountEmail != "publisher@example-project.iam.gserviceaccount.com" || result.ProjectID != "example-project" {
+		t.Fatalf("unexpected key fields: %+v", result)
+	}
+	if result.Authenticated != nil {
+		t.Fatalf("authenticated should be omitted without --check: %+v", result)
+	}
+}
+
+func TestAuthWhoami_EnvKeyWithoutClientEmail(t *testing.T) {
+	clearScopeEnv(t)
+	t.Setenv("GPLAY_CONFIG_PATH", filepath.Join(t.TempDir(), "missing.json"))
+	t.Setenv("GPLAY_SERVICE_ACCOUNT_JSON", writeDoctorFile(t, "key.json", map[string]interface{}{"type": "service_account"}))
+
+	_, err := runWhoami(t)
+	if err == nil || !strings.Contains(err.Error(), "no client_email") {
+		t.Fatalf("expected client_email error, got %v", err)
+	}
+}
+
+func TestAuthWhoami_NoProfileOrEnv(t *testing.T) {
+	clearScopeEnv(t)
+	t.Setenv("GPLAY_CONFIG_PATH", filepath.Join(t.TempDir(), "missing.json"))
+
+	_, err := runWhoami(t)
+	if err == nil || !strings.Contains(err.Error(), "no active identity") {
+		t.Fatalf("expected no active identity error, got %v", err)
+	}
+}
+
+func TestAuthWhoami_CheckReportsAuthenticated(t *testing.T) {
+	clearScopeEnv(t)
+	t.Setenv("GPLAY_CONFIG_PATH", filepath.Join(t.TempDir(), "missing.json"))
+	t.Setenv("GPLAY_SERVICE_ACCOUNT_JSON", writeDoctorFile(t, "key.json", map[string]interface{}{
+		"client_email": "publisher@example-project.iam.gserviceaccount.com",
+	}))
+
+	for _, tt := range []struct {
+		status int
+		want   bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusForbidden, false},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tt.status)
+			if tt.status == http.StatusOK {
+				_, _ = io.WriteString(w, `{"apps":[{"packageName":"com.example.app"}]}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"error":{"code":403,"message":"forbidden"}}`)
+		}))
+		original := newReportingService
+		newReportingService = func(ctx context.Context) (*reportingclient.Service, error) {
+			return reportingclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+		}
+
+		result, err := runWhoami(t, "--check")
+		newReportingService = original
+		server.Close()
+		if err != nil {
+			t.Fatalf("whoami --check: %v", err)
+		}
+		if result.Authenticated == nil || *result.Authenticated != tt.want {
+			t.Fatalf("status %d: authenticated = %v, want %v", tt.status, result.Authenticated, tt.want)
+		}
+		if !tt.want && result.AuthError == "" {
+			t.Fatalf("expected auth_error on failure: %+v", result)
+		}
+	}
+}
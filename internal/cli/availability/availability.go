@@ -37,7 +37,7 @@ func GetCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	track := fs.String("track", "", "Track name (e.g., production, beta, alpha, internal)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
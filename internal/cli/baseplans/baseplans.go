@@ -53,7 +53,7 @@ func ActivateCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -99,7 +99,7 @@ func DeactivateCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -145,8 +145,8 @@ func DeleteCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
-	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	confirm := fs.Bool("confirm", false, "Confirm deletion (prompts when omitted on a terminal)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -166,7 +166,9 @@ func DeleteCommand() *ffcli.Command {
 				return fmt.Errorf("--base-plan-id is required")
 			}
 			if !*confirm {
-				return fmt.Errorf("--confirm is required")
+				if err := shared.RequireConfirmation(ctx, fmt.Sprintf("Delete base plan %s of subscription %s?", *basePlanID, *productID), "--confirm"); err != nil {
+					return err
+				}
 			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
@@ -200,8 +202,8 @@ func MigratePricesCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
-	jsonFlag := fs.String("json", "", "Migration request JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "Migration request JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -226,7 +228,12 @@ JSON format:
 
 priceIncreaseType values:
   - PRICE_INCREASE_TYPE_OPT_IN: User must accept
-  - PRICE_INCREASE_TYPE_OPT_OUT: Auto-applied unless user cancels`,
+  - PRICE_INCREASE_TYPE_OPT_OUT: Auto-applied unless user cancels
+
+With --output table or markdown, a summary of the submitted migration is
+printed: regions migrated, opt-in and opt-out counts, and the region codes.
+The API does not report subscriber counts. --output json prints the raw
+response.`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -263,6 +270,9 @@ priceIncreaseType values:
 			if err != nil {
 				return err
 			}
+			if isSummaryOutput(*outputFlag) {
+				return shared.PrintOutput(summarizeMigratePrices(*productID, *basePlanID, &req), *outputFlag, *pretty)
+			}
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
@@ -272,8 +282,8 @@ func BatchUpdateStatesCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("baseplans batch-update-states", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
-	jsonFlag := fs.String("json", "", "Batch update states request JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "Batch update states request JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -339,8 +349,8 @@ func BatchMigratePricesCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("baseplans batch-migrate-prices", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
-	jsonFlag := fs.String("json", "", "Batch migrate prices request JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "Batch migrate prices request JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -0,0 +1,73 @@
+package baseplans
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+// MigratePricesSummary condenses a price migration for table and markdown
+// output. The API response carries no counts, so the summary is built from
+// the regions that were submitted.
+type MigratePricesSummary struct {
+	ProductID       string   `json:"productId"`
+	BasePlanID      string   `json:"basePlanId"`
+	RegionsVersion  string   `json:"regionsVersion,omitempty"`
+	RegionsMigrated int      `json:"regionsMigrated"`
+	OptIn           int      `json:"optIn"`
+	OptOut          int      `json:"optOut"`
+	Regions         []string `json:"regions"`
+}
+
+var migratePricesTableHeaders = []string{"Product ID", "Base Plan", "Regions Version", "Regions Migrated", "Opt-in", "Opt-out", "Regions"}
+
+func init() {
+	output.RegisterType(&MigratePricesSummary{}, migratePricesTableHeaders, func(data any) [][]string {
+		s := data.(*MigratePricesSummary)
+		return [][]string{{
+			s.ProductID,
+			s.BasePlanID,
+			s.RegionsVersion,
+			strconv.Itoa(s.RegionsMigrated),
+			strconv.Itoa(s.OptIn),
+			strconv.Itoa(s.OptOut),
+			strings.Join(s.Regions, ", "),
+		}}
+	})
+}
+
+// summarizeMigratePrices builds the summary for a migrate-prices request that
+// the API accepted.
+func summarizeMigratePrices(productID, basePlanID string, req *androidpublisher.MigrateBasePlanPricesRequest) *MigratePricesSummary {
+	summary := &MigratePricesSummary{ProductID: productID, BasePlanID: basePlanID, Regions: []string{}}
+	if req.RegionsVersion != nil {
+		summary.RegionsVersion = req.RegionsVersion.Version
+	}
+	for _, migration := range req.RegionalPriceMigrations {
+		if migration == nil {
+			continue
+		}
+		summary.RegionsMigrated++
+		summary.Regions = append(summary.Regions, migration.RegionCode)
+		switch migration.PriceIncreaseType {
+		case "PRICE_INCREASE_TYPE_OPT_IN":
+			summary.OptIn++
+		case "PRICE_INCREASE_TYPE_OPT_OUT":
+			summary.OptOut++
+		}
+	}
+	return summary
+}
+
+// isSummaryOutput reports whether format is rendered for people rather than
+// scripts.
+func isSummaryOutput(format string) bool {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "table", "markdown", "md":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package baseplans
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+func TestSummarizeMigratePrices(t *testing.T) {
+	req := &androidpublisher.MigrateBasePlanPricesRequest{
+		RegionalPriceMigrations: []*androidpublisher.RegionalPriceMigrationConfig{
+			{RegionCode: "US", PriceIncreaseType: "PRICE_INCREASE_TYPE_OPT_IN"},
+			{RegionCode: "DE", PriceIncreaseType: "PRICE_INCREASE_TYPE_OPT_OUT"},
+			{RegionCode: "JP", PriceIncreaseType: "PRICE_INCREASE_TYPE_OPT_IN"},
+		},
+		RegionsVersion: &androidpublisher.RegionsVersion{Version: "2025/03"},
+	}
+
+	got := summarizeMigratePrices("premium", "monthly", req)
+	if got.RegionsMigrated != 3 || got.OptIn != 2 || got.OptOut != 1 {
+		t.Errorf("unexpected counts: %+v", got)
+	}
+	if got.RegionsVersion != "2025/03" || strings.Join(got.Regions, ",") != "US,DE,JP" {
+		t.Errorf("unexpected regions: %+v", got)
+	}
+
+	var buf bytes.Buffer
+	rendered, err := output.RenderRegistered(&buf, got, "markdown")
+	if err != nil || !rendered {
+		t.Fatalf("expected registered rendering, got rendered=%v err=%v", rendered, err)
+	}
+	if !strings.Contains(buf.String(), "US, DE, JP") || !strings.Contains(buf.String(), "Regions Migrated") {
+		t.Fatalf("unexpected markdown:\n%s", buf.String())
+	}
+}
+
+func TestIsSummaryOutput(t *testing.T) {
+	for format, want := range map[string]bool{"table": true, "Markdown": true, "md": true, "json": false, "yaml": false, "": false} {
+		if got := isSummaryOutput(format); got != want {
+			t.Errorf("isSummaryOutput(%q) = %v, want %v", format, got, want)
+		}
+	}
+}
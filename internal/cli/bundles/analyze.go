@@ -17,7 +17,7 @@ func AnalyzeCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("bundles analyze", flag.ExitOnError)
 	file := fs.String("file", "", "Path to .aab or .apk (required)")
 	top := fs.Int("top-files", 20, "Number of largest individual files to include")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -56,7 +56,7 @@ func CompareCommand() *ffcli.Command {
 	base := fs.String("base", "", "Baseline AAB/APK (required)")
 	candidate := fs.String("candidate", "", "Candidate AAB/APK (required)")
 	threshold := fs.String("threshold", "", "Regression threshold in bytes (e.g. 500K, 2M, 1G)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
-	"google.golang.org/api/googleapi"
 
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
 	"github.com/tamtom/play-console-cli/internal/playclient"
@@ -42,7 +41,7 @@ func UploadCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	filePath := fs.String("file", "", "Path to .aab file")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -58,6 +57,9 @@ func UploadCommand() *ffcli.Command {
 			if strings.TrimSpace(*filePath) == "" {
 				return fmt.Errorf("--file is required")
 			}
+			if err := shared.CheckUploadFile(*filePath, ".aab", os.Stderr); err != nil {
+				return err
+			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
 				return err
@@ -69,20 +71,11 @@ func UploadCommand() *ffcli.Command {
 			if strings.TrimSpace(*editID) == "" {
 				return fmt.Errorf("--edit is required")
 			}
-			file, err := os.Open(*filePath)
+			resp, err := UploadBundle(ctx, service, pkg, *editID, *filePath)
 			if err != nil {
-				return shared.WrapActionable(err, "failed to open bundle file", "Check that the file exists and is readable.")
-			}
-			defer file.Close()
-
-			ctx, cancel := shared.ContextWithUploadTimeout(ctx, service.Cfg)
-			defer cancel()
-			call := service.API.Edits.Bundles.Upload(pkg, *editID)
-			call.Media(file, googleapi.ContentType("application/octet-stream"))
-			resp, err := call.Context(ctx).Do()
-			if err != nil {
-				return shared.WrapGoogleAPIError("failed to upload bundle", err)
+				return err
 			}
+			shared.Infof(ctx, "Uploaded bundle version code %d (sha256 %s)\n", resp.VersionCode, resp.Sha256)
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
@@ -92,7 +85,7 @@ func ListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("bundles list", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
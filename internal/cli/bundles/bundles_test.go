@@ -121,6 +121,20 @@ func TestBundlesUploadCommand_WhitespaceFile(t *testing.T) {
 	}
 }
 
+func TestBundlesUploadCommand_NonExistentFile(t *testing.T) {
+	cmd := UploadCommand()
+	if err := cmd.FlagSet.Parse([]string{"--file", "/nonexistent/app.aab"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for non-existent file")
+	}
+	if !strings.Contains(err.Error(), "file not found") {
+		t.Errorf("error should mention file not found, got: %s", err.Error())
+	}
+}
+
 func TestBundlesUploadCommand_InvalidOutputFormat(t *testing.T) {
 	cmd := UploadCommand()
 	if err := cmd.FlagSet.Parse([]string{"--output", "csv"}); err != nil {
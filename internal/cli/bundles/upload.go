@@ -0,0 +1,32 @@
+package bundles
+
+import (
+	"context"
+	"os"
+
+	"google.golang.org/api/androidpublisher/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+// UploadBundle uploads the .aab at path into editID and returns the bundle
+// the API created, whose VersionCode identifies it in track releases.
+func UploadBundle(ctx context.Context, service *playclient.Service, pkg, editID, path string) (*androidpublisher.Bundle, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, shared.WrapActionable(err, "failed to open bundle file", "Check that the file exists and is readable.")
+	}
+	defer file.Close()
+
+	ctx, cancel := shared.ContextWithUploadTimeout(ctx, service.Cfg)
+	defer cancel()
+	call := service.API.Edits.Bundles.Upload(pkg, editID)
+	call.Media(shared.NewFileProgressReader(file), googleapi.ContentType("application/octet-stream"))
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, shared.WrapGoogleAPIError("failed to upload bundle", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,77 @@
+// Package cachecmd implements the `gplay cache` command family.
+package cachecmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/reportcache"
+)
+
+// CacheCommand builds the root `gplay cache` command.
+func CacheCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("cache", flag.ExitOnError)
+	return &ffcli.Command{
+		Name:       "cache",
+		ShortUsage: "gplay cache <subcommand> [flags]",
+		ShortHelp:  "Manage the local report listing cache.",
+		LongHelp: `Manage the local report listing cache.
+
+gplay reports financial list and gplay reports stats list keep bucket
+listings in ~/.gplay/cache when run with --cache-ttl. Override the
+directory with GPLAY_CACHE_DIR.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			clearCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return flag.ErrHelp
+			}
+			fmt.Fprintf(os.Stderr, "Unknown subcommand: %s\n", args[0])
+			return flag.ErrHelp
+		},
+	}
+}
+
+func clearCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("cache clear", flag.ExitOnError)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "clear",
+		ShortUsage: "gplay cache clear",
+		ShortHelp:  "Remove every cached report listing.",
+		LongHelp: `Remove every cached report listing. The next list run fetches the
+bucket again.
+
+Examples:
+  gplay cache clear`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			cache, err := reportcache.Open()
+			if err != nil {
+				return err
+			}
+			removed, err := cache.Clear()
+			if err != nil {
+				return err
+			}
+			return shared.PrintOutput(struct {
+				Removed int    `json:"removed"`
+				Dir     string `json:"dir"`
+			}{Removed: removed, Dir: cache.Dir}, *outputFlag, *pretty)
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package cachecmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/reportcache"
+)
+
+func TestCacheUnknownSubcommand(t *testing.T) {
+	cmd := CacheCommand()
+	if err := cmd.Exec(context.Background(), []string{"what"}); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected ErrHelp, got %v", err)
+	}
+}
+
+func TestCacheClearRemovesEntries(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(reportcache.DirEnvVar, dir)
+	cache, err := reportcache.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put("pubsite_prod_rev_1", "stats/installs/", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := clearCommand()
+	if err := cmd.FlagSet.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = devNull
+	err = cmd.Exec(context.Background(), nil)
+	os.Stdout = stdout
+	_ = devNull.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "reports"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected cache entries removed, found %d", len(entries))
+	}
+}
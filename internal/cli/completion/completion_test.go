@@ -8,17 +8,36 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
 )
 
+func testTree() []*ffcli.Command {
+	statusFS := flag.NewFlagSet("auth status", flag.ContinueOnError)
+	statusFS.Bool("list-scopes", false, "List granted scopes")
+	listFS := flag.NewFlagSet("offers list", flag.ContinueOnError)
+	listFS.String("package", "", "Package name (applicationId)")
+	listFS.String("output", "json", "Output format")
+	return []*ffcli.Command{
+		{Name: "auth", ShortHelp: "Manage authentication.", Subcommands: []*ffcli.Command{
+			{Name: "status", ShortHelp: "Show authentication status.", FlagSet: statusFS},
+		}},
+		{Name: "offers", ShortHelp: "Manage subscription offers.", Subcommands: []*ffcli.Command{
+			{Name: "list", ShortHelp: "List offers.", FlagSet: listFS},
+		}},
+		{Name: "subscriptions", ShortHelp: "Manage subscriptions (don't confuse with offers)."},
+	}
+}
+
 func TestCompletionCommand_Name(t *testing.T) {
-	cmd := CompletionCommand()
+	cmd := CompletionCommand(testTree)
 	if cmd.Name != "completion" {
 		t.Errorf("Name = %q, want %q", cmd.Name, "completion")
 	}
 }
 
 func TestCompletionCommand_HasSubcommands(t *testing.T) {
-	cmd := CompletionCommand()
+	cmd := CompletionCommand(testTree)
 	names := map[string]bool{}
 	for _, sub := range cmd.Subcommands {
 		names[sub.Name] = true
@@ -31,7 +50,7 @@ func TestCompletionCommand_HasSubcommands(t *testing.T) {
 }
 
 func TestCompletionCommand_NoArgs_PrintsSetup(t *testing.T) {
-	cmd := CompletionCommand()
+	cmd := CompletionCommand(testTree)
 
 	// Capture stderr
 	oldStderr := os.Stderr
@@ -62,7 +81,7 @@ func TestCompletionCommand_NoArgs_PrintsSetup(t *testing.T) {
 }
 
 func TestBashCommand_Output(t *testing.T) {
-	cmd := BashCommand()
+	cmd := BashCommand(testTree)
 
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
@@ -87,7 +106,7 @@ func TestBashCommand_Output(t *testing.T) {
 }
 
 func TestZshCommand_Output(t *testing.T) {
-	cmd := ZshCommand()
+	cmd := ZshCommand(testTree)
 
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
@@ -112,7 +131,7 @@ func TestZshCommand_Output(t *testing.T) {
 }
 
 func TestFishCommand_Output(t *testing.T) {
-	cmd := FishCommand()
+	cmd := FishCommand(testTree)
 
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
@@ -139,7 +158,7 @@ func TestFishCommand_Output(t *testing.T) {
 }
 
 func TestPowerShellCommand_Output(t *testing.T) {
-	cmd := PowerShellCommand()
+	cmd := PowerShellCommand(testTree)
 
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
@@ -164,3 +183,65 @@ func TestPowerShellCommand_Output(t *testing.T) {
 		t.Error("expected PowerShell completion commands")
 	}
 }
+
+func TestBashCommand_WalksCommandTree(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeBash(&buf, collectTree(testTree())); err != nil {
+		t.Fatalf("writeBash: %v", err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{
+		`"") echo 'auth offers subscriptions' ;;`,
+		`"auth") echo 'status' ;;`,
+		`"auth status") echo '--list-scopes' ;;`,
+		`"offers list") echo '--output --package' ;;`,
+		"--dry-run",
+		"complete -o default -F _gplay_completions gplay",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("bash script missing %q\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, `"subscriptions")`) {
+		t.Error("leaf commands without flags should not get case entries")
+	}
+}
+
+func TestZshCommand_EscapesDescriptions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeZsh(&buf, collectTree(testTree())); err != nil {
+		t.Fatalf("writeZsh: %v", err)
+	}
+	want := `'subscriptions:Manage subscriptions (don'\''t confuse with offers).'`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("zsh script missing escaped description %q\n%s", want, buf.String())
+	}
+}
+
+func TestFishCommand_EscapesDescriptions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeFish(&buf, collectTree(testTree())); err != nil {
+		t.Fatalf("writeFish: %v", err)
+	}
+	want := `'subscriptions' 'Manage subscriptions (don\'t confuse with offers).'`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("fish script missing escaped description %q\n%s", want, buf.String())
+	}
+}
+
+func TestPowerShellCommand_WalksCommandTree(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writePowerShell(&buf, collectTree(testTree())); err != nil {
+		t.Fatalf("writePowerShell: %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"'' = @('auth', 'offers', 'subscriptions')",
+		"'offers list' = @('--output', '--package')",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("PowerShell script missing %q\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package completion
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+)
+
+// outputFormats are offered as values after --output.
+var outputFormats = []string{"json", "table", "markdown", "yaml"}
+
+// completionItem is a subcommand or flag name with its one-line help.
+type completionItem struct {
+	name string
+	help string
+}
+
+// commandNode describes what can follow a command path. The root command
+// has the empty path; nested paths are space-separated command names.
+type commandNode struct {
+	path        string
+	subcommands []completionItem
+	flags       []completionItem
+}
+
+// collectTree walks the registered commands depth-first and returns one node
+// per command path, starting with the root. Root-level flags are attached to
+// the root node because they must appear before the first subcommand.
+func collectTree(commands []*ffcli.Command) []commandNode {
+	rootFlags := flag.NewFlagSet("gplay", flag.ContinueOnError)
+	shared.BindRootFlags(rootFlags)
+
+	root := commandNode{flags: flagItems(rootFlags)}
+	for _, cmd := range commands {
+		root.subcommands = append(root.subcommands, completionItem{name: cmd.Name, help: cmd.ShortHelp})
+	}
+
+	nodes := []commandNode{root}
+	for _, cmd := range commands {
+		nodes = appendNodes(nodes, cmd.Name, cmd)
+	}
+	return nodes
+}
+
+func appendNodes(nodes []commandNode, path string, cmd *ffcli.Command) []commandNode {
+	node := commandNode{path: path, flags: flagItems(cmd.FlagSet)}
+	for _, sub := range cmd.Subcommands {
+		node.subcommands = append(node.subcommands, completionItem{name: sub.Name, help: sub.ShortHelp})
+	}
+	nodes = append(nodes, node)
+	for _, sub := range cmd.Subcommands {
+		nodes = appendNodes(nodes, path+" "+sub.Name, sub)
+	}
+	return nodes
+}
+
+func flagItems(fs *flag.FlagSet) []completionItem {
+	if fs == nil {
+		return nil
+	}
+	var items []completionItem
+	fs.VisitAll(func(f *flag.Flag) {
+		items = append(items, completionItem{name: "--" + f.Name, help: firstLine(f.Usage)})
+	})
+	return items
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func itemNames(items []completionItem) string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.name
+	}
+	return strings.Join(names, " ")
+}
+
+// singleQuote quotes s for POSIX shells, fish and PowerShell alike by
+// doubling or escaping embedded single quotes as each shell expects.
+func singleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func writeBash(w io.Writer, nodes []commandNode) error {
+	var b strings.Builder
+	b.WriteString("# gplay bash completion script\n")
+	b.WriteString("# Generated by gplay completion bash\n\n")
+
+	b.WriteString("_gplay_subcommands() {\n    case \"$1\" in\n")
+	for _, node := range nodes {
+		if len(node.subcommands) > 0 {
+			fmt.Fprintf(&b, "        %q) echo %s ;;\n", node.path, singleQuote(itemNames(node.subcommands)))
+		}
+	}
+	b.WriteString("    esac\n}\n\n")
+
+	b.WriteString("_gplay_flags() {\n    case \"$1\" in\n")
+	for _, node := range nodes {
+		if len(node.flags) > 0 {
+			fmt.Fprintf(&b, "        %q) echo %s ;;\n", node.path, singleQuote(itemNames(node.flags)))
+		}
+	}
+	b.WriteString("    esac\n}\n\n")
+
+	fmt.Fprintf(&b, `_gplay_completions() {
+    local cur prev word cmdpath="" i
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        word="${COMP_WORDS[i]}"
+        [[ "$word" == -* ]] && continue
+        if [[ " $(_gplay_subcommands "$cmdpath") " == *" $word "* ]]; then
+            cmdpath="${cmdpath:+$cmdpath }$word"
+        fi
+    done
+
+    if [[ "$prev" == "--output" ]]; then
+        COMPREPLY=($(compgen -W %s -- "$cur"))
+        return
+    fi
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "$(_gplay_flags "$cmdpath")" -- "$cur"))
+    else
+        COMPREPLY=($(compgen -W "$(_gplay_subcommands "$cmdpath")" -- "$cur"))
+    fi
+}
+
+complete -o default -F _gplay_completions gplay
+`, singleQuote(strings.Join(outputFormats, " ")))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeZsh(w io.Writer, nodes []commandNode) error {
+	var b strings.Builder
+	b.WriteString("#compdef gplay\n")
+	b.WriteString("# gplay zsh completion script\n")
+	b.WriteString("# Generated by gplay completion zsh\n\n")
+
+	writeZshCase(&b, "_gplay_subcommands", nodes, func(n commandNode) []completionItem { return n.subcommands })
+	writeZshCase(&b, "_gplay_flags", nodes, func(n commandNode) []completionItem { return n.flags })
+
+	fmt.Fprintf(&b, `_gplay() {
+    local -a reply
+    local word cmdpath="" i
+
+    for ((i = 2; i < CURRENT; i++)); do
+        word="${words[i]}"
+        [[ "$word" == -* ]] && continue
+        _gplay_subcommands "$cmdpath"
+        if (( ${reply[(I)${word}:*]} )); then
+            cmdpath="${cmdpath:+$cmdpath }$word"
+        fi
+    done
+
+    if [[ "${words[CURRENT-1]}" == "--output" ]]; then
+        compadd -- %s
+        return
+    fi
+
+    if [[ "$PREFIX" == -* ]]; then
+        _gplay_flags "$cmdpath"
+        _describe -t flags 'flag' reply
+    else
+        _gplay_subcommands "$cmdpath"
+        _describe -t commands 'command' reply
+    fi
+}
+
+_gplay "$@"
+`, strings.Join(outputFormats, " "))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeZshCase(b *strings.Builder, name string, nodes []commandNode, items func(commandNode) []completionItem) {
+	fmt.Fprintf(b, "%s() {\n    reply=()\n    case \"$1\" in\n", name)
+	for _, node := range nodes {
+		list := items(node)
+		if len(list) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "        %q)\n            reply=(\n", node.path)
+		for _, item := range list {
+			fmt.Fprintf(b, "                %s\n", singleQuote(item.name+":"+item.help))
+		}
+		b.WriteString("            )\n            ;;\n")
+	}
+	b.WriteString("    esac\n}\n\n")
+}
+
+func writeFish(w io.Writer, nodes []commandNode) error {
+	var b strings.Builder
+	b.WriteString("# gplay fish completion script\n")
+	b.WriteString("# Generated by gplay completion fish\n\n")
+
+	writeFishSwitch(&b, "__gplay_subcommands", nodes, func(n commandNode) []completionItem { return n.subcommands })
+	writeFishSwitch(&b, "__gplay_flags", nodes, func(n commandNode) []completionItem { return n.flags })
+
+	fmt.Fprintf(&b, `function __gplay_cmdpath
+    set -l cmdpath ''
+    for word in (commandline -opc)[2..-1]
+        string match -q -- '-*' $word; and continue
+        if contains -- $word (__gplay_subcommands $cmdpath | string split -f1 \t)
+            set cmdpath (string trim -- "$cmdpath $word")
+        end
+    end
+    echo $cmdpath
+end
+
+function __gplay_wants_subcommand
+    string match -q -- '-*' (commandline -ct); and return 1
+    set -l subs (__gplay_subcommands (__gplay_cmdpath))
+    test (count $subs) -gt 0
+end
+
+function __gplay_wants_flag
+    string match -q -- '-*' (commandline -ct)
+end
+
+complete -c gplay -n __gplay_wants_subcommand -f -a '(__gplay_subcommands (__gplay_cmdpath))'
+complete -c gplay -n __gplay_wants_flag -f -a '(__gplay_flags (__gplay_cmdpath))'
+complete -c gplay -l output -x -a %s
+`, singleQuote(strings.Join(outputFormats, " ")))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeFishSwitch(b *strings.Builder, name string, nodes []commandNode, items func(commandNode) []completionItem) {
+	fmt.Fprintf(b, "function %s\n    switch \"$argv[1]\"\n", name)
+	for _, node := range nodes {
+		list := items(node)
+		if len(list) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "        case %s\n", singleQuote(node.path))
+		for _, item := range list {
+			fmt.Fprintf(b, "            printf '%%s\\t%%s\\n' %s %s\n", singleQuote(item.name), fishQuote(item.help))
+		}
+	}
+	b.WriteString("    end\nend\n\n")
+}
+
+// fishQuote single-quotes s for fish, which escapes quotes with a backslash
+// rather than closing and reopening the string.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+func writePowerShell(w io.Writer, nodes []commandNode) error {
+	var b strings.Builder
+	b.WriteString("# gplay PowerShell completion script\n")
+	b.WriteString("# Generated by gplay completion powershell\n\n")
+	b.WriteString("Register-ArgumentCompleter -Native -CommandName gplay -ScriptBlock {\n")
+	b.WriteString("    param($wordToComplete, $commandAst, $cursorPosition)\n\n")
+
+	writePowerShellTable(&b, "$subcommands", nodes, func(n commandNode) []completionItem { return n.subcommands })
+	writePowerShellTable(&b, "$flags", nodes, func(n commandNode) []completionItem { return n.flags })
+
+	formats := make([]string, len(outputFormats))
+	for i, format := range outputFormats {
+		formats[i] = psQuote(format)
+	}
+	fmt.Fprintf(&b, `    $elements = $commandAst.CommandElements
+    $last = $elements.Count
+    if ($wordToComplete) { $last-- }
+    $cmdpath = ''
+    for ($i = 1; $i -lt $last; $i++) {
+        $element = $elements[$i].ToString()
+        if ($element.StartsWith('-')) { continue }
+        if ($subcommands.ContainsKey($cmdpath) -and $subcommands[$cmdpath] -contains $element) {
+            $cmdpath = "$cmdpath $element".Trim()
+        }
+    }
+
+    if ($last -gt 1 -and $elements[$last - 1].ToString() -eq '--output') {
+        $candidates = @(%s)
+        $kind = 'ParameterValue'
+    } elseif ($wordToComplete -like '-*') {
+        $candidates = $flags[$cmdpath]
+        $kind = 'ParameterName'
+    } else {
+        $candidates = $subcommands[$cmdpath]
+        $kind = 'Command'
+    }
+
+    $candidates | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, $kind, $_)
+    }
+}
+`, strings.Join(formats, ", "))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writePowerShellTable(b *strings.Builder, name string, nodes []commandNode, items func(commandNode) []completionItem) {
+	fmt.Fprintf(b, "    %s = @{\n", name)
+	for _, node := range nodes {
+		list := items(node)
+		if len(list) == 0 {
+			continue
+		}
+		names := make([]string, len(list))
+		for i, item := range list {
+			names[i] = psQuote(item.name)
+		}
+		fmt.Fprintf(b, "        %s = @(%s)\n", psQuote(node.path), strings.Join(names, ", "))
+	}
+	b.WriteString("    }\n\n")
+}
+
+// psQuote single-quotes s for PowerShell, which doubles embedded quotes.
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
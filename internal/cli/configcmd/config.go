@@ -0,0 +1,250 @@
+package configcmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+// configResult is the JSON output of config list and config set.
+type configResult struct {
+	ConfigPath string                 `json:"config_path"`
+	Settings   map[string]interface{} `json:"settings"`
+}
+
+// ConfigCommand returns the config command group.
+func ConfigCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("config", flag.ExitOnError)
+	return &ffcli.Command{
+		Name:       "config",
+		ShortUsage: "gplay config <subcommand> [flags]",
+		ShortHelp:  "View and edit config.json settings.",
+		LongHelp: `View and edit settings in the active config.json.
+
+The active file is $GPLAY_CONFIG_PATH, the nearest .gplay/config.json, or
+~/.gplay/config.json. Auth profiles are managed with "gplay auth".
+
+Keys:
+` + keyHelp(),
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			GetCommand(),
+			SetCommand(),
+			SetDefaultOutputCommand(),
+			SetDefaultPrettyCommand(),
+			ListCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+func GetCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("config get", flag.ExitOnError)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "get",
+		ShortUsage: "gplay config get <key>",
+		ShortHelp:  "Print the value of a config key.",
+		FlagSet:    fs,
+		UsageFunc:  shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if len(args) != 1 {
+				return shared.UsageError("config get expects exactly one argument: <key>")
+			}
+			s, err := lookupSetting(args[0])
+			if err != nil {
+				return err
+			}
+			_, cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			result := map[string]interface{}{"key": args[0], "value": s.get(cfg)}
+			return shared.PrintOutput(result, *outputFlag, *pretty)
+		},
+	}
+}
+
+func SetCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("config set", flag.ExitOnError)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "set",
+		ShortUsage: "gplay config set <key> <value>",
+		ShortHelp:  "Set a config key and save config.json.",
+		LongHelp: `Set a config key and save config.json.
+
+Pass an empty value ("") to clear a key. The file is created if it does
+not exist yet.
+
+Examples:
+  gplay config set package_name com.example.app
+  gplay config set timeout 2m
+  gplay config set max_retries 5`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if len(args) != 2 {
+				return shared.UsageError("config set expects two arguments: <key> <value>")
+			}
+			return setAndSave(args[0], args[1], *outputFlag, *pretty)
+		},
+	}
+}
+
+func SetDefaultOutputCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("config set-default-output", flag.ExitOnError)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "set-default-output",
+		ShortUsage: "gplay config set-default-output <json|table|markdown|yaml>",
+		ShortHelp:  "Set the output format used when --output is omitted.",
+		LongHelp: `Set the output format used when --output is omitted.
+
+An explicit --output always wins. GPLAY_DEFAULT_OUTPUT, when set, takes
+precedence over this setting. Pass "" to clear it.
+
+Same as: gplay config set default_output <format>
+
+Examples:
+  gplay config set-default-output table
+  gplay config set-default-output ""`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if len(args) != 1 {
+				return shared.UsageError("config set-default-output expects exactly one argument: <format>")
+			}
+			return setAndSave("default_output", args[0], *outputFlag, *pretty)
+		},
+	}
+}
+
+func SetDefaultPrettyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("config set-default-pretty", flag.ExitOnError)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "set-default-pretty",
+		ShortUsage: "gplay config set-default-pretty <true|false>",
+		ShortHelp:  "Pretty-print JSON output when --pretty is omitted.",
+		LongHelp: `Pretty-print JSON output when --pretty is omitted.
+
+The default only applies when the output format is JSON; an explicit
+--pretty=false always wins.
+
+Same as: gplay config set default_pretty <true|false>
+
+Examples:
+  gplay config set-default-pretty true`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if len(args) != 1 {
+				return shared.UsageError("config set-default-pretty expects exactly one argument: <true|false>")
+			}
+			return setAndSave("default_pretty", args[0], *outputFlag, *pretty)
+		},
+	}
+}
+
+func ListCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("config list", flag.ExitOnError)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "list",
+		ShortUsage: "gplay config list",
+		ShortHelp:  "Print all editable config keys and their values.",
+		FlagSet:    fs,
+		UsageFunc:  shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			path, cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return shared.PrintOutput(configResult{ConfigPath: path, Settings: settingValues(cfg)}, *outputFlag, *pretty)
+		},
+	}
+}
+
+// setAndSave sets key to value in the active config file, validates the
+// result, and prints the updated settings.
+func setAndSave(key, value, outputFormat string, pretty bool) error {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return err
+	}
+	path, cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := s.set(cfg, strings.TrimSpace(value)); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if err := config.SaveAt(path, cfg); err != nil {
+		return err
+	}
+	return shared.PrintOutput(configResult{ConfigPath: path, Settings: settingValues(cfg)}, outputFormat, pretty)
+}
+
+// loadConfig reads the active config file, starting from an empty config
+// when none exists yet.
+func loadConfig() (string, *config.Config, error) {
+	path, err := config.Path()
+	if err != nil {
+		return "", nil, err
+	}
+	cfg, err := config.LoadAt(path)
+	if errors.Is(err, config.ErrNotFound) {
+		return path, &config.Config{}, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return path, cfg, nil
+}
+
+func keyHelp() string {
+	var b strings.Builder
+	for _, name := range settingNames() {
+		fmt.Fprintf(&b, "  %-24s %s\n", name, settings[name].help)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,207 @@
+package configcmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+func useTempConfig(t *testing.T, cfg *config.Config) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if cfg != nil {
+		if err := config.SaveAt(path, cfg); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	t.Setenv("GPLAY_CONFIG_PATH", path)
+	return path
+}
+
+func captureConfigStdout(fn func() error) (string, error) {
+	origStdout := os.Stdout
+	rOut, wOut, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+
+	os.Stdout = wOut
+
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&buf, rOut)
+	}()
+
+	runErr := fn()
+
+	_ = wOut.Close()
+	os.Stdout = origStdout
+	wg.Wait()
+	_ = rOut.Close()
+
+	return buf.String(), runErr
+}
+
+func run(t *testing.T, cmd *ffcli.Command, args ...string) (string, error) {
+	t.Helper()
+	if err := cmd.FlagSet.Parse(args); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	return captureConfigStdout(func() error {
+		return cmd.Exec(context.Background(), cmd.FlagSet.Args())
+	})
+}
+
+func TestConfigSet_PersistsAndReloads(t *testing.T) {
+	path := useTempConfig(t, &config.Config{
+		DefaultProfile: "ci",
+		Profiles:       []config.Profile{{Name: "ci", Type: "service_account", KeyPath: "/keys/ci.json"}},
+	})
+
+	stdout, err := run(t, SetCommand(), "package_name", "com.example.app")
+	if err != nil {
+		t.Fatalf("set package_name: %v", err)
+	}
+	var result configResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output %q: %v", stdout, err)
+	}
+	if result.ConfigPath != path || result.Settings["package_name"] != "com.example.app" {
+		t.Fatalf("unexpected output: %+v", result)
+	}
+
+	if _, err := run(t, SetCommand(), "timeout", "2m"); err != nil {
+		t.Fatalf("set timeout: %v", err)
+	}
+	if _, err := run(t, SetCommand(), "max_retries", "5"); err != nil {
+		t.Fatalf("set max_retries: %v", err)
+	}
+
+	reloaded, err := config.LoadAt(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if reloaded.PackageName != "com.example.app" {
+		t.Errorf("package_name = %q", reloaded.PackageName)
+	}
+	if d, ok := reloaded.Timeout.Value(); !ok || d != 2*time.Minute {
+		t.Errorf("timeout = %v", reloaded.Timeout)
+	}
+	if reloaded.MaxRetries != 5 {
+		t.Errorf("max_retries = %d", reloaded.MaxRetries)
+	}
+	if len(reloaded.Profiles) != 1 || reloaded.Profiles[0].KeyPath != "/keys/ci.json" {
+		t.Errorf("profiles were not preserved: %+v", reloaded.Profiles)
+	}
+}
+
+func TestConfigSet_CreatesMissingFile(t *testing.T) {
+	path := useTempConfig(t, nil)
+	if _, err := run(t, SetCommand(), "retry_delay", "3s"); err != nil {
+		t.Fatalf("set retry_delay: %v", err)
+	}
+	reloaded, err := config.LoadAt(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if reloaded.RetryDelay != "3s" {
+		t.Fatalf("retry_delay = %q", reloaded.RetryDelay)
+	}
+}
+
+func TestConfigSet_RejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown key", []string{"colour", "blue"}, "unknown config key"},
+		{"non-integer retries", []string{"max_retries", "many"}, "must be an integer"},
+		{"retries out of range", []string{"max_retries", "99"}, "max_retries must be between"},
+		{"bad duration", []string{"timeout", "soon"}, "expected a duration"},
+		{"bad retry delay", []string{"retry_delay", "5"}, "retry_delay must be a duration"},
+		{"bad debug", []string{"debug", "maybe"}, "debug must be true or false"},
+		{"unknown profile", []string{"default_profile", "prod"}, "not found in profiles"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := useTempConfig(t, &config.Config{Profiles: []config.Profile{{Name: "ci", Type: "service_account"}}})
+			before, _ := os.ReadFile(path)
+
+			_, err := run(t, SetCommand(), tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+			after, _ := os.ReadFile(path)
+			if !bytes.Equal(before, after) {
+				t.Fatal("config file must not change on invalid input")
+			}
+		})
+	}
+}
+
+func TestConfigGet(t *testing.T) {
+	useTempConfig(t, &config.Config{PackageName: "com.example.app"})
+	stdout, err := run(t, GetCommand(), "package_name")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got, want := strings.TrimSpace(stdout), `{"key":"package_name","value":"com.example.app"}`; got != want {
+		t.Fatalf("output = %s, want %s", got, want)
+	}
+}
+
+func TestConfigList_IncludesEveryKey(t *testing.T) {
+	useTempConfig(t, nil)
+	stdout, err := run(t, ListCommand())
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var result configResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	for _, key := range settingNames() {
+		if _, ok := result.Settings[key]; !ok {
+			t.Errorf("missing key %q in list output", key)
+		}
+	}
+}
+
+func TestConfigSetDefaultOutputAndPretty(t *testing.T) {
+	path := useTempConfig(t, nil)
+	if _, err := run(t, SetDefaultOutputCommand(), "TABLE"); err != nil {
+		t.Fatalf("set-default-output: %v", err)
+	}
+	if _, err := run(t, SetDefaultPrettyCommand(), "true"); err != nil {
+		t.Fatalf("set-default-pretty: %v", err)
+	}
+	reloaded, err := config.LoadAt(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if reloaded.DefaultOutput != "table" || !reloaded.DefaultPretty {
+		t.Fatalf("default_output = %q, default_pretty = %v", reloaded.DefaultOutput, reloaded.DefaultPretty)
+	}
+
+	if _, err := run(t, SetDefaultOutputCommand(), "xml"); err == nil || !strings.Contains(err.Error(), "default_output must be one of") {
+		t.Fatalf("expected default_output error, got %v", err)
+	}
+	if _, err := run(t, SetDefaultPrettyCommand(), "maybe"); err == nil || !strings.Contains(err.Error(), "default_pretty must be true or false") {
+		t.Fatalf("expected default_pretty error, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package configcmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+// setting is a config.json key editable with gplay config set.
+type setting struct {
+	help string
+	get  func(cfg *config.Config) interface{}
+	set  func(cfg *config.Config, value string) error
+}
+
+var settings = map[string]setting{
+	"package_name": {
+		help: "Default package name used when --package is omitted (a profile's default_package wins)",
+		get:  func(cfg *config.Config) interface{} { return cfg.PackageName },
+		set: func(cfg *config.Config, value string) error {
+			cfg.PackageName = value
+			return nil
+		},
+	},
+	"developer_id": {
+		help: "Default developer ID used when --developer is omitted (a profile's default_developer wins)",
+		get:  func(cfg *config.Config) interface{} { return cfg.DeveloperID },
+		set: func(cfg *config.Config, value string) error {
+			cfg.DeveloperID = value
+			return nil
+		},
+	},
+	"default_profile": {
+		help: "Auth profile used when --profile is omitted",
+		get:  func(cfg *config.Config) interface{} { return cfg.DefaultProfile },
+		set: func(cfg *config.Config, value string) error {
+			cfg.DefaultProfile = value
+			return nil
+		},
+	},
+	"timeout":                durationSetting("Request timeout (e.g. 60s, 2m)", func(cfg *config.Config) *config.DurationValue { return &cfg.Timeout }),
+	"timeout_seconds":        durationSetting("Request timeout in seconds", func(cfg *config.Config) *config.DurationValue { return &cfg.TimeoutSeconds }),
+	"upload_timeout":         durationSetting("Upload timeout (e.g. 10m)", func(cfg *config.Config) *config.DurationValue { return &cfg.UploadTimeout }),
+	"upload_timeout_seconds": durationSetting("Upload timeout in seconds", func(cfg *config.Config) *config.DurationValue { return &cfg.UploadTimeoutSeconds }),
+	"max_retries": {
+		help: "Maximum retries for failed requests (0-30)",
+		get:  func(cfg *config.Config) interface{} { return cfg.MaxRetries },
+		set: func(cfg *config.Config, value string) error {
+			if value == "" {
+				cfg.MaxRetries = 0
+				return nil
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("max_retries must be an integer, got %q", value)
+			}
+			cfg.MaxRetries = n
+			return nil
+		},
+	},
+	"max_qps": {
+		help: "Maximum API requests per second (0 = unlimited)",
+		get:  func(cfg *config.Config) interface{} { return cfg.MaxQPS },
+		set: func(cfg *config.Config, value string) error {
+			if value == "" {
+				cfg.MaxQPS = 0
+				return nil
+			}
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("max_qps must be a number, got %q", value)
+			}
+			cfg.MaxQPS = n
+			return nil
+		},
+	},
+	"retry_delay": {
+		help: "Delay between retries (e.g. 2s)",
+		get:  func(cfg *config.Config) interface{} { return cfg.RetryDelay },
+		set: func(cfg *config.Config, value string) error {
+			if value != "" {
+				if _, err := time.ParseDuration(value); err != nil {
+					return fmt.Errorf("retry_delay must be a duration such as 2s, got %q", value)
+				}
+			}
+			cfg.RetryDelay = value
+			return nil
+		},
+	},
+	"default_output": {
+		help: "Output format used when --output is omitted (json, table, markdown, yaml)",
+		get:  func(cfg *config.Config) interface{} { return cfg.DefaultOutput },
+		set: func(cfg *config.Config, value string) error {
+			cfg.DefaultOutput = strings.ToLower(value)
+			return nil
+		},
+	},
+	"default_pretty": {
+		help: "Pretty-print JSON output when --pretty is omitted (true/false)",
+		get:  func(cfg *config.Config) interface{} { return cfg.DefaultPretty },
+		set: func(cfg *config.Config, value string) error {
+			if value == "" {
+				cfg.DefaultPretty = false
+				return nil
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("default_pretty must be true or false, got %q", value)
+			}
+			cfg.DefaultPretty = b
+			return nil
+		},
+	},
+	"debug": {
+		help: "Enable debug logging (true/false)",
+		get:  func(cfg *config.Config) interface{} { return cfg.Debug },
+		set: func(cfg *config.Config, value string) error {
+			if value != "" {
+				if _, err := strconv.ParseBool(value); err != nil {
+					return fmt.Errorf("debug must be true or false, got %q", value)
+				}
+			}
+			cfg.Debug = value
+			return nil
+		},
+	},
+}
+
+func durationSetting(help string, field func(cfg *config.Config) *config.DurationValue) setting {
+	return setting{
+		help: help,
+		get:  func(cfg *config.Config) interface{} { return field(cfg).String() },
+		set: func(cfg *config.Config, value string) error {
+			parsed, err := config.ParseDurationValue(value)
+			if err != nil {
+				return fmt.Errorf("expected a duration such as 30s or a number of seconds: %w", err)
+			}
+			*field(cfg) = parsed
+			return nil
+		},
+	}
+}
+
+// settingNames returns the editable keys in sorted order.
+func settingNames() []string {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupSetting(key string) (setting, error) {
+	s, ok := settings[strings.TrimSpace(key)]
+	if !ok {
+		return setting{}, fmt.Errorf("unknown config key %q (valid keys: %s)", key, strings.Join(settingNames(), ", "))
+	}
+	return s, nil
+}
+
+// settingValues returns every editable key with its current value.
+func settingValues(cfg *config.Config) map[string]interface{} {
+	values := make(map[string]interface{}, len(settings))
+	for name, s := range settings {
+		values[name] = s.get(cfg)
+	}
+	return values
+}
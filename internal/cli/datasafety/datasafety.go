@@ -41,8 +41,8 @@ Play Store listing.`,
 func UpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("data-safety update", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "SafetyLabelsUpdateRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "SafetyLabelsUpdateRequest JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -4,7 +4,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
@@ -41,8 +40,8 @@ func UploadCommand() *ffcli.Command {
 	editID := fs.String("edit", "", "Edit ID")
 	apkVersionCode := fs.String("apk-version", "", "APK version code")
 	deobfuscationType := fs.String("type", "proguard", "Deobfuscation file type: proguard (default), nativeCode")
-	filePath := fs.String("file", "", "Path to mapping file (e.g., mapping.txt)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	filePath := fs.String("file", "", "Path to mapping file (e.g., mapping.txt, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -86,7 +85,7 @@ func UploadCommand() *ffcli.Command {
 				return fmt.Errorf("--package is required")
 			}
 
-			file, err := os.Open(*filePath)
+			file, err := shared.OpenInput(*filePath)
 			if err != nil {
 				return shared.WrapActionable(err, "failed to open deobfuscation file", "Check that the file exists and is readable.")
 			}
@@ -0,0 +1,113 @@
+package deploy
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/notify"
+	"github.com/tamtom/play-console-cli/internal/cli/release"
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+)
+
+// DeployCommand returns the deploy command.
+func DeployCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("deploy", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	bundlePath := fs.String("bundle", "", "Path to .aab bundle file")
+	track := fs.String("track", "internal", "Target track (production, beta, alpha, internal, or custom track)")
+	rolloutFraction := fs.Float64("rollout", 1.0, "Staged rollout fraction (0.0-1.0, default: 1.0 for full rollout)")
+	status := fs.String("status", "completed", "Release status: draft, inProgress, halted, completed")
+	releaseNotes := fs.String("release-notes", "", "Release notes: plain text (en-US), JSON array, or @file path")
+	changesNotSent := fs.Bool("changes-not-sent-for-review", false, "Commit without sending changes for review")
+	assumeYes := fs.Bool("assume-yes", false, "Skip the typed confirmation required for the production track")
+	dryRun := fs.Bool("dry-run", false, "Validate the bundle and print the deploy plan without calling the API")
+	webhookURL := fs.String("webhook-url", "", "Webhook URL to notify after a successful deploy")
+	webhookFormat := fs.String("webhook-format", "slack", "Webhook payload format: slack (default), discord, generic")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "deploy",
+		ShortUsage: "gplay deploy --package <name> --bundle <path> [--track <track>] [--rollout <fraction>] [--webhook-url <url>]",
+		ShortHelp:  "Validate, upload, release, and commit a bundle in one step.",
+		LongHelp: `Deploy an app bundle end to end:
+  1. Validate the bundle locally
+  2. Create an edit
+  3. Upload the bundle
+  4. Set the track release (status, rollout, release notes)
+  5. Validate and commit the edit
+  6. Send a webhook notification (if --webhook-url is set)
+
+If any step after the edit is created fails, the edit is deleted so no
+partial changes are left behind. A failed notification is reported as a
+warning because the release has already been committed.
+
+--dry-run (or the root --dry-run flag) runs the local validation and prints
+the planned steps without calling the Play API or the webhook.
+
+Examples:
+  gplay deploy --package com.example.app --bundle app.aab --track internal
+  gplay deploy --package com.example.app --bundle app.aab --track production --rollout 0.1 --assume-yes --webhook-url URL`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*bundlePath) == "" {
+				return fmt.Errorf("--bundle is required")
+			}
+			if strings.TrimSpace(*track) == "" {
+				return fmt.Errorf("--track is required")
+			}
+			if *rolloutFraction <= 0 || *rolloutFraction > 1 {
+				return fmt.Errorf("--rollout must be greater than 0.0 and at most 1.0")
+			}
+			if err := validateStatus(strings.TrimSpace(*status)); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*releaseNotes) != "" {
+				if _, err := release.ParseReleaseNotes(*releaseNotes); err != nil {
+					return fmt.Errorf("--release-notes: %w", err)
+				}
+			}
+			if strings.TrimSpace(*webhookURL) != "" {
+				if err := notify.ValidateWebhookURL(*webhookURL); err != nil {
+					return err
+				}
+				if _, err := notify.ParseFormat(*webhookFormat); err != nil {
+					return err
+				}
+			}
+			preview := *dryRun || shared.IsDryRun(ctx)
+			if !preview {
+				if err := shared.ConfirmProductionTrack(*track, "deploy", *assumeYes); err != nil {
+					return err
+				}
+			}
+
+			result, err := Run(ctx, Options{
+				PackageName:     *packageName,
+				BundlePath:      *bundlePath,
+				Track:           strings.TrimSpace(*track),
+				RolloutFraction: *rolloutFraction,
+				Status:          *status,
+				ReleaseNotes:    *releaseNotes,
+				ChangesNotSent:  *changesNotSent,
+				DryRun:          preview,
+				WebhookURL:      *webhookURL,
+				WebhookFormat:   *webhookFormat,
+				WebhookClient:   http.DefaultClient,
+			})
+			if err != nil {
+				return err
+			}
+			return shared.PrintOutput(result, *outputFlag, *pretty)
+		},
+	}
+}
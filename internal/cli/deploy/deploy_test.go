@@ -0,0 +1,328 @@
+package deploy
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+func writeTestBundle(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "app.aab")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(file)
+	for _, name := range []string{"BundleConfig.pb", "base/manifest/AndroidManifest.xml"} {
+		if _, err := w.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// fakePlay records the API calls made during a deploy and can fail one of
+// them to exercise rollback.
+type fakePlay struct {
+	mu        sync.Mutex
+	calls     []string
+	failOn    string
+	trackBody map[string]interface{}
+}
+
+func (f *fakePlay) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		var call string
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/applications/com.example.app/edits"):
+			call = "insert"
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits/edit-1/bundles"):
+			call = "upload"
+		case r.Method == http.MethodPut && strings.HasSuffix(path, "/edits/edit-1/tracks/beta"):
+			call = "track"
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode track body: %v", err)
+			}
+			f.mu.Lock()
+			f.trackBody = body
+			f.mu.Unlock()
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits/edit-1:validate"):
+			call = "validate"
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits/edit-1:commit"):
+			call = "commit"
+		case r.Method == http.MethodDelete && strings.HasSuffix(path, "/edits/edit-1"):
+			call = "delete"
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		f.mu.Lock()
+		f.calls = append(f.calls, call)
+		f.mu.Unlock()
+
+		if call == f.failOn {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":{"code":400,"message":"boom"}}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch call {
+		case "upload":
+			_, _ = io.WriteString(w, `{"versionCode":42}`)
+		case "delete":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = io.WriteString(w, `{"id":"edit-1"}`)
+		}
+	}
+}
+
+func installFakePlay(t *testing.T, fake *fakePlay) {
+	t.Helper()
+	server := httptest.NewServer(fake.handler(t))
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() { newPlayService = original })
+}
+
+type recordingDoer struct {
+	requests int
+	body     string
+}
+
+func (d *recordingDoer) Do(req *http.Request) (*http.Response, error) {
+	d.requests++
+	body, _ := io.ReadAll(req.Body)
+	d.body = string(body)
+	return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader("ok"))}, nil
+}
+
+func testOptions(t *testing.T) Options {
+	return Options{
+		PackageName:     "com.example.app",
+		BundlePath:      writeTestBundle(t),
+		Track:           "beta",
+		RolloutFraction: 0.2,
+		Status:          "completed",
+		ReleaseNotes:    "Bug fixes",
+	}
+}
+
+func stepNames(steps []Step) []string {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.Name + ":" + step.Status
+	}
+	return names
+}
+
+func TestRun_HappyPathSequence(t *testing.T) {
+	fake := &fakePlay{}
+	installFakePlay(t, fake)
+	doer := &recordingDoer{}
+
+	opts := testOptions(t)
+	opts.WebhookURL = "https://hooks.example.com/abc"
+	opts.WebhookFormat = "generic"
+	opts.WebhookClient = doer
+
+	result, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"insert", "upload", "track", "validate", "commit"}; !reflect.DeepEqual(fake.calls, want) {
+		t.Fatalf("calls = %v, want %v", fake.calls, want)
+	}
+	wantSteps := []string{"validate-bundle:ok", "create-edit:ok", "upload-bundle:ok", "update-track:ok", "validate-edit:ok", "commit-edit:ok", "notify:ok"}
+	if got := stepNames(result.Steps); !reflect.DeepEqual(got, wantSteps) {
+		t.Fatalf("steps = %v, want %v", got, wantSteps)
+	}
+	if result.VersionCode != 42 || result.EditID != "edit-1" || result.Status != "inProgress" || result.RolloutFraction != 0.2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	releases := fake.trackBody["releases"].([]interface{})
+	rel := releases[0].(map[string]interface{})
+	if rel["status"] != "inProgress" || rel["userFraction"] != 0.2 {
+		t.Errorf("unexpected release: %v", rel)
+	}
+	if codes := rel["versionCodes"].([]interface{}); len(codes) != 1 || codes[0] != "42" {
+		t.Errorf("versionCodes = %v, want [42]", codes)
+	}
+
+	if doer.requests != 1 || !strings.Contains(doer.body, "version code 42") {
+		t.Errorf("expected one webhook mentioning the version code, got %d requests: %s", doer.requests, doer.body)
+	}
+}
+
+func TestRun_FailureRollsBackEdit(t *testing.T) {
+	for _, failOn := range []string{"upload", "track", "validate", "commit"} {
+		t.Run(failOn, func(t *testing.T) {
+			fake := &fakePlay{failOn: failOn}
+			installFakePlay(t, fake)
+			doer := &recordingDoer{}
+
+			opts := testOptions(t)
+			opts.WebhookURL = "https://hooks.example.com/abc"
+			opts.WebhookClient = doer
+
+			_, err := Run(context.Background(), opts)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), "edit edit-1 deleted") {
+				t.Errorf("error should report the rollback, got %v", err)
+			}
+			if last := fake.calls[len(fake.calls)-1]; last != "delete" {
+				t.Errorf("expected edit delete after failure, calls = %v", fake.calls)
+			}
+			for _, call := range fake.calls {
+				if call == "commit" && failOn != "commit" {
+					t.Errorf("commit must not run after %s fails", failOn)
+				}
+			}
+			if doer.requests != 0 {
+				t.Error("webhook must not be called for a failed deploy")
+			}
+		})
+	}
+}
+
+func TestRun_RollbackFailureIsReported(t *testing.T) {
+	fake := &fakePlay{failOn: "track"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fake.handler(t)(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() { newPlayService = original })
+
+	_, err := Run(context.Background(), testOptions(t))
+	if err == nil || !strings.Contains(err.Error(), "rollback failed") || !strings.Contains(err.Error(), "gplay edits delete") {
+		t.Fatalf("expected rollback failure guidance, got %v", err)
+	}
+}
+
+func TestRun_DryRunMakesNoCalls(t *testing.T) {
+	fake := &fakePlay{}
+	installFakePlay(t, fake)
+	doer := &recordingDoer{}
+
+	opts := testOptions(t)
+	opts.DryRun = true
+	opts.WebhookURL = "https://hooks.example.com/abc"
+	opts.WebhookClient = doer
+
+	result, err := Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.calls) != 0 || doer.requests != 0 {
+		t.Fatalf("dry run made calls: play=%v webhook=%d", fake.calls, doer.requests)
+	}
+	want := []string{"validate-bundle:ok", "create-edit:planned", "upload-bundle:planned", "update-track:planned", "validate-edit:planned", "commit-edit:planned", "notify:planned"}
+	if got := stepNames(result.Steps); !reflect.DeepEqual(got, want) {
+		t.Fatalf("steps = %v, want %v", got, want)
+	}
+}
+
+func TestRun_InvalidBundleStopsBeforeEdit(t *testing.T) {
+	fake := &fakePlay{}
+	installFakePlay(t, fake)
+
+	opts := testOptions(t)
+	opts.BundlePath = filepath.Join(t.TempDir(), "missing.aab")
+
+	_, err := Run(context.Background(), opts)
+	if err == nil || !strings.Contains(err.Error(), "bundle validation failed") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if len(fake.calls) != 0 {
+		t.Fatalf("expected no API calls, got %v", fake.calls)
+	}
+}
+
+func TestRun_InvalidStatusStopsBeforeEdit(t *testing.T) {
+	fake := &fakePlay{}
+	installFakePlay(t, fake)
+
+	opts := testOptions(t)
+	opts.Status = "rolling"
+
+	_, err := Run(context.Background(), opts)
+	if err == nil || !strings.Contains(err.Error(), "--status") {
+		t.Fatalf("expected --status error, got %v", err)
+	}
+	if len(fake.calls) != 0 {
+		t.Fatalf("expected no API calls, got %v", fake.calls)
+	}
+}
+
+func TestDeployCommand_RequiresBundle(t *testing.T) {
+	cmd := DeployCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--bundle") {
+		t.Fatalf("expected --bundle error, got %v", err)
+	}
+}
+
+func TestDeployCommand_ProductionRequiresConfirmation(t *testing.T) {
+	cmd := DeployCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--bundle", "app.aab", "--track", "production"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--assume-yes") {
+		t.Fatalf("expected confirmation error, got %v", err)
+	}
+}
+
+func TestDeployCommand_RejectsInvalidStatus(t *testing.T) {
+	cmd := DeployCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--bundle", "app.aab", "--track", "production", "--status", "live"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--status must be one of") {
+		t.Fatalf("expected --status error, got %v", err)
+	}
+}
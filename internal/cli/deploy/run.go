@@ -0,0 +1,267 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/bundles"
+	"github.com/tamtom/play-console-cli/internal/cli/notify"
+	"github.com/tamtom/play-console-cli/internal/cli/release"
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/cli/validate"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+var newPlayService = playclient.NewService
+
+// Step names in execution order.
+const (
+	stepValidateBundle = "validate-bundle"
+	stepCreateEdit     = "create-edit"
+	stepUploadBundle   = "upload-bundle"
+	stepUpdateTrack    = "update-track"
+	stepValidateEdit   = "validate-edit"
+	stepCommitEdit     = "commit-edit"
+	stepNotify         = "notify"
+	stepRollback       = "rollback"
+)
+
+// Step statuses.
+const (
+	statusOK      = "ok"
+	statusPlanned = "planned"
+	statusFailed  = "failed"
+)
+
+// Options describes the deploy pipeline inputs.
+type Options struct {
+	PackageName     string
+	BundlePath      string
+	Track           string
+	RolloutFraction float64
+	Status          string
+	ReleaseNotes    string
+	ChangesNotSent  bool
+	DryRun          bool
+	WebhookURL      string
+	WebhookFormat   string
+	WebhookClient   notify.HTTPDoer
+}
+
+// Step records the outcome of one pipeline step.
+type Step struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Result summarizes a deploy run.
+type Result struct {
+	PackageName     string  `json:"packageName"`
+	Track           string  `json:"track"`
+	EditID          string  `json:"editId,omitempty"`
+	VersionCode     int64   `json:"versionCode,omitempty"`
+	Status          string  `json:"status"`
+	RolloutFraction float64 `json:"rolloutFraction,omitempty"`
+	DryRun          bool    `json:"dryRun,omitempty"`
+	Steps           []Step  `json:"steps"`
+}
+
+func (r *Result) record(name, status, detail string) {
+	r.Steps = append(r.Steps, Step{Name: name, Status: status, Detail: detail})
+}
+
+// Run executes the deploy pipeline. Once an edit exists, any failure deletes
+// it before the error is returned.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	trackRelease, err := buildRelease(opts)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{
+		PackageName:     strings.TrimSpace(opts.PackageName),
+		Track:           opts.Track,
+		Status:          trackRelease.Status,
+		RolloutFraction: trackRelease.UserFraction,
+		DryRun:          opts.DryRun,
+	}
+
+	check := validate.ValidateBundleFile(opts.BundlePath)
+	if !check.Valid {
+		return nil, fmt.Errorf("bundle validation failed: %s", strings.Join(check.Errors, "; "))
+	}
+	for _, warning := range check.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	result.record(stepValidateBundle, statusOK, opts.BundlePath)
+
+	if opts.DryRun {
+		for _, name := range []string{stepCreateEdit, stepUploadBundle, stepUpdateTrack, stepValidateEdit, stepCommitEdit} {
+			result.record(name, statusPlanned, "")
+		}
+		if strings.TrimSpace(opts.WebhookURL) != "" {
+			result.record(stepNotify, statusPlanned, notify.MaskURL(opts.WebhookURL))
+		}
+		return result, nil
+	}
+
+	service, err := newPlayService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pkg := shared.ResolvePackageName(opts.PackageName, service.Cfg)
+	if strings.TrimSpace(pkg) == "" {
+		return nil, fmt.Errorf("--package is required")
+	}
+	result.PackageName = pkg
+
+	shared.Infof(ctx, "Creating edit...\n")
+	editCtx, editCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	edit, err := service.API.Edits.Insert(pkg, &androidpublisher.AppEdit{}).Context(editCtx).Do()
+	editCancel()
+	if err != nil {
+		return nil, fmt.Errorf("deploy failed at %s: %w", stepCreateEdit, err)
+	}
+	result.EditID = edit.Id
+	result.record(stepCreateEdit, statusOK, edit.Id)
+
+	if err := runEditSteps(ctx, service, pkg, edit.Id, trackRelease, opts, result); err != nil {
+		return nil, rollback(ctx, service, pkg, edit.Id, result, err)
+	}
+
+	if strings.TrimSpace(opts.WebhookURL) != "" {
+		sendNotification(ctx, service, opts, result)
+	}
+	return result, nil
+}
+
+// runEditSteps uploads, configures, validates and commits the edit.
+func runEditSteps(ctx context.Context, service *playclient.Service, pkg, editID string, trackRelease *androidpublisher.TrackRelease, opts Options, result *Result) error {
+	shared.Infof(ctx, "Uploading bundle: %s\n", opts.BundlePath)
+	bundle, err := bundles.UploadBundle(ctx, service, pkg, editID, opts.BundlePath)
+	if err != nil {
+		result.record(stepUploadBundle, statusFailed, err.Error())
+		return fmt.Errorf("deploy failed at %s: %w", stepUploadBundle, err)
+	}
+	result.VersionCode = bundle.VersionCode
+	result.record(stepUploadBundle, statusOK, fmt.Sprintf("version code %d", bundle.VersionCode))
+
+	trackRelease.VersionCodes = []int64{bundle.VersionCode}
+	trackObj := &androidpublisher.Track{Track: opts.Track, Releases: []*androidpublisher.TrackRelease{trackRelease}}
+	shared.Infof(ctx, "Configuring track: %s\n", opts.Track)
+	trackCtx, trackCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	_, err = service.API.Edits.Tracks.Update(pkg, editID, opts.Track, trackObj).Context(trackCtx).Do()
+	trackCancel()
+	if err != nil {
+		result.record(stepUpdateTrack, statusFailed, err.Error())
+		return fmt.Errorf("deploy failed at %s: %w", stepUpdateTrack, err)
+	}
+	result.record(stepUpdateTrack, statusOK, opts.Track)
+
+	shared.Infof(ctx, "Validating edit...\n")
+	validateCtx, validateCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	_, err = service.API.Edits.Validate(pkg, editID).Context(validateCtx).Do()
+	validateCancel()
+	if err != nil {
+		result.record(stepValidateEdit, statusFailed, err.Error())
+		return fmt.Errorf("deploy failed at %s: %w", stepValidateEdit, err)
+	}
+	result.record(stepValidateEdit, statusOK, "")
+
+	shared.Infof(ctx, "Committing edit...\n")
+	commitCtx, commitCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	commitCall := service.API.Edits.Commit(pkg, editID).Context(commitCtx)
+	if opts.ChangesNotSent {
+		commitCall = commitCall.ChangesNotSentForReview(true)
+	}
+	_, err = commitCall.Do()
+	commitCancel()
+	if err != nil {
+		result.record(stepCommitEdit, statusFailed, err.Error())
+		return fmt.Errorf("deploy failed at %s: %w", stepCommitEdit, err)
+	}
+	result.record(stepCommitEdit, statusOK, "")
+	return nil
+}
+
+// rollback deletes the edit after a failed step and folds the outcome into
+// the returned error.
+func rollback(ctx context.Context, service *playclient.Service, pkg, editID string, result *Result, cause error) error {
+	shared.Infof(ctx, "Rolling back: deleting edit %s\n", editID)
+	// The parent context may be the reason the step failed; give the
+	// delete its own deadline so the edit is still cleaned up.
+	deleteCtx, cancel := shared.ContextWithTimeout(context.WithoutCancel(ctx), service.Cfg)
+	defer cancel()
+	if err := service.API.Edits.Delete(pkg, editID).Context(deleteCtx).Do(); err != nil {
+		result.record(stepRollback, statusFailed, err.Error())
+		return fmt.Errorf("%w (rollback failed: %v; delete it with: gplay edits delete --package %s --edit %s)", cause, err, pkg, editID)
+	}
+	result.record(stepRollback, statusOK, editID)
+	return fmt.Errorf("%w (edit %s deleted)", cause, editID)
+}
+
+// sendNotification posts the deploy summary to the webhook. Failures are
+// warnings because the release is already committed.
+func sendNotification(ctx context.Context, service *playclient.Service, opts Options, result *Result) {
+	format, err := notify.ParseFormat(opts.WebhookFormat)
+	if err != nil {
+		result.record(stepNotify, statusFailed, err.Error())
+		fmt.Fprintf(os.Stderr, "Warning: notification not sent: %v\n", err)
+		return
+	}
+	message := fmt.Sprintf("Deployed version code %d to %s (%s)", result.VersionCode, result.Track, result.Status)
+	payload := notify.BuildPayload(format, message, "release", result.PackageName)
+
+	notifyCtx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	defer cancel()
+	if _, err := notify.PostWebhook(notifyCtx, opts.WebhookClient, opts.WebhookURL, payload); err != nil {
+		result.record(stepNotify, statusFailed, err.Error())
+		fmt.Fprintf(os.Stderr, "Warning: notification failed: %v\n", err)
+		return
+	}
+	result.record(stepNotify, statusOK, notify.MaskURL(opts.WebhookURL))
+}
+
+// buildRelease converts the status, rollout and release notes options into
+// a track release; version codes are filled in after the upload.
+func buildRelease(opts Options) (*androidpublisher.TrackRelease, error) {
+	trackRelease := &androidpublisher.TrackRelease{Status: strings.TrimSpace(opts.Status)}
+	if trackRelease.Status == "" {
+		trackRelease.Status = "completed"
+	}
+	if err := validateStatus(trackRelease.Status); err != nil {
+		return nil, err
+	}
+	if opts.RolloutFraction > 0 && opts.RolloutFraction < 1 {
+		if trackRelease.Status == "completed" {
+			trackRelease.Status = "inProgress"
+		}
+		trackRelease.UserFraction = opts.RolloutFraction
+	}
+	if strings.TrimSpace(opts.ReleaseNotes) != "" {
+		notes, err := release.ParseReleaseNotes(opts.ReleaseNotes)
+		if err != nil {
+			return nil, fmt.Errorf("--release-notes: %w", err)
+		}
+		for _, note := range notes {
+			trackRelease.ReleaseNotes = append(trackRelease.ReleaseNotes, &androidpublisher.LocalizedText{
+				Language: note.Language,
+				Text:     note.Text,
+			})
+		}
+	}
+	return trackRelease, nil
+}
+
+// validateStatus rejects release statuses the tracks API does not accept.
+func validateStatus(status string) error {
+	switch status {
+	case "draft", "inProgress", "halted", "completed":
+		return nil
+	}
+	return fmt.Errorf("--status must be one of: draft, inProgress, halted, completed (got %q)", status)
+}
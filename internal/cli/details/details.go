@@ -13,6 +13,16 @@ import (
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+var newPlayService = playclient.NewService
+
+// detailsFlagFields maps the field flags to their AppDetails JSON names.
+var detailsFlagFields = map[string]string{
+	"contact-email":    "ContactEmail",
+	"contact-phone":    "ContactPhone",
+	"contact-website":  "ContactWebsite",
+	"default-language": "DefaultLanguage",
+}
+
 func DetailsCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("details", flag.ExitOnError)
 	return &ffcli.Command{
@@ -39,7 +49,7 @@ func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("details get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -55,7 +65,7 @@ func GetCommand() *ffcli.Command {
 			if strings.TrimSpace(*editID) == "" {
 				return fmt.Errorf("--edit is required")
 			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -78,12 +88,12 @@ func UpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("details update", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	contactEmail := fs.String("contact-email", "", "Contact email address")
-	contactPhone := fs.String("contact-phone", "", "Contact phone number")
-	contactWebsite := fs.String("contact-website", "", "Contact website URL")
-	defaultLanguage := fs.String("default-language", "", "Default language (BCP-47 code)")
-	jsonFlag := fs.String("json", "", "Full AppDetails JSON (or @file) - overrides other flags")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	fs.String("contact-email", "", "Contact email address")
+	fs.String("contact-phone", "", "Contact phone number")
+	fs.String("contact-website", "", "Contact website URL")
+	fs.String("default-language", "", "Default language (BCP-47 code)")
+	jsonFlag := fs.String("json", "", "Full AppDetails JSON (or @file, - for stdin) - overrides other flags")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -109,7 +119,7 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			return updateDetails(ctx, *packageName, *editID, *contactEmail, *contactPhone, *contactWebsite, *defaultLanguage, *jsonFlag, *outputFlag, *pretty, false)
+			return updateDetails(ctx, fs, *packageName, *editID, *jsonFlag, *outputFlag, *pretty, false)
 		},
 	}
 }
@@ -118,19 +128,20 @@ func PatchCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("details patch", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	contactEmail := fs.String("contact-email", "", "Contact email address")
-	contactPhone := fs.String("contact-phone", "", "Contact phone number")
-	contactWebsite := fs.String("contact-website", "", "Contact website URL")
-	defaultLanguage := fs.String("default-language", "", "Default language (BCP-47 code)")
-	jsonFlag := fs.String("json", "", "Partial AppDetails JSON (or @file) - overrides other flags")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	fs.String("contact-email", "", "Contact email address")
+	fs.String("contact-phone", "", "Contact phone number")
+	fs.String("contact-website", "", "Contact website URL")
+	fs.String("default-language", "", "Default language (BCP-47 code)")
+	jsonFlag := fs.String("json", "", "Partial AppDetails JSON (or @file, - for stdin) - overrides other flags")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "patch",
 		ShortUsage: "gplay details patch --package <name> --edit <id> [--contact-email <email>] [--contact-phone <phone>] [--contact-website <url>] [--default-language <lang>] [--json <json>]",
 		ShortHelp:  "Patch app details (partial update).",
-		LongHelp: `Patch app details. Only updates provided fields.
+		LongHelp: `Patch app details. Only the fields whose flags are given are sent;
+pass an empty value (for example --contact-phone "") to clear a field.
 
 Use individual flags for simple updates, or --json for full control.
 When --json is provided, it overrides all other flags.
@@ -146,12 +157,12 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			return updateDetails(ctx, *packageName, *editID, *contactEmail, *contactPhone, *contactWebsite, *defaultLanguage, *jsonFlag, *outputFlag, *pretty, true)
+			return updateDetails(ctx, fs, *packageName, *editID, *jsonFlag, *outputFlag, *pretty, true)
 		},
 	}
 }
 
-func updateDetails(ctx context.Context, packageName, editID, contactEmail, contactPhone, contactWebsite, defaultLanguage, jsonFlag, outputFlag string, pretty, patch bool) error {
+func updateDetails(ctx context.Context, fs *flag.FlagSet, packageName, editID, jsonFlag, outputFlag string, pretty, patch bool) error {
 	if err := shared.ValidateOutputFlags(outputFlag, pretty); err != nil {
 		return err
 	}
@@ -159,7 +170,20 @@ func updateDetails(ctx context.Context, packageName, editID, contactEmail, conta
 		return fmt.Errorf("--edit is required")
 	}
 
-	service, err := playclient.NewService(ctx)
+	var details *androidpublisher.AppDetails
+	if strings.TrimSpace(jsonFlag) != "" {
+		details = &androidpublisher.AppDetails{}
+		if err := shared.LoadJSONArg(jsonFlag, details); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+	} else {
+		details = detailsFromFlags(fs)
+		if len(details.ForceSendFields) == 0 {
+			return fmt.Errorf("provide at least one of --contact-email, --contact-phone, --contact-website, --default-language, or --json")
+		}
+	}
+
+	service, err := newPlayService(ctx)
 	if err != nil {
 		return err
 	}
@@ -168,42 +192,46 @@ func updateDetails(ctx context.Context, packageName, editID, contactEmail, conta
 		return fmt.Errorf("--package is required")
 	}
 
-	var details androidpublisher.AppDetails
-
-	if strings.TrimSpace(jsonFlag) != "" {
-		if err := shared.LoadJSONArg(jsonFlag, &details); err != nil {
-			return fmt.Errorf("invalid JSON: %w", err)
-		}
-	} else {
-		// Build from individual flags
-		if strings.TrimSpace(contactEmail) != "" {
-			details.ContactEmail = contactEmail
-		}
-		if strings.TrimSpace(contactPhone) != "" {
-			details.ContactPhone = contactPhone
-		}
-		if strings.TrimSpace(contactWebsite) != "" {
-			details.ContactWebsite = contactWebsite
-		}
-		if strings.TrimSpace(defaultLanguage) != "" {
-			details.DefaultLanguage = defaultLanguage
-		}
-	}
-
 	ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 	defer cancel()
 
 	if patch {
-		resp, err := service.API.Edits.Details.Patch(pkg, editID, &details).Context(ctx).Do()
+		resp, err := service.API.Edits.Details.Patch(pkg, editID, details).Context(ctx).Do()
 		if err != nil {
 			return err
 		}
 		return shared.PrintOutput(resp, outputFlag, pretty)
 	}
 
-	resp, err := service.API.Edits.Details.Update(pkg, editID, &details).Context(ctx).Do()
+	resp, err := service.API.Edits.Details.Update(pkg, editID, details).Context(ctx).Do()
 	if err != nil {
 		return err
 	}
 	return shared.PrintOutput(resp, outputFlag, pretty)
 }
+
+// detailsFromFlags builds AppDetails from the field flags that were set on
+// the command line. Set fields are force-sent so an explicit empty value
+// (--contact-phone "") clears the field instead of being dropped.
+func detailsFromFlags(fs *flag.FlagSet) *androidpublisher.AppDetails {
+	details := &androidpublisher.AppDetails{}
+	fs.Visit(func(f *flag.Flag) {
+		field, ok := detailsFlagFields[f.Name]
+		if !ok {
+			return
+		}
+		value := strings.TrimSpace(f.Value.String())
+		switch f.Name {
+		case "contact-email":
+			details.ContactEmail = value
+		case "contact-phone":
+			details.ContactPhone = value
+		case "contact-website":
+			details.ContactWebsite = value
+		case "default-language":
+			details.DefaultLanguage = value
+		}
+		details.ForceSendFields = append(details.ForceSendFields, field)
+	})
+	return details
+}
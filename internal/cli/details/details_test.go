@@ -0,0 +1,162 @@
+package details
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+func installMockDetailsPlayService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() {
+		newPlayService = original
+	})
+}
+
+func captureDetailsStdout(fn func() error) (string, error) {
+	origStdout := os.Stdout
+	rOut, wOut, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+
+	os.Stdout = wOut
+
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&buf, rOut)
+	}()
+
+	runErr := fn()
+
+	_ = wOut.Close()
+	os.Stdout = origStdout
+	wg.Wait()
+	_ = rOut.Close()
+
+	return buf.String(), runErr
+}
+
+func TestDetailsCommands_RequireEdit(t *testing.T) {
+	for _, cmd := range []*ffcli.Command{GetCommand(), UpdateCommand(), PatchCommand()} {
+		t.Run(cmd.Name, func(t *testing.T) {
+			if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app"}); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.Exec(context.Background(), nil)
+			if err == nil || !strings.Contains(err.Error(), "--edit is required") {
+				t.Fatalf("expected --edit error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDetailsPatchCommand_RequiresAField(t *testing.T) {
+	cmd := PatchCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "provide at least one of") {
+		t.Fatalf("expected missing field error, got %v", err)
+	}
+}
+
+func TestDetailsFromFlags_OnlySetFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want map[string]interface{}
+	}{
+		{
+			name: "single field",
+			args: []string{"--contact-email", "support@example.com"},
+			want: map[string]interface{}{"contactEmail": "support@example.com"},
+		},
+		{
+			name: "explicit empty clears",
+			args: []string{"--contact-phone", "", "--default-language", "en-US"},
+			want: map[string]interface{}{"contactPhone": "", "defaultLanguage": "en-US"},
+		},
+		{
+			name: "non-field flags ignored",
+			args: []string{"--edit", "edit-1", "--contact-website", "https://example.com"},
+			want: map[string]interface{}{"contactWebsite": "https://example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := PatchCommand()
+			if err := cmd.FlagSet.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(detailsFromFlags(cmd.FlagSet))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got map[string]interface{}
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("patch body = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetailsPatchCommand_SendsOnlyProvidedFields(t *testing.T) {
+	var body map[string]interface{}
+	installMockDetailsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || !strings.HasSuffix(r.URL.Path, "/edits/edit-1/details") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"contactEmail":"support@example.com","defaultLanguage":"en-US"}`)
+	})
+
+	cmd := PatchCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--contact-email", "support@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := captureDetailsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]interface{}{"contactEmail": "support@example.com"}; !reflect.DeepEqual(body, want) {
+		t.Fatalf("patch body = %v, want %v", body, want)
+	}
+	if !strings.Contains(stdout, `"contactEmail":"support@example.com"`) {
+		t.Fatalf("expected response in output, got %s", stdout)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
@@ -43,12 +44,14 @@ or device model.`,
 func ListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("device-tiers list", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	pageSize := fs.Int("page-size", 100, "Page size")
+	paginate := fs.Bool("paginate", false, "Fetch all pages")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
-		ShortUsage: "gplay device-tiers list --package <name>",
+		ShortUsage: "gplay device-tiers list --package <name> [--page-size <n>] [--paginate]",
 		ShortHelp:  "List device tier configurations.",
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
@@ -56,6 +59,9 @@ func ListCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
+			if *pageSize < 1 {
+				return fmt.Errorf("--page-size must be greater than 0")
+			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
 				return err
@@ -68,11 +74,27 @@ func ListCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			resp, err := service.API.Applications.DeviceTierConfigs.List(pkg).Context(ctx).Do()
-			if err != nil {
-				return err
-			}
-			return shared.PrintOutput(resp, *outputFlag, *pretty)
+			var all []*androidpublisher.DeviceTierConfig
+			pageToken := ""
+			for {
+				call := service.API.Applications.DeviceTierConfigs.List(pkg).Context(ctx).PageSize(int64(*pageSize))
+				if pageToken != "" {
+					call = call.PageToken(pageToken)
+				}
+				resp, err := call.Do()
+				if err != nil {
+					return err
+				}
+				if !*paginate {
+					return shared.PrintOutput(resp, *outputFlag, *pretty)
+				}
+				all = append(all, resp.DeviceTierConfigs...)
+				if resp.NextPageToken == "" {
+					break
+				}
+				pageToken = resp.NextPageToken
+			}
+			return shared.PrintOutput(all, *outputFlag, *pretty)
 		},
 	}
 }
@@ -80,8 +102,8 @@ func ListCommand() *ffcli.Command {
 func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("device-tiers get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	configID := fs.Int64("config-id", 0, "Device tier config ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	configID := fs.String("config-id", "", "Device tier config ID (numeric)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -94,9 +116,13 @@ func GetCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if *configID == 0 {
+			if strings.TrimSpace(*configID) == "" {
 				return fmt.Errorf("--config-id is required")
 			}
+			id, err := strconv.ParseInt(strings.TrimSpace(*configID), 10, 64)
+			if err != nil {
+				return fmt.Errorf("--config-id must be numeric, got %q", *configID)
+			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
 				return err
@@ -109,7 +135,7 @@ func GetCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			resp, err := service.API.Applications.DeviceTierConfigs.Get(pkg, *configID).Context(ctx).Do()
+			resp, err := service.API.Applications.DeviceTierConfigs.Get(pkg, id).Context(ctx).Do()
 			if err != nil {
 				return err
 			}
@@ -121,9 +147,9 @@ func GetCommand() *ffcli.Command {
 func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("device-tiers create", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "DeviceTierConfig JSON (or @file)")
+	jsonFlag := fs.String("json", "", "DeviceTierConfig JSON (or @file, - for stdin)")
 	allowUnknownDevices := fs.Bool("allow-unknown-devices", false, "Allow unknown devices in tiers")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -0,0 +1,159 @@
+package devicetiers
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestDeviceTiersCommand_Name(t *testing.T) {
+	cmd := DeviceTiersCommand()
+	if cmd.Name != "device-tiers" {
+		t.Errorf("expected name %q, got %q", "device-tiers", cmd.Name)
+	}
+}
+
+func TestDeviceTiersCommand_UsageFunc(t *testing.T) {
+	cmd := DeviceTiersCommand()
+	if cmd.UsageFunc == nil {
+		t.Error("expected UsageFunc to be set")
+	}
+}
+
+func TestDeviceTiersCommand_SubcommandNames(t *testing.T) {
+	cmd := DeviceTiersCommand()
+	expected := map[string]bool{
+		"list":   false,
+		"get":    false,
+		"create": false,
+	}
+	for _, sub := range cmd.Subcommands {
+		if _, ok := expected[sub.Name]; ok {
+			expected[sub.Name] = true
+		} else {
+			t.Errorf("unexpected subcommand: %s", sub.Name)
+		}
+		if sub.UsageFunc == nil {
+			t.Errorf("subcommand %q missing UsageFunc", sub.Name)
+		}
+	}
+	for name, found := range expected {
+		if !found {
+			t.Errorf("missing subcommand: %s", name)
+		}
+	}
+}
+
+func TestDeviceTiersCommand_NoArgs_ReturnsHelp(t *testing.T) {
+	cmd := DeviceTiersCommand()
+	err := cmd.Exec(context.Background(), nil)
+	if !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp, got %v", err)
+	}
+}
+
+// --- list ---
+
+func TestListCommand_InvalidOutputFormat(t *testing.T) {
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--output", "xml"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err == nil {
+		t.Fatal("expected error for invalid output format")
+	}
+}
+
+func TestListCommand_PaginationFlags(t *testing.T) {
+	cmd := ListCommand()
+	for _, name := range []string{"page-size", "paginate"} {
+		if cmd.FlagSet.Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
+
+func TestListCommand_InvalidPageSize(t *testing.T) {
+	for _, size := range []string{"0", "-5"} {
+		cmd := ListCommand()
+		if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--page-size", size}); err != nil {
+			t.Fatal(err)
+		}
+		err := cmd.Exec(context.Background(), nil)
+		if err == nil || !strings.Contains(err.Error(), "--page-size") {
+			t.Errorf("--page-size %s: expected --page-size error, got %v", size, err)
+		}
+	}
+}
+
+// --- get ---
+
+func TestGetCommand_MissingConfigID(t *testing.T) {
+	cmd := GetCommand()
+	if err := cmd.FlagSet.Parse([]string{}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for missing --config-id")
+	}
+	if !strings.Contains(err.Error(), "--config-id is required") {
+		t.Errorf("error should mention --config-id, got: %s", err.Error())
+	}
+}
+
+func TestGetCommand_NonNumericConfigID(t *testing.T) {
+	for _, id := range []string{"abc", "12a", "1.5"} {
+		t.Run(id, func(t *testing.T) {
+			cmd := GetCommand()
+			if err := cmd.FlagSet.Parse([]string{"--config-id", id}); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.Exec(context.Background(), nil)
+			if err == nil {
+				t.Fatalf("expected error for --config-id %q", id)
+			}
+			if !strings.Contains(err.Error(), "must be numeric") {
+				t.Errorf("unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestGetCommand_PrettyWithTable(t *testing.T) {
+	cmd := GetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--config-id", "1", "--output", "table", "--pretty"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err == nil {
+		t.Fatal("expected error for --pretty with table output")
+	}
+}
+
+// --- create ---
+
+func TestCreateCommand_MissingJSON(t *testing.T) {
+	cmd := CreateCommand()
+	if err := cmd.FlagSet.Parse([]string{}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for missing --json")
+	}
+	if !strings.Contains(err.Error(), "--json") {
+		t.Errorf("error should mention --json, got: %s", err.Error())
+	}
+}
+
+func TestCreateCommand_WhitespaceJSON(t *testing.T) {
+	cmd := CreateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--json", "   "}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err == nil {
+		t.Fatal("expected error for whitespace-only --json")
+	}
+}
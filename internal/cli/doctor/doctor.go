@@ -16,6 +16,7 @@ import (
 func DoctorCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
 	outputFlag := fs.String("output", "text", "Output format: text (default), json, markdown, table")
+	shared.DeclareOutputFormats(fs, "text", "json", "markdown", "table")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
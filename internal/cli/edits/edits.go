@@ -2,6 +2,7 @@ package edits
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"strings"
@@ -13,6 +14,9 @@ import (
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+// newPlayService builds the Play API client; tests replace it with a fake.
+var newPlayService = playclient.NewService
+
 func EditsCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("edits", flag.ExitOnError)
 	return &ffcli.Command{
@@ -50,7 +54,7 @@ edit ID to commands that require it.`,
 func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("edits create", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -67,7 +71,7 @@ Only one active edit per app is allowed at a time.`,
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -90,7 +94,7 @@ func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("edits get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -109,7 +113,7 @@ Use this to check whether an edit ID is still valid before making changes.`,
 			if strings.TrimSpace(*editID) == "" {
 				return fmt.Errorf("--edit is required")
 			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -132,7 +136,7 @@ func ValidateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("edits validate", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -152,7 +156,7 @@ required fields are present and values are within allowed ranges.`,
 			if strings.TrimSpace(*editID) == "" {
 				return fmt.Errorf("--edit is required")
 			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -176,7 +180,9 @@ func CommitCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	changesNotSent := fs.Bool("changes-not-sent-for-review", false, "Changes not sent for review")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	track := fs.String("track", "", "Hint: with production, confirm without checking the edit's tracks first")
+	assumeYes := fs.Bool("assume-yes", false, "Skip the typed confirmation required when the edit changes the production track")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -189,9 +195,14 @@ Use --changes-not-sent-for-review to commit changes without submitting
 them for review. This is useful for metadata-only updates that don't
 require review.
 
+Before committing, the edit's production track is compared with the live
+one. If the edit changes it, the commit requires typing the track name to
+confirm. Pass --assume-yes to skip the check and the prompt in CI.
+
 Examples:
   gplay edits commit --package com.example --edit EDIT_ID
-  gplay edits commit --package com.example --edit EDIT_ID --changes-not-sent-for-review`,
+  gplay edits commit --package com.example --edit EDIT_ID --changes-not-sent-for-review
+  gplay edits commit --package com.example --edit EDIT_ID --assume-yes`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -201,7 +212,11 @@ Examples:
 			if strings.TrimSpace(*editID) == "" {
 				return fmt.Errorf("--edit is required")
 			}
-			service, err := playclient.NewService(ctx)
+			action := "commit edit " + *editID
+			if err := shared.ConfirmProductionTrack(*track, action, *assumeYes); err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -209,6 +224,19 @@ Examples:
 			if strings.TrimSpace(pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
+			if !*assumeYes && !shared.IsProductionTrack(*track) {
+				checkCtx, checkCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+				changed, err := editChangesProduction(checkCtx, service, pkg, *editID)
+				checkCancel()
+				if err != nil {
+					return fmt.Errorf("check production track: %w", err)
+				}
+				if changed {
+					if err := shared.ConfirmProductionTrack(shared.ProductionTrack, action, false); err != nil {
+						return err
+					}
+				}
+			}
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 			call := service.API.Edits.Commit(pkg, *editID).Context(ctx)
@@ -224,12 +252,50 @@ Examples:
 	}
 }
 
+// editChangesProduction reports whether the production track in editID
+// differs from the live one, which is read through a temporary edit.
+func editChangesProduction(ctx context.Context, service *playclient.Service, pkg, editID string) (bool, error) {
+	tracks, err := service.API.Edits.Tracks.List(pkg, editID).Context(ctx).Do()
+	if err != nil {
+		return false, err
+	}
+	var pending *androidpublisher.Track
+	for _, t := range tracks.Tracks {
+		if shared.IsProductionTrack(t.Track) {
+			pending = t
+		}
+	}
+	if pending == nil {
+		return false, nil
+	}
+
+	live, err := service.API.Edits.Insert(pkg, &androidpublisher.AppEdit{}).Context(ctx).Do()
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = service.API.Edits.Delete(pkg, live.Id).Context(ctx).Do() }()
+	current, err := service.API.Edits.Tracks.Get(pkg, live.Id, shared.ProductionTrack).Context(ctx).Do()
+	if err != nil {
+		return false, err
+	}
+
+	want, err := json.Marshal(pending.Releases)
+	if err != nil {
+		return false, err
+	}
+	got, err := json.Marshal(current.Releases)
+	if err != nil {
+		return false, err
+	}
+	return string(want) != string(got), nil
+}
+
 func DeleteCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("edits delete", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	confirm := fs.Bool("confirm", false, "Confirm delete")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -251,7 +317,7 @@ Requires --confirm to prevent accidental deletion.`,
 			if !*confirm {
 				return fmt.Errorf("--confirm is required")
 			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
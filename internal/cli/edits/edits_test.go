@@ -4,8 +4,14 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
 func TestEditsCommand_Name(t *testing.T) {
@@ -232,6 +238,107 @@ func TestEditsCommitCommand_PrettyWithMarkdown(t *testing.T) {
 	}
 }
 
+func TestEditsCommitCommand_ProductionRequiresConfirmation(t *testing.T) {
+	cmd := CommitCommand()
+	if err := cmd.FlagSet.Parse([]string{"--edit", "EDIT_ID", "--track", "production"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for production commit without confirmation")
+	}
+	if !strings.Contains(err.Error(), "--assume-yes") {
+		t.Errorf("error should mention --assume-yes, got: %s", err.Error())
+	}
+}
+
+// installCommitServer serves an edit whose production track holds
+// pendingReleases while the live track holds liveReleases, and records the
+// calls made.
+func installCommitServer(t *testing.T, pendingReleases, liveReleases string) *[]string {
+	t.Helper()
+	var mu sync.Mutex
+	calls := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		var call string
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(path, "/edits/EDIT_ID/tracks"):
+			call = "list"
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits"):
+			call = "insert"
+		case r.Method == http.MethodGet && strings.HasSuffix(path, "/edits/live-1/tracks/production"):
+			call = "live"
+		case r.Method == http.MethodDelete && strings.HasSuffix(path, "/edits/live-1"):
+			call = "delete"
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits/EDIT_ID:commit"):
+			call = "commit"
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch call {
+		case "list":
+			_, _ = io.WriteString(w, `{"tracks":[{"track":"beta","releases":[]},{"track":"production","releases":`+pendingReleases+`}]}`)
+		case "insert":
+			_, _ = io.WriteString(w, `{"id":"live-1"}`)
+		case "live":
+			_, _ = io.WriteString(w, `{"track":"production","releases":`+liveReleases+`}`)
+		case "delete":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = io.WriteString(w, `{"id":"EDIT_ID"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() { newPlayService = original })
+	return &calls
+}
+
+func TestEditsCommitCommand_ProductionChangeRequiresConfirmation(t *testing.T) {
+	calls := installCommitServer(t, `[{"status":"completed","versionCodes":["43"]}]`, `[{"status":"completed","versionCodes":["42"]}]`)
+
+	cmd := CommitCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "EDIT_ID"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--assume-yes") {
+		t.Fatalf("expected production confirmation error, got %v", err)
+	}
+	for _, call := range *calls {
+		if call == "commit" {
+			t.Fatalf("edit was committed without confirmation: %v", *calls)
+		}
+	}
+}
+
+func TestEditsCommitCommand_UnchangedProductionCommits(t *testing.T) {
+	releases := `[{"status":"completed","versionCodes":["42"]}]`
+	calls := installCommitServer(t, releases, releases)
+
+	cmd := CommitCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "EDIT_ID"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("expected commit to succeed, got %v", err)
+	}
+	if got := strings.Join(*calls, ","); got != "list,insert,live,delete,commit" {
+		t.Fatalf("calls = %s", got)
+	}
+}
+
 // --- edits delete ---
 
 func TestEditsDeleteCommand_Name(t *testing.T) {
@@ -55,15 +55,15 @@ func UpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("expansion update", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	apkVersionCode := fs.Int64("apk-version", 0, "APK version code")
+	versionCode := bindVersionCodeFlag(fs)
 	expansionType := fs.String("type", "main", "Expansion file type: main (default), patch")
 	referencesVersion := fs.Int64("references-version", 0, "APK version code that contains the file to reference")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "update",
-		ShortUsage: "gplay expansion update --package <name> --edit <id> --apk-version <code> --type <type> --references-version <code>",
+		ShortUsage: "gplay expansion update --package <name> --edit <id> --version-code <code> --type <type> --references-version <code>",
 		ShortHelp:  "Update expansion file metadata.",
 		LongHelp: `Update expansion file metadata using edits.expansionfiles.update.
 
@@ -75,19 +75,13 @@ reference to an expansion file from another APK version.`,
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*editID) == "" {
-				return fmt.Errorf("--edit is required")
-			}
-			if *apkVersionCode == 0 {
-				return fmt.Errorf("--apk-version is required")
+			expType, err := validateTarget(*editID, *versionCode, *expansionType)
+			if err != nil {
+				return err
 			}
-			if *referencesVersion == 0 {
+			if *referencesVersion <= 0 {
 				return fmt.Errorf("--references-version is required")
 			}
-			expType := strings.ToLower(strings.TrimSpace(*expansionType))
-			if expType != "main" && expType != "patch" {
-				return fmt.Errorf("--type must be 'main' or 'patch'")
-			}
 			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
@@ -103,7 +97,7 @@ reference to an expansion file from another APK version.`,
 			expansionFile := &androidpublisher.ExpansionFile{
 				ReferencesVersion: *referencesVersion,
 			}
-			resp, err := service.API.Edits.Expansionfiles.Update(pkg, *editID, *apkVersionCode, expType, expansionFile).Context(ctx).Do()
+			resp, err := service.API.Edits.Expansionfiles.Update(pkg, *editID, *versionCode, expType, expansionFile).Context(ctx).Do()
 			if err != nil {
 				return shared.WrapGoogleAPIError("update expansion file", err)
 			}
@@ -116,14 +110,14 @@ func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("expansion get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	apkVersionCode := fs.Int64("apk-version", 0, "APK version code")
+	versionCode := bindVersionCodeFlag(fs)
 	expansionType := fs.String("type", "main", "Expansion file type: main (default), patch")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "get",
-		ShortUsage: "gplay expansion get --package <name> --edit <id> --apk-version <code> --type <type>",
+		ShortUsage: "gplay expansion get --package <name> --edit <id> --version-code <code> --type <type>",
 		ShortHelp:  "Get expansion file information.",
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
@@ -131,17 +125,11 @@ func GetCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*editID) == "" {
-				return fmt.Errorf("--edit is required")
-			}
-			if *apkVersionCode == 0 {
-				return fmt.Errorf("--apk-version is required")
-			}
-			expType := strings.ToLower(strings.TrimSpace(*expansionType))
-			if expType != "main" && expType != "patch" {
-				return fmt.Errorf("--type must be 'main' or 'patch'")
+			expType, err := validateTarget(*editID, *versionCode, *expansionType)
+			if err != nil {
+				return err
 			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -153,7 +141,7 @@ func GetCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			resp, err := service.API.Edits.Expansionfiles.Get(pkg, *editID, *apkVersionCode, expType).Context(ctx).Do()
+			resp, err := service.API.Edits.Expansionfiles.Get(pkg, *editID, *versionCode, expType).Context(ctx).Do()
 			if err != nil {
 				return err
 			}
@@ -166,15 +154,15 @@ func UploadCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("expansion upload", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	apkVersionCode := fs.Int64("apk-version", 0, "APK version code")
+	versionCode := bindVersionCodeFlag(fs)
 	expansionType := fs.String("type", "main", "Expansion file type: main (default), patch")
 	filePath := fs.String("file", "", "Path to .obb file")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "upload",
-		ShortUsage: "gplay expansion upload --package <name> --edit <id> --apk-version <code> --type <type> --file <path>",
+		ShortUsage: "gplay expansion upload --package <name> --edit <id> --version-code <code> --type <type> --file <path>",
 		ShortHelp:  "Upload an expansion file.",
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
@@ -182,20 +170,14 @@ func UploadCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*editID) == "" {
-				return fmt.Errorf("--edit is required")
-			}
-			if *apkVersionCode == 0 {
-				return fmt.Errorf("--apk-version is required")
+			expType, err := validateTarget(*editID, *versionCode, *expansionType)
+			if err != nil {
+				return err
 			}
 			if strings.TrimSpace(*filePath) == "" {
 				return fmt.Errorf("--file is required")
 			}
-			expType := strings.ToLower(strings.TrimSpace(*expansionType))
-			if expType != "main" && expType != "patch" {
-				return fmt.Errorf("--type must be 'main' or 'patch'")
-			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -213,7 +195,7 @@ func UploadCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithUploadTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			call := service.API.Edits.Expansionfiles.Upload(pkg, *editID, *apkVersionCode, expType)
+			call := service.API.Edits.Expansionfiles.Upload(pkg, *editID, *versionCode, expType)
 			call.Media(file, googleapi.ContentType("application/octet-stream"))
 			resp, err := call.Context(ctx).Do()
 			if err != nil {
@@ -228,15 +210,15 @@ func PatchCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("expansion patch", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	apkVersionCode := fs.Int64("apk-version", 0, "APK version code")
+	versionCode := bindVersionCodeFlag(fs)
 	expansionType := fs.String("type", "main", "Expansion file type: main (default), patch")
 	referencesVersion := fs.Int64("references-version", 0, "APK version code that contains the file to reference")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "patch",
-		ShortUsage: "gplay expansion patch --package <name> --edit <id> --apk-version <code> --type <type> --references-version <code>",
+		ShortUsage: "gplay expansion patch --package <name> --edit <id> --version-code <code> --type <type> --references-version <code>",
 		ShortHelp:  "Reference an expansion file from another APK version.",
 		LongHelp: `Reference an expansion file from a different APK version.
 
@@ -248,20 +230,14 @@ without re-uploading it.`,
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*editID) == "" {
-				return fmt.Errorf("--edit is required")
-			}
-			if *apkVersionCode == 0 {
-				return fmt.Errorf("--apk-version is required")
+			expType, err := validateTarget(*editID, *versionCode, *expansionType)
+			if err != nil {
+				return err
 			}
-			if *referencesVersion == 0 {
+			if *referencesVersion <= 0 {
 				return fmt.Errorf("--references-version is required")
 			}
-			expType := strings.ToLower(strings.TrimSpace(*expansionType))
-			if expType != "main" && expType != "patch" {
-				return fmt.Errorf("--type must be 'main' or 'patch'")
-			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -277,7 +253,7 @@ without re-uploading it.`,
 				ReferencesVersion: *referencesVersion,
 			}
 
-			resp, err := service.API.Edits.Expansionfiles.Patch(pkg, *editID, *apkVersionCode, expType, expansionFile).Context(ctx).Do()
+			resp, err := service.API.Edits.Expansionfiles.Patch(pkg, *editID, *versionCode, expType, expansionFile).Context(ctx).Do()
 			if err != nil {
 				return err
 			}
@@ -285,3 +261,31 @@ without re-uploading it.`,
 		},
 	}
 }
+
+// bindVersionCodeFlag registers --version-code and its older spelling
+// --apk-version on the same value.
+func bindVersionCodeFlag(fs *flag.FlagSet) *int64 {
+	versionCode := new(int64)
+	fs.Int64Var(versionCode, "version-code", 0, "APK version code the expansion file belongs to")
+	fs.Int64Var(versionCode, "apk-version", 0, "Alias for --version-code")
+	return versionCode
+}
+
+// validateTarget checks the flags every expansion subcommand shares and
+// returns the normalized expansion file type.
+func validateTarget(editID string, versionCode int64, expansionType string) (string, error) {
+	if strings.TrimSpace(editID) == "" {
+		return "", fmt.Errorf("--edit is required")
+	}
+	if versionCode == 0 {
+		return "", fmt.Errorf("--version-code is required")
+	}
+	if versionCode < 0 {
+		return "", fmt.Errorf("--version-code must be positive")
+	}
+	expType := strings.ToLower(strings.TrimSpace(expansionType))
+	if expType != "main" && expType != "patch" {
+		return "", fmt.Errorf("--type must be 'main' or 'patch'")
+	}
+	return expType, nil
+}
@@ -9,10 +9,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 
+	"github.com/peterbourgon/ff/v3/ffcli"
+
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
@@ -84,6 +87,103 @@ func TestExpansionUpdateCommand_CallsAPI(t *testing.T) {
 	}
 }
 
+func TestExpansionCommands_ValidateFlags(t *testing.T) {
+	commands := map[string]func() *ffcli.Command{
+		"get":    GetCommand,
+		"upload": UploadCommand,
+		"patch":  PatchCommand,
+		"update": UpdateCommand,
+	}
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing edit", []string{"--version-code", "1"}, "--edit is required"},
+		{"missing version code", []string{"--edit", "e"}, "--version-code is required"},
+		{"negative version code", []string{"--edit", "e", "--version-code", "-3"}, "--version-code must be positive"},
+		{"invalid type", []string{"--edit", "e", "--version-code", "1", "--type", "extra"}, "--type must be 'main' or 'patch'"},
+	}
+	for name, newCmd := range commands {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				cmd := newCmd()
+				if err := cmd.FlagSet.Parse(tt.args); err != nil {
+					t.Fatalf("parse flags: %v", err)
+				}
+				err := cmd.Exec(context.Background(), nil)
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Fatalf("expected error containing %q, got %v", tt.want, err)
+				}
+			})
+		}
+	}
+}
+
+func TestExpansionCommands_RequireCommandSpecificFlags(t *testing.T) {
+	upload := UploadCommand()
+	if err := upload.FlagSet.Parse([]string{"--edit", "e", "--version-code", "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := upload.Exec(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "--file is required") {
+		t.Fatalf("expected --file error, got %v", err)
+	}
+
+	for _, cmd := range []*ffcli.Command{PatchCommand(), UpdateCommand()} {
+		if err := cmd.FlagSet.Parse([]string{"--edit", "e", "--version-code", "1"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.Exec(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "--references-version is required") {
+			t.Fatalf("%s: expected --references-version error, got %v", cmd.Name, err)
+		}
+	}
+}
+
+func TestExpansionCommand_RejectsNonNumericVersionCode(t *testing.T) {
+	cmd := GetCommand()
+	cmd.FlagSet.Init(cmd.FlagSet.Name(), flag.ContinueOnError)
+	cmd.FlagSet.SetOutput(io.Discard)
+	if err := cmd.FlagSet.Parse([]string{"--version-code", "abc"}); err == nil {
+		t.Fatal("expected parse error for non-numeric --version-code")
+	}
+}
+
+func TestExpansionUploadCommand_UsesVersionCode(t *testing.T) {
+	var gotPath string
+	installMockExpansionPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"expansionFile":{"fileSize":"4"}}`)
+	})
+
+	obb := filepath.Join(t.TempDir(), "main.obb")
+	if err := os.WriteFile(obb, []byte("data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := UploadCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--edit", "edit-1",
+		"--version-code", "456",
+		"--type", "PATCH",
+		"--file", obb,
+	}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureExpansionStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasSuffix(gotPath, "/applications/com.example.app/edits/edit-1/apks/456/expansionFiles/patch") {
+		t.Fatalf("unexpected upload path: %s", gotPath)
+	}
+	if !strings.Contains(stdout, `"fileSize":"4"`) {
+		t.Fatalf("expected expansion file output, got %s", stdout)
+	}
+}
+
 func installMockExpansionPlayService(t *testing.T, handler http.HandlerFunc) {
 	t.Helper()
 
@@ -43,8 +43,8 @@ func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("external-transactions create", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	externalTxID := fs.String("external-transaction-id", "", "External transaction ID (your system's ID)")
-	jsonFlag := fs.String("json", "", "ExternalTransaction JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "ExternalTransaction JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -125,7 +125,7 @@ func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("external-transactions get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	externalTxID := fs.String("external-transaction-id", "", "External transaction ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -168,9 +168,9 @@ func RefundCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("external-transactions refund", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	externalTxID := fs.String("external-transaction-id", "", "External transaction ID")
-	jsonFlag := fs.String("json", "", "Refund JSON (or @file)")
+	jsonFlag := fs.String("json", "", "Refund JSON (or @file, - for stdin)")
 	confirm := fs.Bool("confirm", false, "Confirm refund")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -46,7 +46,7 @@ func ListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("generated-apks list", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	versionCode := fs.Int64("version-code", 0, "Version code of the app bundle")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -89,7 +89,8 @@ func DownloadCommand() *ffcli.Command {
 	versionCode := fs.Int64("version-code", 0, "Version code of the app bundle")
 	downloadID := fs.String("download-id", "", "Download ID from list command")
 	outputDir := fs.String("output", ".", "Output directory for downloaded APK")
-	outputFlag := fs.String("format", "json", "Output format: json (default), table, markdown")
+	shared.DeclareOutputFormats(fs)
+	outputFlag := fs.String("format", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -0,0 +1,247 @@
+package grants
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+// Grant diff actions.
+const (
+	DiffActionCreate = "create"
+	DiffActionUpdate = "update"
+	DiffActionDelete = "delete"
+)
+
+// GrantsDiff is the result of grants diff.
+type GrantsDiff struct {
+	Developer string             `json:"developer"`
+	Email     string             `json:"email"`
+	InSync    bool               `json:"inSync"`
+	Changes   []PackageGrantDiff `json:"changes"`
+}
+
+// PackageGrantDiff lists the permission changes needed on one app.
+type PackageGrantDiff struct {
+	PackageName string   `json:"packageName"`
+	Action      string   `json:"action"`
+	Add         []string `json:"add,omitempty"`
+	Remove      []string `json:"remove,omitempty"`
+}
+
+func DiffCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("grants diff", flag.ExitOnError)
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
+	email := fs.String("email", "", "User email address")
+	file := fs.String("file", "", "Desired grants JSON file (- for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "diff",
+		ShortUsage: "gplay grants diff --developer <id> --email <email> --file <path>",
+		ShortHelp:  "Compare a user's app grants with a desired state file.",
+		LongHelp: `Compare a user's current app-level permissions with a desired state file
+and report the changes needed, without applying them.
+
+The file is authoritative: apps it does not list are reported for deletion.
+It holds an array of grants, or a user object with a "grants" array (as
+printed by gplay users list):
+
+[
+  {"packageName": "com.example.app", "appLevelPermissions": ["CAN_ACCESS_APP", "CAN_REPLY_TO_REVIEWS"]},
+  {"packageName": "com.example.other", "appLevelPermissions": ["CAN_ACCESS_APP"]}
+]
+
+Each change has an action of create, update, or delete with the permissions
+to add and remove.
+
+Examples:
+  gplay grants diff --developer 1234567890 --email dev@example.com --file grants/dev.json
+  gplay grants diff --email dev@example.com --file grants/dev.json --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
+				return fmt.Errorf("--developer is required")
+			}
+			if strings.TrimSpace(*email) == "" {
+				return fmt.Errorf("--email is required")
+			}
+			if strings.TrimSpace(*file) == "" {
+				return fmt.Errorf("--file is required")
+			}
+			source := strings.TrimSpace(*file)
+			if source != "-" {
+				source = "@" + source
+			}
+			raw, err := shared.LoadJSONArgRaw(source)
+			if err != nil {
+				return fmt.Errorf("read --file: %w", err)
+			}
+			desired, err := parseDesiredGrants(raw)
+			if err != nil {
+				return err
+			}
+
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			current, err := fetchUserGrants(ctx, service, developer, *email)
+			if err != nil {
+				return err
+			}
+			changes := DiffGrants(current, desired)
+			result := &GrantsDiff{
+				Developer: developer,
+				Email:     *email,
+				InSync:    len(changes) == 0,
+				Changes:   changes,
+			}
+			return shared.PrintOutput(result, *outputFlag, *pretty)
+		},
+	}
+}
+
+// fetchUserGrants returns the grants of the user with email in developer.
+func fetchUserGrants(ctx context.Context, service *playclient.Service, developer, email string) ([]*androidpublisher.Grant, error) {
+	parent := fmt.Sprintf("developers/%s", developer)
+	users, _, err := shared.FetchAllPages(ctx, 0, func(ctx context.Context, token string) ([]*androidpublisher.User, string, error) {
+		call := service.API.Users.List(parent).Context(ctx).PageSize(100)
+		if token != "" {
+			call.PageToken(token)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Users, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if strings.EqualFold(strings.TrimSpace(user.Email), strings.TrimSpace(email)) {
+			return user.Grants, nil
+		}
+	}
+	return nil, fmt.Errorf("user %s not found in developer account %s", email, developer)
+}
+
+// parseDesiredGrants reads an array of grants or a user object with a
+// grants array. Each grant needs a packageName, and an app may appear once.
+func parseDesiredGrants(raw []byte) ([]*androidpublisher.Grant, error) {
+	var grants []*androidpublisher.Grant
+	if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+		if err := json.Unmarshal(raw, &grants); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	} else {
+		var user androidpublisher.User
+		if err := json.Unmarshal(raw, &user); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		grants = user.Grants
+	}
+	seen := map[string]bool{}
+	for i, grant := range grants {
+		if grant == nil || strings.TrimSpace(grant.PackageName) == "" {
+			return nil, fmt.Errorf("grant %d has no packageName", i+1)
+		}
+		if seen[grant.PackageName] {
+			return nil, fmt.Errorf("package %s is listed more than once", grant.PackageName)
+		}
+		seen[grant.PackageName] = true
+	}
+	return grants, nil
+}
+
+// DiffGrants returns the changes that turn current into desired, ordered by
+// package name.
+func DiffGrants(current, desired []*androidpublisher.Grant) []PackageGrantDiff {
+	have := grantPermissions(current)
+	want := grantPermissions(desired)
+
+	packages := make([]string, 0, len(have)+len(want))
+	for pkg := range have {
+		packages = append(packages, pkg)
+	}
+	for pkg := range want {
+		if _, ok := have[pkg]; !ok {
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Strings(packages)
+
+	changes := []PackageGrantDiff{}
+	for _, pkg := range packages {
+		cur, hasCur := have[pkg]
+		des, hasDes := want[pkg]
+		change := PackageGrantDiff{PackageName: pkg, Add: missingFrom(des, cur), Remove: missingFrom(cur, des)}
+		switch {
+		case !hasCur:
+			change.Action = DiffActionCreate
+		case !hasDes:
+			change.Action = DiffActionDelete
+		case len(change.Add) > 0 || len(change.Remove) > 0:
+			change.Action = DiffActionUpdate
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// grantPermissions maps each package to its set of normalized permissions.
+func grantPermissions(grants []*androidpublisher.Grant) map[string]map[string]bool {
+	out := make(map[string]map[string]bool, len(grants))
+	for _, grant := range grants {
+		if grant == nil {
+			continue
+		}
+		perms := out[grant.PackageName]
+		if perms == nil {
+			perms = map[string]bool{}
+			out[grant.PackageName] = perms
+		}
+		for _, p := range grant.AppLevelPermissions {
+			if p = strings.ToUpper(strings.TrimSpace(p)); p != "" {
+				perms[p] = true
+			}
+		}
+	}
+	return out
+}
+
+// missingFrom returns the sorted permissions in a that b lacks.
+func missingFrom(a, b map[string]bool) []string {
+	var out []string
+	for p := range a {
+		if !b[p] {
+			out = append(out, p)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
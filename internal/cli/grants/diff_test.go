@@ -0,0 +1,94 @@
+package grants
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func TestDiffGrants(t *testing.T) {
+	current := []*androidpublisher.Grant{
+		{PackageName: "com.example.app", AppLevelPermissions: []string{"CAN_ACCESS_APP", "CAN_VIEW_FINANCIAL_DATA"}},
+		{PackageName: "com.example.same", AppLevelPermissions: []string{"CAN_ACCESS_APP"}},
+		{PackageName: "com.example.old", AppLevelPermissions: []string{"CAN_ACCESS_APP", "CAN_REPLY_TO_REVIEWS"}},
+	}
+	desired := []*androidpublisher.Grant{
+		{PackageName: "com.example.app", AppLevelPermissions: []string{"can_access_app", "CAN_REPLY_TO_REVIEWS"}},
+		{PackageName: "com.example.same", AppLevelPermissions: []string{"CAN_ACCESS_APP"}},
+		{PackageName: "com.example.new", AppLevelPermissions: []string{"CAN_ACCESS_APP"}},
+	}
+
+	got := DiffGrants(current, desired)
+	want := []PackageGrantDiff{
+		{PackageName: "com.example.app", Action: DiffActionUpdate, Add: []string{"CAN_REPLY_TO_REVIEWS"}, Remove: []string{"CAN_VIEW_FINANCIAL_DATA"}},
+		{PackageName: "com.example.new", Action: DiffActionCreate, Add: []string{"CAN_ACCESS_APP"}},
+		{PackageName: "com.example.old", Action: DiffActionDelete, Remove: []string{"CAN_ACCESS_APP", "CAN_REPLY_TO_REVIEWS"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DiffGrants() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := DiffGrants(current[1:2], current[1:2]); len(got) != 0 {
+		t.Fatalf("expected no changes for identical grants, got %+v", got)
+	}
+}
+
+func TestParseDesiredGrants(t *testing.T) {
+	fromUser, err := parseDesiredGrants([]byte(`{"email":"dev@example.com","grants":[{"packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP"]}]}`))
+	if err != nil || len(fromUser) != 1 || fromUser[0].PackageName != "com.example.app" {
+		t.Fatalf("user object: %+v, %v", fromUser, err)
+	}
+	if _, err := parseDesiredGrants([]byte(`[{"appLevelPermissions":["CAN_ACCESS_APP"]}]`)); err == nil || !strings.Contains(err.Error(), "packageName") {
+		t.Fatalf("expected missing packageName error, got %v", err)
+	}
+	if _, err := parseDesiredGrants([]byte(`[{"packageName":"a"},{"packageName":"a"}]`)); err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Fatalf("expected duplicate package error, got %v", err)
+	}
+}
+
+func TestDiffCommand_ReportsChanges(t *testing.T) {
+	installMockGrantsPlayService(t, usersHandler(t))
+
+	file := filepath.Join(t.TempDir(), "dev.json")
+	if err := os.WriteFile(file, []byte(`[{"packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP"]}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := DiffCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123", "--email", "dev@example.com", "--file", file}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := captureGrantsStdout(func() error { return cmd.Exec(context.Background(), nil) })
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+
+	var got GrantsDiff
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal %q: %v", out, err)
+	}
+	if got.InSync || len(got.Changes) != 1 {
+		t.Fatalf("unexpected diff: %s", out)
+	}
+	change := got.Changes[0]
+	if change.Action != DiffActionUpdate || !reflect.DeepEqual(change.Remove, []string{"CAN_VIEW_FINANCIAL_DATA"}) || len(change.Add) != 0 {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+}
+
+func TestDiffCommand_RequiresFile(t *testing.T) {
+	cmd := DiffCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123", "--email", "dev@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--file") {
+		t.Fatalf("expected --file error, got %v", err)
+	}
+}
@@ -10,9 +10,13 @@ import (
 	"google.golang.org/api/androidpublisher/v3"
 
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+// newPlayService is swapped out by tests.
+var newPlayService = playclient.NewService
+
 func GrantsCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("grants", flag.ExitOnError)
 	return &ffcli.Command{
@@ -26,9 +30,11 @@ as opposed to account-wide permissions.`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
+			ListCommand(),
 			CreateCommand(),
 			UpdateCommand(),
 			DeleteCommand(),
+			DiffCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if len(args) == 0 {
@@ -41,11 +47,11 @@ as opposed to account-wide permissions.`,
 
 func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("grants create", flag.ExitOnError)
-	developerID := fs.String("developer", "", "Developer ID")
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
 	email := fs.String("email", "", "User email address")
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "Grant permissions JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "Grant permissions JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -87,7 +93,9 @@ Available app permissions:
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*developerID) == "" {
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
 				return fmt.Errorf("--developer is required")
 			}
 			if strings.TrimSpace(*email) == "" {
@@ -114,7 +122,7 @@ Available app permissions:
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			parent := fmt.Sprintf("developers/%s/users/%s", *developerID, *email)
+			parent := fmt.Sprintf("developers/%s/users/%s", developer, *email)
 			resp, err := service.API.Grants.Create(parent, &grant).Context(ctx).Do()
 			if err != nil {
 				return err
@@ -126,12 +134,12 @@ Available app permissions:
 
 func UpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("grants update", flag.ExitOnError)
-	developerID := fs.String("developer", "", "Developer ID")
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
 	email := fs.String("email", "", "User email address")
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "Updated grant permissions JSON (or @file)")
+	jsonFlag := fs.String("json", "", "Updated grant permissions JSON (or @file, - for stdin)")
 	updateMask := fs.String("update-mask", "", "Fields to update (comma-separated)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -168,7 +176,9 @@ fields in the request body are applied.`,
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*developerID) == "" {
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
 				return fmt.Errorf("--developer is required")
 			}
 			if strings.TrimSpace(*email) == "" {
@@ -194,7 +204,7 @@ fields in the request body are applied.`,
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			name := fmt.Sprintf("developers/%s/users/%s/grants/%s", *developerID, *email, pkg)
+			name := fmt.Sprintf("developers/%s/users/%s/grants/%s", developer, *email, pkg)
 			call := service.API.Grants.Patch(name, &grant).Context(ctx)
 			if strings.TrimSpace(*updateMask) != "" {
 				call.UpdateMask(*updateMask)
@@ -210,11 +220,11 @@ fields in the request body are applied.`,
 
 func DeleteCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("grants delete", flag.ExitOnError)
-	developerID := fs.String("developer", "", "Developer ID")
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
 	email := fs.String("email", "", "User email address")
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -227,7 +237,9 @@ func DeleteCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*developerID) == "" {
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
 				return fmt.Errorf("--developer is required")
 			}
 			if strings.TrimSpace(*email) == "" {
@@ -248,7 +260,7 @@ func DeleteCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			name := fmt.Sprintf("developers/%s/users/%s/grants/%s", *developerID, *email, pkg)
+			name := fmt.Sprintf("developers/%s/users/%s/grants/%s", developer, *email, pkg)
 			err = service.API.Grants.Delete(name).Context(ctx).Do()
 			if err != nil {
 				return err
@@ -30,7 +30,7 @@ func TestGrantsCommand_HasSubcommands(t *testing.T) {
 		names[sub.Name] = true
 	}
 
-	for _, want := range []string{"create", "update", "delete"} {
+	for _, want := range []string{"list", "create", "update", "delete", "diff"} {
 		if !names[want] {
 			t.Errorf("missing subcommand %q", want)
 		}
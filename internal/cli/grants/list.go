@@ -0,0 +1,68 @@
+package grants
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+func ListCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("grants list", flag.ExitOnError)
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
+	email := fs.String("email", "", "User email address (required)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "list",
+		ShortUsage: "gplay grants list --developer <id> --email <email>",
+		ShortHelp:  "List a user's app grants.",
+		LongHelp: `List the app-level grants of one user.
+
+The API has no call listing every grant in a developer account, so the
+grants are read from the user's entry in the account's user list. Use
+gplay users list to see all users with their grants.
+
+Examples:
+  gplay grants list --developer 1234567890 --email dev@example.com
+  gplay grants list --email dev@example.com --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
+				return fmt.Errorf("--developer is required")
+			}
+			if strings.TrimSpace(*email) == "" {
+				return fmt.Errorf("--email is required: the API cannot list grants across all users; list one user's grants with --email, or use gplay users list")
+			}
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			grants, err := fetchUserGrants(ctx, service, developer, *email)
+			if err != nil {
+				return err
+			}
+			if grants == nil {
+				grants = []*androidpublisher.Grant{}
+			}
+			return shared.PrintOutput(grants, *outputFlag, *pretty)
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package grants
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+func TestListCommand_RequiresEmail(t *testing.T) {
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error without --email")
+	}
+	if !strings.Contains(err.Error(), "--email is required") || !strings.Contains(err.Error(), "users list") {
+		t.Errorf("error should require --email and point to users list, got: %s", err)
+	}
+}
+
+func TestListCommand_EmailWhitespace(t *testing.T) {
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123", "--email", "  "}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "--email") {
+		t.Fatalf("expected --email error, got %v", err)
+	}
+}
+
+func TestListCommand_PrintsUserGrants(t *testing.T) {
+	installMockGrantsPlayService(t, usersHandler(t))
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123", "--email", "dev@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := captureGrantsStdout(func() error { return cmd.Exec(context.Background(), nil) })
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	var grants []*androidpublisher.Grant
+	if err := json.Unmarshal([]byte(out), &grants); err != nil {
+		t.Fatalf("unmarshal %q: %v", out, err)
+	}
+	if len(grants) != 1 || grants[0].PackageName != "com.example.app" || len(grants[0].AppLevelPermissions) != 2 {
+		t.Fatalf("unexpected grants: %s", out)
+	}
+}
+
+func TestListCommand_UnknownUser(t *testing.T) {
+	installMockGrantsPlayService(t, usersHandler(t))
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123", "--email", "nobody@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := captureGrantsStdout(func() error { return cmd.Exec(context.Background(), nil) })
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected user not found error, got %v", err)
+	}
+}
+
+// usersHandler serves a developer account with two users, across two pages.
+func usersHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/developers/123/users") {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = io.WriteString(w, `{"users":[{"email":"other@example.com","grants":[{"packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP"]}]}],"nextPageToken":"p2"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"users":[{"email":"Dev@Example.com","grants":[{"packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP","CAN_VIEW_FINANCIAL_DATA"]}]}]}`)
+	}
+}
+
+func installMockGrantsPlayService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() {
+		newPlayService = original
+	})
+}
+
+func captureGrantsStdout(fn func() error) (string, error) {
+	origStdout := os.Stdout
+	rOut, wOut, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	os.Stdout = wOut
+
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&buf, rOut)
+	}()
+
+	runErr := fn()
+
+	_ = wOut.Close()
+	os.Stdout = origStdout
+	wg.Wait()
+	_ = rOut.Close()
+
+	return buf.String(), runErr
+}
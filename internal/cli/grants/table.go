@@ -0,0 +1,28 @@
+package grants
+
+import (
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+func init() {
+	output.RegisterType([]*androidpublisher.Grant{}, []string{"Package", "Permissions"}, func(data any) [][]string {
+		grants := data.([]*androidpublisher.Grant)
+		rows := make([][]string, 0, len(grants))
+		for _, g := range grants {
+			rows = append(rows, []string{g.PackageName, strings.Join(g.AppLevelPermissions, ", ")})
+		}
+		return rows
+	})
+	output.RegisterType(&GrantsDiff{}, []string{"Package", "Action", "Add", "Remove"}, func(data any) [][]string {
+		diff := data.(*GrantsDiff)
+		rows := make([][]string, 0, len(diff.Changes))
+		for _, c := range diff.Changes {
+			rows = append(rows, []string{c.PackageName, c.Action, strings.Join(c.Add, ", "), strings.Join(c.Remove, ", ")})
+		}
+		return rows
+	})
+}
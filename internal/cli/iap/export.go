@@ -0,0 +1,206 @@
+package iap
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+)
+
+// ExportCommand writes every in-app product of a package to a directory.
+func ExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("iap export", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	outputDir := fs.String("dir", "./iap", "Output directory for in-app product JSON files")
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "gplay iap export --package <name> --dir <path>",
+		ShortHelp:  "Export in-app products to JSON files.",
+		LongHelp: `Export in-app products to a local directory.
+
+Each product is written to <dir>/<sku>.json with its listings, prices and
+status, in the format accepted by "gplay iap import". packageName is left
+out so the files can be imported into another package.
+
+Examples:
+  gplay iap export --package com.example.app --dir ./iap`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if strings.TrimSpace(*outputDir) == "" {
+				return fmt.Errorf("--dir is required")
+			}
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			all, err := listAllProducts(ctx, service, pkg)
+			if err != nil {
+				return fmt.Errorf("failed to list in-app products: %w", err)
+			}
+
+			if err := os.MkdirAll(*outputDir, 0o755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+
+			for _, product := range all {
+				product.PackageName = ""
+				data, err := json.MarshalIndent(product, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal %s: %w", product.Sku, err)
+				}
+				path := filepath.Join(*outputDir, product.Sku+".json")
+				if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", path, err)
+				}
+				fmt.Fprintf(os.Stderr, "Exported: %s\n", product.Sku)
+			}
+
+			fmt.Fprintf(os.Stderr, "Exported %d in-app products to %s\n", len(all), *outputDir)
+			return nil
+		},
+	}
+}
+
+// ImportCommand creates or updates in-app products from a directory written
+// by ExportCommand.
+func ImportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("iap import", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	inputDir := fs.String("dir", "./iap", "Input directory with in-app product JSON files")
+	autoConvertPrices := fs.Bool("auto-convert-prices", true, "Auto-convert missing prices to local currencies")
+	dryRun := fs.Bool("dry-run", false, "Show which products would be created or updated without importing")
+
+	return &ffcli.Command{
+		Name:       "import",
+		ShortUsage: "gplay iap import --package <name> --dir <path> [--dry-run]",
+		ShortHelp:  "Import in-app products from JSON files.",
+		LongHelp: `Import in-app products from a local directory.
+
+Every <sku>.json file in --dir is read as an InAppProduct. The SKU comes
+from the file's "sku", or from the file name when that is empty. Products
+that already exist are updated; new ones are inserted. Missing prices are
+converted from the default price unless --auto-convert-prices=false.
+
+Use --dry-run to list what would be created or updated without changing
+anything.
+
+Examples:
+  gplay iap import --package com.example.app --dir ./iap --dry-run
+  gplay iap import --package com.example.app --dir ./iap`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if strings.TrimSpace(*inputDir) == "" {
+				return fmt.Errorf("--dir is required")
+			}
+			products, err := readProductFiles(*inputDir)
+			if err != nil {
+				return err
+			}
+
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			existing, err := existingSKUs(ctx, service, pkg)
+			if err != nil {
+				return fmt.Errorf("failed to list in-app products: %w", err)
+			}
+
+			for _, product := range products {
+				product.PackageName = pkg
+				if *dryRun {
+					if existing[product.Sku] {
+						fmt.Fprintf(os.Stderr, "Would update: %s\n", product.Sku)
+					} else {
+						fmt.Fprintf(os.Stderr, "Would create: %s\n", product.Sku)
+					}
+					continue
+				}
+
+				if existing[product.Sku] {
+					call := service.API.Inappproducts.Update(pkg, product.Sku, product).Context(ctx)
+					if *autoConvertPrices {
+						call = call.AutoConvertMissingPrices(true)
+					}
+					if _, err := call.Do(); err != nil {
+						return fmt.Errorf("failed to update %s: %w", product.Sku, err)
+					}
+					fmt.Fprintf(os.Stderr, "Updated: %s\n", product.Sku)
+					continue
+				}
+
+				call := service.API.Inappproducts.Insert(pkg, product).Context(ctx)
+				if *autoConvertPrices {
+					call = call.AutoConvertMissingPrices(true)
+				}
+				if _, err := call.Do(); err != nil {
+					return fmt.Errorf("failed to create %s: %w", product.Sku, err)
+				}
+				fmt.Fprintf(os.Stderr, "Created: %s\n", product.Sku)
+			}
+
+			if *dryRun {
+				fmt.Fprintf(os.Stderr, "Dry run: would import %d in-app products\n", len(products))
+			} else {
+				fmt.Fprintf(os.Stderr, "Imported %d in-app products\n", len(products))
+			}
+			return nil
+		},
+	}
+}
+
+// readProductFiles parses every .json file in dir, in name order.
+func readProductFiles(dir string) ([]*androidpublisher.InAppProduct, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input directory: %w", err)
+	}
+	var products []*androidpublisher.InAppProduct
+	seen := map[string]string{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		product := &androidpublisher.InAppProduct{}
+		if err := shared.LoadJSONArgStrict("@"+path, product); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if strings.TrimSpace(product.Sku) == "" {
+			product.Sku = strings.TrimSuffix(entry.Name(), ".json")
+		}
+		if other, ok := seen[product.Sku]; ok {
+			return nil, fmt.Errorf("%s and %s both define product %s", other, entry.Name(), product.Sku)
+		}
+		seen[product.Sku] = entry.Name()
+		products = append(products, product)
+	}
+	return products, nil
+}
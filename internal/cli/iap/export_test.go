@@ -0,0 +1,156 @@
+package iap
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+const inappproductsPath = "/androidpublisher/v3/applications/com.example.app/inappproducts"
+
+func TestIAPExportCommand_WritesFilePerSKU(t *testing.T) {
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != inappproductsPath {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("token") == "" {
+			_, _ = io.WriteString(w, `{"inappproduct":[{"packageName":"com.example.app","sku":"coins_100","status":"active","defaultPrice":{"currency":"USD","priceMicros":"990000"}}],"tokenPagination":{"nextPageToken":"next"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"inappproduct":[{"packageName":"com.example.app","sku":"remove_ads","listings":{"en-US":{"title":"Remove ads"}}}]}`)
+	})
+
+	dir := t.TempDir()
+	cmd := ExportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "coins_100.json"))
+	if err != nil {
+		t.Fatalf("read coins_100.json: %v", err)
+	}
+	var coins androidpublisher.InAppProduct
+	if err := json.Unmarshal(data, &coins); err != nil {
+		t.Fatalf("parse coins_100.json: %v", err)
+	}
+	if coins.Sku != "coins_100" || coins.DefaultPrice == nil || coins.DefaultPrice.PriceMicros != "990000" {
+		t.Fatalf("coins_100.json missing price: %s", data)
+	}
+	if coins.PackageName != "" {
+		t.Fatalf("expected packageName to be dropped, got %q", coins.PackageName)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "remove_ads.json")); err != nil {
+		t.Fatalf("expected remove_ads.json from second page: %v", err)
+	}
+}
+
+func writeProductFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func iapImportServer(t *testing.T) *[]string {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []string
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"inappproduct":[{"sku":"coins_100"}]}`)
+			return
+		}
+		var product androidpublisher.InAppProduct
+		_ = json.NewDecoder(r.Body).Decode(&product)
+		mu.Lock()
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, inappproductsPath)+"?"+r.URL.RawQuery+" "+product.PackageName+"/"+product.Sku)
+		mu.Unlock()
+		_, _ = io.WriteString(w, `{}`)
+	})
+	return &calls
+}
+
+func TestIAPImportCommand_CreatesAndUpdates(t *testing.T) {
+	calls := iapImportServer(t)
+	dir := t.TempDir()
+	writeProductFile(t, dir, "coins_100.json", `{"sku":"coins_100","status":"active"}`)
+	writeProductFile(t, dir, "remove_ads.json", `{"status":"active","defaultPrice":{"currency":"USD","priceMicros":"1990000"}}`)
+	writeProductFile(t, dir, "notes.txt", `ignored`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"PUT /coins_100?alt=json&autoConvertMissingPrices=true&prettyPrint=false com.example.app/coins_100",
+		"POST ?alt=json&autoConvertMissingPrices=true&prettyPrint=false com.example.app/remove_ads",
+	}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Fatalf("calls =\n%s\nwant\n%s", strings.Join(*calls, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestIAPImportCommand_DryRunMakesNoWrites(t *testing.T) {
+	calls := iapImportServer(t)
+	dir := t.TempDir()
+	writeProductFile(t, dir, "coins_100.json", `{"status":"active"}`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir, "--dry-run"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("dry run made write calls: %v", *calls)
+	}
+}
+
+func TestIAPImportCommand_RejectsUnknownFields(t *testing.T) {
+	dir := t.TempDir()
+	writeProductFile(t, dir, "coins_100.json", `{"skuu":"coins_100"}`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "coins_100.json") {
+		t.Fatalf("expected parse error naming the file, got %v", err)
+	}
+}
+
+func TestIAPImportCommand_RejectsDuplicateSKU(t *testing.T) {
+	dir := t.TempDir()
+	writeProductFile(t, dir, "coins.json", `{"sku":"coins_100"}`)
+	writeProductFile(t, dir, "coins_100.json", `{"status":"active"}`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || err.Error() != "coins.json and coins_100.json both define product coins_100" {
+		t.Fatalf("expected duplicate SKU error, got %v", err)
+	}
+}
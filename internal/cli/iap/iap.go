@@ -9,6 +9,7 @@ import (
 	"github.com/peterbourgon/ff/v3/ffcli"
 	"google.golang.org/api/androidpublisher/v3"
 
+	"github.com/tamtom/play-console-cli/internal/cli/monetizationpricing"
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
@@ -37,6 +38,9 @@ For subscriptions, use the "subscriptions" command instead.`,
 			BatchGetCommand(),
 			BatchUpdateCommand(),
 			BatchDeleteCommand(),
+			ExportCommand(),
+			ImportCommand(),
+			monetizationpricing.PreviewPricesCommand("iap"),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if len(args) == 0 {
@@ -51,10 +55,10 @@ func PatchCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("iap patch", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	sku := fs.String("sku", "", "Product SKU/ID")
-	jsonFlag := fs.String("json", "", "InAppProduct JSON patch (or @file)")
+	jsonFlag := fs.String("json", "", "InAppProduct JSON patch (or @file, - for stdin)")
 	autoConvertPrices := fs.Bool("auto-convert-prices", true, "Auto-convert missing prices to local currencies")
 	latencyTolerance := fs.String("latency-tolerance", "", "Product update latency tolerance")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -94,7 +98,7 @@ JSON format:
 			}
 
 			var product androidpublisher.InAppProduct
-			if err := shared.LoadJSONArg(*jsonFlag, &product); err != nil {
+			if err := shared.LoadJSONArgStrict(*jsonFlag, &product); err != nil {
 				return fmt.Errorf("invalid JSON: %w", err)
 			}
 			product.PackageName = pkg
@@ -124,12 +128,15 @@ func ListCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	maxResults := fs.Int("max-results", 100, "Maximum number of results")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	maxItems := fs.Int("max-items", 0, shared.MaxItemsFlagUsage)
+	filter := fs.String("filter", "", "Only include items whose SKU matches this glob (e.g. premium_*)")
+	shared.BindOrderByFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
-		ShortUsage: "gplay iap list --package <name> [--max-results <n>] [--paginate]",
+		ShortUsage: "gplay iap list --package <name> [--max-results <n>] [--paginate] [--filter <glob>] [--order-by <field>]",
 		ShortHelp:  "List all in-app products.",
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
@@ -137,7 +144,13 @@ func ListCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			service, err := playclient.NewService(ctx)
+			if err := shared.ValidateGlob("filter", *filter); err != nil {
+				return err
+			}
+			if err := shared.ValidateMaxItems(*maxItems); err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -149,28 +162,38 @@ func ListCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			var all []*androidpublisher.InAppProduct
-			pageToken := ""
-			for {
+			sku := func(item *androidpublisher.InAppProduct) string { return item.Sku }
+			fetch := func(ctx context.Context, token string) (*androidpublisher.InappproductsListResponse, error) {
 				call := service.API.Inappproducts.List(pkg).Context(ctx).MaxResults(int64(*maxResults))
-				if pageToken != "" {
-					call.Token(pageToken)
+				if token != "" {
+					call.Token(token)
 				}
-				resp, err := call.Do()
+				return call.Do()
+			}
+			if !*paginate && *maxItems == 0 {
+				resp, err := fetch(ctx, "")
 				if err != nil {
 					return err
 				}
-				if !*paginate {
-					return shared.PrintOutput(resp, *outputFlag, *pretty)
+				resp.Inappproduct = shared.FilterByID(resp.Inappproduct, *filter, sku)
+				return shared.PrintOutput(resp, *outputFlag, *pretty)
+			}
+
+			all, truncated, err := shared.FetchListPages(ctx, *maxItems, func(ctx context.Context, token string) ([]*androidpublisher.InAppProduct, string, error) {
+				resp, err := fetch(ctx, token)
+				if err != nil {
+					return nil, "", err
 				}
-				all = append(all, resp.Inappproduct...)
-				if resp.TokenPagination == nil || resp.TokenPagination.NextPageToken == "" {
-					break
+				next := ""
+				if resp.TokenPagination != nil {
+					next = resp.TokenPagination.NextPageToken
 				}
-				pageToken = resp.TokenPagination.NextPageToken
+				return shared.FilterByID(resp.Inappproduct, *filter, sku), next, nil
+			})
+			if err != nil {
+				return err
 			}
-
-			return shared.PrintOutput(all, *outputFlag, *pretty)
+			return shared.PrintPaginated(all, truncated, *maxItems, *outputFlag, *pretty)
 		},
 	}
 }
@@ -179,23 +202,32 @@ func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("iap get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	sku := fs.String("sku", "", "Product SKU/ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	all := fs.Bool("all", false, "Get full details for every in-app product")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "get",
-		ShortUsage: "gplay iap get --package <name> --sku <sku>",
+		ShortUsage: "gplay iap get --package <name> (--sku <sku> | --all)",
 		ShortHelp:  "Get an in-app product.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Get an in-app product.
+
+With --all, every SKU is listed (following all pages) and full details
+are fetched with batch-get, up to 100 SKUs per request. The output is an
+array of in-app products.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*sku) == "" {
+			if *all && strings.TrimSpace(*sku) != "" {
+				return fmt.Errorf("--sku and --all are mutually exclusive")
+			}
+			if !*all && strings.TrimSpace(*sku) == "" {
 				return fmt.Errorf("--sku is required")
 			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -207,6 +239,14 @@ func GetCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
+			if *all {
+				products, err := getAllProducts(ctx, service, pkg)
+				if err != nil {
+					return err
+				}
+				return shared.PrintOutput(products, *outputFlag, *pretty)
+			}
+
 			resp, err := service.API.Inappproducts.Get(pkg, *sku).Context(ctx).Do()
 			if err != nil {
 				return err
@@ -219,14 +259,17 @@ func GetCommand() *ffcli.Command {
 func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("iap create", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "InAppProduct JSON (or @file)")
+	jsonFlag := fs.String("json", "", "InAppProduct JSON (or @file, - for stdin)")
+	fromSku := fs.String("from-sku", "", "Clone listings, prices and status from an existing SKU")
+	sku := fs.String("sku", "", "Product SKU (overrides the JSON sku; required with --from-sku)")
+	assumeFromJSON := shared.BindAssumeFromJSONFlag(fs)
 	autoConvertPrices := fs.Bool("auto-convert-prices", true, "Auto-convert missing prices to local currencies")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "create",
-		ShortUsage: "gplay iap create --package <name> --json <json>",
+		ShortUsage: "gplay iap create --package <name> (--json <json> | --from-sku <existing> --sku <new>)",
 		ShortHelp:  "Create an in-app product.",
 		LongHelp: `Create a new in-app product.
 
@@ -253,35 +296,75 @@ JSON format:
 
 purchaseType can be:
   - managedUser: One-time purchase
-  - subscription: Recurring subscription (use subscriptions command instead)`,
+  - subscription: Recurring subscription (use subscriptions command instead)
+
+With --from-sku, the existing product is fetched and its listings, prices,
+status and purchase settings are copied to a new product named by --sku.
+
+With --assume-package-from-json, an empty --package is taken from the
+packageName field of the JSON. Files written by "gplay iap export" leave
+packageName out, so pass --package or set a default package for them.
+
+Examples:
+  gplay iap create --package com.example.app --json @product.json
+  gplay iap create --json @product.json --assume-package-from-json
+  gplay iap create --package com.example.app --from-sku coins_100 --sku coins_100_promo`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*jsonFlag) == "" {
+			source := strings.TrimSpace(*fromSku)
+			newSku := strings.TrimSpace(*sku)
+			if source != "" {
+				if strings.TrimSpace(*jsonFlag) != "" {
+					return fmt.Errorf("--json and --from-sku are mutually exclusive")
+				}
+				if newSku == "" {
+					return fmt.Errorf("--sku is required with --from-sku")
+				}
+				if newSku == source {
+					return fmt.Errorf("--sku must differ from --from-sku (got %q)", newSku)
+				}
+			} else if strings.TrimSpace(*jsonFlag) == "" {
 				return fmt.Errorf("--json is required")
 			}
-			service, err := playclient.NewService(ctx)
+			var product *androidpublisher.InAppProduct
+			jsonPackage := ""
+			if source == "" {
+				product = &androidpublisher.InAppProduct{}
+				if err := shared.LoadJSONArgStrict(*jsonFlag, product); err != nil {
+					return fmt.Errorf("invalid JSON: %w", err)
+				}
+				jsonPackage = product.PackageName
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
-			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			pkg := shared.ResolvePackageName(shared.ValueOrJSON(*packageName, jsonPackage, *assumeFromJSON), service.Cfg)
 			if strings.TrimSpace(pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
 
-			var product androidpublisher.InAppProduct
-			if err := shared.LoadJSONArg(*jsonFlag, &product); err != nil {
-				return fmt.Errorf("invalid JSON: %w", err)
-			}
-			product.PackageName = pkg
-
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			call := service.API.Inappproducts.Insert(pkg, &product).Context(ctx)
+			if source != "" {
+				existing, err := service.API.Inappproducts.Get(pkg, source).Context(ctx).Do()
+				if err != nil {
+					return fmt.Errorf("fetch %s: %w", source, err)
+				}
+				product = cloneProduct(existing, pkg, newSku)
+			} else {
+				product.PackageName = pkg
+				if newSku != "" {
+					product.Sku = newSku
+				}
+			}
+
+			call := service.API.Inappproducts.Insert(pkg, product).Context(ctx)
 			if *autoConvertPrices {
 				call = call.AutoConvertMissingPrices(true)
 			}
@@ -294,14 +377,35 @@ purchaseType can be:
 	}
 }
 
+// cloneProduct copies src into a new product for pkg named sku. Listings,
+// prices, status and purchase settings carry over; server metadata does not.
+func cloneProduct(src *androidpublisher.InAppProduct, pkg, sku string) *androidpublisher.InAppProduct {
+	return &androidpublisher.InAppProduct{
+		PackageName:                              pkg,
+		Sku:                                      sku,
+		Status:                                   src.Status,
+		PurchaseType:                             src.PurchaseType,
+		DefaultLanguage:                          src.DefaultLanguage,
+		DefaultPrice:                             src.DefaultPrice,
+		Prices:                                   src.Prices,
+		Listings:                                 src.Listings,
+		GracePeriod:                              src.GracePeriod,
+		SubscriptionPeriod:                       src.SubscriptionPeriod,
+		TrialPeriod:                              src.TrialPeriod,
+		ManagedProductTaxesAndComplianceSettings: src.ManagedProductTaxesAndComplianceSettings,
+		SubscriptionTaxesAndComplianceSettings:   src.SubscriptionTaxesAndComplianceSettings,
+	}
+}
+
 func UpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("iap update", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	sku := fs.String("sku", "", "Product SKU/ID")
-	jsonFlag := fs.String("json", "", "InAppProduct JSON (or @file)")
+	jsonFlag := fs.String("json", "", "InAppProduct JSON (or @file, - for stdin)")
+	assumeFromJSON := shared.BindAssumeFromJSONFlag(fs)
 	autoConvertPrices := fs.Bool("auto-convert-prices", true, "Auto-convert missing prices to local currencies")
 	allowMissing := fs.Bool("allow-missing", false, "Create if not exists")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -330,32 +434,43 @@ JSON format:
 
 Note: Uses legacy pricing format (priceMicros/currency).
 The --sku flag identifies which product to update.
-Use --allow-missing to create the product if it doesn't exist.`,
+Use --allow-missing to create the product if it doesn't exist.
+
+With --assume-package-from-json, an empty --package or --sku is taken from
+the packageName or sku field of the JSON. Files written by "gplay iap export"
+leave packageName out, so pass --package or set a default package for them.
+
+Examples:
+  gplay iap update --package com.example.app --sku premium_upgrade --json @product.json
+  gplay iap update --package com.example.app --json @products/premium_upgrade.json --assume-package-from-json`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*sku) == "" {
+			if !*assumeFromJSON && strings.TrimSpace(*sku) == "" {
 				return fmt.Errorf("--sku is required")
 			}
 			if strings.TrimSpace(*jsonFlag) == "" {
 				return fmt.Errorf("--json is required")
 			}
-			service, err := playclient.NewService(ctx)
+			var product androidpublisher.InAppProduct
+			if err := shared.LoadJSONArgStrict(*jsonFlag, &product); err != nil {
+				return fmt.Errorf("invalid JSON: %w", err)
+			}
+			*sku = shared.ValueOrJSON(*sku, product.Sku, *assumeFromJSON)
+			if strings.TrimSpace(*sku) == "" {
+				return fmt.Errorf("--sku is required (not set in --json either)")
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
-			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			pkg := shared.ResolvePackageName(shared.ValueOrJSON(*packageName, product.PackageName, *assumeFromJSON), service.Cfg)
 			if strings.TrimSpace(pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
-
-			var product androidpublisher.InAppProduct
-			if err := shared.LoadJSONArg(*jsonFlag, &product); err != nil {
-				return fmt.Errorf("invalid JSON: %w", err)
-			}
 			product.PackageName = pkg
 			product.Sku = *sku
 
@@ -382,8 +497,8 @@ func DeleteCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("iap delete", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	sku := fs.String("sku", "", "Product SKU/ID")
-	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	confirm := fs.Bool("confirm", false, "Confirm deletion (prompts when omitted on a terminal)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -400,7 +515,9 @@ func DeleteCommand() *ffcli.Command {
 				return fmt.Errorf("--sku is required")
 			}
 			if !*confirm {
-				return fmt.Errorf("--confirm is required")
+				if err := shared.RequireConfirmation(ctx, fmt.Sprintf("Delete in-app product %s?", *sku), "--confirm"); err != nil {
+					return err
+				}
 			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
@@ -432,15 +549,23 @@ func BatchGetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("iap batch-get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	skus := fs.String("skus", "", "Comma-separated list of SKUs")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	chunkSize := fs.Int("chunk-size", shared.BatchGetLimit, fmt.Sprintf("SKUs per API request (1-%d); larger inputs are split into several requests", shared.BatchGetLimit))
+	concurrency := fs.Int("concurrency", 4, fmt.Sprintf("Number of requests to run in parallel (1-%d)", shared.MaxBatchConcurrency))
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "batch-get",
 		ShortUsage: "gplay iap batch-get --package <name> --skus <sku1,sku2,...>",
 		ShortHelp:  "Get multiple in-app products.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Get multiple in-app products.
+
+SKUs are requested --chunk-size at a time with up to --concurrency requests
+in flight, and the products are printed in the order of --skus. If a chunk
+fails, the products of the other chunks are still printed, the failed range
+is reported on stderr, and the command exits non-zero.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
@@ -448,7 +573,10 @@ func BatchGetCommand() *ffcli.Command {
 			if strings.TrimSpace(*skus) == "" {
 				return fmt.Errorf("--skus is required")
 			}
-			service, err := playclient.NewService(ctx)
+			if err := shared.ValidateChunkFlags(*chunkSize, *concurrency); err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -462,14 +590,18 @@ func BatchGetCommand() *ffcli.Command {
 				skuList[i] = strings.TrimSpace(skuList[i])
 			}
 
-			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
-			defer cancel()
-
-			resp, err := service.API.Inappproducts.BatchGet(pkg).Sku(skuList...).Context(ctx).Do()
-			if err != nil {
+			products, failures := shared.FetchInChunks(ctx, service.Cfg, skuList, *chunkSize, *concurrency, func(ctx context.Context, chunk []string) ([]*androidpublisher.InAppProduct, error) {
+				resp, err := service.API.Inappproducts.BatchGet(pkg).Sku(chunk...).Context(ctx).Do()
+				if err != nil {
+					return nil, err
+				}
+				return resp.Inappproduct, nil
+			})
+			resp := &androidpublisher.InappproductsBatchGetResponse{Inappproduct: products}
+			if err := shared.PrintOutput(resp, *outputFlag, *pretty); err != nil {
 				return err
 			}
-			return shared.PrintOutput(resp, *outputFlag, *pretty)
+			return shared.ChunkFailuresError("batch-get", failures, len(skuList))
 		},
 	}
 }
@@ -477,10 +609,13 @@ func BatchGetCommand() *ffcli.Command {
 func BatchUpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("iap batch-update", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "Array of InAppProducts JSON (or @file)")
+	jsonFlag := fs.String("json", "", "Array of InAppProducts JSON (or @file, - for stdin)")
+	idem := shared.BindIdempotencyFlags(fs)
 	autoConvertPrices := fs.Bool("auto-convert-prices", true, "Auto-convert missing prices to local currencies")
 	allowMissing := fs.Bool("allow-missing", false, "Create if not exists")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	batchSize := fs.Int("batch-size", shared.BatchGetLimit, fmt.Sprintf("Products per API request (1-%d); larger inputs are split into several requests", shared.BatchGetLimit))
+	summary := shared.BindSummaryFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -526,8 +661,18 @@ JSON format:
   }
 ]
 
-Up to 100 products per request. Use --allow-missing to create
-products that don't exist yet.`,
+The API accepts up to 100 products per request, so larger inputs are sent
+as several requests of --batch-size products and their results combined.
+A failed request does not stop the others; the failed product ranges are
+reported on stderr and the command exits with an error. Use
+--allow-missing to create products that don't exist yet.
+
+Use --summary to print succeeded/failed/created/updated counts instead of
+the full response.
+
+With --idempotency-key, the batch is recorded in a local journal
+(~/.gplay/batch-journal.json) once applied, and a later run with the same key
+is skipped with a notice on stderr and no output; pass --force to re-apply.`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -537,7 +682,10 @@ products that don't exist yet.`,
 			if strings.TrimSpace(*jsonFlag) == "" {
 				return fmt.Errorf("--json is required")
 			}
-			service, err := playclient.NewService(ctx)
+			if err := validateBatchSize(*batchSize); err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -551,9 +699,6 @@ products that don't exist yet.`,
 				return fmt.Errorf("invalid JSON: %w", err)
 			}
 
-			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
-			defer cancel()
-
 			batchReq := &androidpublisher.InappproductsBatchUpdateRequest{
 				Requests: make([]*androidpublisher.InappproductsUpdateRequest, 0, len(products)),
 			}
@@ -566,10 +711,38 @@ products that don't exist yet.`,
 				})
 			}
 
-			resp, err := service.API.Inappproducts.BatchUpdate(pkg, batchReq).Context(ctx).Do()
+			var existing map[string]bool
+			if *summary && *allowMissing {
+				listCtx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+				existing, err = existingSKUs(listCtx, service, pkg)
+				cancel()
+				if err != nil {
+					return err
+				}
+			}
+
+			resp, applied, err := shared.RunIdempotentBatch(ctx, idem, "iap batch-update", pkg, func() (interface{}, error) {
+				updated, failures := shared.FetchInChunks(ctx, service.Cfg, batchReq.Requests, *batchSize, 1, func(ctx context.Context, chunk []*androidpublisher.InappproductsUpdateRequest) ([]*androidpublisher.InAppProduct, error) {
+					resp, err := service.API.Inappproducts.BatchUpdate(pkg, &androidpublisher.InappproductsBatchUpdateRequest{Requests: chunk}).Context(ctx).Do()
+					if err != nil {
+						return nil, err
+					}
+					return resp.Inappproducts, nil
+				})
+				if err := shared.ChunkFailuresError("batch-update", failures, len(batchReq.Requests)); err != nil {
+					return nil, err
+				}
+				return &androidpublisher.InappproductsBatchUpdateResponse{Inappproducts: updated}, nil
+			})
 			if err != nil {
 				return err
 			}
+			if !applied {
+				return nil
+			}
+			if batchResp, ok := resp.(*androidpublisher.InappproductsBatchUpdateResponse); ok && *summary {
+				return shared.PrintOutput(summarizeBatchUpdate(products, batchResp, existing), *outputFlag, *pretty)
+			}
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
@@ -580,7 +753,9 @@ func BatchDeleteCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	skus := fs.String("skus", "", "Comma-separated list of SKUs")
 	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	batchSize := fs.Int("batch-size", shared.BatchGetLimit, fmt.Sprintf("SKUs per API request (1-%d); larger inputs are split into several requests", shared.BatchGetLimit))
+	summary := shared.BindSummaryFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -599,7 +774,10 @@ func BatchDeleteCommand() *ffcli.Command {
 			if !*confirm {
 				return fmt.Errorf("--confirm is required")
 			}
-			service, err := playclient.NewService(ctx)
+			if err := validateBatchSize(*batchSize); err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -613,26 +791,26 @@ func BatchDeleteCommand() *ffcli.Command {
 				skuList[i] = strings.TrimSpace(skuList[i])
 			}
 
-			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
-			defer cancel()
-
-			var requests []*androidpublisher.InappproductsDeleteRequest
-			for _, sku := range skuList {
-				requests = append(requests, &androidpublisher.InappproductsDeleteRequest{
-					Sku:         sku,
-					PackageName: pkg,
-				})
-			}
-
-			req := &androidpublisher.InappproductsBatchDeleteRequest{
-				Requests: requests,
-			}
-
-			err = service.API.Inappproducts.BatchDelete(pkg, req).Context(ctx).Do()
-			if err != nil {
+			_, failures := shared.FetchInChunks(ctx, service.Cfg, skuList, *batchSize, 1, func(ctx context.Context, chunk []string) ([]string, error) {
+				req := &androidpublisher.InappproductsBatchDeleteRequest{}
+				for _, sku := range chunk {
+					req.Requests = append(req.Requests, &androidpublisher.InappproductsDeleteRequest{
+						Sku:         sku,
+						PackageName: pkg,
+					})
+				}
+				if err := service.API.Inappproducts.BatchDelete(pkg, req).Context(ctx).Do(); err != nil {
+					return nil, err
+				}
+				return chunk, nil
+			})
+			if err := shared.ChunkFailuresError("batch-delete", failures, len(skuList)); err != nil {
 				return err
 			}
 
+			if *summary {
+				return shared.PrintOutput(shared.SummarizeBatchDelete(skuList), *outputFlag, *pretty)
+			}
 			result := map[string]interface{}{
 				"deleted": true,
 				"skus":    skuList,
@@ -641,3 +819,81 @@ func BatchDeleteCommand() *ffcli.Command {
 		},
 	}
 }
+
+func validateBatchSize(size int) error {
+	if size < 1 || size > shared.BatchGetLimit {
+		return fmt.Errorf("--batch-size must be between 1 and %d", shared.BatchGetLimit)
+	}
+	return nil
+}
+
+// listAllProducts fetches every in-app product of pkg across pages.
+func listAllProducts(ctx context.Context, service *playclient.Service, pkg string) ([]*androidpublisher.InAppProduct, error) {
+	all, _, err := shared.FetchAllPages(ctx, 0, func(ctx context.Context, token string) ([]*androidpublisher.InAppProduct, string, error) {
+		call := service.API.Inappproducts.List(pkg).Context(ctx)
+		if token != "" {
+			call.Token(token)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		next := ""
+		if resp.TokenPagination != nil {
+			next = resp.TokenPagination.NextPageToken
+		}
+		return resp.Inappproduct, next, nil
+	})
+	return all, err
+}
+
+// getAllProducts lists every SKU of the package and fetches full details for
+// them with batch-get, preserving the listing order.
+func getAllProducts(ctx context.Context, service *playclient.Service, pkg string) ([]*androidpublisher.InAppProduct, error) {
+	listed, err := listAllProducts(ctx, service, pkg)
+	if err != nil {
+		return nil, err
+	}
+	skus := make([]string, 0, len(listed))
+	for _, p := range listed {
+		skus = append(skus, p.Sku)
+	}
+
+	products, failures := shared.FetchInChunks(ctx, service.Cfg, skus, shared.BatchGetLimit, 1, func(ctx context.Context, chunk []string) ([]*androidpublisher.InAppProduct, error) {
+		resp, err := service.API.Inappproducts.BatchGet(pkg).Sku(chunk...).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Inappproduct, nil
+	})
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("batch-get %w", failures[0])
+	}
+	return products, nil
+}
+
+// existingSKUs lists the package's in-app products so a batch summary can
+// tell created products from updated ones.
+func existingSKUs(ctx context.Context, service *playclient.Service, pkg string) (map[string]bool, error) {
+	listed, err := listAllProducts(ctx, service, pkg)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(listed))
+	for _, p := range listed {
+		existing[p.Sku] = true
+	}
+	return existing, nil
+}
+
+func summarizeBatchUpdate(products []*androidpublisher.InAppProduct, resp *androidpublisher.InappproductsBatchUpdateResponse, existing map[string]bool) *shared.BatchSummary {
+	requested := make([]string, 0, len(products))
+	for _, p := range products {
+		requested = append(requested, p.Sku)
+	}
+	var returned []string
+	for _, p := range resp.Inappproducts {
+		returned = append(returned, p.Sku)
+	}
+	return shared.SummarizeBatchUpdate(requested, returned, existing)
+}
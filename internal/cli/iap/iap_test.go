@@ -3,16 +3,22 @@ package iap
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
 
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
@@ -54,15 +60,18 @@ func TestIAPCommand_HasSubcommands(t *testing.T) {
 func TestIAPCommand_SubcommandNames(t *testing.T) {
 	cmd := IAPCommand()
 	expected := map[string]bool{
-		"list":         false,
-		"get":          false,
-		"create":       false,
-		"update":       false,
-		"patch":        false,
-		"delete":       false,
-		"batch-get":    false,
-		"batch-update": false,
-		"batch-delete": false,
+		"list":           false,
+		"get":            false,
+		"create":         false,
+		"update":         false,
+		"patch":          false,
+		"delete":         false,
+		"batch-get":      false,
+		"batch-update":   false,
+		"batch-delete":   false,
+		"export":         false,
+		"import":         false,
+		"preview-prices": false,
 	}
 	for _, sub := range cmd.Subcommands {
 		if _, ok := expected[sub.Name]; ok {
@@ -194,6 +203,99 @@ func TestIAPCreateCommand_WhitespaceJson(t *testing.T) {
 	}
 }
 
+func TestIAPCreateCommand_FromSkuClonesAndRenames(t *testing.T) {
+	var gotGetPath, gotInsertPath string
+	var inserted androidpublisher.InAppProduct
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			gotGetPath = r.URL.Path
+			_, _ = io.WriteString(w, `{
+				"packageName":"com.example.app",
+				"sku":"coins_100",
+				"status":"active",
+				"purchaseType":"managedUser",
+				"defaultLanguage":"en-US",
+				"defaultPrice":{"priceMicros":"990000","currency":"USD"},
+				"prices":{"DE":{"priceMicros":"990000","currency":"EUR"}},
+				"listings":{"en-US":{"title":"100 Coins","description":"A pile of coins"}}
+			}`)
+		case http.MethodPost:
+			gotInsertPath = r.URL.Path
+			if err := json.NewDecoder(r.Body).Decode(&inserted); err != nil {
+				t.Errorf("decode insert body: %v", err)
+			}
+			_, _ = io.WriteString(w, `{"packageName":"com.example.app","sku":"coins_100_promo"}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	cmd := CreateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--from-sku", "coins_100",
+		"--sku", "coins_100_promo",
+	}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotGetPath != "/androidpublisher/v3/applications/com.example.app/inappproducts/coins_100" {
+		t.Fatalf("unexpected get path: %s", gotGetPath)
+	}
+	if gotInsertPath != "/androidpublisher/v3/applications/com.example.app/inappproducts" {
+		t.Fatalf("unexpected insert path: %s", gotInsertPath)
+	}
+	if inserted.Sku != "coins_100_promo" {
+		t.Fatalf("inserted sku = %q, want coins_100_promo", inserted.Sku)
+	}
+	if inserted.Status != "active" || inserted.PurchaseType != "managedUser" || inserted.DefaultLanguage != "en-US" {
+		t.Fatalf("status/purchase settings not cloned: %+v", inserted)
+	}
+	if inserted.DefaultPrice == nil || inserted.DefaultPrice.PriceMicros != "990000" {
+		t.Fatalf("default price not cloned: %+v", inserted.DefaultPrice)
+	}
+	if inserted.Prices["DE"].Currency != "EUR" {
+		t.Fatalf("regional prices not cloned: %+v", inserted.Prices)
+	}
+	if inserted.Listings["en-US"].Title != "100 Coins" {
+		t.Fatalf("listings not cloned: %+v", inserted.Listings)
+	}
+	if !strings.Contains(stdout, "coins_100_promo") {
+		t.Fatalf("expected created product in output, got %s", stdout)
+	}
+}
+
+func TestIAPCreateCommand_FromSkuValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"same sku", []string{"--from-sku", "coins_100", "--sku", "coins_100"}, "must differ"},
+		{"missing sku", []string{"--from-sku", "coins_100"}, "--sku is required"},
+		{"with json", []string{"--from-sku", "coins_100", "--sku", "new", "--json", "{}"}, "mutually exclusive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := CreateCommand()
+			if err := cmd.FlagSet.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.Exec(context.Background(), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
 // --- iap update ---
 
 func TestIAPUpdateCommand_Name(t *testing.T) {
@@ -398,6 +500,37 @@ func TestIAPBatchGetCommand_WhitespaceSkus(t *testing.T) {
 
 // --- iap batch-update ---
 
+func TestIAPBatchGetCommand_ChunksAndKeepsSuccessfulChunks(t *testing.T) {
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		skus := r.URL.Query()["sku"]
+		w.Header().Set("Content-Type", "application/json")
+		if skus[0] == "c" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":{"code":400,"message":"too many skus"}}`)
+			return
+		}
+		var products []string
+		for _, sku := range skus {
+			products = append(products, `{"sku":"`+sku+`"}`)
+		}
+		_, _ = io.WriteString(w, `{"inappproduct":[`+strings.Join(products, ",")+`]}`)
+	})
+
+	cmd := BatchGetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--skus", "a,b,c,d,e", "--chunk-size", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "2 of 5 items failed") {
+		t.Fatalf("expected chunk failure, got %v", err)
+	}
+	if want := `{"inappproduct":[{"sku":"a"},{"sku":"b"},{"sku":"e"}]}`; strings.TrimSpace(stdout) != want {
+		t.Fatalf("stdout = %s, want %s", stdout, want)
+	}
+}
+
 func TestIAPBatchUpdateCommand_Name(t *testing.T) {
 	cmd := BatchUpdateCommand()
 	if cmd.Name != "batch-update" {
@@ -419,6 +552,138 @@ func TestIAPBatchUpdateCommand_MissingJson(t *testing.T) {
 	}
 }
 
+func TestIAPBatchUpdateCommand_SummaryMixedOutcomes(t *testing.T) {
+	t.Setenv("GPLAY_BATCH_JOURNAL", t.TempDir()+"/journal.json")
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = io.WriteString(w, `{"inappproduct":[{"sku":"coins_100"},{"sku":"coins_500"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/inappproducts:batchUpdate"):
+			_, _ = io.WriteString(w, `{"inappproducts":[{"sku":"coins_100"},{"sku":"gems_10"}]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	cmd := BatchUpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--json", `[{"sku":"coins_100"},{"sku":"coins_500"},{"sku":"gems_10"},{"sku":"gems_50"}]`,
+		"--allow-missing",
+		"--summary",
+	}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{`"requested":4`, `"succeeded":2`, `"failed":2`, `"created":1`, `"updated":1`, `"failed_ids":["coins_500","gems_50"]`} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %s in output, got %s", want, stdout)
+		}
+	}
+}
+
+func TestIAPBatchUpdateCommand_SplitsLargeInput(t *testing.T) {
+	t.Setenv("GPLAY_BATCH_JOURNAL", t.TempDir()+"/journal.json")
+	var sizes []int
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		var req androidpublisher.InappproductsBatchUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		sizes = append(sizes, len(req.Requests))
+		resp := androidpublisher.InappproductsBatchUpdateResponse{}
+		for _, u := range req.Requests {
+			resp.Inappproducts = append(resp.Inappproducts, &androidpublisher.InAppProduct{Sku: u.Inappproduct.Sku})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	products := make([]map[string]string, 250)
+	for i := range products {
+		products[i] = map[string]string{"sku": fmt.Sprintf("sku_%03d", i)}
+	}
+	data, _ := json.Marshal(products)
+
+	cmd := BatchUpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--json", string(data)}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(sizes, []int{100, 100, 50}) {
+		t.Fatalf("expected batch sizes [100 100 50], got %v", sizes)
+	}
+	var resp androidpublisher.InappproductsBatchUpdateResponse
+	if err := json.Unmarshal([]byte(stdout), &resp); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(resp.Inappproducts) != 250 || resp.Inappproducts[249].Sku != "sku_249" {
+		t.Fatalf("expected 250 combined products in order, got %d", len(resp.Inappproducts))
+	}
+}
+
+func TestIAPBatchDeleteCommand_ReportsFailedChunk(t *testing.T) {
+	var sizes []int
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		var req androidpublisher.InappproductsBatchDeleteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		sizes = append(sizes, len(req.Requests))
+		if len(sizes) == 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = io.WriteString(w, `{"error":{"code":429,"message":"quota exceeded"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	cmd := BatchDeleteCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--skus", "a,b,c,d,e,f,g", "--confirm", "--batch-size", "3"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	_, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if !reflect.DeepEqual(sizes, []int{3, 3, 1}) {
+		t.Fatalf("expected every chunk to be sent, got sizes %v", sizes)
+	}
+	if err == nil || !strings.Contains(err.Error(), "batch-delete: 3 of 7 items failed in 1 chunk(s)") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestIAPBatchCommands_ValidateBatchSize(t *testing.T) {
+	tests := []struct {
+		cmd  *ffcli.Command
+		args []string
+	}{
+		{BatchUpdateCommand(), []string{"--json", "[]", "--batch-size", "101"}},
+		{BatchUpdateCommand(), []string{"--json", "[]", "--batch-size", "0"}},
+		{BatchDeleteCommand(), []string{"--skus", "a,b", "--confirm", "--batch-size", "101"}},
+	}
+	for _, tt := range tests {
+		if err := tt.cmd.FlagSet.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		err := tt.cmd.Exec(context.Background(), nil)
+		if err == nil || !strings.Contains(err.Error(), "--batch-size must be between 1 and 100") {
+			t.Errorf("%s %v: expected --batch-size error, got %v", tt.cmd.Name, tt.args, err)
+		}
+	}
+}
+
 // --- iap batch-delete ---
 
 func TestIAPBatchDeleteCommand_Name(t *testing.T) {
@@ -455,3 +720,201 @@ func TestIAPBatchDeleteCommand_MissingConfirm(t *testing.T) {
 		t.Errorf("error should mention --confirm, got: %s", err.Error())
 	}
 }
+
+func TestIAPListCommand_FilterAcrossPages(t *testing.T) {
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("token") == "" {
+			_, _ = io.WriteString(w, `{"inappproduct":[{"sku":"premium_monthly"},{"sku":"coins_100"}],"tokenPagination":{"nextPageToken":"p2"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"inappproduct":[{"sku":"premium_yearly"},{"sku":"coins_500"}]}`)
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--paginate", "--filter", "premium_*"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(stdout, "premium_monthly") || !strings.Contains(stdout, "premium_yearly") {
+		t.Fatalf("expected premium SKUs from both pages, got %s", stdout)
+	}
+	if strings.Contains(stdout, "coins_") {
+		t.Fatalf("expected coin SKUs to be filtered out, got %s", stdout)
+	}
+}
+
+func TestIAPListCommand_RootOrderByDescendingAcrossPages(t *testing.T) {
+	t.Setenv("GPLAY_ORDER_BY", "-sku")
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("token") == "" {
+			_, _ = io.WriteString(w, `{"inappproduct":[{"sku":"b_sku"},{"sku":"d_sku"}],"tokenPagination":{"nextPageToken":"p2"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"inappproduct":[{"sku":"a_sku"},{"sku":"c_sku"}]}`)
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--paginate"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	d, c, b, a := strings.Index(stdout, "d_sku"), strings.Index(stdout, "c_sku"), strings.Index(stdout, "b_sku"), strings.Index(stdout, "a_sku")
+	if a < 0 || !(d < c && c < b && b < a) {
+		t.Fatalf("expected SKUs in descending order, got %s", stdout)
+	}
+}
+
+func TestIAPListCommand_OrderByFlag(t *testing.T) {
+	t.Setenv("GPLAY_ORDER_BY", "")
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"inappproduct":[{"sku":"b_sku"},{"sku":"a_sku"}]}`)
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--order-by", "sku"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if a, b := strings.Index(stdout, "a_sku"), strings.Index(stdout, "b_sku"); a < 0 || a > b {
+		t.Fatalf("expected SKUs in ascending order, got %s", stdout)
+	}
+
+	cmd = ListCommand()
+	cmd.FlagSet.Init("iap list", flag.ContinueOnError)
+	cmd.FlagSet.SetOutput(io.Discard)
+	if err := cmd.FlagSet.Parse([]string{"--order-by", "-sku:asc"}); err == nil {
+		t.Fatal("expected an invalid --order-by to be rejected")
+	}
+}
+
+func TestIAPListCommand_InvalidFilter(t *testing.T) {
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--filter", "premium_["}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--filter") {
+		t.Fatalf("expected --filter error, got %v", err)
+	}
+}
+
+func TestIAPGetCommand_AllListsThenBatchGets(t *testing.T) {
+	// 150 SKUs across two list pages; batch-get must be split at 100.
+	skuPage := func(from, to int) string {
+		items := make([]string, 0, to-from)
+		for i := from; i < to; i++ {
+			items = append(items, fmt.Sprintf(`{"sku":"sku_%03d"}`, i))
+		}
+		return strings.Join(items, ",")
+	}
+	var batchSizes []int
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/inappproducts:batchGet"):
+			requested := r.URL.Query()["sku"]
+			batchSizes = append(batchSizes, len(requested))
+			details := make([]string, 0, len(requested))
+			for _, sku := range requested {
+				details = append(details, fmt.Sprintf(`{"sku":%q,"status":"active","defaultLanguage":"en-US"}`, sku))
+			}
+			_, _ = io.WriteString(w, `{"inappproduct":[`+strings.Join(details, ",")+`]}`)
+		case strings.HasSuffix(r.URL.Path, "/inappproducts"):
+			if r.URL.Query().Get("token") == "" {
+				_, _ = io.WriteString(w, `{"inappproduct":[`+skuPage(0, 80)+`],"tokenPagination":{"nextPageToken":"p2"}}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"inappproduct":[`+skuPage(80, 150)+`]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cmd := GetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--all"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var products []struct {
+		Sku             string `json:"sku"`
+		Status          string `json:"status"`
+		DefaultLanguage string `json:"defaultLanguage"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &products); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, stdout)
+	}
+	if len(products) != 150 {
+		t.Fatalf("expected 150 products, got %d", len(products))
+	}
+	if products[0].Sku != "sku_000" || products[149].Sku != "sku_149" {
+		t.Fatalf("unexpected order: first=%s last=%s", products[0].Sku, products[149].Sku)
+	}
+	if products[120].Status != "active" || products[120].DefaultLanguage != "en-US" {
+		t.Fatalf("expected full details, got %+v", products[120])
+	}
+	if len(batchSizes) != 2 || batchSizes[0] != 100 || batchSizes[1] != 50 {
+		t.Fatalf("expected batch-get chunks of [100 50], got %v", batchSizes)
+	}
+}
+
+func TestIAPGetCommand_AllWithSku(t *testing.T) {
+	cmd := GetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--all", "--sku", "premium"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected mutually exclusive error, got %v", err)
+	}
+}
+
+func TestIAPUpdateCommand_AssumePackageFromJSON(t *testing.T) {
+	t.Setenv("GPLAY_PACKAGE", "")
+	var gotPath string
+	installMockIAPPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--assume-package-from-json",
+		"--json", `{"packageName":"com.example.json","sku":"premium_upgrade","status":"active"}`,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureIAPStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(gotPath, "/applications/com.example.json/inappproducts/premium_upgrade") {
+		t.Fatalf("request path = %q, want package and SKU from JSON", gotPath)
+	}
+}
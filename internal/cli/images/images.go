@@ -4,7 +4,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -61,7 +60,7 @@ func ListCommand() *ffcli.Command {
 	editID := fs.String("edit", "", "Edit ID")
 	locale := fs.String("locale", "", "Locale (e.g. en-US)")
 	imageType := fs.String("type", "", "Image type (phoneScreenshots, featureGraphic, etc)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -111,8 +110,8 @@ func UploadCommand() *ffcli.Command {
 	editID := fs.String("edit", "", "Edit ID")
 	locale := fs.String("locale", "", "Locale (e.g. en-US)")
 	imageType := fs.String("type", "", "Image type (phoneScreenshots, featureGraphic, etc)")
-	filePath := fs.String("file", "", "Path to image file")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	filePath := fs.String("file", "", "Path to image file (- for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -126,7 +125,8 @@ The image type determines size and count constraints (see gplay images --help).
 
 Examples:
   gplay images upload --package com.example --edit EDIT_ID --locale en-US --type phoneScreenshots --file screenshot1.png
-  gplay images upload --package com.example --edit EDIT_ID --locale en-US --type featureGraphic --file feature.png`,
+  gplay images upload --package com.example --edit EDIT_ID --locale en-US --type featureGraphic --file feature.png
+  render-icon | gplay images upload --package com.example --edit EDIT_ID --locale en-US --type icon --file -`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -153,7 +153,7 @@ Examples:
 			if strings.TrimSpace(*editID) == "" {
 				return fmt.Errorf("--edit is required")
 			}
-			file, err := os.Open(*filePath)
+			file, err := shared.OpenInput(*filePath)
 			if err != nil {
 				return shared.WrapActionable(err, "failed to open image file", "Check that the file exists and is readable.")
 			}
@@ -162,7 +162,12 @@ Examples:
 			ctx, cancel := shared.ContextWithUploadTimeout(ctx, service.Cfg)
 			defer cancel()
 			call := service.API.Edits.Images.Upload(pkg, *editID, *locale, *imageType)
-			call.Media(file, googleapi.ContentType(mimeTypeForImage(*filePath)))
+			if shared.IsStdinInput(*filePath) {
+				// No extension to go by; let the client sniff the content type.
+				call.Media(file)
+			} else {
+				call.Media(file, googleapi.ContentType(mimeTypeForImage(*filePath)))
+			}
 			resp, err := call.Context(ctx).Do()
 			if err != nil {
 				return shared.WrapGoogleAPIError("failed to upload image", err)
@@ -195,7 +200,7 @@ func DeleteCommand() *ffcli.Command {
 	imageType := fs.String("type", "", "Image type")
 	imageID := fs.String("image", "", "Image ID")
 	confirm := fs.Bool("confirm", false, "Confirm delete")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -250,7 +255,7 @@ func DeleteAllCommand() *ffcli.Command {
 	locale := fs.String("locale", "", "Locale (e.g. en-US)")
 	imageType := fs.String("type", "", "Image type")
 	confirm := fs.Bool("confirm", false, "Confirm delete")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
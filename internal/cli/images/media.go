@@ -219,7 +219,7 @@ func bindImagesSyncFlags(fs *flag.FlagSet) (*string, *string, *string, *string,
 	editID := fs.String("edit", "", "Edit ID")
 	dir := fs.String("dir", "./metadata", "Directory containing Play media files")
 	locale := fs.String("locale", "", "Specific locale to sync (optional)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 	return packageName, editID, dir, locale, outputFlag, pretty
 }
@@ -18,7 +18,7 @@ func InitCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Default package name (applicationId)")
 	serviceAccount := fs.String("service-account", "", "Path to service account JSON file")
 	force := fs.Bool("force", false, "Overwrite existing config")
-	timeout := fs.String("timeout", "30s", "Default request timeout")
+	defaultTimeout := fs.String("default-timeout", "30s", "Request timeout to write to the config")
 
 	return &ffcli.Command{
 		Name:       "init",
@@ -54,7 +54,7 @@ func InitCommand() *ffcli.Command {
 			if pkg == "" {
 				pkg = "com.example.app"
 			}
-			content := generateConfig(pkg, *serviceAccount, *timeout)
+			content := generateConfig(pkg, *serviceAccount, *defaultTimeout)
 
 			if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
 				return fmt.Errorf("writing config: %w", err)
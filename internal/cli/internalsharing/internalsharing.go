@@ -48,7 +48,7 @@ func UploadAPKCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("internal-sharing upload-apk", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	filePath := fs.String("file", "", "Path to .apk file")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -101,7 +101,7 @@ func UploadBundleCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("internal-sharing upload-bundle", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	filePath := fs.String("file", "", "Path to .aab bundle file")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
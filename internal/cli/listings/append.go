@@ -0,0 +1,118 @@
+package listings
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/validation"
+)
+
+// Separators placed between existing text and appended text.
+const (
+	fullDescriptionAppendSeparator  = "\n\n"
+	shortDescriptionAppendSeparator = " "
+)
+
+// AppendToListing returns a copy of current with fullDesc and shortDesc
+// appended to its descriptions. Empty inputs leave the field unchanged. The
+// result is checked against Play's description length limits.
+func AppendToListing(current *androidpublisher.Listing, locale, fullDesc, shortDesc string) (*androidpublisher.Listing, error) {
+	merged := &androidpublisher.Listing{
+		Language:         current.Language,
+		Title:            current.Title,
+		FullDescription:  appendText(current.FullDescription, fullDesc, fullDescriptionAppendSeparator),
+		ShortDescription: appendText(current.ShortDescription, shortDesc, shortDescriptionAppendSeparator),
+		Video:            current.Video,
+	}
+	if fullDesc != "" {
+		if check := validation.ValidateFullDescription(locale, merged.FullDescription); check != nil {
+			return nil, fmt.Errorf("%s: appending would exceed the limit: %s", locale, check.Message)
+		}
+	}
+	if shortDesc != "" {
+		if check := validation.ValidateShortDescription(locale, merged.ShortDescription); check != nil {
+			return nil, fmt.Errorf("%s: appending would exceed the limit: %s", locale, check.Message)
+		}
+	}
+	return merged, nil
+}
+
+func appendText(current, addition, separator string) string {
+	if addition == "" {
+		return current
+	}
+	if current == "" {
+		return addition
+	}
+	return current + separator + addition
+}
+
+// appendListings appends to the descriptions of each locale in the edit,
+// where locales is a comma-separated list or "all". Every locale is checked
+// against the length limits before any listing is written.
+func appendListings(ctx context.Context, packageName, editID, locales, fullDesc, shortDesc, outputFlag string, pretty bool) error {
+	if fullDesc == "" && shortDesc == "" {
+		return fmt.Errorf("--append requires --full-description or --short-description")
+	}
+	service, err := newPlayService(ctx)
+	if err != nil {
+		return err
+	}
+	pkg := shared.ResolvePackageName(packageName, service.Cfg)
+	if strings.TrimSpace(pkg) == "" {
+		return fmt.Errorf("--package is required")
+	}
+	if strings.TrimSpace(editID) == "" {
+		return fmt.Errorf("--edit is required")
+	}
+
+	ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	defer cancel()
+
+	var current []*androidpublisher.Listing
+	if strings.TrimSpace(locales) == "all" {
+		resp, err := service.API.Edits.Listings.List(pkg, editID).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		current = resp.Listings
+	} else {
+		for _, locale := range shared.SplitCSV(locales) {
+			locale = shared.NormalizeLocaleFlag("--locale", locale)
+			listing, err := service.API.Edits.Listings.Get(pkg, editID, locale).Context(ctx).Do()
+			if err != nil {
+				return fmt.Errorf("failed to get listing for %s: %w", locale, err)
+			}
+			current = append(current, listing)
+		}
+	}
+	if len(current) == 0 {
+		return fmt.Errorf("no listings to append to in edit %s", editID)
+	}
+
+	merged := make([]*androidpublisher.Listing, 0, len(current))
+	for _, listing := range current {
+		m, err := AppendToListing(listing, listing.Language, fullDesc, shortDesc)
+		if err != nil {
+			return err
+		}
+		merged = append(merged, m)
+	}
+
+	updated := make([]*androidpublisher.Listing, 0, len(merged))
+	for _, listing := range merged {
+		resp, err := service.API.Edits.Listings.Update(pkg, editID, listing.Language, listing).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("failed to update listing for %s: %w", listing.Language, err)
+		}
+		updated = append(updated, resp)
+	}
+	if len(updated) == 1 {
+		return shared.PrintOutput(updated[0], outputFlag, pretty)
+	}
+	return shared.PrintOutput(updated, outputFlag, pretty)
+}
@@ -0,0 +1,157 @@
+package listings
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+func installMockListingsPlayService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() {
+		newPlayService = original
+	})
+}
+
+func TestAppendToListing(t *testing.T) {
+	current := &androidpublisher.Listing{
+		Language:         "en-US",
+		Title:            "My App",
+		FullDescription:  "Original description.",
+		ShortDescription: "Short text.",
+		Video:            "https://youtu.be/a",
+	}
+	got, err := AppendToListing(current, "en-US", "Summer sale!", "Now 50% off.")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.FullDescription != "Original description.\n\nSummer sale!" {
+		t.Errorf("full description = %q", got.FullDescription)
+	}
+	if got.ShortDescription != "Short text. Now 50% off." {
+		t.Errorf("short description = %q", got.ShortDescription)
+	}
+	if got.Title != "My App" || got.Video != "https://youtu.be/a" {
+		t.Errorf("title and video should be kept, got %+v", got)
+	}
+	if current.FullDescription != "Original description." {
+		t.Errorf("current listing was modified: %q", current.FullDescription)
+	}
+
+	onlyFull, err := AppendToListing(&androidpublisher.Listing{ShortDescription: "Keep"}, "de-DE", "Neu", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if onlyFull.FullDescription != "Neu" || onlyFull.ShortDescription != "Keep" {
+		t.Errorf("unexpected merge into empty description: %+v", onlyFull)
+	}
+}
+
+func TestAppendToListing_LengthLimit(t *testing.T) {
+	current := &androidpublisher.Listing{FullDescription: strings.Repeat("a", 3995), ShortDescription: strings.Repeat("b", 75)}
+
+	_, err := AppendToListing(current, "en-US", "too much", "")
+	if err == nil || !strings.Contains(err.Error(), "en-US") || !strings.Contains(err.Error(), "4000") {
+		t.Fatalf("expected full description limit error, got %v", err)
+	}
+	_, err = AppendToListing(current, "en-US", "", "overflow")
+	if err == nil || !strings.Contains(err.Error(), "80") {
+		t.Fatalf("expected short description limit error, got %v", err)
+	}
+}
+
+func TestListingsUpdateCommand_AppendAllLocales(t *testing.T) {
+	var mu sync.Mutex
+	updated := map[string]androidpublisher.Listing{}
+	installMockListingsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = io.WriteString(w, `{"listings":[
+				{"language":"en-US","title":"App","fullDescription":"Hello."},
+				{"language":"de-DE","title":"App","fullDescription":"Hallo."}
+			]}`)
+		case http.MethodPut:
+			var listing androidpublisher.Listing
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &listing)
+			mu.Lock()
+			updated[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]] = listing
+			mu.Unlock()
+			_, _ = w.Write(body)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app", "--edit", "edit-1", "--locale", "all",
+		"--append", "--full-description", "Sale!",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updated) != 2 {
+		t.Fatalf("expected 2 updates, got %v", updated)
+	}
+	if got := updated["en-US"]; got.FullDescription != "Hello.\n\nSale!" || got.Title != "App" {
+		t.Errorf("en-US update = %+v", got)
+	}
+	if got := updated["de-DE"]; got.FullDescription != "Hallo.\n\nSale!" {
+		t.Errorf("de-DE update = %+v", got)
+	}
+}
+
+func TestListingsUpdateCommand_AppendOverLimitWritesNothing(t *testing.T) {
+	long := strings.Repeat("x", 3999)
+	installMockListingsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected write %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"language":"en-US","fullDescription":"`+long+`"}`)
+	})
+
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app", "--edit", "edit-1", "--locale", "en-US",
+		"--append", "--full-description", "more",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "exceed") {
+		t.Fatalf("expected length limit error, got %v", err)
+	}
+}
+
+func TestListingsUpdateCommand_AppendRejectsTitle(t *testing.T) {
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--locale", "en-US", "--append", "--title", "New"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--append") {
+		t.Fatalf("expected --append conflict error, got %v", err)
+	}
+}
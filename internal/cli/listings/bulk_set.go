@@ -0,0 +1,194 @@
+package listings
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/validation"
+)
+
+// bulkSetFields are the listing fields bulk-set can write.
+var bulkSetFields = []string{"video", "title", "short-description", "full-description"}
+
+// BulkSetResult reports the outcome of listings bulk-set.
+type BulkSetResult struct {
+	Field     string   `json:"field"`
+	DryRun    bool     `json:"dryRun,omitempty"`
+	Updated   int      `json:"updated"`
+	Unchanged int      `json:"unchanged"`
+	Locales   []string `json:"locales"`
+}
+
+func BulkSetCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("listings bulk-set", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	editID := fs.String("edit", "", "Edit ID")
+	field := fs.String("field", "", "Field to set: "+strings.Join(bulkSetFields, ", "))
+	value := fs.String("value", "", "Value to set in every locale")
+	locales := fs.String("locales", "", "Comma-separated locales to update (default: every listing in the edit)")
+	dryRun := fs.Bool("dry-run", false, "Show which locales would be updated without writing")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "bulk-set",
+		ShortUsage: "gplay listings bulk-set --package <name> --edit <id> --field <field> --value <v> [--locales a,b] [--dry-run]",
+		ShortHelp:  "Set one field to the same value across locales.",
+		LongHelp: `Set one listing field to the same value in every locale of an edit.
+
+Only the chosen field is patched; the other fields of each listing are left
+as they are. Locales that already hold the value are skipped. Use --locales
+to limit the update to some of the edit's listings.
+
+Fields: video, title, short-description, full-description
+
+Examples:
+  gplay listings bulk-set --package com.example --edit EDIT_ID --field video --value "https://youtu.be/abc123"
+  gplay listings bulk-set --package com.example --edit EDIT_ID --field short-description --value "The best app" --locales en-US,en-GB --dry-run`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*field) == "" {
+				return fmt.Errorf("--field is required")
+			}
+			if *value == "" {
+				return fmt.Errorf("--value is required")
+			}
+			if err := validateBulkSetValue(*field, *value); err != nil {
+				return err
+			}
+			var filter []string
+			for _, locale := range shared.SplitCSV(*locales) {
+				filter = append(filter, shared.NormalizeLocaleFlag("--locales", locale))
+			}
+
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+			if strings.TrimSpace(*editID) == "" {
+				return fmt.Errorf("--edit is required")
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			resp, err := service.API.Edits.Listings.List(pkg, *editID).Context(ctx).Do()
+			if err != nil {
+				return err
+			}
+			targets, err := selectListings(resp.Listings, filter)
+			if err != nil {
+				return err
+			}
+
+			result := &BulkSetResult{Field: *field, DryRun: *dryRun, Locales: []string{}}
+			for _, listing := range targets {
+				if bulkSetFieldValue(listing, *field) == *value {
+					result.Unchanged++
+					continue
+				}
+				if *dryRun {
+					fmt.Fprintf(os.Stderr, "Would update: %s\n", listing.Language)
+				} else {
+					patch := newBulkSetPatch(*field, *value)
+					if _, err := service.API.Edits.Listings.Patch(pkg, *editID, listing.Language, patch).Context(ctx).Do(); err != nil {
+						return fmt.Errorf("failed to patch listing for %s after updating %d: %w", listing.Language, result.Updated, err)
+					}
+				}
+				result.Updated++
+				result.Locales = append(result.Locales, listing.Language)
+			}
+			return shared.PrintOutput(result, *outputFlag, *pretty)
+		},
+	}
+}
+
+// validateBulkSetValue checks that field is supported and value fits it.
+func validateBulkSetValue(field, value string) error {
+	var check *validation.CheckResult
+	switch field {
+	case "video":
+		return ValidateVideoURL(value)
+	case "title":
+		check = validation.ValidateTitle("", value)
+	case "short-description":
+		check = validation.ValidateShortDescription("", value)
+	case "full-description":
+		check = validation.ValidateFullDescription("", value)
+	default:
+		return fmt.Errorf("--field must be one of: %s", strings.Join(bulkSetFields, ", "))
+	}
+	if check != nil {
+		return fmt.Errorf("--value: %s", check.Message)
+	}
+	return nil
+}
+
+// selectListings returns the listings whose locale is in filter, or all of
+// them when filter is empty. A filtered locale missing from the edit is an
+// error.
+func selectListings(listings []*androidpublisher.Listing, filter []string) ([]*androidpublisher.Listing, error) {
+	if len(filter) == 0 {
+		if len(listings) == 0 {
+			return nil, fmt.Errorf("no listings found in edit")
+		}
+		return listings, nil
+	}
+	byLocale := make(map[string]*androidpublisher.Listing, len(listings))
+	for _, listing := range listings {
+		byLocale[strings.ToLower(listing.Language)] = listing
+	}
+	selected := make([]*androidpublisher.Listing, 0, len(filter))
+	for _, locale := range filter {
+		listing, ok := byLocale[strings.ToLower(locale)]
+		if !ok {
+			return nil, fmt.Errorf("no listing for locale %s in edit", locale)
+		}
+		selected = append(selected, listing)
+	}
+	return selected, nil
+}
+
+func bulkSetFieldValue(listing *androidpublisher.Listing, field string) string {
+	switch field {
+	case "video":
+		return listing.Video
+	case "title":
+		return listing.Title
+	case "short-description":
+		return listing.ShortDescription
+	default:
+		return listing.FullDescription
+	}
+}
+
+// newBulkSetPatch builds a patch body carrying only field.
+func newBulkSetPatch(field, value string) *androidpublisher.Listing {
+	patch := &androidpublisher.Listing{}
+	switch field {
+	case "video":
+		patch.Video = value
+	case "title":
+		patch.Title = value
+	case "short-description":
+		patch.ShortDescription = value
+	default:
+		patch.FullDescription = value
+	}
+	return patch
+}
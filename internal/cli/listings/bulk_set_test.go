@@ -0,0 +1,173 @@
+package listings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const bulkSetListingsJSON = `{"listings":[
+	{"language":"en-US","title":"App","shortDescription":"Short","video":"https://youtu.be/old"},
+	{"language":"de-DE","title":"App DE","shortDescription":"Kurz","video":"https://youtu.be/new"},
+	{"language":"fr-FR","title":"App FR","shortDescription":"Court"}
+]}`
+
+func captureListingsStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+
+	orig := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	runErr := fn()
+	_ = w.Close()
+	os.Stdout = orig
+	out := <-done
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+	return out
+}
+
+func TestBulkSetCommand_PatchesOnlyTargetField(t *testing.T) {
+	var mu sync.Mutex
+	patched := map[string]map[string]any{}
+	installMockListingsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = io.WriteString(w, bulkSetListingsJSON)
+		case http.MethodPatch:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			patched[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]] = body
+			mu.Unlock()
+			_, _ = io.WriteString(w, `{}`)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	cmd := BulkSetCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app", "--edit", "edit-1",
+		"--field", "video", "--value", "https://youtu.be/new",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	out := captureListingsStdout(t, func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+
+	if len(patched) != 2 {
+		t.Fatalf("expected 2 patches (de-DE already set), got %v", patched)
+	}
+	for _, locale := range []string{"en-US", "fr-FR"} {
+		body := patched[locale]
+		if len(body) != 1 || body["video"] != "https://youtu.be/new" {
+			t.Errorf("%s patch body = %v, want only video", locale, body)
+		}
+	}
+
+	var result BulkSetResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse output %q: %v", out, err)
+	}
+	if result.Updated != 2 || result.Unchanged != 1 || strings.Join(result.Locales, ",") != "en-US,fr-FR" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestBulkSetCommand_DryRunWithLocalesFilter(t *testing.T) {
+	installMockListingsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, bulkSetListingsJSON)
+	})
+
+	cmd := BulkSetCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app", "--edit", "edit-1",
+		"--field", "short-description", "--value", "Best app",
+		"--locales", "de-de,fr-FR", "--dry-run",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	out := captureListingsStdout(t, func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+
+	var result BulkSetResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse output %q: %v", out, err)
+	}
+	if !result.DryRun || result.Updated != 2 || strings.Join(result.Locales, ",") != "de-DE,fr-FR" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestBulkSetCommand_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing field", []string{"--value", "x"}, "--field is required"},
+		{"unknown field", []string{"--field", "icon", "--value", "x"}, "--field must be one of"},
+		{"missing value", []string{"--field", "title"}, "--value is required"},
+		{"title too long", []string{"--field", "title", "--value", strings.Repeat("a", 31)}, "30 character limit"},
+		{"bad video", []string{"--field", "video", "--value", "https://example.com"}, "invalid YouTube URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := BulkSetCommand()
+			if err := cmd.FlagSet.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.Exec(context.Background(), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBulkSetCommand_UnknownLocaleInFilter(t *testing.T) {
+	installMockListingsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected write %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, bulkSetListingsJSON)
+	})
+
+	cmd := BulkSetCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app", "--edit", "edit-1",
+		"--field", "title", "--value", "App", "--locales", "ja-JP",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "ja-JP") {
+		t.Fatalf("expected missing locale error, got %v", err)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+var newPlayService = playclient.NewService
+
 func ListingsCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("listings", flag.ExitOnError)
 	return &ffcli.Command{
@@ -38,6 +40,7 @@ Listings are scoped to an edit. Create an edit first with gplay edits create.`,
 			DeleteCommand(),
 			DeleteAllCommand(),
 			LocalesCommand(),
+			BulkSetCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if len(args) == 0 {
@@ -52,7 +55,7 @@ func ListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("listings list", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -96,7 +99,7 @@ func GetCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	locale := fs.String("locale", "", "Locale (e.g. en-US)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -112,6 +115,7 @@ func GetCommand() *ffcli.Command {
 			if strings.TrimSpace(*locale) == "" {
 				return fmt.Errorf("--locale is required")
 			}
+			*locale = shared.NormalizeLocaleFlag("--locale", *locale)
 			service, err := playclient.NewService(ctx)
 			if err != nil {
 				return err
@@ -144,24 +148,45 @@ func UpdateCommand() *ffcli.Command {
 	fullDescription := fs.String("full-description", "", "Full description")
 	shortDescription := fs.String("short-description", "", "Short description")
 	video := fs.String("video", "", "YouTube promotional video URL (empty to clear)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	appendMode := fs.Bool("append", false, "Append --full-description/--short-description to the current text instead of replacing the listing")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "update",
-		ShortUsage: "gplay listings update --package <name> --edit <id> --locale <lang> [flags]",
+		ShortUsage: "gplay listings update --package <name> --edit <id> --locale <lang> [--append] [flags]",
 		ShortHelp:  "Update or create a listing.",
 		LongHelp: `Update a store listing for a specific locale.
 
 Sets all fields for the given locale. Fields not provided will be cleared.
 Use gplay listings patch for partial updates.
 
+With --append, the current listing is fetched and --full-description and
+--short-description are appended to its text (after a blank line and a
+space respectively); the title and video are kept. --locale then accepts a
+comma-separated list or "all" for every listing in the edit. The command
+fails without writing anything if any result would exceed the 4000 or 80
+character limits.
+
 Examples:
   gplay listings update --package com.example --edit EDIT_ID --locale en-US --title "My App" --short-description "A great app"
-  gplay listings update --package com.example --edit EDIT_ID --locale en-US --title "My App" --video "https://youtube.com/watch?v=..."`,
+  gplay listings update --package com.example --edit EDIT_ID --locale en-US --title "My App" --video "https://youtube.com/watch?v=..."
+  gplay listings update --package com.example --edit EDIT_ID --locale all --append --full-description "Summer sale: 50% off premium!"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
+			if *appendMode {
+				if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+					return err
+				}
+				if strings.TrimSpace(*locale) == "" {
+					return fmt.Errorf("--locale is required")
+				}
+				if *title != "" || *video != "" {
+					return fmt.Errorf("--title and --video cannot be used with --append")
+				}
+				return appendListings(ctx, *packageName, *editID, *locale, *fullDescription, *shortDescription, *outputFlag, *pretty)
+			}
 			return updateListing(ctx, *packageName, *editID, *locale, *title, *fullDescription, *shortDescription, *video, *outputFlag, *pretty, false)
 		},
 	}
@@ -176,7 +201,7 @@ func PatchCommand() *ffcli.Command {
 	fullDescription := fs.String("full-description", "", "Full description")
 	shortDescription := fs.String("short-description", "", "Short description")
 	video := fs.String("video", "", "YouTube promotional video URL (empty to clear)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -197,7 +222,7 @@ func DeleteCommand() *ffcli.Command {
 	editID := fs.String("edit", "", "Edit ID")
 	locale := fs.String("locale", "", "Locale (e.g. en-US)")
 	confirm := fs.Bool("confirm", false, "Confirm delete")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -213,6 +238,7 @@ func DeleteCommand() *ffcli.Command {
 			if strings.TrimSpace(*locale) == "" {
 				return fmt.Errorf("--locale is required")
 			}
+			*locale = shared.NormalizeLocaleFlag("--locale", *locale)
 			if !*confirm {
 				return fmt.Errorf("--confirm is required")
 			}
@@ -243,7 +269,7 @@ func DeleteAllCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	confirm := fs.Bool("confirm", false, "Confirm delete")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -288,7 +314,8 @@ func updateListing(ctx context.Context, packageName, editID, locale, title, full
 	if strings.TrimSpace(locale) == "" {
 		return fmt.Errorf("--locale is required")
 	}
-	service, err := playclient.NewService(ctx)
+	locale = shared.NormalizeLocaleFlag("--locale", locale)
+	service, err := newPlayService(ctx)
 	if err != nil {
 		return err
 	}
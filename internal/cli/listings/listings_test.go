@@ -46,6 +46,7 @@ func TestListingsCommand_SubcommandNames(t *testing.T) {
 		"delete":     false,
 		"delete-all": false,
 		"locales":    false,
+		"bulk-set":   false,
 	}
 	for _, sub := range cmd.Subcommands {
 		if _, ok := expected[sub.Name]; ok {
@@ -22,7 +22,7 @@ func PullCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	dir := fs.String("dir", "", "Output directory for metadata files (required)")
 	locales := fs.String("locales", "", "Comma-separated list of locales to pull (optional, pulls all if omitted)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -33,7 +33,7 @@ func PushCommand() *ffcli.Command {
 	locales := fs.String("locales", "", "Comma-separated list of locales to push (optional, pushes all if omitted)")
 	confirm := fs.Bool("confirm", false, "Confirm push (required for safety)")
 	dryRun := fs.Bool("dry-run", false, "Show what would be updated without calling API")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -44,7 +44,7 @@ type validationResult struct {
 func ValidateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("metadata validate", flag.ExitOnError)
 	dir := fs.String("dir", "", "Metadata directory to validate (required)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -66,7 +66,7 @@ func FastlaneCommand() *ffcli.Command {
 	outputDir := fs.String("output-dir", ".gplay/metadata/", "Output directory for imported metadata")
 	dryRun := fs.Bool("dry-run", false, "Preview what would be imported without writing files")
 	locales := fs.String("locales", "", "Comma-separated list of locales to import (default: all)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -0,0 +1,81 @@
+package monetizationpricing
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+// newPlayService and convertRegionPrices are swapped out by tests.
+var (
+	newPlayService      = playclient.NewService
+	convertRegionPrices = ConvertRegionPrices
+)
+
+// PreviewPricesCommand returns the read-only "preview-prices" subcommand for
+// the given product group (iap or subscriptions).
+func PreviewPricesCommand(group string) *ffcli.Command {
+	fs := flag.NewFlagSet(group+" preview-prices", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	price := fs.String("price", "", "Base price as CURRENCY:AMOUNT (e.g. USD:4.99)")
+	productTaxCategoryCode := fs.String("product-tax-category-code", "", "Product tax category code")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "preview-prices",
+		ShortUsage: fmt.Sprintf("gplay %s preview-prices --package <name> --price <CUR:amount>", group),
+		ShortHelp:  "Preview the local price Google Play sets in each region.",
+		LongHelp: fmt.Sprintf(`Preview the local price Google Play would set in every billable region
+for a base price, before creating a product priced only in that currency.
+
+Nothing is created or changed; the prices come from Play's price
+conversion, rounded to its pricing tiers. Use --output table for one row
+per region.
+
+Examples:
+  gplay %[1]s preview-prices --package com.example.app --price USD:4.99 --output table
+  gplay %[1]s preview-prices --package com.example.app --price EUR:9.99`, group),
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*price) == "" {
+				return fmt.Errorf("--price is required")
+			}
+			base, err := ParsePriceString(*price)
+			if err != nil {
+				return fmt.Errorf("invalid --price: %w", err)
+			}
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			resp, err := convertRegionPrices(ctx, service, pkg, base, *productTaxCategoryCode)
+			if err != nil {
+				return err
+			}
+			prices, err := RegionPrices(base, resp)
+			if err != nil {
+				return err
+			}
+			return shared.PrintOutput(prices, *outputFlag, *pretty)
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package monetizationpricing
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+func installPreviewFakes(t *testing.T, convert func(context.Context, *playclient.Service, string, *androidpublisher.Money, string) (*androidpublisher.ConvertRegionPricesResponse, error)) {
+	t.Helper()
+	originalService, originalConvert := newPlayService, convertRegionPrices
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, http.DefaultClient, "http://127.0.0.1:0/")
+	}
+	convertRegionPrices = convert
+	t.Cleanup(func() {
+		newPlayService, convertRegionPrices = originalService, originalConvert
+	})
+}
+
+func capturePreviewStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	orig := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	runErr := fn()
+	_ = w.Close()
+	os.Stdout = orig
+	return <-done, runErr
+}
+
+func TestPreviewPricesCommand_RendersConvertedTable(t *testing.T) {
+	var gotPkg string
+	var gotPrice *androidpublisher.Money
+	installPreviewFakes(t, func(ctx context.Context, service *playclient.Service, pkg string, price *androidpublisher.Money, tax string) (*androidpublisher.ConvertRegionPricesResponse, error) {
+		gotPkg, gotPrice = pkg, price
+		return convertedFixture(), nil
+	})
+
+	cmd := PreviewPricesCommand("iap")
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--price", "USD:4.99", "--output", "table"}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := capturePreviewStdout(t, func() error { return cmd.Exec(context.Background(), nil) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPkg != "com.example.app" || gotPrice == nil || gotPrice.CurrencyCode != "USD" || gotPrice.Units != 4 || gotPrice.Nanos != 990000000 {
+		t.Fatalf("convert called with %q %+v", gotPkg, gotPrice)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	var rows []string
+	for _, line := range lines {
+		if strings.Contains(line, "BGN") || strings.Contains(line, "USD") {
+			rows = append(rows, strings.Join(strings.Fields(strings.Trim(line, "│| ")), " "))
+		}
+	}
+	if len(rows) != 2 || !strings.Contains(rows[0], "BG") || !strings.Contains(rows[0], "18.99") || !strings.Contains(rows[1], "US") || !strings.Contains(rows[1], "9.99") {
+		t.Fatalf("unexpected table:\n%s", out)
+	}
+}
+
+func TestPreviewPricesCommand_InvalidPrice(t *testing.T) {
+	installPreviewFakes(t, func(context.Context, *playclient.Service, string, *androidpublisher.Money, string) (*androidpublisher.ConvertRegionPricesResponse, error) {
+		t.Fatal("convert should not be called")
+		return nil, nil
+	})
+	for _, price := range []string{"", "4.99", "US:4.99", "USD:abc"} {
+		cmd := PreviewPricesCommand("subscriptions")
+		if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--price", price}); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.Exec(context.Background(), nil); err == nil {
+			t.Errorf("expected error for --price %q", price)
+		}
+	}
+}
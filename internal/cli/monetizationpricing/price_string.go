@@ -0,0 +1,107 @@
+package monetizationpricing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+// RegionPrice is one converted price in a region's local currency.
+type RegionPrice struct {
+	CurrencyCode string `json:"currencyCode"`
+	Price        string `json:"price"`
+}
+
+// RegionPriceMap is the converted price for every billable region.
+type RegionPriceMap struct {
+	RegionVersion string                 `json:"regionVersion"`
+	BasePrice     string                 `json:"basePrice"`
+	Prices        map[string]RegionPrice `json:"prices"`
+}
+
+// ParsePriceString parses a "CUR:amount" price such as "USD:9.99" into Money.
+func ParsePriceString(value string) (*androidpublisher.Money, error) {
+	currency, amount, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return nil, fmt.Errorf("price %q must be CURRENCY:AMOUNT (e.g. USD:9.99)", value)
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if len(currency) != 3 || strings.Trim(currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return nil, fmt.Errorf("currency %q must be a 3-letter ISO 4217 code", currency)
+	}
+
+	amount = strings.TrimSpace(amount)
+	whole, frac, _ := strings.Cut(amount, ".")
+	if whole == "" || !isDigits(whole) || (frac != "" && !isDigits(frac)) || strings.HasSuffix(amount, ".") {
+		return nil, fmt.Errorf("amount %q must be a non-negative decimal number", amount)
+	}
+	if len(frac) > 9 {
+		return nil, fmt.Errorf("amount %q has more than 9 decimal places", amount)
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("amount %q is out of range", amount)
+	}
+	var nanos int64
+	if frac != "" {
+		nanos, _ = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+	}
+	return &androidpublisher.Money{
+		CurrencyCode: currency,
+		Units:        units,
+		Nanos:        nanos,
+	}, nil
+}
+
+// FormatMoney renders Money as a decimal amount without the currency code,
+// trimming trailing zeros but keeping at least two decimal places.
+func FormatMoney(m *androidpublisher.Money) string {
+	if m == nil {
+		return ""
+	}
+	frac := strings.TrimRight(fmt.Sprintf("%09d", m.Nanos), "0")
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	return fmt.Sprintf("%d.%s", m.Units, frac)
+}
+
+// RegionPrices flattens a conversion response into a region -> price map.
+func RegionPrices(base *androidpublisher.Money, resp *androidpublisher.ConvertRegionPricesResponse) (*RegionPriceMap, error) {
+	version, err := RegionVersion(resp)
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[string]RegionPrice, len(resp.ConvertedRegionPrices))
+	for _, key := range sortedRegionKeys(resp) {
+		converted := resp.ConvertedRegionPrices[key]
+		regionCode := convertedRegionCode(key, converted)
+		if regionCode == "" || converted.Price == nil {
+			continue
+		}
+		prices[regionCode] = RegionPrice{
+			CurrencyCode: converted.Price.CurrencyCode,
+			Price:        FormatMoney(converted.Price),
+		}
+	}
+	basePrice := ""
+	if base != nil {
+		basePrice = base.CurrencyCode + ":" + FormatMoney(base)
+	}
+	return &RegionPriceMap{
+		RegionVersion: version,
+		BasePrice:     basePrice,
+		Prices:        prices,
+	}, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
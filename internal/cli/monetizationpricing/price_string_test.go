@@ -0,0 +1,77 @@
+package monetizationpricing
+
+import (
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func TestParsePriceString(t *testing.T) {
+	tests := []struct {
+		in       string
+		currency string
+		units    int64
+		nanos    int64
+	}{
+		{"USD:9.99", "USD", 9, 990000000},
+		{"eur:5", "EUR", 5, 0},
+		{" JPY : 1200 ", "JPY", 1200, 0},
+		{"GBP:0.5", "GBP", 0, 500000000},
+		{"USD:1.000000001", "USD", 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParsePriceString(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.CurrencyCode != tt.currency || got.Units != tt.units || got.Nanos != tt.nanos {
+				t.Errorf("got %s %d/%d, want %s %d/%d", got.CurrencyCode, got.Units, got.Nanos, tt.currency, tt.units, tt.nanos)
+			}
+		})
+	}
+}
+
+func TestParsePriceString_Invalid(t *testing.T) {
+	for _, in := range []string{"", "9.99", "USD", "USD:", "US:9.99", "USDX:9.99", "U1D:1", "USD:-1", "USD:1.", "USD:.5", "USD:abc", "USD:1.2.3", "USD:1.0000000001"} {
+		t.Run(in, func(t *testing.T) {
+			if _, err := ParsePriceString(in); err == nil {
+				t.Fatalf("expected error for %q", in)
+			}
+		})
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		money *androidpublisher.Money
+		want  string
+	}{
+		{&androidpublisher.Money{Units: 9, Nanos: 990000000}, "9.99"},
+		{&androidpublisher.Money{Units: 29}, "29.00"},
+		{&androidpublisher.Money{Units: 1, Nanos: 500000000}, "1.50"},
+		{&androidpublisher.Money{Units: 0, Nanos: 1}, "0.000000001"},
+	}
+	for _, tt := range tests {
+		if got := FormatMoney(tt.money); got != tt.want {
+			t.Errorf("FormatMoney(%+v) = %q, want %q", tt.money, got, tt.want)
+		}
+	}
+}
+
+func TestRegionPrices(t *testing.T) {
+	base := &androidpublisher.Money{CurrencyCode: "USD", Units: 9, Nanos: 990000000}
+	got, err := RegionPrices(base, convertedFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.RegionVersion != "2026/05" || got.BasePrice != "USD:9.99" {
+		t.Fatalf("unexpected header: %+v", got)
+	}
+	if len(got.Prices) != 2 {
+		t.Fatalf("prices len = %d, want 2", len(got.Prices))
+	}
+	if got.Prices["US"].CurrencyCode != "USD" {
+		t.Errorf("US price = %+v", got.Prices["US"])
+	}
+}
@@ -0,0 +1,24 @@
+package monetizationpricing
+
+import (
+	"sort"
+
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+func init() {
+	output.RegisterType(&RegionPriceMap{}, []string{"Region", "Currency", "Price"}, func(data any) [][]string {
+		prices := data.(*RegionPriceMap)
+		regions := make([]string, 0, len(prices.Prices))
+		for region := range prices.Prices {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+		rows := make([][]string, 0, len(regions))
+		for _, region := range regions {
+			p := prices.Prices[region]
+			rows = append(rows, []string{region, p.CurrencyCode, p.Price})
+		}
+		return rows
+	})
+}
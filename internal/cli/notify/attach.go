@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxAttachmentBytes caps the size of each file attached to a generic
+// webhook payload. Base64 inflates the body by a third, and most webhook
+// receivers reject request bodies well before tens of megabytes.
+const maxAttachmentBytes = 5 << 20
+
+// Attachment is a base64-encoded file embedded in a generic webhook payload.
+type Attachment struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Data        string `json:"data"`
+}
+
+// attachFileFlag collects repeated --attach-file values.
+type attachFileFlag []string
+
+func (f *attachFileFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(*f, ",")
+}
+
+func (f *attachFileFlag) Set(value string) error {
+	path := strings.TrimSpace(value)
+	if path == "" {
+		return fmt.Errorf("--attach-file must not be empty")
+	}
+	*f = append(*f, path)
+	return nil
+}
+
+// LoadAttachments reads and base64-encodes each file in paths.
+func LoadAttachments(paths []string) ([]Attachment, error) {
+	attachments := make([]Attachment, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment: %w", err)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("attachment %s is a directory", path)
+		}
+		if info.Size() > maxAttachmentBytes {
+			return nil, fmt.Errorf("attachment %s is %d bytes, exceeding the %d byte limit", path, info.Size(), maxAttachmentBytes)
+		}
+		data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the user via --attach-file
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment: %w", err)
+		}
+		attachments = append(attachments, Attachment{
+			Name:        filepath.Base(path),
+			ContentType: http.DetectContentType(data),
+			Size:        int64(len(data)),
+			Data:        base64.StdEncoding.EncodeToString(data),
+		})
+	}
+	return attachments, nil
+}
@@ -3,10 +3,13 @@ package notify
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -142,6 +145,73 @@ func TestBuildPayload_GenericJSON(t *testing.T) {
 	}
 }
 
+func TestBuildPayload_SlackTruncatesLongMessage(t *testing.T) {
+	long := strings.Repeat("a", slackSectionLimit+500)
+	payload := BuildPayload(FormatSlack, long, "release", "com.example.app")
+	sp, ok := payload.(SlackPayload)
+	if !ok {
+		t.Fatalf("expected SlackPayload, got %T", payload)
+	}
+	section := sp.Blocks[0].Text.Text
+	if n := len([]rune(section)); n != slackSectionLimit {
+		t.Errorf("section length = %d, want %d", n, slackSectionLimit)
+	}
+	if !strings.HasSuffix(section, "… (truncated)") {
+		t.Errorf("expected truncation marker, got suffix %q", section[len(section)-20:])
+	}
+	if sp.Text != long {
+		t.Error("top-level text under the Slack limit should not be truncated")
+	}
+}
+
+func TestBuildPayload_DiscordTruncatesLongMessage(t *testing.T) {
+	long := strings.Repeat("é", discordContentLimit*2)
+	payload := BuildPayload(FormatDiscord, long, "", "")
+	dp, ok := payload.(DiscordPayload)
+	if !ok {
+		t.Fatalf("expected DiscordPayload, got %T", payload)
+	}
+	if n := len([]rune(dp.Content)); n != discordContentLimit {
+		t.Errorf("content length = %d, want %d", n, discordContentLimit)
+	}
+	if !strings.HasSuffix(dp.Content, "… (truncated)") {
+		t.Error("expected truncation marker")
+	}
+}
+
+func TestBuildPayload_GenericNotTruncated(t *testing.T) {
+	long := strings.Repeat("a", slackTextLimit+1)
+	payload := BuildPayload(FormatGeneric, long, "", "")
+	gp, ok := payload.(GenericPayload)
+	if !ok {
+		t.Fatalf("expected GenericPayload, got %T", payload)
+	}
+	if gp.Message != long {
+		t.Error("generic payloads should not be truncated")
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		limit int
+		want  string
+	}{
+		{"under limit", "hello", 10, "hello"},
+		{"at limit", "hello", 5, "hello"},
+		{"over limit", "hello world, this is long", 20, "hello w… (truncated)"},
+		{"limit smaller than marker", "hello world", 3, "… (truncated)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateMessage(tt.in, tt.limit); got != tt.want {
+				t.Errorf("truncateMessage(%q, %d) = %q, want %q", tt.in, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
 // --- Webhook URL validation tests ---
 
 func TestValidateWebhookURL(t *testing.T) {
@@ -437,6 +507,94 @@ func TestRunSend_Success(t *testing.T) {
 	}
 }
 
+func TestRunSend_GenericAttachFile(t *testing.T) {
+	content := []byte("## Changelog\n- Fixed crash on launch\n")
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var capturedBody []byte
+	mock := &mockDoer{
+		handler: func(req *http.Request) (*http.Response, error) {
+			capturedBody, _ = io.ReadAll(req.Body)
+			return &http.Response{
+				StatusCode: 200,
+				Status:     "200 OK",
+				Body:       io.NopCloser(strings.NewReader("ok")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+
+	err := runSend(context.Background(), sendOpts{
+		webhookURL:  "https://example.com/hook",
+		message:     "Release notes",
+		format:      "generic",
+		attachFiles: []string{path},
+		outputFlag:  "json",
+		client:      mock,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var gp GenericPayload
+	if err := json.Unmarshal(capturedBody, &gp); err != nil {
+		t.Fatalf("failed to unmarshal sent payload: %v", err)
+	}
+	if len(gp.Files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(gp.Files))
+	}
+	file := gp.Files[0]
+	if file.Name != "CHANGELOG.md" {
+		t.Errorf("name = %q, want %q", file.Name, "CHANGELOG.md")
+	}
+	if file.Size != int64(len(content)) {
+		t.Errorf("size = %d, want %d", file.Size, len(content))
+	}
+	if want := base64.StdEncoding.EncodeToString(content); file.Data != want {
+		t.Errorf("data = %q, want %q", file.Data, want)
+	}
+}
+
+func TestRunSend_AttachFileRequiresGeneric(t *testing.T) {
+	err := runSend(context.Background(), sendOpts{
+		webhookURL:  "https://hooks.slack.com/services/T00/B00/xxx",
+		message:     "hello",
+		format:      "slack",
+		attachFiles: []string{"report.json"},
+		outputFlag:  "json",
+		client:      newMockDoer(200, "ok"),
+	})
+	if err == nil {
+		t.Fatal("expected error for --attach-file with slack format")
+	}
+	if !strings.Contains(err.Error(), "--format generic") {
+		t.Errorf("error = %q, want to mention --format generic", err.Error())
+	}
+}
+
+func TestLoadAttachments_Oversized(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "big.bin")
+	if err := os.WriteFile(path, make([]byte, maxAttachmentBytes+1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadAttachments([]string{path})
+	if err == nil {
+		t.Fatal("expected error for oversized attachment")
+	}
+	if !strings.Contains(err.Error(), "limit") {
+		t.Errorf("error = %q, want to mention limit", err.Error())
+	}
+}
+
+func TestLoadAttachments_Missing(t *testing.T) {
+	if _, err := LoadAttachments([]string{filepath.Join(t.TempDir(), "nope.txt")}); err == nil {
+		t.Fatal("expected error for missing attachment")
+	}
+}
+
 func TestRunSend_WebhookError(t *testing.T) {
 	err := runSend(context.Background(), sendOpts{
 		webhookURL: "https://hooks.slack.com/services/T00/B00/xxx",
@@ -603,3 +761,116 @@ func TestSendEndToEnd_GenericWebhook(t *testing.T) {
 		t.Errorf("package = %q", gp.Package)
 	}
 }
+
+// --- Slack style tests ---
+
+func TestBuildPayload_SlackStyleByEventType(t *testing.T) {
+	tests := []struct {
+		eventType string
+		emoji     string
+		color     string
+	}{
+		{"release", ":rocket:", ""},
+		{"rollout", ":chart_with_upwards_trend:", ""},
+		{"Review", ":speech_balloon:", ""},
+		{"warning", ":warning:", "warning"},
+		{"error", ":x:", "danger"},
+		{"custom", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			sp := BuildPayload(FormatSlack, "msg", tt.eventType, "").(SlackPayload)
+			blocks := sp.Blocks
+			if tt.color != "" {
+				if len(sp.Blocks) != 0 || len(sp.Attachments) != 1 {
+					t.Fatalf("expected blocks inside one attachment, got %+v", sp)
+				}
+				if sp.Attachments[0].Color != tt.color {
+					t.Errorf("color = %q, want %q", sp.Attachments[0].Color, tt.color)
+				}
+				blocks = sp.Attachments[0].Blocks
+			} else if len(sp.Attachments) != 0 {
+				t.Fatalf("expected no attachments, got %+v", sp.Attachments)
+			}
+			body := blocks[0].Text.Text
+			if tt.emoji != "" && !strings.HasPrefix(body, tt.emoji+" ") {
+				t.Errorf("expected body to start with %s, got %q", tt.emoji, body)
+			}
+			if tt.emoji == "" && strings.HasPrefix(body, ":") {
+				t.Errorf("expected no emoji, got %q", body)
+			}
+		})
+	}
+}
+
+func TestRunSend_SlackStyleOverrides(t *testing.T) {
+	var capturedBody []byte
+	mock := &mockDoer{
+		handler: func(req *http.Request) (*http.Response, error) {
+			capturedBody, _ = io.ReadAll(req.Body)
+			return &http.Response{
+				StatusCode: 200,
+				Status:     "200 OK",
+				Body:       io.NopCloser(strings.NewReader("ok")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+
+	err := runSend(context.Background(), sendOpts{
+		webhookURL: "https://hooks.slack.com/services/T00/B00/xxx",
+		message:    "Rollout halted",
+		format:     "slack",
+		eventType:  "rollout",
+		emoji:      ":octagonal_sign:",
+		color:      "#E01E5A",
+		outputFlag: "json",
+		client:     mock,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sp SlackPayload
+	if err := json.Unmarshal(capturedBody, &sp); err != nil {
+		t.Fatalf("failed to unmarshal sent payload: %v", err)
+	}
+	if len(sp.Attachments) != 1 || sp.Attachments[0].Color != "#E01E5A" {
+		t.Fatalf("expected #E01E5A attachment, got %s", capturedBody)
+	}
+	if body := sp.Attachments[0].Blocks[0].Text.Text; !strings.HasPrefix(body, ":octagonal_sign: ") {
+		t.Errorf("expected overridden emoji, got %q", body)
+	}
+}
+
+func TestSlackStyle_WithOverridesNone(t *testing.T) {
+	style := SlackStyleFor("error").withOverrides("none", "none")
+	if style != (SlackStyle{}) {
+		t.Errorf("expected none to clear the style, got %+v", style)
+	}
+}
+
+func TestRunSend_SlackStyleFlagValidation(t *testing.T) {
+	tests := []struct {
+		format, emoji, color string
+		want                 string
+	}{
+		{"discord", ":rocket:", "", "only supported with --format slack"},
+		{"slack", "rocket", "", "--emoji must be"},
+		{"slack", "", "red", "--color must be"},
+	}
+	for _, tt := range tests {
+		err := runSend(context.Background(), sendOpts{
+			webhookURL: "https://hooks.slack.com/services/T00/B00/xxx",
+			message:    "msg",
+			format:     tt.format,
+			emoji:      tt.emoji,
+			color:      tt.color,
+			outputFlag: "json",
+			client:     &mockDoer{},
+		})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%+v: expected %q, got %v", tt, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package notify
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+	"github.com/tamtom/play-console-cli/internal/rtdn"
+)
+
+// rtdnSampleKinds maps --type values to notification kinds.
+var rtdnSampleKinds = map[string]rtdn.NotificationKind{
+	"subscription": rtdn.KindSubscription,
+	"onetime":      rtdn.KindOneTime,
+	"voided":       rtdn.KindVoided,
+}
+
+// RTDNSampleCommand returns the "notify rtdn-sample" subcommand.
+func RTDNSampleCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("notify rtdn-sample", flag.ExitOnError)
+	kind := fs.String("type", "", "Notification type: subscription, onetime, voided")
+	packageName := fs.String("package", "com.example.app", "Package name in the notification")
+	productID := fs.String("product-id", "sample_product", "Subscription ID or one-time product SKU in the notification")
+	purchaseToken := fs.String("purchase-token", "sample-purchase-token", "Purchase token in the notification")
+	notificationType := fs.Int("notification-type", 0, "notificationType code (default: 4 SUBSCRIPTION_PURCHASED, or 1 ONE_TIME_PRODUCT_PURCHASED)")
+	messageBase64 := fs.String("message-base64", "", "Base64-encoded DeveloperNotification to wrap as message.data instead of generating one")
+	webhookURL := fs.String("webhook-url", "", "Also POST the envelope to this endpoint")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "rtdn-sample",
+		ShortUsage: "gplay notify rtdn-sample --type <subscription|onetime|voided> [--webhook-url <url>] [flags]",
+		ShortHelp:  "Generate a sample Real-time Developer Notification payload.",
+		LongHelp: `Generate a sample Real-time Developer Notification (RTDN) to test an
+endpoint that receives Play notifications.
+
+The output is the envelope Pub/Sub POSTs to a push subscription: message.data
+holds the base64-encoded DeveloperNotification with a subscription,
+one-time product, or voided purchase notification. --message-base64 wraps
+an existing encoded notification instead, for replaying a real one.
+
+With --webhook-url, the envelope is also POSTed to the endpoint and the
+response status is printed to stderr. Decode a payload with gplay rtdn
+decode.
+
+Examples:
+  gplay notify rtdn-sample --type subscription
+  gplay notify rtdn-sample --type subscription --notification-type 3 --product-id premium
+  gplay notify rtdn-sample --type voided --webhook-url https://example.com/rtdn
+  gplay notify rtdn-sample --message-base64 eyJ2ZXJzaW9uIjoiMS4wIn0= --webhook-url http://localhost:8080/rtdn`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			return runRTDNSample(ctx, rtdnSampleOpts{
+				kind:             *kind,
+				packageName:      *packageName,
+				productID:        *productID,
+				purchaseToken:    *purchaseToken,
+				notificationType: *notificationType,
+				messageBase64:    *messageBase64,
+				webhookURL:       *webhookURL,
+				outputFlag:       *outputFlag,
+				pretty:           *pretty,
+				client:           http.DefaultClient,
+				now:              time.Now(),
+			})
+		},
+	}
+}
+
+type rtdnSampleOpts struct {
+	kind             string
+	packageName      string
+	productID        string
+	purchaseToken    string
+	notificationType int
+	messageBase64    string
+	webhookURL       string
+	outputFlag       string
+	pretty           bool
+	client           HTTPDoer
+	now              time.Time
+}
+
+func runRTDNSample(ctx context.Context, opts rtdnSampleOpts) error {
+	if err := shared.ValidateOutputFlags(opts.outputFlag, opts.pretty); err != nil {
+		return err
+	}
+	kind := strings.ToLower(strings.TrimSpace(opts.kind))
+	message := strings.TrimSpace(opts.messageBase64)
+	switch {
+	case kind == "" && message == "":
+		return fmt.Errorf("--type or --message-base64 is required")
+	case kind != "" && message != "":
+		return fmt.Errorf("--type and --message-base64 are mutually exclusive")
+	}
+	if strings.TrimSpace(opts.webhookURL) != "" {
+		if err := ValidateWebhookURL(opts.webhookURL); err != nil {
+			return err
+		}
+	}
+
+	data := message
+	if message != "" {
+		if err := validateMessageBase64(message); err != nil {
+			return err
+		}
+	} else {
+		notificationKind, ok := rtdnSampleKinds[kind]
+		if !ok {
+			return fmt.Errorf("--type must be one of: subscription, onetime, voided (got %q)", opts.kind)
+		}
+		notification, err := rtdn.NewSampleNotification(rtdn.SampleOptions{
+			Kind:             notificationKind,
+			PackageName:      opts.packageName,
+			ProductID:        opts.productID,
+			PurchaseToken:    opts.purchaseToken,
+			NotificationType: opts.notificationType,
+			Time:             opts.now,
+		})
+		if err != nil {
+			return fmt.Errorf("--notification-type: %w", err)
+		}
+		data, err = rtdn.EncodeNotification(notification)
+		if err != nil {
+			return err
+		}
+	}
+	envelope := rtdn.NewEnvelope(data, opts.now)
+
+	if strings.TrimSpace(opts.webhookURL) != "" {
+		cfg, _ := config.Load()
+		if cfg == nil {
+			cfg = &config.Config{}
+		}
+		postCtx, cancel := shared.ContextWithTimeout(ctx, cfg)
+		defer cancel()
+
+		result, err := PostWebhook(postCtx, opts.client, opts.webhookURL, envelope)
+		if err != nil {
+			if result != nil {
+				return fmt.Errorf("posting sample notification failed (HTTP %d): %w", result.StatusCode, err)
+			}
+			return fmt.Errorf("posting sample notification failed: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Posted sample notification to %s: %s\n", MaskURL(opts.webhookURL), result.Status)
+	}
+
+	return shared.PrintOutput(envelope, opts.outputFlag, opts.pretty)
+}
+
+// validateMessageBase64 checks that value is base64-encoded JSON.
+func validateMessageBase64(value string) error {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("--message-base64 is not valid base64: %w", err)
+	}
+	if !json.Valid(decoded) {
+		return fmt.Errorf("--message-base64 does not decode to JSON")
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/rtdn"
+)
+
+func captureNotifyStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	orig := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	runErr := fn()
+	_ = w.Close()
+	os.Stdout = orig
+	return <-done, runErr
+}
+
+func decodeSampleEnvelope(t *testing.T, out string) rtdn.DeveloperNotification {
+	t.Helper()
+	var env rtdn.PubsubEnvelope
+	if err := json.Unmarshal([]byte(out), &env); err != nil {
+		t.Fatalf("unmarshal envelope %q: %v", out, err)
+	}
+	data, err := base64.StdEncoding.DecodeString(env.Message.Data)
+	if err != nil {
+		t.Fatalf("message.data is not base64: %v", err)
+	}
+	var n rtdn.DeveloperNotification
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&n); err != nil {
+		t.Fatalf("message.data is not a DeveloperNotification: %v", err)
+	}
+	return n
+}
+
+func TestRunRTDNSample_Types(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		kind     string
+		wantKind rtdn.NotificationKind
+		check    func(t *testing.T, n rtdn.DeveloperNotification)
+	}{
+		{"subscription", rtdn.KindSubscription, func(t *testing.T, n rtdn.DeveloperNotification) {
+			s := n.SubscriptionNotification
+			if s == nil || s.NotificationType != 4 || s.SubscriptionID != "premium" || s.PurchaseToken != "tok" {
+				t.Errorf("unexpected subscriptionNotification: %+v", s)
+			}
+			if n.OneTimeProductNotification != nil || n.VoidedPurchaseNotification != nil {
+				t.Errorf("expected only subscriptionNotification: %+v", n)
+			}
+		}},
+		{"onetime", rtdn.KindOneTime, func(t *testing.T, n rtdn.DeveloperNotification) {
+			o := n.OneTimeProductNotification
+			if o == nil || o.NotificationType != 1 || o.SKU != "premium" {
+				t.Errorf("unexpected oneTimeProductNotification: %+v", o)
+			}
+		}},
+		{"voided", rtdn.KindVoided, func(t *testing.T, n rtdn.DeveloperNotification) {
+			v := n.VoidedPurchaseNotification
+			if v == nil || v.PurchaseToken != "tok" || v.OrderID == "" {
+				t.Errorf("unexpected voidedPurchaseNotification: %+v", v)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			out, err := captureNotifyStdout(t, func() error {
+				return runRTDNSample(context.Background(), rtdnSampleOpts{
+					kind:          tt.kind,
+					packageName:   "com.example.app",
+					productID:     "premium",
+					purchaseToken: "tok",
+					outputFlag:    "json",
+					now:           now,
+				})
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			n := decodeSampleEnvelope(t, out)
+			if n.PackageName != "com.example.app" || n.EventTimeMillis != "1772366400000" {
+				t.Errorf("unexpected notification: %+v", n)
+			}
+			tt.check(t, n)
+
+			decoded, err := rtdn.Decode([]byte(out))
+			if err != nil {
+				t.Fatalf("rtdn.Decode: %v", err)
+			}
+			if decoded.Kind != tt.wantKind {
+				t.Errorf("decoded kind = %s, want %s", decoded.Kind, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestRunRTDNSample_PostsToWebhook(t *testing.T) {
+	var captured []byte
+	mock := &mockDoer{
+		handler: func(req *http.Request) (*http.Response, error) {
+			captured, _ = io.ReadAll(req.Body)
+			return &http.Response{StatusCode: 200, Status: "200 OK", Body: io.NopCloser(strings.NewReader("")), Header: make(http.Header)}, nil
+		},
+	}
+	out, err := captureNotifyStdout(t, func() error {
+		return runRTDNSample(context.Background(), rtdnSampleOpts{
+			kind:             "subscription",
+			notificationType: 3,
+			webhookURL:       "https://example.com/rtdn",
+			outputFlag:       "json",
+			client:           mock,
+			now:              time.Now(),
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(string(captured)) != strings.TrimSpace(out) {
+		t.Errorf("posted body %s differs from printed envelope %s", captured, out)
+	}
+	if n := decodeSampleEnvelope(t, string(captured)); n.SubscriptionNotification == nil || n.SubscriptionNotification.NotificationType != 3 {
+		t.Errorf("unexpected posted notification: %+v", n)
+	}
+}
+
+func TestRunRTDNSample_MessageBase64(t *testing.T) {
+	data := base64.StdEncoding.EncodeToString([]byte(`{"version":"1.0","packageName":"com.example.app","eventTimeMillis":"1","testNotification":{"version":"1.0"}}`))
+	out, err := captureNotifyStdout(t, func() error {
+		return runRTDNSample(context.Background(), rtdnSampleOpts{messageBase64: data, outputFlag: "json", now: time.Now()})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded, err := rtdn.Decode([]byte(out))
+	if err != nil || decoded.Kind != rtdn.KindTest {
+		t.Fatalf("expected test notification, got %+v, %v", decoded, err)
+	}
+}
+
+func TestRunRTDNSample_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts rtdnSampleOpts
+		want string
+	}{
+		{"missing type", rtdnSampleOpts{}, "--type or --message-base64 is required"},
+		{"unknown type", rtdnSampleOpts{kind: "refund"}, "--type must be one of"},
+		{"both", rtdnSampleOpts{kind: "voided", messageBase64: "e30="}, "mutually exclusive"},
+		{"bad notification type", rtdnSampleOpts{kind: "subscription", notificationType: 99}, "unknown notification type 99"},
+		{"bad base64", rtdnSampleOpts{messageBase64: "not base64!"}, "not valid base64"},
+		{"bad webhook", rtdnSampleOpts{kind: "voided", webhookURL: "ftp://example.com"}, "http or https"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.outputFlag = "json"
+			err := runRTDNSample(context.Background(), tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
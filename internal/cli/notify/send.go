@@ -21,15 +21,37 @@ func SendCommand() *ffcli.Command {
 	format := fs.String("format", "slack", "Payload format: slack (default), discord, generic")
 	eventType := fs.String("event-type", "", "Event tag (e.g., release, review, rollout)")
 	packageName := fs.String("package", "", "Package name for message context")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	emoji := fs.String("emoji", "", "Slack emoji shortcode overriding the event type default (none to disable)")
+	color := fs.String("color", "", "Slack color (#RRGGBB, good, warning, danger) overriding the event type default (none to disable)")
+	var attachFiles attachFileFlag
+	fs.Var(&attachFiles, "attach-file", "File to attach as base64 (generic format only, repeatable)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "send",
 		ShortUsage: "gplay notify send --webhook-url <url> --message <text> [flags]",
 		ShortHelp:  "Send a notification to a webhook.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Send a notification to a Slack, Discord, or generic webhook.
+
+With --format generic, --attach-file embeds files (for example a changelog
+or validation report) as base64 entries in the payload's "files" array.
+Each attachment is limited to 5 MiB.
+
+Slack messages are styled by --event-type:
+  release  :rocket:
+  rollout  :chart_with_upwards_trend:
+  review   :speech_balloon:
+  warning  :warning: with a yellow bar
+  error    :x: with a red bar
+--emoji and --color override the default; pass none to drop it.
+
+Examples:
+  gplay notify send --webhook-url URL --message "Released 1.2.3" --event-type release
+  gplay notify send --webhook-url URL --message "Rollout halted" --event-type rollout --emoji :octagonal_sign: --color "#E01E5A"
+  gplay notify send --webhook-url URL --message "Report" --format generic --attach-file report.json`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			return runSend(ctx, sendOpts{
 				webhookURL:  *webhookURL,
@@ -37,6 +59,9 @@ func SendCommand() *ffcli.Command {
 				format:      *format,
 				eventType:   *eventType,
 				packageName: *packageName,
+				emoji:       *emoji,
+				color:       *color,
+				attachFiles: attachFiles,
 				outputFlag:  *outputFlag,
 				pretty:      *pretty,
 				client:      http.DefaultClient,
@@ -51,6 +76,9 @@ type sendOpts struct {
 	format      string
 	eventType   string
 	packageName string
+	emoji       string
+	color       string
+	attachFiles []string
 	outputFlag  string
 	pretty      bool
 	client      HTTPDoer
@@ -77,7 +105,35 @@ func runSend(ctx context.Context, opts sendOpts) error {
 		return err
 	}
 
-	payload := BuildPayload(pf, opts.message, opts.eventType, opts.packageName)
+	if len(opts.attachFiles) > 0 && pf != FormatGeneric {
+		return fmt.Errorf("--attach-file is only supported with --format generic")
+	}
+
+	if opts.emoji != "" || opts.color != "" {
+		if pf != FormatSlack {
+			return fmt.Errorf("--emoji and --color are only supported with --format slack")
+		}
+		if err := validateSlackStyleFlags(opts.emoji, opts.color); err != nil {
+			return err
+		}
+	}
+
+	var payload interface{}
+	if pf == FormatSlack {
+		style := SlackStyleFor(opts.eventType).withOverrides(opts.emoji, opts.color)
+		payload = BuildSlackPayload(opts.message, opts.eventType, opts.packageName, style)
+	} else {
+		payload = BuildPayload(pf, opts.message, opts.eventType, opts.packageName)
+	}
+	if len(opts.attachFiles) > 0 {
+		files, err := LoadAttachments(opts.attachFiles)
+		if err != nil {
+			return err
+		}
+		generic := payload.(GenericPayload)
+		generic.Files = files
+		payload = generic
+	}
 
 	// Apply timeout from config if available.
 	cfg, _ := config.Load()
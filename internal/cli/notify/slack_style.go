@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// styleNone disables the default emoji or color for an event type.
+const styleNone = "none"
+
+// SlackStyle is the visual treatment of a Slack message. Emoji prefixes the
+// message body; a non-empty Color wraps the blocks in an attachment so Slack
+// draws a colored bar beside them.
+type SlackStyle struct {
+	Emoji string
+	Color string
+}
+
+// DefaultSlackStyles maps --event-type values to their Slack style.
+var DefaultSlackStyles = map[string]SlackStyle{
+	"release": {Emoji: ":rocket:"},
+	"rollout": {Emoji: ":chart_with_upwards_trend:"},
+	"review":  {Emoji: ":speech_balloon:"},
+	"warning": {Emoji: ":warning:", Color: "warning"},
+	"error":   {Emoji: ":x:", Color: "danger"},
+}
+
+var (
+	slackEmojiRegex = regexp.MustCompile(`^:[a-z0-9_+'-]+:$`)
+	slackColorRegex = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// SlackStyleFor returns the default style for an event type; unknown types
+// get no emoji or color.
+func SlackStyleFor(eventType string) SlackStyle {
+	return DefaultSlackStyles[strings.ToLower(strings.TrimSpace(eventType))]
+}
+
+// withOverrides replaces the emoji and color with any non-empty override.
+// "none" clears the value.
+func (s SlackStyle) withOverrides(emoji, color string) SlackStyle {
+	if emoji = strings.TrimSpace(emoji); emoji != "" {
+		s.Emoji = emoji
+		if emoji == styleNone {
+			s.Emoji = ""
+		}
+	}
+	if color = strings.TrimSpace(color); color != "" {
+		s.Color = color
+		if color == styleNone {
+			s.Color = ""
+		}
+	}
+	return s
+}
+
+// validateSlackStyleFlags checks --emoji and --color values.
+func validateSlackStyleFlags(emoji, color string) error {
+	emoji = strings.TrimSpace(emoji)
+	if emoji != "" && emoji != styleNone && !slackEmojiRegex.MatchString(emoji) {
+		return fmt.Errorf("--emoji must be a Slack shortcode like :rocket: or %q, got %q", styleNone, emoji)
+	}
+	switch color = strings.TrimSpace(color); color {
+	case "", styleNone, "good", "warning", "danger":
+		return nil
+	}
+	if !slackColorRegex.MatchString(color) {
+		return fmt.Errorf("--color must be #RRGGBB, good, warning, danger, or %q, got %q", styleNone, color)
+	}
+	return nil
+}
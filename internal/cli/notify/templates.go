@@ -15,6 +15,19 @@ const (
 	FormatGeneric PayloadFormat = "generic"
 )
 
+// Platform message length limits, in characters.
+const (
+	// slackTextLimit is the maximum length of a Slack message's top-level text.
+	slackTextLimit = 40000
+	// slackSectionLimit is the maximum length of a Slack section block's text.
+	slackSectionLimit = 3000
+	// discordContentLimit is the maximum length of a Discord message's content.
+	discordContentLimit = 2000
+)
+
+// truncationMarker is appended to messages that exceed a platform limit.
+const truncationMarker = "… (truncated)"
+
 // ParseFormat validates and returns a PayloadFormat.
 func ParseFormat(s string) (PayloadFormat, error) {
 	switch strings.ToLower(strings.TrimSpace(s)) {
@@ -31,8 +44,15 @@ func ParseFormat(s string) (PayloadFormat, error) {
 
 // SlackPayload is the JSON structure for Slack webhooks.
 type SlackPayload struct {
-	Text   string       `json:"text"`
-	Blocks []SlackBlock `json:"blocks,omitempty"`
+	Text        string            `json:"text"`
+	Blocks      []SlackBlock      `json:"blocks,omitempty"`
+	Attachments []SlackAttachment `json:"attachments,omitempty"`
+}
+
+// SlackAttachment carries blocks with a colored side bar.
+type SlackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []SlackBlock `json:"blocks"`
 }
 
 // SlackBlock represents a Slack block element.
@@ -54,10 +74,11 @@ type DiscordPayload struct {
 
 // GenericPayload is the JSON structure for generic HTTP webhooks.
 type GenericPayload struct {
-	EventType string `json:"event_type,omitempty"`
-	Package   string `json:"package,omitempty"`
-	Message   string `json:"message"`
-	Timestamp string `json:"timestamp"`
+	EventType string       `json:"event_type,omitempty"`
+	Package   string       `json:"package,omitempty"`
+	Message   string       `json:"message"`
+	Timestamp string       `json:"timestamp"`
+	Files     []Attachment `json:"files,omitempty"`
 }
 
 // BuildPayload constructs the appropriate payload for the given format.
@@ -68,30 +89,38 @@ func BuildPayload(format PayloadFormat, message, eventType, packageName string)
 	case FormatGeneric:
 		return buildGenericPayload(message, eventType, packageName)
 	default:
-		return buildSlackPayload(message, eventType, packageName)
+		return BuildSlackPayload(message, eventType, packageName, SlackStyleFor(eventType))
 	}
 }
 
-func buildSlackPayload(message, eventType, packageName string) SlackPayload {
+// BuildSlackPayload constructs a Slack payload with an explicit style.
+func BuildSlackPayload(message, eventType, packageName string, style SlackStyle) SlackPayload {
 	body := formatMessageBody(message, eventType, packageName)
-	return SlackPayload{
-		Text: message,
-		Blocks: []SlackBlock{
-			{
-				Type: "section",
-				Text: &SlackTextObj{
-					Type: "mrkdwn",
-					Text: body,
-				},
+	if style.Emoji != "" {
+		body = style.Emoji + " " + body
+	}
+	blocks := []SlackBlock{
+		{
+			Type: "section",
+			Text: &SlackTextObj{
+				Type: "mrkdwn",
+				Text: truncateMessage(body, slackSectionLimit),
 			},
 		},
 	}
+	payload := SlackPayload{Text: truncateMessage(message, slackTextLimit)}
+	if style.Color != "" {
+		payload.Attachments = []SlackAttachment{{Color: style.Color, Blocks: blocks}}
+	} else {
+		payload.Blocks = blocks
+	}
+	return payload
 }
 
 func buildDiscordPayload(message, eventType, packageName string) DiscordPayload {
 	body := formatMessageBody(message, eventType, packageName)
 	return DiscordPayload{
-		Content: body,
+		Content: truncateMessage(body, discordContentLimit),
 	}
 }
 
@@ -120,3 +149,18 @@ func formatMessageBody(message, eventType, packageName string) string {
 	}
 	return message
 }
+
+// truncateMessage shortens s to at most limit characters, ending it with
+// truncationMarker when it had to be cut. Lengths are counted in runes so
+// multi-byte characters are never split.
+func truncateMessage(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	keep := limit - len([]rune(truncationMarker))
+	if keep < 0 {
+		keep = 0
+	}
+	return strings.TrimRight(string(runes[:keep]), " \t\n") + truncationMarker
+}
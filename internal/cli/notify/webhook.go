@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
 )
 
 // WebhookResult holds the response from a webhook POST.
@@ -79,22 +81,6 @@ func PostWebhook(ctx context.Context, client HTTPDoer, webhookURL string, payloa
 }
 
 // MaskURL redacts all but the last 6 characters of the URL path for safe display.
-// The leading "/" of the path is always preserved.
 func MaskURL(raw string) string {
-	u, err := url.Parse(raw)
-	if err != nil {
-		return "***"
-	}
-	path := u.Path
-	// Strip leading slash for masking, then re-add it.
-	trimmed := strings.TrimPrefix(path, "/")
-	if len(trimmed) > 6 {
-		trimmed = "***" + trimmed[len(trimmed)-6:]
-	}
-	if strings.HasPrefix(path, "/") {
-		path = "/" + trimmed
-	} else {
-		path = trimmed
-	}
-	return fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, path)
+	return shared.MaskURL(raw)
 }
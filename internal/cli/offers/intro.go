@@ -0,0 +1,80 @@
+package offers
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/monetizationpricing"
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+)
+
+var regionCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// introFlags are the offers create shortcuts for a single-phase
+// introductory price, used instead of --json.
+type introFlags struct {
+	price    *string
+	region   *string
+	duration *string
+	count    *int
+}
+
+func bindIntroFlags(fs *flag.FlagSet) introFlags {
+	return introFlags{
+		price:    fs.String("intro-price", "", "Introductory price as CURRENCY:AMOUNT (e.g. USD:4.99); builds the offer instead of --json"),
+		region:   fs.String("intro-region", "", "Region code of the introductory price (e.g. US)"),
+		duration: fs.String("intro-duration", "", "ISO 8601 duration of one introductory period (e.g. P1M)"),
+		count:    fs.Int("intro-count", 1, "Number of introductory periods"),
+	}
+}
+
+// set reports whether any intro flag was given.
+func (f introFlags) set() bool {
+	return strings.TrimSpace(*f.price) != "" ||
+		strings.TrimSpace(*f.region) != "" ||
+		strings.TrimSpace(*f.duration) != ""
+}
+
+// offer builds a SubscriptionOffer with one phase that charges the intro
+// price in one region for --intro-count periods of --intro-duration.
+func (f introFlags) offer() (*androidpublisher.SubscriptionOffer, error) {
+	if strings.TrimSpace(*f.price) == "" {
+		return nil, fmt.Errorf("--intro-price is required")
+	}
+	price, err := monetizationpricing.ParsePriceString(*f.price)
+	if err != nil {
+		return nil, fmt.Errorf("--intro-price: %w", err)
+	}
+	region := strings.ToUpper(strings.TrimSpace(*f.region))
+	if region == "" {
+		return nil, fmt.Errorf("--intro-region is required")
+	}
+	if !regionCodePattern.MatchString(region) {
+		return nil, fmt.Errorf("--intro-region must be a 2-letter region code (got %q)", *f.region)
+	}
+	duration := strings.TrimSpace(*f.duration)
+	if duration == "" {
+		return nil, fmt.Errorf("--intro-duration is required")
+	}
+	if !shared.IsISODuration(duration) {
+		return nil, fmt.Errorf("--intro-duration must be an ISO 8601 duration such as P1M (got %q)", *f.duration)
+	}
+	if *f.count < 1 {
+		return nil, fmt.Errorf("--intro-count must be at least 1")
+	}
+	return &androidpublisher.SubscriptionOffer{
+		Phases: []*androidpublisher.SubscriptionOfferPhase{
+			{
+				Duration:        duration,
+				RecurrenceCount: int64(*f.count),
+				RegionalConfigs: []*androidpublisher.RegionalSubscriptionOfferPhaseConfig{
+					{RegionCode: region, Price: price},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,132 @@
+package offers
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func parseIntroFlags(t *testing.T, args ...string) introFlags {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	intro := bindIntroFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	return intro
+}
+
+func TestIntroFlags_BuildsSinglePhaseOffer(t *testing.T) {
+	intro := parseIntroFlags(t, "--intro-price", "USD:4.99", "--intro-region", "us", "--intro-duration", "P1M", "--intro-count", "3")
+	if !intro.set() {
+		t.Fatal("expected intro flags to be set")
+	}
+	offer, err := intro.offer()
+	if err != nil {
+		t.Fatalf("offer: %v", err)
+	}
+	if len(offer.Phases) != 1 {
+		t.Fatalf("expected one phase, got %d", len(offer.Phases))
+	}
+	phase := offer.Phases[0]
+	if phase.Duration != "P1M" || phase.RecurrenceCount != 3 {
+		t.Fatalf("phase = %s x%d, want P1M x3", phase.Duration, phase.RecurrenceCount)
+	}
+	if len(phase.RegionalConfigs) != 1 {
+		t.Fatalf("expected one regional config, got %d", len(phase.RegionalConfigs))
+	}
+	cfg := phase.RegionalConfigs[0]
+	if cfg.RegionCode != "US" {
+		t.Fatalf("regionCode = %q, want US", cfg.RegionCode)
+	}
+	if cfg.Price == nil || cfg.Price.CurrencyCode != "USD" || cfg.Price.Units != 4 || cfg.Price.Nanos != 990000000 {
+		t.Fatalf("price = %+v, want USD 4 units 990000000 nanos", cfg.Price)
+	}
+}
+
+func TestIntroFlags_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"bad price", []string{"--intro-price", "4.99", "--intro-region", "US", "--intro-duration", "P1M"}, "--intro-price"},
+		{"bad region", []string{"--intro-price", "USD:4.99", "--intro-region", "USA", "--intro-duration", "P1M"}, "--intro-region must be"},
+		{"missing region", []string{"--intro-price", "USD:4.99", "--intro-duration", "P1M"}, "--intro-region is required"},
+		{"bad duration", []string{"--intro-price", "USD:4.99", "--intro-region", "US", "--intro-duration", "1 month"}, "--intro-duration must be"},
+		{"bad count", []string{"--intro-price", "USD:4.99", "--intro-region", "US", "--intro-duration", "P1M", "--intro-count", "0"}, "--intro-count"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseIntroFlags(t, tt.args...).offer()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCreateCommand_IntroFlagsSendSynthesizedOffer(t *testing.T) {
+	var sent androidpublisher.SubscriptionOffer
+	installMockOffersPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &sent); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+
+	cmd := CreateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--product-id", "premium",
+		"--base-plan-id", "monthly",
+		"--offer-id", "intro",
+		"--intro-price", "USD:4.99",
+		"--intro-region", "US",
+		"--intro-duration", "P1W",
+	}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if _, err := captureOffersStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if sent.OfferId != "intro" || sent.BasePlanId != "monthly" {
+		t.Fatalf("unexpected ids in request: %+v", sent)
+	}
+	if len(sent.Phases) != 1 || sent.Phases[0].Duration != "P1W" || sent.Phases[0].RecurrenceCount != 1 {
+		t.Fatalf("unexpected phases: %+v", sent.Phases)
+	}
+	price := sent.Phases[0].RegionalConfigs[0].Price
+	if price.Units != 4 || price.Nanos != 990000000 {
+		t.Fatalf("price = %+v", price)
+	}
+}
+
+func TestCreateCommand_JSONAndIntroFlagsConflict(t *testing.T) {
+	cmd := CreateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--product-id", "premium",
+		"--base-plan-id", "monthly",
+		"--offer-id", "intro",
+		"--json", `{"phases":[]}`,
+		"--intro-price", "USD:4.99",
+	}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
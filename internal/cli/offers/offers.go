@@ -2,7 +2,6 @@ package offers
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"strings"
@@ -14,6 +13,8 @@ import (
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+var newPlayService = playclient.NewService
+
 // offerMutableFields are the top-level fields on SubscriptionOffer that can be
 // set via update_mask. Must match the fields the SDK can serialize.
 var offerMutableFields = []string{
@@ -67,28 +68,46 @@ func ListCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
+	allBasePlans := fs.Bool("all-base-plans", false, "List offers of every base plan of the subscription instead of --base-plan-id")
 	pageSize := fs.Int("page-size", 100, "Page size")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	maxItems := fs.Int("max-items", 0, shared.MaxItemsFlagUsage)
+	filter := fs.String("filter", "", "Only include items whose offer ID matches this glob (e.g. intro_*)")
+	shared.BindOrderByFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
-		ShortUsage: "gplay offers list --package <name> --product-id <id> --base-plan-id <plan>",
+		ShortUsage: "gplay offers list --package <name> --product-id <id> (--base-plan-id <plan> | --all-base-plans) [--filter <glob>] [--order-by <field>]",
 		ShortHelp:  "List all offers for a base plan.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `List all offers for a base plan.
+
+With --all-base-plans, the subscription is fetched to find its base plans,
+and every page of offers for each one is merged into a single array. Each
+offer's basePlanId names the plan it belongs to.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
+			if err := shared.ValidateGlob("filter", *filter); err != nil {
+				return err
+			}
+			if err := shared.ValidateMaxItems(*maxItems); err != nil {
+				return err
+			}
 			if strings.TrimSpace(*productID) == "" {
 				return fmt.Errorf("--product-id is required")
 			}
-			if strings.TrimSpace(*basePlanID) == "" {
-				return fmt.Errorf("--base-plan-id is required")
+			if *allBasePlans && strings.TrimSpace(*basePlanID) != "" {
+				return fmt.Errorf("--base-plan-id and --all-base-plans are mutually exclusive")
 			}
-			service, err := playclient.NewService(ctx)
+			if !*allBasePlans && strings.TrimSpace(*basePlanID) == "" {
+				return fmt.Errorf("--base-plan-id is required (or use --all-base-plans)")
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -100,28 +119,68 @@ func ListCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			var all []*androidpublisher.SubscriptionOffer
-			pageToken := ""
-			for {
-				call := service.API.Monetization.Subscriptions.BasePlans.Offers.List(pkg, *productID, *basePlanID).Context(ctx).PageSize(int64(*pageSize))
-				if pageToken != "" {
-					call.PageToken(pageToken)
+			offerID := func(item *androidpublisher.SubscriptionOffer) string { return item.OfferId }
+			fetchPlan := func(ctx context.Context, basePlan, token string) (*androidpublisher.ListSubscriptionOffersResponse, error) {
+				call := service.API.Monetization.Subscriptions.BasePlans.Offers.List(pkg, *productID, basePlan).Context(ctx).PageSize(int64(*pageSize))
+				if token != "" {
+					call.PageToken(token)
 				}
-				resp, err := call.Do()
+				return call.Do()
+			}
+			fetchAll := func(basePlan string, maxItems int) ([]*androidpublisher.SubscriptionOffer, bool, error) {
+				return shared.FetchListPages(ctx, maxItems, func(ctx context.Context, token string) ([]*androidpublisher.SubscriptionOffer, string, error) {
+					resp, err := fetchPlan(ctx, basePlan, token)
+					if err != nil {
+						return nil, "", err
+					}
+					return shared.FilterByID(resp.SubscriptionOffers, *filter, offerID), resp.NextPageToken, nil
+				})
+			}
+
+			if *allBasePlans {
+				sub, err := service.API.Monetization.Subscriptions.Get(pkg, *productID).Context(ctx).Do()
 				if err != nil {
 					return err
 				}
-				if !*paginate {
-					return shared.PrintOutput(resp, *outputFlag, *pretty)
+				all := []*androidpublisher.SubscriptionOffer{}
+				truncated := false
+				for _, bp := range sub.BasePlans {
+					remaining := 0
+					if *maxItems > 0 {
+						remaining = *maxItems - len(all)
+					}
+					offers, more, err := fetchAll(bp.BasePlanId, remaining)
+					if err != nil {
+						return fmt.Errorf("list offers for base plan %s: %w", bp.BasePlanId, err)
+					}
+					for _, offer := range offers {
+						if offer.BasePlanId == "" {
+							offer.BasePlanId = bp.BasePlanId
+						}
+					}
+					all = append(all, offers...)
+					if *maxItems > 0 && len(all) >= *maxItems {
+						truncated = more || bp != sub.BasePlans[len(sub.BasePlans)-1]
+						break
+					}
 				}
-				all = append(all, resp.SubscriptionOffers...)
-				if resp.NextPageToken == "" {
-					break
+				return shared.PrintPaginated(all, truncated, *maxItems, *outputFlag, *pretty)
+			}
+
+			if !*paginate && *maxItems == 0 {
+				resp, err := fetchPlan(ctx, *basePlanID, "")
+				if err != nil {
+					return err
 				}
-				pageToken = resp.NextPageToken
+				resp.SubscriptionOffers = shared.FilterByID(resp.SubscriptionOffers, *filter, offerID)
+				return shared.PrintOutput(resp, *outputFlag, *pretty)
 			}
 
-			return shared.PrintOutput(all, *outputFlag, *pretty)
+			all, truncated, err := fetchAll(*basePlanID, *maxItems)
+			if err != nil {
+				return err
+			}
+			return shared.PrintPaginated(all, truncated, *maxItems, *outputFlag, *pretty)
 		},
 	}
 }
@@ -132,7 +191,7 @@ func GetCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
 	offerID := fs.String("offer-id", "", "Offer ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -181,14 +240,16 @@ func CreateCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
 	offerID := fs.String("offer-id", "", "Offer ID")
-	jsonFlag := fs.String("json", "", "SubscriptionOffer JSON (or @file)")
+	jsonFlag := fs.String("json", "", "SubscriptionOffer JSON (or @file, - for stdin)")
+	assumeFromJSON := shared.BindAssumeFromJSONFlag(fs)
+	intro := bindIntroFlags(fs)
 	regionsVersion := fs.String("regions-version", "", "Regions version")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "create",
-		ShortUsage: "gplay offers create --package <name> --product-id <id> --base-plan-id <plan> --offer-id <offer> --json <json>",
+		ShortUsage: "gplay offers create --package <name> --product-id <id> --base-plan-id <plan> --offer-id <offer> (--json <json> | --intro-price <CUR:amount> --intro-region <code> --intro-duration <ISO>)",
 		ShortHelp:  "Create an offer.",
 		LongHelp: `Create a new subscription offer.
 
@@ -236,38 +297,63 @@ JSON format for introductory price:
       ]
     }
   ]
-}`,
+}
+
+The same introductory price without JSON:
+  gplay offers create --package com.example.app --product-id premium \
+    --base-plan-id monthly --offer-id intro --intro-price USD:4.99 \
+    --intro-region US --intro-duration P1M --intro-count 3
+
+With --assume-package-from-json, an empty --package, --product-id,
+--base-plan-id or --offer-id is taken from the packageName, productId,
+basePlanId or offerId field of the JSON:
+  gplay offers create --json @offer.json --assume-package-from-json`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*productID) == "" {
-				return fmt.Errorf("--product-id is required")
-			}
-			if strings.TrimSpace(*basePlanID) == "" {
-				return fmt.Errorf("--base-plan-id is required")
+			fromJSON := *assumeFromJSON && strings.TrimSpace(*jsonFlag) != ""
+			if !fromJSON {
+				if err := requireOfferIDs(*productID, *basePlanID, *offerID); err != nil {
+					return err
+				}
 			}
-			if strings.TrimSpace(*offerID) == "" {
-				return fmt.Errorf("--offer-id is required")
+			var offer androidpublisher.SubscriptionOffer
+			switch {
+			case strings.TrimSpace(*jsonFlag) != "" && intro.set():
+				return fmt.Errorf("--json cannot be combined with --intro-* flags")
+			case intro.set():
+				built, err := intro.offer()
+				if err != nil {
+					return err
+				}
+				offer = *built
+			case strings.TrimSpace(*jsonFlag) == "":
+				return fmt.Errorf("--json is required (or use --intro-price, --intro-region, and --intro-duration)")
+			default:
+				if err := shared.LoadJSONArgStrict(*jsonFlag, &offer); err != nil {
+					return fmt.Errorf("invalid JSON: %w", err)
+				}
 			}
-			if strings.TrimSpace(*jsonFlag) == "" {
-				return fmt.Errorf("--json is required")
+			if fromJSON {
+				*productID = shared.ValueOrJSON(*productID, offer.ProductId, true)
+				*basePlanID = shared.ValueOrJSON(*basePlanID, offer.BasePlanId, true)
+				*offerID = shared.ValueOrJSON(*offerID, offer.OfferId, true)
+				if err := requireOfferIDs(*productID, *basePlanID, *offerID); err != nil {
+					return fmt.Errorf("%w (not set in --json either)", err)
+				}
 			}
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
-			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			pkg := shared.ResolvePackageName(shared.ValueOrJSON(*packageName, offer.PackageName, *assumeFromJSON), service.Cfg)
 			if strings.TrimSpace(pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
 
-			var offer androidpublisher.SubscriptionOffer
-			if err := shared.LoadJSONArg(*jsonFlag, &offer); err != nil {
-				return fmt.Errorf("invalid JSON: %w", err)
-			}
 			offer.PackageName = pkg
 			offer.ProductId = *productID
 			offer.BasePlanId = *basePlanID
@@ -295,11 +381,12 @@ func UpdateCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
 	offerID := fs.String("offer-id", "", "Offer ID")
-	jsonFlag := fs.String("json", "", "SubscriptionOffer JSON (or @file)")
+	jsonFlag := fs.String("json", "", "SubscriptionOffer JSON (or @file, - for stdin)")
+	assumeFromJSON := shared.BindAssumeFromJSONFlag(fs)
 	updateMask := fs.String("update-mask", "", "Fields to update (comma-separated)")
 	regionsVersion := fs.String("regions-version", "", "Regions version")
 	allowMissing := fs.Bool("allow-missing", false, "Create if not exists")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -328,8 +415,13 @@ JSON format:
   ]
 }
 
+With --assume-package-from-json, an empty --package, --product-id,
+--base-plan-id or --offer-id is taken from the packageName, productId,
+basePlanId or offerId field of the JSON.
+
 Examples:
   gplay offers update --package com.example --product-id premium --base-plan-id monthly --offer-id trial --json @offer.json
+  gplay offers update --json @offer.json --assume-package-from-json
   gplay offers update --package com.example --product-id premium --base-plan-id monthly --offer-id trial --json '{"offerTags":[{"tag":"promo"}]}' --update-mask offerTags`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
@@ -337,14 +429,10 @@ Examples:
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*productID) == "" {
-				return fmt.Errorf("--product-id is required")
-			}
-			if strings.TrimSpace(*basePlanID) == "" {
-				return fmt.Errorf("--base-plan-id is required")
-			}
-			if strings.TrimSpace(*offerID) == "" {
-				return fmt.Errorf("--offer-id is required")
+			if !*assumeFromJSON {
+				if err := requireOfferIDs(*productID, *basePlanID, *offerID); err != nil {
+					return err
+				}
 			}
 			if strings.TrimSpace(*jsonFlag) == "" {
 				return fmt.Errorf("--json is required")
@@ -353,6 +441,10 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("invalid JSON: %w", err)
 			}
+			var offer androidpublisher.SubscriptionOffer
+			if err := shared.UnmarshalStrict(raw, &offer); err != nil {
+				return fmt.Errorf("invalid JSON: %w", err)
+			}
 			mask := strings.TrimSpace(*updateMask)
 			if mask == "" {
 				derived, err := shared.DeriveUpdateMask(raw, offerMutableFields)
@@ -360,17 +452,23 @@ Examples:
 					return err
 				}
 				mask = derived
+			} else if err := shared.ValidateUpdateMask(mask, &offer); err != nil {
+				return err
 			}
-			var offer androidpublisher.SubscriptionOffer
-			if err := json.Unmarshal(raw, &offer); err != nil {
-				return fmt.Errorf("invalid JSON: %w", err)
+			if *assumeFromJSON {
+				*productID = shared.ValueOrJSON(*productID, offer.ProductId, true)
+				*basePlanID = shared.ValueOrJSON(*basePlanID, offer.BasePlanId, true)
+				*offerID = shared.ValueOrJSON(*offerID, offer.OfferId, true)
+				if err := requireOfferIDs(*productID, *basePlanID, *offerID); err != nil {
+					return fmt.Errorf("%w (not set in --json either)", err)
+				}
 			}
 
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
-			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			pkg := shared.ResolvePackageName(shared.ValueOrJSON(*packageName, offer.PackageName, *assumeFromJSON), service.Cfg)
 			if strings.TrimSpace(pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
@@ -404,7 +502,7 @@ func ActivateCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
 	offerID := fs.String("offer-id", "", "Offer ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -454,7 +552,7 @@ func DeactivateCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
 	offerID := fs.String("offer-id", "", "Offer ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -504,8 +602,8 @@ func DeleteCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
 	offerID := fs.String("offer-id", "", "Offer ID")
-	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	confirm := fs.Bool("confirm", false, "Confirm deletion (prompts when omitted on a terminal)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -528,7 +626,9 @@ func DeleteCommand() *ffcli.Command {
 				return fmt.Errorf("--offer-id is required")
 			}
 			if !*confirm {
-				return fmt.Errorf("--confirm is required")
+				if err := shared.RequireConfirmation(ctx, fmt.Sprintf("Delete offer %s on base plan %s?", *offerID, *basePlanID), "--confirm"); err != nil {
+					return err
+				}
 			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
@@ -564,15 +664,23 @@ func BatchGetCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
 	offerIDs := fs.String("offer-ids", "", "Comma-separated list of offer IDs")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	chunkSize := fs.Int("chunk-size", shared.BatchGetLimit, fmt.Sprintf("Offers per API request (1-%d); larger inputs are split into several requests", shared.BatchGetLimit))
+	concurrency := fs.Int("concurrency", 4, fmt.Sprintf("Number of requests to run in parallel (1-%d)", shared.MaxBatchConcurrency))
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "batch-get",
 		ShortUsage: "gplay offers batch-get --package <name> --product-id <id> --base-plan-id <plan> --offer-ids <id1,id2>",
 		ShortHelp:  "Get multiple offers.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Get multiple offers.
+
+Offer IDs are requested --chunk-size at a time with up to --concurrency
+requests in flight, and the offers are printed in the order of --offer-ids.
+If a chunk fails, the offers of the other chunks are still printed, the
+failed range is reported on stderr, and the command exits non-zero.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
@@ -586,7 +694,10 @@ func BatchGetCommand() *ffcli.Command {
 			if strings.TrimSpace(*offerIDs) == "" {
 				return fmt.Errorf("--offer-ids is required")
 			}
-			service, err := playclient.NewService(ctx)
+			if err := shared.ValidateChunkFlags(*chunkSize, *concurrency); err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -600,26 +711,29 @@ func BatchGetCommand() *ffcli.Command {
 				idList[i] = strings.TrimSpace(idList[i])
 			}
 
-			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
-			defer cancel()
-
-			req := &androidpublisher.BatchGetSubscriptionOffersRequest{
-				Requests: make([]*androidpublisher.GetSubscriptionOfferRequest, 0, len(idList)),
-			}
-			for _, id := range idList {
-				req.Requests = append(req.Requests, &androidpublisher.GetSubscriptionOfferRequest{
-					PackageName: pkg,
-					ProductId:   *productID,
-					BasePlanId:  *basePlanID,
-					OfferId:     id,
-				})
-			}
-
-			resp, err := service.API.Monetization.Subscriptions.BasePlans.Offers.BatchGet(pkg, *productID, *basePlanID, req).Context(ctx).Do()
-			if err != nil {
+			offers, failures := shared.FetchInChunks(ctx, service.Cfg, idList, *chunkSize, *concurrency, func(ctx context.Context, chunk []string) ([]*androidpublisher.SubscriptionOffer, error) {
+				req := &androidpublisher.BatchGetSubscriptionOffersRequest{
+					Requests: make([]*androidpublisher.GetSubscriptionOfferRequest, 0, len(chunk)),
+				}
+				for _, id := range chunk {
+					req.Requests = append(req.Requests, &androidpublisher.GetSubscriptionOfferRequest{
+						PackageName: pkg,
+						ProductId:   *productID,
+						BasePlanId:  *basePlanID,
+						OfferId:     id,
+					})
+				}
+				resp, err := service.API.Monetization.Subscriptions.BasePlans.Offers.BatchGet(pkg, *productID, *basePlanID, req).Context(ctx).Do()
+				if err != nil {
+					return nil, err
+				}
+				return resp.SubscriptionOffers, nil
+			})
+			resp := &androidpublisher.BatchGetSubscriptionOffersResponse{SubscriptionOffers: offers}
+			if err := shared.PrintOutput(resp, *outputFlag, *pretty); err != nil {
 				return err
 			}
-			return shared.PrintOutput(resp, *outputFlag, *pretty)
+			return shared.ChunkFailuresError("batch-get", failures, len(idList))
 		},
 	}
 }
@@ -629,8 +743,10 @@ func BatchUpdateCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
-	jsonFlag := fs.String("json", "", "Batch update request JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "Batch update request JSON (or @file, - for stdin)")
+	idem := shared.BindIdempotencyFlags(fs)
+	summary := shared.BindSummaryFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -666,7 +782,14 @@ JSON format:
       "regionsVersion": {"version": "2025/02"}
     }
   ]
-}`,
+}
+
+Use --summary to print succeeded/failed/created/updated counts instead of
+the full response.
+
+With --idempotency-key, the batch is recorded in a local journal
+(~/.gplay/batch-journal.json) once applied, and a later run with the same key
+is skipped with a notice on stderr and no output; pass --force to re-apply.`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -699,10 +822,26 @@ JSON format:
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			resp, err := service.API.Monetization.Subscriptions.BasePlans.Offers.BatchUpdate(pkg, *productID, *basePlanID, &req).Context(ctx).Do()
+			var existing map[string]bool
+			if *summary && anyAllowMissing(&req) {
+				existing, err = existingOfferIDs(ctx, service, pkg, *productID, *basePlanID)
+				if err != nil {
+					return err
+				}
+			}
+
+			resp, applied, err := shared.RunIdempotentBatch(ctx, idem, "offers batch-update", pkg, func() (interface{}, error) {
+				return service.API.Monetization.Subscriptions.BasePlans.Offers.BatchUpdate(pkg, *productID, *basePlanID, &req).Context(ctx).Do()
+			})
 			if err != nil {
 				return err
 			}
+			if !applied {
+				return nil
+			}
+			if batchResp, ok := resp.(*androidpublisher.BatchUpdateSubscriptionOffersResponse); ok && *summary {
+				return shared.PrintOutput(summarizeBatchUpdate(&req, batchResp, existing), *outputFlag, *pretty)
+			}
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
@@ -713,8 +852,8 @@ func BatchUpdateStatesCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
 	basePlanID := fs.String("base-plan-id", "", "Base plan ID")
-	jsonFlag := fs.String("json", "", "Batch update states request JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "Batch update states request JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -784,3 +923,63 @@ JSON format:
 		},
 	}
 }
+
+func anyAllowMissing(req *androidpublisher.BatchUpdateSubscriptionOffersRequest) bool {
+	for _, r := range req.Requests {
+		if r != nil && r.AllowMissing {
+			return true
+		}
+	}
+	return false
+}
+
+// existingOfferIDs lists the base plan's offers so a batch summary can tell
+// created offers from updated ones.
+func existingOfferIDs(ctx context.Context, service *playclient.Service, pkg, productID, basePlanID string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	pageToken := ""
+	for {
+		call := service.API.Monetization.Subscriptions.BasePlans.Offers.List(pkg, productID, basePlanID).Context(ctx)
+		if pageToken != "" {
+			call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range resp.SubscriptionOffers {
+			existing[o.OfferId] = true
+		}
+		if resp.NextPageToken == "" {
+			return existing, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func summarizeBatchUpdate(req *androidpublisher.BatchUpdateSubscriptionOffersRequest, resp *androidpublisher.BatchUpdateSubscriptionOffersResponse, existing map[string]bool) *shared.BatchSummary {
+	requested := make([]string, 0, len(req.Requests))
+	for _, r := range req.Requests {
+		if r != nil && r.SubscriptionOffer != nil {
+			requested = append(requested, r.SubscriptionOffer.OfferId)
+		}
+	}
+	var returned []string
+	for _, o := range resp.SubscriptionOffers {
+		returned = append(returned, o.OfferId)
+	}
+	return shared.SummarizeBatchUpdate(requested, returned, existing)
+}
+
+// requireOfferIDs checks that the IDs naming an offer are all set.
+func requireOfferIDs(productID, basePlanID, offerID string) error {
+	switch {
+	case strings.TrimSpace(productID) == "":
+		return fmt.Errorf("--product-id is required")
+	case strings.TrimSpace(basePlanID) == "":
+		return fmt.Errorf("--base-plan-id is required")
+	case strings.TrimSpace(offerID) == "":
+		return fmt.Errorf("--offer-id is required")
+	}
+	return nil
+}
@@ -1,9 +1,20 @@
 package offers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
+	"sync"
 	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
 func TestUpdateCommand_EmptyJSON_NoUpdateMask_ReturnsError(t *testing.T) {
@@ -60,3 +71,217 @@ func TestUpdateCommand_WithExplicitUpdateMask_SkipsDeriving(t *testing.T) {
 		t.Errorf("explicit --update-mask should skip derive; got: %s", err.Error())
 	}
 }
+
+func TestUpdateCommand_UnknownFieldSuggestsFix(t *testing.T) {
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--product-id", "test",
+		"--base-plan-id", "monthly",
+		"--offer-id", "trial",
+		"--json", `{"phases":[{"duration":"P7D","reccurrenceCount":1}]}`,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for misspelled field")
+	}
+	if !strings.Contains(err.Error(), `unknown field "reccurrenceCount" (did you mean "recurrenceCount"?)`) {
+		t.Errorf("error should name the unknown field and suggest a fix, got: %s", err.Error())
+	}
+}
+
+func TestSummarizeBatchUpdate_MixedOutcomes(t *testing.T) {
+	req := &androidpublisher.BatchUpdateSubscriptionOffersRequest{
+		Requests: []*androidpublisher.UpdateSubscriptionOfferRequest{
+			{SubscriptionOffer: &androidpublisher.SubscriptionOffer{OfferId: "trial"}},
+			{SubscriptionOffer: &androidpublisher.SubscriptionOffer{OfferId: "intro"}},
+		},
+	}
+	resp := &androidpublisher.BatchUpdateSubscriptionOffersResponse{
+		SubscriptionOffers: []*androidpublisher.SubscriptionOffer{{OfferId: "trial"}},
+	}
+	got := summarizeBatchUpdate(req, resp, nil)
+	if got.Succeeded != 1 || got.Updated != 1 || got.Created != 0 || got.Failed != 1 {
+		t.Errorf("unexpected summary: %+v", got)
+	}
+}
+
+func TestOffersListCommand_FilterAcrossPages(t *testing.T) {
+	installMockOffersPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = io.WriteString(w, `{"subscriptionOffers":[{"offerId":"intro_week"},{"offerId":"winback"}],"nextPageToken":"p2"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"subscriptionOffers":[{"offerId":"intro_month"}]}`)
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--product-id", "premium",
+		"--base-plan-id", "monthly",
+		"--paginate",
+		"--filter", "intro_*",
+	}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureOffersStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(stdout, "intro_week") || !strings.Contains(stdout, "intro_month") || strings.Contains(stdout, "winback") {
+		t.Fatalf("unexpected filtered output: %s", stdout)
+	}
+}
+
+func TestOffersListCommand_AllBasePlansMergesOffers(t *testing.T) {
+	installMockOffersPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/subscriptions/premium"):
+			_, _ = io.WriteString(w, `{"productId":"premium","basePlans":[{"basePlanId":"monthly"},{"basePlanId":"yearly"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/basePlans/monthly/offers"):
+			if r.URL.Query().Get("pageToken") == "" {
+				_, _ = io.WriteString(w, `{"subscriptionOffers":[{"offerId":"intro_week"}],"nextPageToken":"p2"}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"subscriptionOffers":[{"offerId":"winback"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/basePlans/yearly/offers"):
+			_, _ = io.WriteString(w, `{"subscriptionOffers":[{"offerId":"intro_year"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--product-id", "premium",
+		"--all-base-plans",
+	}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureOffersStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var offers []androidpublisher.SubscriptionOffer
+	if err := json.Unmarshal([]byte(stdout), &offers); err != nil {
+		t.Fatalf("expected JSON array, got %q: %v", stdout, err)
+	}
+	want := [][2]string{{"monthly", "intro_week"}, {"monthly", "winback"}, {"yearly", "intro_year"}}
+	if len(offers) != len(want) {
+		t.Fatalf("expected %d offers, got %d: %s", len(want), len(offers), stdout)
+	}
+	for i, w := range want {
+		if offers[i].BasePlanId != w[0] || offers[i].OfferId != w[1] {
+			t.Fatalf("offer %d = %s/%s, want %s/%s", i, offers[i].BasePlanId, offers[i].OfferId, w[0], w[1])
+		}
+	}
+}
+
+func TestOffersListCommand_AllBasePlansConflictsWithBasePlanID(t *testing.T) {
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--product-id", "premium",
+		"--base-plan-id", "monthly",
+		"--all-base-plans",
+	}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected mutually exclusive error, got %v", err)
+	}
+}
+
+func installMockOffersPlayService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() {
+		newPlayService = original
+	})
+}
+
+func captureOffersStdout(fn func() error) (string, error) {
+	origStdout := os.Stdout
+	rOut, wOut, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+
+	os.Stdout = wOut
+
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&buf, rOut)
+	}()
+
+	runErr := fn()
+
+	_ = wOut.Close()
+	os.Stdout = origStdout
+	wg.Wait()
+	_ = rOut.Close()
+
+	return buf.String(), runErr
+}
+
+func TestOffersCreateCommand_AssumePackageFromJSON(t *testing.T) {
+	t.Setenv("GPLAY_PACKAGE", "")
+	var gotPath, gotOfferID string
+	installMockOffersPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotOfferID = r.URL.Query().Get("offerId")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	cmd := CreateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--assume-package-from-json",
+		"--json", `{"packageName":"com.example.json","productId":"premium","basePlanId":"monthly","offerId":"trial","phases":[]}`,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureOffersStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(gotPath, "/applications/com.example.json/subscriptions/premium/basePlans/monthly/offers") || gotOfferID != "trial" {
+		t.Fatalf("request = %q offerId=%q, want IDs from JSON", gotPath, gotOfferID)
+	}
+}
+
+func TestOffersUpdateCommand_AssumeFromJSONStillNeedsIDs(t *testing.T) {
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--assume-package-from-json",
+		"--json", `{"productId":"premium","offerId":"trial","offerTags":[]}`,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--base-plan-id is required (not set in --json either)") {
+		t.Fatalf("expected --base-plan-id error, got %v", err)
+	}
+}
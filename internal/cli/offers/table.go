@@ -0,0 +1,65 @@
+package offers
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+var offerTableHeaders = []string{"Product ID", "Base Plan", "Offer ID", "State", "Phases"}
+
+func init() {
+	output.RegisterType(&androidpublisher.SubscriptionOffer{}, offerTableHeaders, func(data any) [][]string {
+		return offerRows([]*androidpublisher.SubscriptionOffer{data.(*androidpublisher.SubscriptionOffer)})
+	})
+	output.RegisterType(&androidpublisher.ListSubscriptionOffersResponse{}, offerTableHeaders, func(data any) [][]string {
+		return offerRows(data.(*androidpublisher.ListSubscriptionOffersResponse).SubscriptionOffers)
+	})
+	output.RegisterType([]*androidpublisher.SubscriptionOffer{}, offerTableHeaders, func(data any) [][]string {
+		return offerRows(data.([]*androidpublisher.SubscriptionOffer))
+	})
+}
+
+func offerRows(offers []*androidpublisher.SubscriptionOffer) [][]string {
+	rows := make([][]string, 0, len(offers))
+	for _, offer := range offers {
+		if offer == nil {
+			continue
+		}
+		rows = append(rows, []string{offer.ProductId, offer.BasePlanId, offer.OfferId, offer.State, describePhases(offer.Phases)})
+	}
+	return rows
+}
+
+// describePhases summarizes offer phases in order, e.g.
+// "1 week free, 3 x 1 month".
+func describePhases(phases []*androidpublisher.SubscriptionOfferPhase) string {
+	parts := make([]string, 0, len(phases))
+	for _, phase := range phases {
+		if phase == nil {
+			continue
+		}
+		desc := shared.HumanizeISODuration(phase.Duration)
+		if phase.RecurrenceCount > 1 {
+			desc = fmt.Sprintf("%d x %s", phase.RecurrenceCount, desc)
+		}
+		if phaseIsFree(phase) {
+			desc += " free"
+		}
+		parts = append(parts, desc)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func phaseIsFree(phase *androidpublisher.SubscriptionOfferPhase) bool {
+	for _, cfg := range phase.RegionalConfigs {
+		if cfg != nil && cfg.Free != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package offers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+func TestOfferTable_HumanizesPhases(t *testing.T) {
+	offer := &androidpublisher.SubscriptionOffer{
+		ProductId:  "premium",
+		BasePlanId: "monthly",
+		OfferId:    "intro",
+		State:      "ACTIVE",
+		Phases: []*androidpublisher.SubscriptionOfferPhase{
+			{Duration: "P7D", RecurrenceCount: 1, RegionalConfigs: []*androidpublisher.RegionalSubscriptionOfferPhaseConfig{
+				{RegionCode: "US", Free: &androidpublisher.RegionalSubscriptionOfferPhaseFreePriceOverride{}},
+			}},
+			{Duration: "P1M", RecurrenceCount: 3},
+		},
+	}
+	if got := describePhases(offer.Phases); got != "7 days free, 3 x 1 month" {
+		t.Fatalf("describePhases() = %q", got)
+	}
+
+	var buf bytes.Buffer
+	rendered, err := output.RenderRegistered(&buf, offer, "table")
+	if err != nil || !rendered {
+		t.Fatalf("expected registered table rendering, got rendered=%v err=%v", rendered, err)
+	}
+	if !strings.Contains(buf.String(), "7 days free, 3 x 1 month") {
+		t.Fatalf("expected humanized phases, got:\n%s", buf.String())
+	}
+}
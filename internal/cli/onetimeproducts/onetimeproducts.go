@@ -2,7 +2,6 @@ package onetimeproducts
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"strings"
@@ -15,6 +14,8 @@ import (
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+var newPlayService = playclient.NewService
+
 // otpMutableFields are the top-level fields on OneTimeProduct that can be
 // set via update_mask. Must match the fields the SDK can serialize.
 var otpMutableFields = []string{
@@ -61,12 +62,15 @@ func ListCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	pageSize := fs.Int("page-size", 100, "Page size")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	maxItems := fs.Int("max-items", 0, shared.MaxItemsFlagUsage)
+	filter := fs.String("filter", "", "Only include items whose product ID matches this glob (e.g. premium_*)")
+	shared.BindOrderByFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
-		ShortUsage: "gplay onetimeproducts list --package <name>",
+		ShortUsage: "gplay onetimeproducts list --package <name> [--filter <glob>] [--order-by <field>]",
 		ShortHelp:  "List all one-time products.",
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
@@ -74,7 +78,13 @@ func ListCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			service, err := playclient.NewService(ctx)
+			if err := shared.ValidateGlob("filter", *filter); err != nil {
+				return err
+			}
+			if err := shared.ValidateMaxItems(*maxItems); err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -86,27 +96,34 @@ func ListCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			var all []*androidpublisher.OneTimeProduct
-			pageToken := ""
-			for {
+			productID := func(item *androidpublisher.OneTimeProduct) string { return item.ProductId }
+			fetch := func(ctx context.Context, token string) (*androidpublisher.ListOneTimeProductsResponse, error) {
 				call := service.API.Monetization.Onetimeproducts.List(pkg).Context(ctx).PageSize(int64(*pageSize))
-				if pageToken != "" {
-					call = call.PageToken(pageToken)
+				if token != "" {
+					call = call.PageToken(token)
 				}
-				resp, err := call.Do()
+				return call.Do()
+			}
+			if !*paginate && *maxItems == 0 {
+				resp, err := fetch(ctx, "")
 				if err != nil {
 					return err
 				}
-				if !*paginate {
-					return shared.PrintOutput(resp, *outputFlag, *pretty)
-				}
-				all = append(all, resp.OneTimeProducts...)
-				if resp.NextPageToken == "" {
-					break
+				resp.OneTimeProducts = shared.FilterByID(resp.OneTimeProducts, *filter, productID)
+				return shared.PrintOutput(resp, *outputFlag, *pretty)
+			}
+
+			all, truncated, err := shared.FetchListPages(ctx, *maxItems, func(ctx context.Context, token string) ([]*androidpublisher.OneTimeProduct, string, error) {
+				resp, err := fetch(ctx, token)
+				if err != nil {
+					return nil, "", err
 				}
-				pageToken = resp.NextPageToken
+				return shared.FilterByID(resp.OneTimeProducts, *filter, productID), resp.NextPageToken, nil
+			})
+			if err != nil {
+				return err
 			}
-			return shared.PrintOutput(all, *outputFlag, *pretty)
+			return shared.PrintPaginated(all, truncated, *maxItems, *outputFlag, *pretty)
 		},
 	}
 }
@@ -115,7 +132,7 @@ func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("onetimeproducts get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Product ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -156,12 +173,13 @@ func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("onetimeproducts create", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Product ID")
-	jsonFlag := fs.String("json", "", "OneTimeProduct JSON (or @file)")
+	jsonFlag := fs.String("json", "", "OneTimeProduct JSON (or @file, - for stdin)")
+	assumeFromJSON := shared.BindAssumeFromJSONFlag(fs)
 	regionsVersion := fs.String("regions-version", "", "Regions version for price migration")
 	autoConvertRegionalPrices := fs.Bool("auto-convert-regional-prices", false, "Generate regional pricing from --base-price-json")
-	basePriceJSON := fs.String("base-price-json", "", "Base Money JSON for --auto-convert-regional-prices (or @file)")
+	basePriceJSON := fs.String("base-price-json", "", "Base Money JSON for --auto-convert-regional-prices (or @file, - for stdin)")
 	productTaxCategoryCode := fs.String("product-tax-category-code", "", "Product tax category code for price conversion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -177,6 +195,9 @@ region-specific converted prices.
 The --package and --product-id flag values are applied to the request body,
 so they do not need to be repeated in the JSON.
 
+With --assume-package-from-json, an empty --package or --product-id is taken
+from the packageName or productId field of the JSON.
+
 Use --auto-convert-regional-prices with --base-price-json to let Google Play
 generate valid regionalPricingAndAvailabilityConfigs, newRegionsConfig, and
 regionsVersion from one base price. This replaces any regional pricing in the
@@ -223,7 +244,7 @@ Examples:
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*productID) == "" {
+			if !*assumeFromJSON && strings.TrimSpace(*productID) == "" {
 				return fmt.Errorf("--product-id is required")
 			}
 			if strings.TrimSpace(*jsonFlag) == "" {
@@ -233,13 +254,17 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("invalid JSON: %w", err)
 			}
+			var product androidpublisher.OneTimeProduct
+			if err := shared.UnmarshalStrict(raw, &product); err != nil {
+				return fmt.Errorf("invalid JSON: %w", err)
+			}
 			updateMask, err := shared.DeriveUpdateMask(raw, otpMutableFields)
 			if err != nil {
 				return err
 			}
-			var product androidpublisher.OneTimeProduct
-			if err := json.Unmarshal(raw, &product); err != nil {
-				return fmt.Errorf("invalid JSON: %w", err)
+			*productID = shared.ValueOrJSON(*productID, product.ProductId, *assumeFromJSON)
+			if strings.TrimSpace(*productID) == "" {
+				return fmt.Errorf("--product-id is required (not set in --json either)")
 			}
 			var basePrice *androidpublisher.Money
 			resolvedRegionsVersion := strings.TrimSpace(*regionsVersion)
@@ -257,11 +282,11 @@ Examples:
 				}
 			}
 
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
-			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			pkg := shared.ResolvePackageName(shared.ValueOrJSON(*packageName, product.PackageName, *assumeFromJSON), service.Cfg)
 			if strings.TrimSpace(pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
@@ -305,11 +330,12 @@ func PatchCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("onetimeproducts patch", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Product ID")
-	jsonFlag := fs.String("json", "", "OneTimeProduct JSON (or @file)")
+	jsonFlag := fs.String("json", "", "OneTimeProduct JSON (or @file, - for stdin)")
+	assumeFromJSON := shared.BindAssumeFromJSONFlag(fs)
 	updateMask := fs.String("update-mask", "", "Fields to update (comma-separated)")
 	regionsVersion := fs.String("regions-version", "", "Regions version for price migration")
 	allowMissing := fs.Bool("allow-missing", false, "Create if not exists")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -323,6 +349,9 @@ If --update-mask is not provided, it is automatically derived from the JSON keys
 Mutable fields: listings, offerTags, purchaseOptions, restrictedPaymentCountries,
 taxAndComplianceSettings.
 
+With --assume-package-from-json, an empty --package or --product-id is taken
+from the packageName or productId field of the JSON.
+
 JSON format (partial update):
 {
   "listings": [
@@ -337,14 +366,15 @@ JSON format (partial update):
 Examples:
   gplay onetimeproducts patch --package com.example.app --product-id coins_100 --json @patch.json
   gplay onetimeproducts patch --package com.example.app --product-id coins_100 --json '{"listings":[...]}' --update-mask listings
-  gplay onetimeproducts patch --package com.example.app --product-id coins_100 --json @product.json --regions-version 2025/02 --allow-missing`,
+  gplay onetimeproducts patch --package com.example.app --product-id coins_100 --json @product.json --regions-version 2025/02 --allow-missing
+  gplay onetimeproducts patch --json @product.json --assume-package-from-json`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*productID) == "" {
+			if !*assumeFromJSON && strings.TrimSpace(*productID) == "" {
 				return fmt.Errorf("--product-id is required")
 			}
 			if strings.TrimSpace(*jsonFlag) == "" {
@@ -354,6 +384,10 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("invalid JSON: %w", err)
 			}
+			var product androidpublisher.OneTimeProduct
+			if err := shared.UnmarshalStrict(raw, &product); err != nil {
+				return fmt.Errorf("invalid JSON: %w", err)
+			}
 			mask := strings.TrimSpace(*updateMask)
 			if mask == "" {
 				derived, err := shared.DeriveUpdateMask(raw, otpMutableFields)
@@ -361,17 +395,19 @@ Examples:
 					return err
 				}
 				mask = derived
+			} else if err := shared.ValidateUpdateMask(mask, &product); err != nil {
+				return err
 			}
-			var product androidpublisher.OneTimeProduct
-			if err := json.Unmarshal(raw, &product); err != nil {
-				return fmt.Errorf("invalid JSON: %w", err)
+			*productID = shared.ValueOrJSON(*productID, product.ProductId, *assumeFromJSON)
+			if strings.TrimSpace(*productID) == "" {
+				return fmt.Errorf("--product-id is required (not set in --json either)")
 			}
 
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
-			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			pkg := shared.ResolvePackageName(shared.ValueOrJSON(*packageName, product.PackageName, *assumeFromJSON), service.Cfg)
 			if strings.TrimSpace(pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
@@ -402,7 +438,7 @@ func DeleteCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Product ID")
 	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -451,7 +487,7 @@ func BatchGetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("onetimeproducts batch-get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productIDs := fs.String("product-ids", "", "Comma-separated product IDs")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -492,8 +528,10 @@ func BatchGetCommand() *ffcli.Command {
 func BatchUpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("onetimeproducts batch-update", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "BatchUpdateRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "BatchUpdateRequest JSON (or @file, - for stdin)")
+	idem := shared.BindIdempotencyFlags(fs)
+	summary := shared.BindSummaryFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -543,7 +581,15 @@ JSON format (BatchUpdateOneTimeProductsRequest):
 
 Examples:
   gplay onetimeproducts batch-update --package com.example.app --json @batch.json
-  gplay onetimeproducts batch-update --package com.example.app --json '{"requests":[...]}'`,
+  gplay onetimeproducts batch-update --package com.example.app --json '{"requests":[...]}'
+  gplay onetimeproducts batch-update --package com.example.app --json @batch.json --summary
+
+Use --summary to print succeeded/failed/created/updated counts instead of
+the full response.
+
+With --idempotency-key, the batch is recorded in a local journal
+(~/.gplay/batch-journal.json) once applied, and a later run with the same key
+is skipped with a notice on stderr and no output; pass --force to re-apply.`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -570,10 +616,26 @@ Examples:
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			resp, err := service.API.Monetization.Onetimeproducts.BatchUpdate(pkg, &req).Context(ctx).Do()
+			var existing map[string]bool
+			if *summary && anyAllowMissing(&req) {
+				existing, err = existingProductIDs(ctx, service, pkg)
+				if err != nil {
+					return err
+				}
+			}
+
+			resp, applied, err := shared.RunIdempotentBatch(ctx, idem, "onetimeproducts batch-update", pkg, func() (interface{}, error) {
+				return service.API.Monetization.Onetimeproducts.BatchUpdate(pkg, &req).Context(ctx).Do()
+			})
 			if err != nil {
 				return err
 			}
+			if !applied {
+				return nil
+			}
+			if batchResp, ok := resp.(*androidpublisher.BatchUpdateOneTimeProductsResponse); ok && *summary {
+				return shared.PrintOutput(summarizeBatchUpdate(&req, batchResp, existing), *outputFlag, *pretty)
+			}
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
@@ -582,9 +644,10 @@ Examples:
 func BatchDeleteCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("onetimeproducts batch-delete", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "BatchDeleteRequest JSON (or @file)")
+	jsonFlag := fs.String("json", "", "BatchDeleteRequest JSON (or @file, - for stdin)")
 	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	summary := shared.BindSummaryFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -640,6 +703,15 @@ Examples:
 				return err
 			}
 
+			if *summary {
+				ids := make([]string, 0, len(req.Requests))
+				for _, r := range req.Requests {
+					if r != nil {
+						ids = append(ids, r.ProductId)
+					}
+				}
+				return shared.PrintOutput(shared.SummarizeBatchDelete(ids), *outputFlag, *pretty)
+			}
 			result := map[string]interface{}{
 				"deleted": true,
 			}
@@ -647,3 +719,50 @@ Examples:
 		},
 	}
 }
+
+func anyAllowMissing(req *androidpublisher.BatchUpdateOneTimeProductsRequest) bool {
+	for _, r := range req.Requests {
+		if r != nil && r.AllowMissing {
+			return true
+		}
+	}
+	return false
+}
+
+// existingProductIDs lists the package's one-time products so a batch summary
+// can tell created products from updated ones.
+func existingProductIDs(ctx context.Context, service *playclient.Service, pkg string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	pageToken := ""
+	for {
+		call := service.API.Monetization.Onetimeproducts.List(pkg).Context(ctx)
+		if pageToken != "" {
+			call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range resp.OneTimeProducts {
+			existing[p.ProductId] = true
+		}
+		if resp.NextPageToken == "" {
+			return existing, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func summarizeBatchUpdate(req *androidpublisher.BatchUpdateOneTimeProductsRequest, resp *androidpublisher.BatchUpdateOneTimeProductsResponse, existing map[string]bool) *shared.BatchSummary {
+	requested := make([]string, 0, len(req.Requests))
+	for _, r := range req.Requests {
+		if r != nil && r.OneTimeProduct != nil {
+			requested = append(requested, r.OneTimeProduct.ProductId)
+		}
+	}
+	var returned []string
+	for _, p := range resp.OneTimeProducts {
+		returned = append(returned, p.ProductId)
+	}
+	return shared.SummarizeBatchUpdate(requested, returned, existing)
+}
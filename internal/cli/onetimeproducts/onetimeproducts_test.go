@@ -1,11 +1,21 @@
 package onetimeproducts
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
+	"sync"
 	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
 func TestOneTimeProductsCommand_Name(t *testing.T) {
@@ -452,3 +462,125 @@ func TestBatchDeleteCommand_MissingConfirm(t *testing.T) {
 		t.Errorf("error should mention --confirm, got: %s", err.Error())
 	}
 }
+
+func TestSummarizeBatchUpdate_MixedOutcomes(t *testing.T) {
+	req := &androidpublisher.BatchUpdateOneTimeProductsRequest{
+		Requests: []*androidpublisher.UpdateOneTimeProductRequest{
+			{OneTimeProduct: &androidpublisher.OneTimeProduct{ProductId: "coins_100"}, AllowMissing: true},
+			{OneTimeProduct: &androidpublisher.OneTimeProduct{ProductId: "coins_500"}, AllowMissing: true},
+			{OneTimeProduct: &androidpublisher.OneTimeProduct{ProductId: "gems_10"}, AllowMissing: true},
+		},
+	}
+	resp := &androidpublisher.BatchUpdateOneTimeProductsResponse{
+		OneTimeProducts: []*androidpublisher.OneTimeProduct{{ProductId: "coins_100"}, {ProductId: "gems_10"}},
+	}
+	got := summarizeBatchUpdate(req, resp, map[string]bool{"coins_100": true})
+	if got.Requested != 3 || got.Succeeded != 2 || got.Failed != 1 || got.Created != 1 || got.Updated != 1 {
+		t.Errorf("unexpected summary: %+v", got)
+	}
+	if len(got.FailedIDs) != 1 || got.FailedIDs[0] != "coins_500" {
+		t.Errorf("unexpected failed IDs: %v", got.FailedIDs)
+	}
+}
+
+func TestOneTimeProductsListCommand_FilterSinglePage(t *testing.T) {
+	installMockOneTimeProductsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"oneTimeProducts":[{"productId":"coins_10"},{"productId":"coins_100"},{"productId":"gems_10"}]}`)
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--filter", "coins_??"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureOneTimeProductsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(stdout, `"coins_10"`) || strings.Contains(stdout, "coins_100") || strings.Contains(stdout, "gems_10") {
+		t.Fatalf("unexpected filtered output: %s", stdout)
+	}
+}
+
+func installMockOneTimeProductsPlayService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() {
+		newPlayService = original
+	})
+}
+
+func captureOneTimeProductsStdout(fn func() error) (string, error) {
+	origStdout := os.Stdout
+	rOut, wOut, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+
+	os.Stdout = wOut
+
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&buf, rOut)
+	}()
+
+	runErr := fn()
+
+	_ = wOut.Close()
+	os.Stdout = origStdout
+	wg.Wait()
+	_ = rOut.Close()
+
+	return buf.String(), runErr
+}
+
+func TestOneTimeProductsPatchCommand_AssumePackageFromJSON(t *testing.T) {
+	t.Setenv("GPLAY_PACKAGE", "")
+	var gotPath string
+	installMockOneTimeProductsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	cmd := PatchCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--assume-package-from-json",
+		"--json", `{"packageName":"com.example.json","productId":"coins_100","listings":[{"languageCode":"en-US","title":"Coins"}]}`,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureOneTimeProductsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(gotPath, "/applications/com.example.json/onetimeproducts/coins_100") {
+		t.Fatalf("request path = %q, want package and product from JSON", gotPath)
+	}
+}
+
+func TestOneTimeProductsCreateCommand_AssumePackageFromJSONRequiresProductID(t *testing.T) {
+	cmd := CreateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--assume-package-from-json", "--json", `{"packageName":"com.example.json","listings":[]}`,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--product-id is required") {
+		t.Fatalf("expected --product-id error, got %v", err)
+	}
+}
@@ -43,7 +43,7 @@ func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("orders get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	orderID := fs.String("order-id", "", "Order ID (e.g., GPA.1234-5678-9012-34567)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -84,7 +84,7 @@ func BatchGetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("orders batch-get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	orderIDs := fs.String("order-ids", "", "Comma-separated list of order IDs")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -138,7 +138,7 @@ func RefundCommand() *ffcli.Command {
 	orderID := fs.String("order-id", "", "Order ID to refund")
 	revoke := fs.Bool("revoke", false, "Revoke entitlement (user loses access)")
 	confirm := fs.Bool("confirm", false, "Confirm refund")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -13,6 +13,8 @@ import (
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+var newPlayService = playclient.NewService
+
 func OTPOffersCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("otp-offers", flag.ExitOnError)
 	return &ffcli.Command{
@@ -51,15 +53,23 @@ func ListCommand() *ffcli.Command {
 	purchaseOptionID := fs.String("purchase-option-id", "", "Purchase option ID")
 	pageSize := fs.Int("page-size", 100, "Page size")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	expired := fs.Bool("expired", false, "Only include offers whose availability window has ended")
+	upcoming := fs.Bool("upcoming", false, "Only include offers whose availability window hasn't started")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
-		ShortUsage: "gplay otp-offers list --package <name> --product-id <id> --purchase-option-id <id>",
+		ShortUsage: "gplay otp-offers list --package <name> --product-id <id> --purchase-option-id <id> [--expired | --upcoming]",
 		ShortHelp:  "List all offers for a purchase option.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `List all offers for a purchase option.
+
+--expired and --upcoming compare the startTime/endTime of discounted and
+pre-order offers against the current time, which helps find stale
+promotions to clean up. Offers without an availability window are left
+out when either filter is set.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
@@ -70,7 +80,10 @@ func ListCommand() *ffcli.Command {
 			if strings.TrimSpace(*purchaseOptionID) == "" {
 				return fmt.Errorf("--purchase-option-id is required")
 			}
-			service, err := playclient.NewService(ctx)
+			if *expired && *upcoming {
+				return fmt.Errorf("--expired and --upcoming are mutually exclusive")
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -82,6 +95,7 @@ func ListCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
+			now := nowFunc()
 			var all []*androidpublisher.OneTimeProductOffer
 			pageToken := ""
 			for {
@@ -93,10 +107,15 @@ func ListCommand() *ffcli.Command {
 				if err != nil {
 					return err
 				}
+				offers, err := filterOffersByWindow(resp.OneTimeProductOffers, *expired, *upcoming, now)
+				if err != nil {
+					return err
+				}
 				if !*paginate {
+					resp.OneTimeProductOffers = offers
 					return shared.PrintOutput(resp, *outputFlag, *pretty)
 				}
-				all = append(all, resp.OneTimeProductOffers...)
+				all = append(all, offers...)
 				if resp.NextPageToken == "" {
 					break
 				}
@@ -113,7 +132,7 @@ func ActivateCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "One-time product ID")
 	purchaseOptionID := fs.String("purchase-option-id", "", "Purchase option ID")
 	offerID := fs.String("offer-id", "", "Offer ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -163,7 +182,7 @@ func DeactivateCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "One-time product ID")
 	purchaseOptionID := fs.String("purchase-option-id", "", "Purchase option ID")
 	offerID := fs.String("offer-id", "", "Offer ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -213,7 +232,7 @@ func CancelCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "One-time product ID")
 	purchaseOptionID := fs.String("purchase-option-id", "", "Purchase option ID")
 	offerID := fs.String("offer-id", "", "Offer ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -262,8 +281,8 @@ func BatchGetCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "One-time product ID")
 	purchaseOptionID := fs.String("purchase-option-id", "", "Purchase option ID")
-	jsonFlag := fs.String("json", "", "BatchGetOneTimeProductOffersRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "BatchGetOneTimeProductOffersRequest JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -338,8 +357,9 @@ func BatchUpdateCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "One-time product ID")
 	purchaseOptionID := fs.String("purchase-option-id", "", "Purchase option ID")
-	jsonFlag := fs.String("json", "", "BatchUpdateOneTimeProductOffersRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "BatchUpdateOneTimeProductOffersRequest JSON (or @file, - for stdin)")
+	idem := shared.BindIdempotencyFlags(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -368,7 +388,11 @@ JSON format:
 
 Up to 100 requests per batch. All requests must update
 different offers. Use updateMask to specify which fields
-to update.`,
+to update.
+
+With --idempotency-key, the batch is recorded in a local journal
+(~/.gplay/batch-journal.json) once applied, and a later run with the same key
+is skipped with a notice on stderr and no output; pass --force to re-apply.`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -401,10 +425,15 @@ to update.`,
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			resp, err := service.API.Monetization.Onetimeproducts.PurchaseOptions.Offers.BatchUpdate(pkg, *productID, *purchaseOptionID, &req).Context(ctx).Do()
+			resp, applied, err := shared.RunIdempotentBatch(ctx, idem, "otp-offers batch-update", pkg, func() (interface{}, error) {
+				return service.API.Monetization.Onetimeproducts.PurchaseOptions.Offers.BatchUpdate(pkg, *productID, *purchaseOptionID, &req).Context(ctx).Do()
+			})
 			if err != nil {
 				return err
 			}
+			if !applied {
+				return nil
+			}
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
@@ -415,8 +444,8 @@ func BatchUpdateStatesCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "One-time product ID")
 	purchaseOptionID := fs.String("purchase-option-id", "", "Purchase option ID")
-	jsonFlag := fs.String("json", "", "BatchUpdateOneTimeProductOfferStatesRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "BatchUpdateOneTimeProductOfferStatesRequest JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -505,9 +534,9 @@ func BatchDeleteCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "One-time product ID")
 	purchaseOptionID := fs.String("purchase-option-id", "", "Purchase option ID")
-	jsonFlag := fs.String("json", "", "BatchDeleteOneTimeProductOffersRequest JSON (or @file)")
+	jsonFlag := fs.String("json", "", "BatchDeleteOneTimeProductOffersRequest JSON (or @file, - for stdin)")
 	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -0,0 +1,61 @@
+package otpoffers
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+// nowFunc is the clock used to evaluate offer availability windows.
+var nowFunc = time.Now
+
+// offerWindow returns the availability window of a discounted or pre-order
+// offer. A zero bound means the offer doesn't set it; ok is false when the
+// offer has no window at all.
+func offerWindow(offer *androidpublisher.OneTimeProductOffer) (start, end time.Time, ok bool, err error) {
+	var startStr, endStr string
+	switch {
+	case offer.DiscountedOffer != nil:
+		startStr, endStr = offer.DiscountedOffer.StartTime, offer.DiscountedOffer.EndTime
+	case offer.PreOrderOffer != nil:
+		startStr, endStr = offer.PreOrderOffer.StartTime, offer.PreOrderOffer.EndTime
+	}
+	if startStr == "" && endStr == "" {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	if startStr != "" {
+		if start, err = time.Parse(time.RFC3339, startStr); err != nil {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("offer %s: invalid startTime %q: %w", offer.OfferId, startStr, err)
+		}
+	}
+	if endStr != "" {
+		if end, err = time.Parse(time.RFC3339, endStr); err != nil {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("offer %s: invalid endTime %q: %w", offer.OfferId, endStr, err)
+		}
+	}
+	return start, end, true, nil
+}
+
+// filterOffersByWindow keeps the offers whose window has already ended
+// (expired) or not yet started (upcoming) at now. Offers without a window
+// never match. With neither filter set the offers are returned unchanged.
+func filterOffersByWindow(offers []*androidpublisher.OneTimeProductOffer, expired, upcoming bool, now time.Time) ([]*androidpublisher.OneTimeProductOffer, error) {
+	if !expired && !upcoming {
+		return offers, nil
+	}
+	filtered := make([]*androidpublisher.OneTimeProductOffer, 0, len(offers))
+	for _, offer := range offers {
+		start, end, ok, err := offerWindow(offer)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if (expired && !end.IsZero() && !now.Before(end)) || (upcoming && !start.IsZero() && now.Before(start)) {
+			filtered = append(filtered, offer)
+		}
+	}
+	return filtered, nil
+}
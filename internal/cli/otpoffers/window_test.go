@@ -0,0 +1,123 @@
+package otpoffers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+var windowTestNow = time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func windowTestOffers() []*androidpublisher.OneTimeProductOffer {
+	return []*androidpublisher.OneTimeProductOffer{
+		{OfferId: "past", DiscountedOffer: &androidpublisher.OneTimeProductDiscountedOffer{StartTime: "2026-01-01T00:00:00Z", EndTime: "2026-02-01T00:00:00Z"}},
+		{OfferId: "current", DiscountedOffer: &androidpublisher.OneTimeProductDiscountedOffer{StartTime: "2026-06-01T00:00:00Z", EndTime: "2026-07-01T00:00:00Z"}},
+		{OfferId: "future", PreOrderOffer: &androidpublisher.OneTimeProductPreOrderOffer{StartTime: "2026-09-01T00:00:00Z", EndTime: "2026-10-01T00:00:00Z"}},
+		{OfferId: "open-ended", DiscountedOffer: &androidpublisher.OneTimeProductDiscountedOffer{StartTime: "2026-01-01T00:00:00Z"}},
+		{OfferId: "no-window"},
+	}
+}
+
+func offerIDs(offers []*androidpublisher.OneTimeProductOffer) string {
+	ids := make([]string, 0, len(offers))
+	for _, o := range offers {
+		ids = append(ids, o.OfferId)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestFilterOffersByWindow(t *testing.T) {
+	tests := []struct {
+		name              string
+		expired, upcoming bool
+		want              string
+	}{
+		{"no filter", false, false, "past,current,future,open-ended,no-window"},
+		{"expired", true, false, "past"},
+		{"upcoming", false, true, "future"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterOffersByWindow(windowTestOffers(), tt.expired, tt.upcoming, windowTestNow)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ids := offerIDs(got); ids != tt.want {
+				t.Errorf("got %s, want %s", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterOffersByWindow_InvalidTime(t *testing.T) {
+	offers := []*androidpublisher.OneTimeProductOffer{
+		{OfferId: "bad", DiscountedOffer: &androidpublisher.OneTimeProductDiscountedOffer{EndTime: "soon"}},
+	}
+	_, err := filterOffersByWindow(offers, true, false, windowTestNow)
+	if err == nil || !strings.Contains(err.Error(), `offer bad: invalid endTime "soon"`) {
+		t.Fatalf("expected invalid endTime error, got %v", err)
+	}
+}
+
+func TestListCommand_ExpiredUsesClock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"oneTimeProductOffers":[
+			{"offerId":"past","discountedOffer":{"startTime":"2026-01-01T00:00:00Z","endTime":"2026-02-01T00:00:00Z"}},
+			{"offerId":"current","discountedOffer":{"startTime":"2026-06-01T00:00:00Z","endTime":"2026-07-01T00:00:00Z"}}
+		]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	origService, origNow := newPlayService, nowFunc
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	nowFunc = func() time.Time { return windowTestNow }
+	t.Cleanup(func() {
+		newPlayService, nowFunc = origService, origNow
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--product-id", "p", "--purchase-option-id", "o", "--expired"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	origStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	runErr := cmd.Exec(context.Background(), nil)
+	_ = w.Close()
+	os.Stdout = origStdout
+	out, _ := io.ReadAll(r)
+
+	if runErr != nil {
+		t.Fatalf("expected no error, got %v", runErr)
+	}
+	if !strings.Contains(string(out), `"past"`) || strings.Contains(string(out), `"current"`) {
+		t.Errorf("expected only the expired offer, got %s", out)
+	}
+}
+
+func TestListCommand_ExpiredAndUpcomingConflict(t *testing.T) {
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "p", "--purchase-option-id", "o", "--expired", "--upcoming"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected mutually exclusive error, got %v", err)
+	}
+}
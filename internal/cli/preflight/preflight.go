@@ -23,6 +23,7 @@ func PreflightCommand() *ffcli.Command {
 	skipSecrets := fs.Bool("skip-secrets", false, "Skip secret-pattern scan (faster)")
 	severity := fs.String("fail-on", "error", "Exit non-zero when findings reach this severity: info, warning, error")
 	outputFlag := fs.String("output", "text", "Output format: text (default), json, markdown")
+	shared.DeclareOutputFormats(fs, "text", "json", "markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
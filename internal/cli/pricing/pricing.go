@@ -38,9 +38,9 @@ func PricingCommand() *ffcli.Command {
 func RegionsVersionCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("pricing regions-version", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	priceJSON := fs.String("price-json", "", "Base Money JSON (or @file)")
+	priceJSON := fs.String("price-json", "", "Base Money JSON (or @file, - for stdin)")
 	productTaxCategoryCode := fs.String("product-tax-category-code", "", "Product tax category code")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -112,8 +112,8 @@ Examples:
 func ConvertCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("pricing convert", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "ConvertRegionPricesRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "ConvertRegionPricesRequest JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
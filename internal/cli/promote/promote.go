@@ -21,9 +21,9 @@ func PromoteCommand() *ffcli.Command {
 	toTrack := fs.String("to", "", "Destination track (e.g., beta, production)")
 	rolloutFraction := fs.Float64("rollout", 1.0, "Staged rollout fraction for destination (0.0-1.0)")
 	status := fs.String("status", "completed", "Release status: draft, inProgress, halted, completed")
-	releaseNotesJSON := fs.String("release-notes", "", "Release notes JSON (or @file) - if not provided, copies from source")
+	releaseNotesJSON := fs.String("release-notes", "", "Release notes JSON (or @file, - for stdin) - if not provided, copies from source")
 	changesNotSent := fs.Bool("changes-not-sent-for-review", false, "Changes not sent for review")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -62,7 +62,7 @@ func TrackCommand() *ffcli.Command {
 	skipMetadata := fs.Bool("skip-metadata", false, "Skip metadata sync even if --listings-dir is set")
 	skipScreenshots := fs.Bool("skip-screenshots", false, "Skip screenshot sync even if --screenshots-dir is set")
 	strict := fs.Bool("strict", false, "Treat readiness warnings as publish blockers")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
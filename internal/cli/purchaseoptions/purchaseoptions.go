@@ -44,8 +44,8 @@ func BatchUpdateStatesCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("purchase-options batch-update-states", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "One-time product ID")
-	jsonFlag := fs.String("json", "", "BatchUpdatePurchaseOptionStatesRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "BatchUpdatePurchaseOptionStatesRequest JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -119,9 +119,9 @@ func BatchDeleteCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("purchase-options batch-delete", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "One-time product ID")
-	jsonFlag := fs.String("json", "", "BatchDeletePurchaseOptionsRequest JSON (or @file)")
+	jsonFlag := fs.String("json", "", "BatchDeletePurchaseOptionsRequest JSON (or @file, - for stdin)")
 	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 	"google.golang.org/api/androidpublisher/v3"
@@ -56,6 +58,7 @@ func ProductsCommand() *ffcli.Command {
 			ProductsGetCommand(),
 			ProductsAcknowledgeCommand(),
 			ProductsConsumeCommand(),
+			ProductsVerifyBatchCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if len(args) == 0 {
@@ -71,19 +74,32 @@ func ProductsGetCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Product ID (SKU)")
 	token := fs.String("token", "", "Purchase token")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	watch := fs.Bool("watch", false, "Poll until the purchase is final, then print it")
+	interval := fs.Duration("interval", defaultWatchInterval, "Polling interval for --watch")
+	watchTimeout := fs.Duration("watch-timeout", defaultWatchTimeout, "How long --watch waits for a final state")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "get",
-		ShortUsage: "gplay purchases products get --package <name> --product-id <id> --token <token>",
+		ShortUsage: "gplay purchases products get --package <name> --product-id <id> --token <token> [--watch]",
 		ShortHelp:  "Get purchase details for verification.",
 		LongHelp: `Get purchase details for server-side verification.
 
 The response includes:
   - purchaseState: 0=Purchased, 1=Canceled, 2=Pending
   - consumptionState: 0=Not consumed, 1=Consumed
-  - acknowledgementState: 0=Not acknowledged, 1=Acknowledged`,
+  - acknowledgementState: 0=Not acknowledged, 1=Acknowledged
+
+With --watch, the purchase is polled every --interval until it is final:
+purchased and acknowledged, or canceled. Each state change is logged to
+stderr and the final purchase is printed. The command fails if the purchase
+is still pending or unacknowledged after --watch-timeout. The root --timeout
+still bounds each poll request.
+
+Examples:
+  gplay purchases products get --package com.example.app --product-id coins_100 --token <token>
+  gplay purchases products get --package com.example.app --product-id coins_100 --token <token> --watch --interval 30s`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -96,7 +112,13 @@ The response includes:
 			if strings.TrimSpace(*token) == "" {
 				return fmt.Errorf("--token is required")
 			}
-			service, err := playclient.NewService(ctx)
+			if *watch && *interval <= 0 {
+				return fmt.Errorf("--interval must be greater than 0")
+			}
+			if *watch && *watchTimeout <= 0 {
+				return fmt.Errorf("--watch-timeout must be greater than 0")
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -105,10 +127,17 @@ The response includes:
 				return fmt.Errorf("--package is required")
 			}
 
-			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
-			defer cancel()
-
-			resp, err := service.API.Purchases.Products.Get(pkg, *productID, *token).Context(ctx).Do()
+			get := func(ctx context.Context) (*androidpublisher.ProductPurchase, error) {
+				ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+				defer cancel()
+				return service.API.Purchases.Products.Get(pkg, *productID, *token).Context(ctx).Do()
+			}
+			var resp *androidpublisher.ProductPurchase
+			if *watch {
+				resp, err = watchProductPurchase(ctx, get, *interval, *watchTimeout, os.Stderr)
+			} else {
+				resp, err = get(ctx)
+			}
 			if err != nil {
 				return err
 			}
@@ -123,7 +152,7 @@ func ProductsAcknowledgeCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "Product ID (SKU)")
 	token := fs.String("token", "", "Purchase token")
 	developerPayload := fs.String("developer-payload", "", "Optional developer payload")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -182,7 +211,7 @@ func ProductsConsumeCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Product ID (SKU)")
 	token := fs.String("token", "", "Purchase token")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -260,7 +289,7 @@ func ProductsV2GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("purchases productsv2 get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	token := fs.String("token", "", "Purchase token")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -320,6 +349,7 @@ func SubscriptionsCommand() *ffcli.Command {
 			SubscriptionsCancelCommand(),
 			SubscriptionsDeferCommand(),
 			SubscriptionsRevokeCommand(),
+			SubscriptionsRefundCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if len(args) == 0 {
@@ -331,17 +361,28 @@ func SubscriptionsCommand() *ffcli.Command {
 }
 
 func SubscriptionsAcknowledgeCommand() *ffcli.Command {
-	fs := flag.NewFlagSet("purchases subscriptions acknowledge", flag.ExitOnError)
+	return subscriptionAcknowledgeCommand("subscriptions")
+}
+
+// SubscriptionsV2AcknowledgeCommand exposes acknowledgement next to the v2
+// commands. The v2 API has no acknowledge method, so it calls the same
+// purchases.subscriptions.acknowledge endpoint.
+func SubscriptionsV2AcknowledgeCommand() *ffcli.Command {
+	return subscriptionAcknowledgeCommand("subscriptionsv2")
+}
+
+func subscriptionAcknowledgeCommand(group string) *ffcli.Command {
+	fs := flag.NewFlagSet("purchases "+group+" acknowledge", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	subscriptionID := fs.String("subscription-id", "", "Subscription ID")
 	token := fs.String("token", "", "Purchase token")
 	developerPayload := fs.String("developer-payload", "", "Optional developer payload")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "acknowledge",
-		ShortUsage: "gplay purchases subscriptions acknowledge --package <name> --subscription-id <id> --token <token>",
+		ShortUsage: "gplay purchases " + group + " acknowledge --package <name> --subscription-id <id> --token <token> [--developer-payload <payload>]",
 		ShortHelp:  "Acknowledge a subscription purchase.",
 		LongHelp: `Acknowledge a subscription purchase using the legacy
 purchases.subscriptions.acknowledge API.
@@ -404,6 +445,7 @@ fields can be used without waiting for CLI-specific flags.`,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			SubscriptionsV2GetCommand(),
+			SubscriptionsV2AcknowledgeCommand(),
 			SubscriptionsV2CancelCommand(),
 			SubscriptionsV2DeferCommand(),
 			SubscriptionsV2RevokeCommand(),
@@ -421,15 +463,24 @@ func SubscriptionsV2GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("purchases subscriptionsv2 get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	token := fs.String("token", "", "Purchase token")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	lineItemsOnly := fs.Bool("line-items-only", false, "Print only the lineItems array (product, expiry, and renewal state per item)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "get",
-		ShortUsage: "gplay purchases subscriptionsv2 get --package <name> --token <token>",
+		ShortUsage: "gplay purchases subscriptionsv2 get --package <name> --token <token> [--line-items-only]",
 		ShortHelp:  "Get subscription purchase details (v2 API).",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Get subscription purchase details using the v2 API.
+
+--line-items-only prints just the lineItems array, which holds each item's
+productId, expiryTime, and auto-renewing or prepaid plan state.
+
+Examples:
+  gplay purchases subscriptionsv2 get --package com.example.app --token <token>
+  gplay purchases subscriptionsv2 get --package com.example.app --token <token> --line-items-only`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
@@ -453,6 +504,13 @@ func SubscriptionsV2GetCommand() *ffcli.Command {
 			if err != nil {
 				return err
 			}
+			if *lineItemsOnly {
+				lineItems := resp.LineItems
+				if lineItems == nil {
+					lineItems = []*androidpublisher.SubscriptionPurchaseLineItem{}
+				}
+				return shared.PrintOutput(lineItems, *outputFlag, *pretty)
+			}
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
@@ -462,9 +520,9 @@ func SubscriptionsV2CancelCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("purchases subscriptionsv2 cancel", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	token := fs.String("token", "", "Purchase token")
-	jsonFlag := fs.String("json", "", "CancelSubscriptionPurchaseRequest JSON (or @file)")
+	jsonFlag := fs.String("json", "", "CancelSubscriptionPurchaseRequest JSON (or @file, - for stdin)")
 	confirm := fs.Bool("confirm", false, "Confirm cancellation")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -526,8 +584,8 @@ func SubscriptionsV2DeferCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("purchases subscriptionsv2 defer", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	token := fs.String("token", "", "Purchase token")
-	jsonFlag := fs.String("json", "", "DeferSubscriptionPurchaseRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "DeferSubscriptionPurchaseRequest JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -584,9 +642,9 @@ func SubscriptionsV2RevokeCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("purchases subscriptionsv2 revoke", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	token := fs.String("token", "", "Purchase token")
-	jsonFlag := fs.String("json", "", "RevokeSubscriptionPurchaseRequest JSON (or @file)")
+	jsonFlag := fs.String("json", "", "RevokeSubscriptionPurchaseRequest JSON (or @file, - for stdin)")
 	confirm := fs.Bool("confirm", false, "Confirm revocation")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -648,19 +706,33 @@ func SubscriptionsGetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("purchases subscriptions get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	token := fs.String("token", "", "Purchase token")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	watch := fs.Bool("watch", false, "Poll until subscriptionState changes, then print the subscription")
+	interval := fs.Duration("interval", defaultWatchInterval, "Polling interval for --watch")
+	watchTimeout := fs.Duration("watch-timeout", defaultWatchTimeout, "How long --watch waits for a state change")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "get",
-		ShortUsage: "gplay purchases subscriptions get --package <name> --token <token>",
+		ShortUsage: "gplay purchases subscriptions get --package <name> --token <token> [--watch]",
 		ShortHelp:  "Get subscription purchase details (v2 API).",
 		LongHelp: `Get subscription purchase details using the v2 API.
 
 The response includes:
   - subscriptionState: Current state of the subscription
   - lineItems: Details of each subscription item
-  - acknowledgementState: Whether the subscription is acknowledged`,
+  - acknowledgementState: Whether the subscription is acknowledged
+
+With --watch, the subscription is polled every --interval until its
+subscriptionState differs from the first one seen (for example
+SUBSCRIPTION_STATE_PENDING to SUBSCRIPTION_STATE_ACTIVE). States are logged
+to stderr and the changed subscription is printed. The command fails if no
+change happens within --watch-timeout. The root --timeout still bounds each
+poll request.
+
+Examples:
+  gplay purchases subscriptions get --package com.example.app --token <token>
+  gplay purchases subscriptions get --package com.example.app --token <token> --watch --interval 10s --watch-timeout 10m`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -670,7 +742,13 @@ The response includes:
 			if strings.TrimSpace(*token) == "" {
 				return fmt.Errorf("--token is required")
 			}
-			service, err := playclient.NewService(ctx)
+			if *watch && *interval <= 0 {
+				return fmt.Errorf("--interval must be greater than 0")
+			}
+			if *watch && *watchTimeout <= 0 {
+				return fmt.Errorf("--watch-timeout must be greater than 0")
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -679,10 +757,17 @@ The response includes:
 				return fmt.Errorf("--package is required")
 			}
 
-			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
-			defer cancel()
-
-			resp, err := service.API.Purchases.Subscriptionsv2.Get(pkg, *token).Context(ctx).Do()
+			get := func(ctx context.Context) (*androidpublisher.SubscriptionPurchaseV2, error) {
+				ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+				defer cancel()
+				return service.API.Purchases.Subscriptionsv2.Get(pkg, *token).Context(ctx).Do()
+			}
+			var resp *androidpublisher.SubscriptionPurchaseV2
+			if *watch {
+				resp, err = watchSubscriptionState(ctx, get, *interval, *watchTimeout, os.Stderr)
+			} else {
+				resp, err = get(ctx)
+			}
 			if err != nil {
 				return err
 			}
@@ -697,7 +782,7 @@ func SubscriptionsCancelCommand() *ffcli.Command {
 	subscriptionID := fs.String("subscription-id", "", "Subscription ID")
 	token := fs.String("token", "", "Purchase token")
 	confirm := fs.Bool("confirm", false, "Confirm cancellation")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -754,8 +839,8 @@ func SubscriptionsDeferCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	subscriptionID := fs.String("subscription-id", "", "Subscription ID")
 	token := fs.String("token", "", "Purchase token")
-	jsonFlag := fs.String("json", "", "DeferralInfo JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "DeferralInfo JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -823,7 +908,7 @@ func SubscriptionsRevokeCommand() *ffcli.Command {
 	subscriptionID := fs.String("subscription-id", "", "Subscription ID")
 	token := fs.String("token", "", "Purchase token")
 	confirm := fs.Bool("confirm", false, "Confirm revocation")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -875,6 +960,69 @@ the user loses access right away.`,
 	}
 }
 
+func SubscriptionsRefundCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("purchases subscriptions refund", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	subscriptionID := fs.String("subscription-id", "", "Subscription ID")
+	token := fs.String("token", "", "Purchase token")
+	confirm := fs.Bool("confirm", false, "Confirm refund")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "refund",
+		ShortUsage: "gplay purchases subscriptions refund --package <name> --subscription-id <id> --token <token> --confirm",
+		ShortHelp:  "Refund a subscription's current payment.",
+		LongHelp: `Refund the user's current subscription payment.
+
+Unlike revoke, the subscription stays valid: the user keeps access until
+the current billing period ends, and it renews as usual unless it is also
+canceled.
+
+The refund covers the full current payment. To refund part of it and end
+access at the same time, use gplay purchases subscriptionsv2 revoke with a
+"proratedRefund" revocationContext.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*subscriptionID) == "" {
+				return fmt.Errorf("--subscription-id is required")
+			}
+			if strings.TrimSpace(*token) == "" {
+				return fmt.Errorf("--token is required")
+			}
+			if !*confirm {
+				return fmt.Errorf("--confirm is required")
+			}
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			err = service.API.Purchases.Subscriptions.Refund(pkg, *subscriptionID, *token).Context(ctx).Do()
+			if err != nil {
+				return err
+			}
+
+			result := map[string]interface{}{
+				"refunded":       true,
+				"subscriptionId": *subscriptionID,
+			}
+			return shared.PrintOutput(result, *outputFlag, *pretty)
+		},
+	}
+}
+
 // VoidedCommand handles voided purchases
 func VoidedCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("purchases voided", flag.ExitOnError)
@@ -901,16 +1049,21 @@ func VoidedListCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	startTime := fs.Int64("start-time", 0, "Start time in milliseconds since epoch")
 	endTime := fs.Int64("end-time", 0, "End time in milliseconds since epoch")
+	since := fs.String("since", "", "Start time as RFC3339 or YYYY-MM-DD (alternative to --start-time)")
+	until := fs.String("until", "", "End time as RFC3339 or YYYY-MM-DD (alternative to --end-time)")
 	maxResults := fs.Int("max-results", 100, "Maximum results per page")
 	voidedType := fs.Int("type", 0, "Voided source type: 0=All, 1=Refund, 2=Chargeback")
 	includeQuantity := fs.Bool("include-quantity", false, "Include quantity information")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	maxItems := fs.Int("max-items", 0, shared.MaxItemsFlagUsage)
+	follow := fs.Bool("follow", false, "Keep polling and print each newly voided purchase as a JSON line")
+	interval := fs.Duration("interval", defaultFollowInterval, "Polling interval for --follow")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
-		ShortUsage: "gplay purchases voided list --package <name> [--start-time <ms>] [--end-time <ms>]",
+		ShortUsage: "gplay purchases voided list --package <name> [--since <date>] [--until <date>] [--follow]",
 		ShortHelp:  "List voided purchases.",
 		LongHelp: `List voided purchases (refunds and chargebacks).
 
@@ -921,14 +1074,43 @@ Use this to track:
 The --type flag filters by voided source:
   0 = All voided purchases
   1 = Refunds only
-  2 = Chargebacks only`,
+  2 = Chargebacks only
+
+--since and --until accept RFC3339 timestamps or YYYY-MM-DD dates (midnight
+UTC) and replace --start-time and --end-time, which take epoch milliseconds.
+
+With --follow, the command keeps running and polls every --interval, printing
+each voided purchase once as a JSON line as it appears. Each poll starts at
+the newest voidedTimeMillis seen so far. Stop it with Ctrl+C. Note the API's
+daily and per-30-second quotas when choosing --interval.
+
+Examples:
+  gplay purchases voided list --package com.example.app --since 2026-01-01
+  gplay purchases voided list --package com.example.app --since 2026-01-01T08:00:00Z --until 2026-01-31
+  gplay purchases voided list --package com.example.app --since 2026-01-01 --follow --interval 2m`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			service, err := playclient.NewService(ctx)
+			if err := shared.ValidateMaxItems(*maxItems); err != nil {
+				return err
+			}
+			start, err := resolveVoidedTime("start-time", *startTime, "since", *since)
+			if err != nil {
+				return err
+			}
+			end, err := resolveVoidedTime("end-time", *endTime, "until", *until)
+			if err != nil {
+				return err
+			}
+			if *follow {
+				if err := validateVoidedFollow(*outputFlag, end, *paginate, *maxItems, *interval); err != nil {
+					return err
+				}
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -937,18 +1119,13 @@ The --type flag filters by voided source:
 				return fmt.Errorf("--package is required")
 			}
 
-			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
-			defer cancel()
-
-			var all []*androidpublisher.VoidedPurchase
-			pageToken := ""
-			for {
+			fetchFrom := func(ctx context.Context, start int64, token string) (*androidpublisher.VoidedPurchasesListResponse, error) {
 				call := service.API.Purchases.Voidedpurchases.List(pkg).Context(ctx).MaxResults(int64(*maxResults))
-				if *startTime > 0 {
-					call = call.StartTime(*startTime)
+				if start > 0 {
+					call = call.StartTime(start)
 				}
-				if *endTime > 0 {
-					call = call.EndTime(*endTime)
+				if end > 0 {
+					call = call.EndTime(end)
 				}
 				if *voidedType > 0 {
 					call = call.Type(int64(*voidedType))
@@ -956,24 +1133,92 @@ The --type flag filters by voided source:
 				if *includeQuantity {
 					call = call.IncludeQuantityBasedPartialRefund(true)
 				}
-				if pageToken != "" {
-					call = call.Token(pageToken)
+				if token != "" {
+					call = call.Token(token)
 				}
-				resp, err := call.Do()
-				if err != nil {
-					return err
+				return call.Do()
+			}
+			pagesFrom := func(start int64) shared.PageFetcher[*androidpublisher.VoidedPurchase] {
+				return func(ctx context.Context, token string) ([]*androidpublisher.VoidedPurchase, string, error) {
+					resp, err := fetchFrom(ctx, start, token)
+					if err != nil {
+						return nil, "", err
+					}
+					next := ""
+					if resp.TokenPagination != nil {
+						next = resp.TokenPagination.NextPageToken
+					}
+					return resp.VoidedPurchases, next, nil
 				}
-				if !*paginate {
-					return shared.PrintOutput(resp, *outputFlag, *pretty)
+			}
+
+			if *follow {
+				poll := func(ctx context.Context, start int64) ([]*androidpublisher.VoidedPurchase, error) {
+					ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+					defer cancel()
+					all, _, err := shared.FetchAllPages(ctx, 0, pagesFrom(start))
+					return all, err
 				}
-				all = append(all, resp.VoidedPurchases...)
-				if resp.TokenPagination == nil || resp.TokenPagination.NextPageToken == "" {
-					break
+				return followVoidedPurchases(ctx, poll, start, *interval, os.Stdout, os.Stderr)
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			if !*paginate && *maxItems == 0 {
+				resp, err := fetchFrom(ctx, start, "")
+				if err != nil {
+					return err
 				}
-				pageToken = resp.TokenPagination.NextPageToken
+				return shared.PrintOutput(resp, *outputFlag, *pretty)
 			}
 
-			return shared.PrintOutput(all, *outputFlag, *pretty)
+			all, truncated, err := shared.FetchListPages(ctx, *maxItems, pagesFrom(start))
+			if err != nil {
+				return err
+			}
+			return shared.PrintPaginated(all, truncated, *maxItems, *outputFlag, *pretty)
 		},
 	}
 }
+
+// validateVoidedFollow checks the flags that --follow cannot be combined with.
+func validateVoidedFollow(outputFormat string, end int64, paginate bool, maxItems int, interval time.Duration) error {
+	switch {
+	case outputFormat != "json" && outputFormat != "":
+		return fmt.Errorf("--follow prints JSON lines and requires --output json")
+	case end > 0:
+		return fmt.Errorf("--follow cannot be combined with --end-time or --until")
+	case paginate || maxItems > 0:
+		return fmt.Errorf("--follow fetches every page on each poll; drop --paginate and --max-items")
+	case interval <= 0:
+		return fmt.Errorf("--interval must be greater than 0")
+	}
+	return nil
+}
+
+// resolveVoidedTime returns the epoch milliseconds for one end of the voided
+// purchases window, taken from either the millisecond flag or the date flag.
+func resolveVoidedTime(msFlag string, ms int64, dateFlag, date string) (int64, error) {
+	date = strings.TrimSpace(date)
+	if date == "" {
+		return ms, nil
+	}
+	if ms != 0 {
+		return 0, fmt.Errorf("--%s and --%s are mutually exclusive", msFlag, dateFlag)
+	}
+	return parseVoidedDate(dateFlag, date)
+}
+
+// parseVoidedDate converts an RFC3339 timestamp or a YYYY-MM-DD date (taken as
+// midnight UTC) to epoch milliseconds.
+func parseVoidedDate(flagName, value string) (int64, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UnixMilli(), nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --%s %q: expected RFC3339 or YYYY-MM-DD", flagName, value)
+	}
+	return t.UnixMilli(), nil
+}
@@ -3,6 +3,7 @@ package purchases
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +12,8 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/peterbourgon/ff/v3/ffcli"
+
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
@@ -71,6 +74,60 @@ func TestSubscriptionsAcknowledgeCommand_CallsAPI(t *testing.T) {
 	}
 }
 
+func TestSubscriptionsV2AcknowledgeCommand_CallsV1Endpoint(t *testing.T) {
+	var gotPath string
+	installMockPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	cmd := SubscriptionsV2AcknowledgeCommand()
+	_ = cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--subscription-id", "premium", "--token", "tok"})
+	stdout, err := capturePurchasesStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotPath != "/androidpublisher/v3/applications/com.example.app/purchases/subscriptions/premium/tokens/tok:acknowledge" {
+		t.Fatalf("unexpected path: %s", gotPath)
+	}
+	if !strings.Contains(stdout, `"acknowledged":true`) {
+		t.Fatalf("expected acknowledged output, got %s", stdout)
+	}
+}
+
+func TestSubscriptionAcknowledgeCommands_RequireFlags(t *testing.T) {
+	commands := map[string]func() *ffcli.Command{
+		"subscriptions":   SubscriptionsAcknowledgeCommand,
+		"subscriptionsv2": SubscriptionsV2AcknowledgeCommand,
+	}
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing subscription-id", []string{"--token", "tok"}, "--subscription-id is required"},
+		{"missing token", []string{"--subscription-id", "premium"}, "--token is required"},
+		{"whitespace token", []string{"--subscription-id", "premium", "--token", "  "}, "--token is required"},
+		{"subscription-id checked first", nil, "--subscription-id is required"},
+	}
+	for group, newCmd := range commands {
+		for _, tt := range tests {
+			t.Run(group+"/"+tt.name, func(t *testing.T) {
+				cmd := newCmd()
+				if err := cmd.FlagSet.Parse(tt.args); err != nil {
+					t.Fatal(err)
+				}
+				err := cmd.Exec(context.Background(), nil)
+				if err == nil || err.Error() != tt.want {
+					t.Fatalf("expected %q, got %v", tt.want, err)
+				}
+			})
+		}
+	}
+}
+
 func TestSubscriptionsV2CancelCommand_CallsAPI(t *testing.T) {
 	var gotPath, gotBody string
 	installMockPlayService(t, func(w http.ResponseWriter, r *http.Request) {
@@ -105,6 +162,55 @@ func TestSubscriptionsV2CancelCommand_CallsAPI(t *testing.T) {
 	}
 }
 
+func TestSubscriptionsRefundCommand_CallsAPI(t *testing.T) {
+	var gotMethod, gotPath string
+	installMockPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	cmd := SubscriptionsRefundCommand()
+	_ = cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--subscription-id", "premium", "--token", "tok", "--confirm"})
+	stdout, err := capturePurchasesStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/androidpublisher/v3/applications/com.example.app/purchases/subscriptions/premium/tokens/tok:refund" {
+		t.Fatalf("unexpected request: %s %s", gotMethod, gotPath)
+	}
+	if !strings.Contains(stdout, `"refunded":true`) {
+		t.Fatalf("expected refunded output, got %s", stdout)
+	}
+}
+
+func TestSubscriptionsRefundCommand_RequireFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"subscription-id checked first", nil, "--subscription-id is required"},
+		{"missing token", []string{"--subscription-id", "premium"}, "--token is required"},
+		{"whitespace token", []string{"--subscription-id", "premium", "--token", "  "}, "--token is required"},
+		{"missing confirm", []string{"--subscription-id", "premium", "--token", "tok"}, "--confirm is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := SubscriptionsRefundCommand()
+			if err := cmd.FlagSet.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.Exec(context.Background(), nil)
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
 func installMockPlayService(t *testing.T, handler http.HandlerFunc) {
 	t.Helper()
 
@@ -146,3 +252,83 @@ func capturePurchasesStdout(fn func() error) (string, error) {
 
 	return buf.String(), runErr
 }
+
+func TestParseVoidedDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int64
+	}{
+		{"2024-01-02", 1704153600000},
+		{"2024-01-02T00:00:00Z", 1704153600000},
+		{"2024-01-02T03:00:00+02:00", 1704157200000},
+	}
+	for _, tt := range tests {
+		got, err := parseVoidedDate("since", tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("parseVoidedDate(%q) = %d, %v; want %d", tt.value, got, err, tt.want)
+		}
+	}
+	if _, err := parseVoidedDate("since", "01/02/2024"); err == nil || !strings.Contains(err.Error(), "--since") {
+		t.Errorf("expected invalid --since error, got %v", err)
+	}
+}
+
+func TestVoidedListCommand_DateAndMillisecondFlagsConflict(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--start-time", "1704153600000", "--since", "2024-01-02"}, "--start-time and --since are mutually exclusive"},
+		{[]string{"--end-time", "1704153600000", "--until", "2024-01-02"}, "--end-time and --until are mutually exclusive"},
+	}
+	for _, tt := range tests {
+		cmd := VoidedListCommand()
+		if err := cmd.FlagSet.Parse(append([]string{"--package", "com.example.app"}, tt.args...)); err != nil {
+			t.Fatal(err)
+		}
+		err := cmd.Exec(context.Background(), nil)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("args %v: expected %q, got %v", tt.args, tt.want, err)
+		}
+	}
+}
+
+func TestSubscriptionsV2GetCommand_LineItemsOnly(t *testing.T) {
+	installMockPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/purchases/subscriptionsv2/tokens/tok") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{
+			"kind": "androidpublisher#subscriptionPurchaseV2",
+			"regionCode": "US",
+			"subscriptionState": "SUBSCRIPTION_STATE_ACTIVE",
+			"lineItems": [
+				{"productId": "premium", "expiryTime": "2026-11-01T00:00:00Z", "autoRenewingPlan": {"autoRenewEnabled": true}},
+				{"productId": "addon", "expiryTime": "2026-10-20T00:00:00Z"}
+			]
+		}`)
+	})
+
+	cmd := SubscriptionsV2GetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--token", "tok", "--line-items-only"}); err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := capturePurchasesStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &items); err != nil {
+		t.Fatalf("expected a JSON array of line items: %v\n%s", err, stdout)
+	}
+	if len(items) != 2 || items[0]["productId"] != "premium" || items[1]["expiryTime"] != "2026-10-20T00:00:00Z" {
+		t.Fatalf("unexpected line items: %s", stdout)
+	}
+	if strings.Contains(stdout, "subscriptionState") || strings.Contains(stdout, "regionCode") {
+		t.Fatalf("expected only line items, got %s", stdout)
+	}
+}
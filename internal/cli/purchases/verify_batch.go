@@ -0,0 +1,179 @@
+package purchases
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+const maxVerifyConcurrency = 32
+
+// purchaseToVerify is one line of the --file input.
+type purchaseToVerify struct {
+	ProductID string `json:"productId"`
+	Token     string `json:"token"`
+}
+
+// verifyResult is the outcome for one purchase token. The states are omitted
+// when the lookup failed, since 0 is a meaningful value for both.
+type verifyResult struct {
+	ProductID            string `json:"productId"`
+	Token                string `json:"token"`
+	PurchaseState        *int64 `json:"purchaseState,omitempty"`
+	AcknowledgementState *int64 `json:"acknowledgementState,omitempty"`
+	Error                string `json:"error,omitempty"`
+}
+
+// productPurchaseGetter looks up one product purchase; tests replace the API
+// call with a fake.
+type productPurchaseGetter func(ctx context.Context, productID, token string) (*androidpublisher.ProductPurchase, error)
+
+func ProductsVerifyBatchCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("purchases products verify-batch", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	file := fs.String("file", "", "JSONL file with one {\"productId\",\"token\"} object per line (- for stdin)")
+	concurrency := fs.Int("concurrency", 4, fmt.Sprintf("Number of purchases to look up in parallel (1-%d)", maxVerifyConcurrency))
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "verify-batch",
+		ShortUsage: "gplay purchases products verify-batch --package <name> --file <tokens.jsonl> [--concurrency <n>]",
+		ShortHelp:  "Verify many product purchase tokens from a file.",
+		LongHelp: `Verify many product purchase tokens from a JSONL file.
+
+Each non-empty line is an object such as:
+  {"productId":"coins_100","token":"<purchase token>"}
+
+Every purchase is looked up with purchases.products.get and the output is
+an array, in input order, of:
+  {"productId", "token", "purchaseState", "acknowledgementState", "error"}
+
+A failed lookup does not stop the batch; its entry carries "error" and no
+states. See "gplay purchases products get --help" for the state values.
+
+Examples:
+  gplay purchases products verify-batch --package com.example.app --file tokens.jsonl
+  cat tokens.jsonl | gplay purchases products verify-batch --package com.example.app --file - --concurrency 8`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*file) == "" {
+				return fmt.Errorf("--file is required")
+			}
+			if *concurrency < 1 || *concurrency > maxVerifyConcurrency {
+				return fmt.Errorf("--concurrency must be between 1 and %d", maxVerifyConcurrency)
+			}
+			in, err := shared.OpenInput(strings.TrimSpace(*file))
+			if err != nil {
+				return fmt.Errorf("failed to open --file: %w", err)
+			}
+			items, err := readPurchasesToVerify(in)
+			_ = in.Close()
+			if err != nil {
+				return err
+			}
+
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+
+			get := func(ctx context.Context, productID, token string) (*androidpublisher.ProductPurchase, error) {
+				return service.API.Purchases.Products.Get(pkg, productID, token).Context(ctx).Do()
+			}
+			results := verifyPurchases(ctx, service.Cfg, items, *concurrency, get)
+
+			failed := 0
+			for _, r := range results {
+				if r.Error != "" {
+					failed++
+				}
+			}
+			if failed > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: %d of %d purchases could not be verified\n", failed, len(results))
+			}
+			return shared.PrintOutput(results, *outputFlag, *pretty)
+		},
+	}
+}
+
+// readPurchasesToVerify parses the JSONL input, skipping blank lines.
+func readPurchasesToVerify(r io.Reader) ([]purchaseToVerify, error) {
+	var items []purchaseToVerify
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var item purchaseToVerify
+		if err := json.Unmarshal([]byte(text), &item); err != nil {
+			return nil, fmt.Errorf("--file line %d: invalid JSON: %w", line, err)
+		}
+		if strings.TrimSpace(item.ProductID) == "" || strings.TrimSpace(item.Token) == "" {
+			return nil, fmt.Errorf("--file line %d: productId and token are required", line)
+		}
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read --file: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("--file contains no purchases")
+	}
+	return items, nil
+}
+
+// verifyPurchases looks up every item with at most concurrency calls in
+// flight, each under its own request timeout from cfg. Results keep the input
+// order; failures are recorded per item.
+func verifyPurchases(ctx context.Context, cfg *config.Config, items []purchaseToVerify, concurrency int, get productPurchaseGetter) []verifyResult {
+	results := make([]verifyResult, len(items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := verifyResult{ProductID: item.ProductID, Token: item.Token}
+			getCtx, cancel := shared.ContextWithTimeout(ctx, cfg)
+			defer cancel()
+			purchase, err := get(getCtx, item.ProductID, item.Token)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.PurchaseState = &purchase.PurchaseState
+				result.AcknowledgementState = &purchase.AcknowledgementState
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,182 @@
+package purchases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+)
+
+func TestVerifyPurchases_MarksFailuresAndKeepsOrder(t *testing.T) {
+	items := []purchaseToVerify{
+		{ProductID: "coins", Token: "good-1"},
+		{ProductID: "coins", Token: "bad"},
+		{ProductID: "gems", Token: "good-2"},
+	}
+	get := func(ctx context.Context, productID, token string) (*androidpublisher.ProductPurchase, error) {
+		if token == "bad" {
+			return nil, errors.New("purchase token not found")
+		}
+		return &androidpublisher.ProductPurchase{PurchaseState: 0, AcknowledgementState: 1}, nil
+	}
+
+	results := verifyPurchases(context.Background(), nil, items, 2, get)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i, item := range items {
+		if results[i].Token != item.Token || results[i].ProductID != item.ProductID {
+			t.Fatalf("result %d out of order: %+v", i, results[i])
+		}
+	}
+	if results[1].Error != "purchase token not found" || results[1].PurchaseState != nil {
+		t.Fatalf("expected failure entry without states, got %+v", results[1])
+	}
+	ok := results[2]
+	if ok.Error != "" || ok.PurchaseState == nil || *ok.PurchaseState != 0 || ok.AcknowledgementState == nil || *ok.AcknowledgementState != 1 {
+		t.Fatalf("unexpected success entry: %+v", ok)
+	}
+
+	data, _ := json.Marshal(results[0])
+	if !strings.Contains(string(data), `"purchaseState":0`) || strings.Contains(string(data), `"error"`) {
+		t.Fatalf("success JSON should keep a zero purchaseState and omit error, got %s", data)
+	}
+}
+
+func TestVerifyPurchases_BoundsConcurrency(t *testing.T) {
+	items := make([]purchaseToVerify, 20)
+	for i := range items {
+		items[i] = purchaseToVerify{ProductID: "coins", Token: "tok"}
+	}
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	get := func(ctx context.Context, productID, token string) (*androidpublisher.ProductPurchase, error) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(2 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return &androidpublisher.ProductPurchase{}, nil
+	}
+
+	verifyPurchases(context.Background(), nil, items, 3, get)
+	if peak > 3 {
+		t.Fatalf("expected at most 3 concurrent lookups, saw %d", peak)
+	}
+}
+
+func TestVerifyPurchases_TimeoutAppliesPerLookup(t *testing.T) {
+	items := make([]purchaseToVerify, 4)
+	for i := range items {
+		items[i] = purchaseToVerify{ProductID: "coins", Token: "tok"}
+	}
+	get := func(ctx context.Context, productID, token string) (*androidpublisher.ProductPurchase, error) {
+		select {
+		case <-time.After(80 * time.Millisecond):
+			return &androidpublisher.ProductPurchase{}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// Four sequential 80ms lookups outlast the 200ms timeout only if it is
+	// shared by the whole batch.
+	ctx := shared.ContextWithTimeoutOverrides(context.Background(), 200*time.Millisecond, 0)
+	for _, r := range verifyPurchases(ctx, nil, items, 1, get) {
+		if r.Error != "" {
+			t.Fatalf("expected every lookup to finish within its own timeout, got %q", r.Error)
+		}
+	}
+}
+
+func TestReadPurchasesToVerify(t *testing.T) {
+	items, err := readPurchasesToVerify(strings.NewReader("{\"productId\":\"a\",\"token\":\"1\"}\n\n{\"productId\":\"b\",\"token\":\"2\"}\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || items[1].ProductID != "b" {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+
+	for input, want := range map[string]string{
+		"{\"productId\":\"a\",\"token\":\"1\"}\nnot json\n": "line 2: invalid JSON",
+		"{\"productId\":\"a\"}\n":                           "line 1: productId and token are required",
+		"\n\n":                                              "no purchases",
+	} {
+		if _, err := readPurchasesToVerify(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("input %q: expected error containing %q, got %v", input, want, err)
+		}
+	}
+}
+
+func TestProductsVerifyBatchCommand_ContinuesPastFailures(t *testing.T) {
+	installMockPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/tokens/bad") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":{"code":404,"message":"not found"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"purchaseState":0,"acknowledgementState":0}`)
+	})
+
+	path := filepath.Join(t.TempDir(), "tokens.jsonl")
+	lines := "{\"productId\":\"coins\",\"token\":\"good\"}\n{\"productId\":\"coins\",\"token\":\"bad\"}\n"
+	if err := os.WriteFile(path, []byte(lines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := ProductsVerifyBatchCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--file", path}); err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := capturePurchasesStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var results []verifyResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, stdout)
+	}
+	if len(results) != 2 || results[0].Error != "" || results[1].Error == "" {
+		t.Fatalf("expected one success and one failure, got %+v", results)
+	}
+}
+
+func TestProductsVerifyBatchCommand_ValidatesFlags(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "--file is required"},
+		{[]string{"--file", "tokens.jsonl", "--concurrency", "0"}, "--concurrency must be between"},
+		{[]string{"--file", "tokens.jsonl", "--concurrency", "100"}, "--concurrency must be between"},
+	}
+	for _, tt := range tests {
+		cmd := ProductsVerifyBatchCommand()
+		if err := cmd.FlagSet.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		err := cmd.Exec(context.Background(), nil)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("args %v: expected %q, got %v", tt.args, tt.want, err)
+		}
+	}
+}
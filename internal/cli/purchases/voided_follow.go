@@ -0,0 +1,67 @@
+package purchases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+const defaultFollowInterval = time.Minute
+
+// voidedSinceFetcher returns every voided purchase voided at or after start,
+// in epoch milliseconds.
+type voidedSinceFetcher func(ctx context.Context, start int64) ([]*androidpublisher.VoidedPurchase, error)
+
+// followVoidedPurchases polls fetch every interval and writes each voided
+// purchase not seen before to out as one JSON line. The high-water mark is
+// the newest voidedTimeMillis seen so far; each poll starts there, and
+// purchases at exactly that time are remembered so they are not repeated.
+// A failing first poll is returned; later failures are logged to log and
+// retried. It returns nil when ctx is cancelled.
+func followVoidedPurchases(ctx context.Context, fetch voidedSinceFetcher, start int64, interval time.Duration, out, log io.Writer) error {
+	enc := json.NewEncoder(out)
+	highWater := start
+	atHighWater := map[string]bool{}
+	for polls := 0; ; polls++ {
+		purchases, err := fetch(ctx, highWater)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil && polls == 0:
+			return err
+		case err != nil:
+			fmt.Fprintf(log, "Warning: poll failed, retrying in %s: %v\n", interval, err)
+		}
+
+		sort.SliceStable(purchases, func(i, j int) bool {
+			return purchases[i].VoidedTimeMillis < purchases[j].VoidedTimeMillis
+		})
+		for _, p := range purchases {
+			if p == nil || p.VoidedTimeMillis < highWater {
+				continue
+			}
+			key := p.OrderId + "|" + p.PurchaseToken
+			if p.VoidedTimeMillis > highWater {
+				highWater = p.VoidedTimeMillis
+				atHighWater = map[string]bool{}
+			} else if atHighWater[key] {
+				continue
+			}
+			atHighWater[key] = true
+			if err := enc.Encode(p); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-watchAfter(interval):
+		}
+	}
+}
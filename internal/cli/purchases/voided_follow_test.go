@@ -0,0 +1,135 @@
+package purchases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func voided(order string, at int64) *androidpublisher.VoidedPurchase {
+	return &androidpublisher.VoidedPurchase{OrderId: order, PurchaseToken: "tok-" + order, VoidedTimeMillis: at}
+}
+
+// pollsThenCancel makes polls fire at once and cancels ctx once n polls
+// have been scheduled.
+func pollsThenCancel(t *testing.T, cancel context.CancelFunc, n int) {
+	t.Helper()
+	original := watchAfter
+	calls := 0
+	watchAfter = func(time.Duration) <-chan time.Time {
+		calls++
+		ch := make(chan time.Time, 1)
+		if calls >= n {
+			cancel()
+			return ch
+		}
+		ch <- time.Now()
+		return ch
+	}
+	t.Cleanup(func() { watchAfter = original })
+}
+
+func TestFollowVoidedPurchases_EmitsOnlyNewEntries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pollsThenCancel(t, cancel, 3)
+
+	polls := [][]*androidpublisher.VoidedPurchase{
+		{voided("A", 1000), voided("B", 2000)},
+		// The API returns B again because the poll starts at its time.
+		{voided("B", 2000), voided("C", 2000), voided("D", 3000)},
+		{voided("D", 3000)},
+	}
+	var starts []int64
+	fetch := func(ctx context.Context, start int64) ([]*androidpublisher.VoidedPurchase, error) {
+		starts = append(starts, start)
+		return polls[len(starts)-1], nil
+	}
+
+	var out, log bytes.Buffer
+	if err := followVoidedPurchases(ctx, fetch, 500, time.Second, &out, &log); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var orders []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var p androidpublisher.VoidedPurchase
+		if err := json.Unmarshal([]byte(line), &p); err != nil {
+			t.Fatalf("decode line %q: %v", line, err)
+		}
+		orders = append(orders, p.OrderId)
+	}
+	if got := strings.Join(orders, ","); got != "A,B,C,D" {
+		t.Fatalf("emitted %s, want A,B,C,D", got)
+	}
+	if len(starts) != 3 || starts[0] != 500 || starts[1] != 2000 || starts[2] != 3000 {
+		t.Fatalf("poll start times = %v, want [500 2000 3000]", starts)
+	}
+}
+
+func TestFollowVoidedPurchases_FirstPollErrorFails(t *testing.T) {
+	fetch := func(ctx context.Context, start int64) ([]*androidpublisher.VoidedPurchase, error) {
+		return nil, errors.New("permission denied")
+	}
+	var out, log bytes.Buffer
+	err := followVoidedPurchases(context.Background(), fetch, 0, time.Second, &out, &log)
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Fatalf("expected first poll error, got %v", err)
+	}
+}
+
+func TestFollowVoidedPurchases_LaterErrorsAreRetried(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pollsThenCancel(t, cancel, 3)
+
+	calls := 0
+	fetch := func(ctx context.Context, start int64) ([]*androidpublisher.VoidedPurchase, error) {
+		calls++
+		switch calls {
+		case 2:
+			return nil, errors.New("503 backend error")
+		case 3:
+			return []*androidpublisher.VoidedPurchase{voided("A", 1000)}, nil
+		}
+		return nil, nil
+	}
+	var out, log bytes.Buffer
+	if err := followVoidedPurchases(ctx, fetch, 0, time.Second, &out, &log); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(log.String(), "poll failed") {
+		t.Fatalf("expected a retry warning, got %q", log.String())
+	}
+	if !strings.Contains(out.String(), `"orderId":"A"`) {
+		t.Fatalf("expected purchase after recovery, got %q", out.String())
+	}
+}
+
+func TestVoidedListCommand_FollowFlagValidation(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--follow", "--output", "table"}, "requires --output json"},
+		{[]string{"--follow", "--until", "2026-01-31"}, "cannot be combined with --end-time or --until"},
+		{[]string{"--follow", "--paginate"}, "drop --paginate and --max-items"},
+		{[]string{"--follow", "--interval", "0s"}, "--interval must be greater than 0"},
+	}
+	for _, tt := range tests {
+		cmd := VoidedListCommand()
+		if err := cmd.FlagSet.Parse(append([]string{"--package", "com.example"}, tt.args...)); err != nil {
+			t.Fatalf("parse %v: %v", tt.args, err)
+		}
+		err := cmd.Exec(context.Background(), nil)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.args, tt.want, err)
+		}
+	}
+}
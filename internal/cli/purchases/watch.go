@@ -0,0 +1,127 @@
+package purchases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+const (
+	defaultWatchInterval = 5 * time.Second
+	defaultWatchTimeout  = 5 * time.Minute
+)
+
+// subscriptionV2Getter fetches the subscription being watched; tests replace
+// the API call with a fake.
+type subscriptionV2Getter func(ctx context.Context) (*androidpublisher.SubscriptionPurchaseV2, error)
+
+// watchAfter is the poll timer, replaceable in tests.
+var watchAfter = time.After
+
+// watchSubscriptionState polls get every interval until subscriptionState
+// differs from the first observed state, logging each state to log, and
+// returns the subscription that carries the new state. It gives up after
+// timeout and stops early when ctx is cancelled.
+func watchSubscriptionState(ctx context.Context, get subscriptionV2Getter, interval, timeout time.Duration, log io.Writer) (*androidpublisher.SubscriptionPurchaseV2, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	sub, err := get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	initial := sub.SubscriptionState
+	fmt.Fprintf(log, "subscriptionState: %s\n", initial)
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, fmt.Errorf("timed out after %s waiting for subscriptionState to change from %s", timeout, initial)
+			}
+			return nil, ctx.Err()
+		case <-watchAfter(interval):
+		}
+
+		sub, err = get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if sub.SubscriptionState != initial {
+			fmt.Fprintf(log, "subscriptionState: %s -> %s\n", initial, sub.SubscriptionState)
+			return sub, nil
+		}
+	}
+}
+
+// productWatchGetter fetches the product purchase being watched.
+type productWatchGetter func(ctx context.Context) (*androidpublisher.ProductPurchase, error)
+
+var (
+	productPurchaseStates = map[int64]string{0: "Purchased", 1: "Canceled", 2: "Pending"}
+	productAckStates      = map[int64]string{0: "Not acknowledged", 1: "Acknowledged"}
+)
+
+// productPurchaseState describes p's purchase and acknowledgement state.
+func productPurchaseState(p *androidpublisher.ProductPurchase) string {
+	purchase, ok := productPurchaseStates[p.PurchaseState]
+	if !ok {
+		purchase = fmt.Sprintf("%d", p.PurchaseState)
+	}
+	ack, ok := productAckStates[p.AcknowledgementState]
+	if !ok {
+		ack = fmt.Sprintf("%d", p.AcknowledgementState)
+	}
+	return purchase + ", " + ack
+}
+
+// productPurchaseFinal reports whether p can no longer change on its own:
+// purchased and acknowledged, or canceled.
+func productPurchaseFinal(p *androidpublisher.ProductPurchase) bool {
+	switch p.PurchaseState {
+	case 0:
+		return p.AcknowledgementState == 1
+	case 1:
+		return true
+	}
+	return false
+}
+
+// watchProductPurchase polls get every interval, logging each state change to
+// log, until the purchase reaches a final state, and returns it. It gives up
+// after timeout and stops early when ctx is cancelled.
+func watchProductPurchase(ctx context.Context, get productWatchGetter, interval, timeout time.Duration, log io.Writer) (*androidpublisher.ProductPurchase, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	purchase, err := get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	last := productPurchaseState(purchase)
+	fmt.Fprintf(log, "state: %s\n", last)
+
+	for !productPurchaseFinal(purchase) {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, fmt.Errorf("timed out after %s waiting for purchase to become final (last state: %s)", timeout, last)
+			}
+			return nil, ctx.Err()
+		case <-watchAfter(interval):
+		}
+
+		purchase, err = get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if state := productPurchaseState(purchase); state != last {
+			fmt.Fprintf(log, "state: %s -> %s\n", last, state)
+			last = state
+		}
+	}
+	return purchase, nil
+}
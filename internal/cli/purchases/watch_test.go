@@ -0,0 +1,185 @@
+package purchases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+// immediateWatchAfter makes every poll fire at once.
+func immediateWatchAfter(t *testing.T) {
+	t.Helper()
+	original := watchAfter
+	watchAfter = func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+	t.Cleanup(func() { watchAfter = original })
+}
+
+func statesGetter(states ...string) (subscriptionV2Getter, *int) {
+	calls := 0
+	return func(ctx context.Context) (*androidpublisher.SubscriptionPurchaseV2, error) {
+		state := states[min(calls, len(states)-1)]
+		calls++
+		return &androidpublisher.SubscriptionPurchaseV2{SubscriptionState: state}, nil
+	}, &calls
+}
+
+func TestWatchSubscriptionState_ExitsOnChange(t *testing.T) {
+	immediateWatchAfter(t)
+	get, calls := statesGetter("SUBSCRIPTION_STATE_PENDING", "SUBSCRIPTION_STATE_PENDING", "SUBSCRIPTION_STATE_ACTIVE", "SUBSCRIPTION_STATE_CANCELED")
+
+	var log bytes.Buffer
+	sub, err := watchSubscriptionState(context.Background(), get, time.Second, time.Minute, &log)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.SubscriptionState != "SUBSCRIPTION_STATE_ACTIVE" {
+		t.Fatalf("expected ACTIVE, got %s", sub.SubscriptionState)
+	}
+	if *calls != 3 {
+		t.Fatalf("expected 3 polls, got %d", *calls)
+	}
+	want := "subscriptionState: SUBSCRIPTION_STATE_PENDING\nsubscriptionState: SUBSCRIPTION_STATE_PENDING -> SUBSCRIPTION_STATE_ACTIVE\n"
+	if log.String() != want {
+		t.Fatalf("log = %q, want %q", log.String(), want)
+	}
+}
+
+func TestWatchSubscriptionState_TimesOut(t *testing.T) {
+	get, _ := statesGetter("SUBSCRIPTION_STATE_PENDING")
+	_, err := watchSubscriptionState(context.Background(), get, time.Millisecond, 20*time.Millisecond, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
+
+func TestWatchSubscriptionState_RespectsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	get, _ := statesGetter("SUBSCRIPTION_STATE_PENDING")
+	cancel()
+	_, err := watchSubscriptionState(ctx, get, time.Hour, time.Hour, &bytes.Buffer{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSubscriptionsGetCommand_ValidatesWatchFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"--token", "tok", "--watch", "--interval", "0s"},
+		{"--token", "tok", "--watch", "--watch-timeout", "0s"},
+	} {
+		cmd := SubscriptionsGetCommand()
+		if err := cmd.FlagSet.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		err := cmd.Exec(context.Background(), nil)
+		if err == nil || !strings.Contains(err.Error(), "must be greater than 0") {
+			t.Errorf("args %v: expected validation error, got %v", args, err)
+		}
+	}
+}
+
+func TestWatchProductPurchase_PollsUntilFinal(t *testing.T) {
+	immediateWatchAfter(t)
+	states := []androidpublisher.ProductPurchase{
+		{PurchaseState: 2},
+		{PurchaseState: 2},
+		{PurchaseState: 0},
+		{PurchaseState: 0, AcknowledgementState: 1},
+	}
+	calls := 0
+	get := func(ctx context.Context) (*androidpublisher.ProductPurchase, error) {
+		p := states[min(calls, len(states)-1)]
+		calls++
+		return &p, nil
+	}
+
+	var log bytes.Buffer
+	purchase, err := watchProductPurchase(context.Background(), get, time.Second, time.Minute, &log)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if purchase.AcknowledgementState != 1 {
+		t.Fatalf("expected acknowledged purchase, got %+v", purchase)
+	}
+	if calls != 4 {
+		t.Fatalf("expected 4 polls, got %d", calls)
+	}
+	want := "state: Pending, Not acknowledged\n" +
+		"state: Pending, Not acknowledged -> Purchased, Not acknowledged\n" +
+		"state: Purchased, Not acknowledged -> Purchased, Acknowledged\n"
+	if log.String() != want {
+		t.Fatalf("log = %q, want %q", log.String(), want)
+	}
+}
+
+func TestWatchProductPurchase_CanceledIsFinal(t *testing.T) {
+	calls := 0
+	get := func(ctx context.Context) (*androidpublisher.ProductPurchase, error) {
+		calls++
+		return &androidpublisher.ProductPurchase{PurchaseState: 1}, nil
+	}
+	if _, err := watchProductPurchase(context.Background(), get, time.Hour, time.Hour, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single poll, got %d", calls)
+	}
+}
+
+func TestWatchProductPurchase_TimesOut(t *testing.T) {
+	get := func(ctx context.Context) (*androidpublisher.ProductPurchase, error) {
+		return &androidpublisher.ProductPurchase{PurchaseState: 2}, nil
+	}
+	_, err := watchProductPurchase(context.Background(), get, time.Millisecond, 20*time.Millisecond, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "Pending") {
+		t.Fatalf("expected timeout error naming the last state, got %v", err)
+	}
+}
+
+func TestProductsGetCommand_WatchPrintsFinalPurchase(t *testing.T) {
+	immediateWatchAfter(t)
+	calls := 0
+	installMockPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		ack := 0
+		if calls > 1 {
+			ack = 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, fmt.Sprintf(`{"orderId":"GPA.1","purchaseState":0,"acknowledgementState":%d}`, ack))
+	})
+
+	cmd := ProductsGetCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--product-id", "coins_100",
+		"--token", "tok",
+		"--watch",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := capturePurchasesStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 polls, got %d", calls)
+	}
+	if !strings.Contains(stdout, `"acknowledgementState":1`) {
+		t.Fatalf("expected final purchase in output, got %s", stdout)
+	}
+}
@@ -56,7 +56,7 @@ func statusCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("quota status", flag.ExitOnError)
 	days := fs.Int("days", defaultDays, "Window in days to include in daily totals")
 	top := fs.Int("top", defaultTop, "Show this many top commands by call count")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -47,7 +47,7 @@ func ListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("recovery list", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	versionCode := fs.Int64("version-code", 0, "Version code (optional, filters by version)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -88,8 +88,8 @@ func ListCommand() *ffcli.Command {
 func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("recovery create", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "CreateDraftAppRecoveryRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "CreateDraftAppRecoveryRequest JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -157,7 +157,7 @@ func DeployCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	recoveryID := fs.Int64("recovery-id", 0, "Recovery action ID")
 	confirm := fs.Bool("confirm", false, "Confirm deployment")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -202,7 +202,7 @@ func CancelCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("recovery cancel", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	recoveryID := fs.Int64("recovery-id", 0, "Recovery action ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -244,8 +244,8 @@ func AddTargetingCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("recovery add-targeting", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	recoveryID := fs.Int64("recovery-id", 0, "Recovery action ID")
-	jsonFlag := fs.String("json", "", "AddTargetingRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "AddTargetingRequest JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
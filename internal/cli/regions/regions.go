@@ -0,0 +1,172 @@
+package regions
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/monetizationpricing"
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+var newPlayService = playclient.NewService
+
+func RegionsCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("regions", flag.ExitOnError)
+	return &ffcli.Command{
+		Name:       "regions",
+		ShortUsage: "gplay regions <subcommand> [flags]",
+		ShortHelp:  "List billable countries/regions and convert prices across them.",
+		LongHelp: `List the countries and regions Google Play can bill in, and convert a
+base price into each region's local currency.
+
+Both subcommands call Google's price conversion API, which also returns the
+current regions version to use with --regions-version.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			ListCommand(),
+			ConvertCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+func ListCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("regions list", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	price := fs.String("price", "USD:1.00", "Reference price as CURRENCY:AMOUNT")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "list",
+		ShortUsage: "gplay regions list --package <name> [--price USD:1.00]",
+		ShortHelp:  "List billable regions and their currencies.",
+		LongHelp: `List the regions available for targeting and pricing, with each region's
+billing currency and the current regions version.
+
+Examples:
+  gplay regions list --package com.example.app
+  gplay regions list --package com.example.app --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			base, err := monetizationpricing.ParsePriceString(*price)
+			if err != nil {
+				return fmt.Errorf("invalid --price: %w", err)
+			}
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			resp, err := monetizationpricing.ConvertRegionPrices(ctx, service, pkg, base, "")
+			if err != nil {
+				return err
+			}
+			summary, err := monetizationpricing.Summary(resp)
+			if err != nil {
+				return err
+			}
+			return shared.PrintOutput(summary, *outputFlag, *pretty)
+		},
+	}
+}
+
+func ConvertCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("regions convert", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	price := fs.String("price", "", "Base price as CURRENCY:AMOUNT (e.g. USD:9.99)")
+	productTaxCategoryCode := fs.String("product-tax-category-code", "", "Product tax category code")
+	jsonOut := fs.String("json-out", "", "Also write the regional price map to this file")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "convert",
+		ShortUsage: "gplay regions convert --package <name> --price <CUR:amount> [--json-out <file>]",
+		ShortHelp:  "Convert a base price into every region's local price.",
+		LongHelp: `Convert a base price into Google Play's local price for every billable
+region, rounded to Play's pricing tiers.
+
+The result maps region codes to their currency and price:
+{
+  "regionVersion": "2025/03",
+  "basePrice": "USD:9.99",
+  "prices": {
+    "DE": {"currencyCode": "EUR", "price": "9.99"},
+    "JP": {"currencyCode": "JPY", "price": "1500.00"}
+  }
+}
+
+For the raw API request/response, use gplay pricing convert.
+
+Examples:
+  gplay regions convert --package com.example.app --price USD:9.99
+  gplay regions convert --package com.example.app --price EUR:4.99 --json-out prices.json`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*price) == "" {
+				return fmt.Errorf("--price is required")
+			}
+			base, err := monetizationpricing.ParsePriceString(*price)
+			if err != nil {
+				return fmt.Errorf("invalid --price: %w", err)
+			}
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			resp, err := monetizationpricing.ConvertRegionPrices(ctx, service, pkg, base, *productTaxCategoryCode)
+			if err != nil {
+				return err
+			}
+			prices, err := monetizationpricing.RegionPrices(base, resp)
+			if err != nil {
+				return err
+			}
+			if path := strings.TrimSpace(*jsonOut); path != "" {
+				data, err := json.MarshalIndent(prices, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := shared.AtomicWrite(path, append(data, '\n'), 0o644); err != nil {
+					return fmt.Errorf("write --json-out: %w", err)
+				}
+				fmt.Fprintf(os.Stderr, "Wrote %d regional prices to %s\n", len(prices.Prices), path)
+			}
+			return shared.PrintOutput(prices, *outputFlag, *pretty)
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package regions
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+func installMockRegionsPlayService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() {
+		newPlayService = original
+	})
+}
+
+func TestRegionsCommand_SubcommandNames(t *testing.T) {
+	cmd := RegionsCommand()
+	expected := map[string]bool{"list": false, "convert": false}
+	for _, sub := range cmd.Subcommands {
+		if _, ok := expected[sub.Name]; !ok {
+			t.Errorf("unexpected subcommand: %s", sub.Name)
+		}
+		expected[sub.Name] = true
+		if sub.UsageFunc == nil {
+			t.Errorf("subcommand %q missing UsageFunc", sub.Name)
+		}
+	}
+	for name, found := range expected {
+		if !found {
+			t.Errorf("missing subcommand: %s", name)
+		}
+	}
+}
+
+func TestRegionsCommand_NoArgs_ReturnsHelp(t *testing.T) {
+	if err := RegionsCommand().Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp, got %v", err)
+	}
+}
+
+func TestConvertCommand_MissingPrice(t *testing.T) {
+	cmd := ConvertCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--price is required") {
+		t.Fatalf("expected --price is required, got %v", err)
+	}
+}
+
+func TestConvertCommand_InvalidPrice(t *testing.T) {
+	cmd := ConvertCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--price", "9.99"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid --price") {
+		t.Fatalf("expected invalid --price, got %v", err)
+	}
+}
+
+func TestListCommand_InvalidOutputFormat(t *testing.T) {
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--output", "xml"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err == nil {
+		t.Fatal("expected error for invalid output format")
+	}
+}
+
+func TestConvertCommand_WritesRegionalPriceMap(t *testing.T) {
+	var gotBody string
+	installMockRegionsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{
+  "regionVersion": {"version": "2026/05"},
+  "convertedRegionPrices": {
+    "DE": {"regionCode": "DE", "price": {"currencyCode": "EUR", "units": "9", "nanos": 490000000}},
+    "US": {"regionCode": "US", "price": {"currencyCode": "USD", "units": "9", "nanos": 990000000}}
+  }
+}`)
+	})
+
+	out := filepath.Join(t.TempDir(), "prices.json")
+	cmd := ConvertCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--price", "USD:9.99", "--json-out", out}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(gotBody, `"currencyCode":"USD"`) || !strings.Contains(gotBody, `"nanos":990000000`) {
+		t.Errorf("unexpected request body: %s", gotBody)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"regionVersion": "2026/05"`, `"DE": {`, `"price": "9.49"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in --json-out file, got:\n%s", want, data)
+		}
+	}
+}
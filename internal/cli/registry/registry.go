@@ -1,9 +1,6 @@
 package registry
 
 import (
-	"context"
-	"fmt"
-
 	"github.com/peterbourgon/ff/v3/ffcli"
 
 	"github.com/tamtom/play-console-cli/internal/cli/apks"
@@ -13,9 +10,12 @@ import (
 	"github.com/tamtom/play-console-cli/internal/cli/availability"
 	"github.com/tamtom/play-console-cli/internal/cli/baseplans"
 	"github.com/tamtom/play-console-cli/internal/cli/bundles"
+	"github.com/tamtom/play-console-cli/internal/cli/cachecmd"
 	"github.com/tamtom/play-console-cli/internal/cli/completion"
+	"github.com/tamtom/play-console-cli/internal/cli/configcmd"
 	"github.com/tamtom/play-console-cli/internal/cli/datasafety"
 	"github.com/tamtom/play-console-cli/internal/cli/deobfuscation"
+	"github.com/tamtom/play-console-cli/internal/cli/deploy"
 	"github.com/tamtom/play-console-cli/internal/cli/details"
 	"github.com/tamtom/play-console-cli/internal/cli/devicetiers"
 	"github.com/tamtom/play-console-cli/internal/cli/docs"
@@ -45,6 +45,7 @@ import (
 	"github.com/tamtom/play-console-cli/internal/cli/purchases"
 	"github.com/tamtom/play-console-cli/internal/cli/quota"
 	"github.com/tamtom/play-console-cli/internal/cli/recovery"
+	"github.com/tamtom/play-console-cli/internal/cli/regions"
 	"github.com/tamtom/play-console-cli/internal/cli/release"
 	releasenotes "github.com/tamtom/play-console-cli/internal/cli/releasenotes"
 	"github.com/tamtom/play-console-cli/internal/cli/reports"
@@ -52,7 +53,6 @@ import (
 	"github.com/tamtom/play-console-cli/internal/cli/rollout"
 	rtdncmd "github.com/tamtom/play-console-cli/internal/cli/rtdn"
 	cliruntime "github.com/tamtom/play-console-cli/internal/cli/runtime"
-	"github.com/tamtom/play-console-cli/internal/cli/shared"
 	"github.com/tamtom/play-console-cli/internal/cli/snitch"
 	"github.com/tamtom/play-console-cli/internal/cli/status"
 	"github.com/tamtom/play-console-cli/internal/cli/subscriptions"
@@ -68,20 +68,6 @@ import (
 	"github.com/tamtom/play-console-cli/internal/cli/workflow"
 )
 
-// VersionCommand returns a version subcommand.
-func VersionCommand(version string) *ffcli.Command {
-	return &ffcli.Command{
-		Name:       "version",
-		ShortUsage: "gplay version",
-		ShortHelp:  "Print version information and exit.",
-		UsageFunc:  shared.DefaultUsageFunc,
-		Exec: func(ctx context.Context, args []string) error {
-			fmt.Println(version)
-			return nil
-		},
-	}
-}
-
 // Subcommands returns all root subcommands in display order.
 func Subcommands(version string) []*ffcli.Command {
 	return SubcommandsWithRuntime(version, nil)
@@ -92,6 +78,7 @@ func Subcommands(version string) []*ffcli.Command {
 func SubcommandsWithRuntime(version string, rt *cliruntime.Runtime) []*ffcli.Command {
 	return []*ffcli.Command{
 		auth.AuthCommand(),
+		configcmd.ConfigCommand(),
 		apps.AppsCommand(rt),
 		auditcmd.AuditCommand(),
 		quota.QuotaCommand(),
@@ -113,6 +100,7 @@ func SubcommandsWithRuntime(version string, rt *cliruntime.Runtime) []*ffcli.Com
 		availability.AvailabilityCommand(),
 		deobfuscation.DeobfuscationCommand(),
 		release.ReleaseCommand(),
+		deploy.DeployCommand(),
 		publish.PublishCommand(),
 		promote.PromoteCommand(),
 		rollout.RolloutCommand(),
@@ -128,6 +116,7 @@ func SubcommandsWithRuntime(version string, rt *cliruntime.Runtime) []*ffcli.Com
 		purchaseoptions.PurchaseOptionsCommand(),
 		otpoffers.OTPOffersCommand(),
 		pricing.PricingCommand(),
+		regions.RegionsCommand(),
 		orders.OrdersCommand(),
 		purchases.PurchasesCommand(),
 		externaltx.ExternalTxCommand(),
@@ -144,11 +133,13 @@ func SubcommandsWithRuntime(version string, rt *cliruntime.Runtime) []*ffcli.Com
 		migrate.MigrateCommand(),
 		releasenotes.ReleaseNotesCommand(),
 		reports.ReportsCommand(),
+		cachecmd.CacheCommand(),
 		workflow.WorkflowCommand(),
 		docs.DocsCommand(),
 		web.WebCommand(),
 		updatecmd.UpdateCommand(),
-		completion.CompletionCommand(),
+		updatecmd.SelfUpdateCommand(),
+		completion.CompletionCommand(func() []*ffcli.Command { return SubcommandsWithRuntime(version, rt) }),
 		VersionCommand(version),
 	}
 }
@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+// readBuildInfo is swapped out by tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// VersionInfo is the build metadata printed by gplay version.
+type VersionInfo struct {
+	Version      string `json:"version"`
+	GoVersion    string `json:"goVersion"`
+	OS           string `json:"os"`
+	Arch         string `json:"arch"`
+	Module       string `json:"module,omitempty"`
+	Revision     string `json:"vcsRevision,omitempty"`
+	RevisionTime string `json:"vcsTime,omitempty"`
+	Modified     bool   `json:"vcsModified,omitempty"`
+}
+
+// VersionCommand returns a version subcommand.
+func VersionCommand(version string) *ffcli.Command {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+	outputFlag := fs.String("output", "text", "Output format: text (default), json")
+	shared.DeclareOutputFormats(fs, "text", "json")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "version",
+		ShortUsage: "gplay version [--output json]",
+		ShortHelp:  "Print version information and exit.",
+		LongHelp: `Print the gplay version with the Go version, OS/arch, and the VCS
+revision the binary was built from. Include it in bug reports.
+
+Examples:
+  gplay version
+  gplay version --output json --pretty`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			normalized := strings.ToLower(strings.TrimSpace(*outputFlag))
+			if normalized != "text" && normalized != "json" {
+				return fmt.Errorf("unsupported format: %s", *outputFlag)
+			}
+			if normalized != "json" && *pretty {
+				return fmt.Errorf("--pretty is only valid with JSON output")
+			}
+
+			info := buildVersionInfo(version)
+			if normalized == "json" {
+				if *pretty {
+					return output.PrintPrettyJSON(info)
+				}
+				return output.PrintJSON(info)
+			}
+			printVersionInfo(info)
+			return nil
+		},
+	}
+}
+
+func buildVersionInfo(version string) VersionInfo {
+	info := VersionInfo{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	build, ok := readBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Module = build.Main.Path
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.RevisionTime = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	return info
+}
+
+func printVersionInfo(info VersionInfo) {
+	fmt.Println(info.Version)
+	fmt.Printf("go: %s\n", info.GoVersion)
+	fmt.Printf("platform: %s/%s\n", info.OS, info.Arch)
+	if info.Revision != "" {
+		revision := info.Revision
+		if info.Modified {
+			revision += " (modified)"
+		}
+		if info.RevisionTime != "" {
+			revision += ", " + info.RevisionTime
+		}
+		fmt.Printf("revision: %s\n", revision)
+	}
+}
@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"runtime/debug"
+	"testing"
+)
+
+func TestSubcommands_IncludesVersion(t *testing.T) {
+	for _, cmd := range Subcommands("1.2.3") {
+		if cmd.Name == "version" {
+			return
+		}
+	}
+	t.Fatal("expected a version subcommand in the registry")
+}
+
+func TestVersionCommand_JSON(t *testing.T) {
+	original := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Path: "github.com/tamtom/play-console-cli"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "abc123"},
+				{Key: "vcs.time", Value: "2026-01-02T03:04:05Z"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+	t.Cleanup(func() { readBuildInfo = original })
+
+	cmd := VersionCommand("1.2.3 (commit: abc123, date: 2026-01-02)")
+	if err := cmd.FlagSet.Parse([]string{"--output", "json"}); err != nil {
+		t.Fatal(err)
+	}
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := cmd.Exec(context.Background(), nil)
+	w.Close()
+	os.Stdout = old
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if got["version"] != "1.2.3 (commit: abc123, date: 2026-01-02)" {
+		t.Errorf("version = %v", got["version"])
+	}
+	for _, key := range []string{"goVersion", "os", "arch"} {
+		if s, _ := got[key].(string); s == "" {
+			t.Errorf("expected %s in %s", key, out)
+		}
+	}
+	if got["vcsRevision"] != "abc123" || got["vcsModified"] != true {
+		t.Errorf("expected VCS info in %s", out)
+	}
+}
+
+func TestVersionCommand_InvalidOutput(t *testing.T) {
+	cmd := VersionCommand("1.2.3")
+	if err := cmd.FlagSet.Parse([]string{"--output", "yaml"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
@@ -25,7 +25,7 @@ func ReleaseCommand() *ffcli.Command {
 	wait := fs.Bool("wait", false, "Wait for processing to complete")
 	pollInterval := fs.Duration("poll-interval", 10*time.Second, "Polling interval when waiting")
 	changesNotSent := fs.Bool("changes-not-sent-for-review", false, "Changes not sent for review")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	// Metadata and screenshots flags
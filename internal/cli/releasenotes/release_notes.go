@@ -38,7 +38,7 @@ func GenerateCommand() *ffcli.Command {
 	sinceRef := fs.String("since-ref", "", "Start from this git ref (exclusive, alternative to --since-tag)")
 	untilRef := fs.String("until-ref", "HEAD", "End at this ref (inclusive, default: HEAD)")
 	maxChars := fs.Int("max-chars", 500, "Maximum character count (Google Play limit: 500)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 
 	return &ffcli.Command{
 		Name:       "generate",
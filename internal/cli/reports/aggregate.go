@@ -0,0 +1,203 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+const aggregateFlagUsage = "Also merge the downloaded monthly CSVs of each report into <type>_<package>_<from>_<to>[_<dimension>].csv in --dir, keeping one header"
+
+// monthlyCSVPattern splits a stats report name such as
+// installs_com.example.app_202501_country.csv into its prefix, month, and
+// optional dimension.
+var monthlyCSVPattern = regexp.MustCompile(`^(.+)_(\d{6})(?:_(.+))?\.csv$`)
+
+// csvEncoding is the text encoding of a report, detected from its byte order
+// mark. Play writes stats reports as UTF-16LE with a BOM.
+type csvEncoding int
+
+const (
+	encodingUTF8 csvEncoding = iota
+	encodingUTF8BOM
+	encodingUTF16LE
+	encodingUTF16BE
+)
+
+// AggregatedFile describes one merged CSV written by --aggregate.
+type AggregatedFile struct {
+	Path  string   `json:"path"`
+	Files []string `json:"files"`
+	Rows  int      `json:"rows"`
+}
+
+// aggregateMonthlyCSVs merges the monthly CSVs among paths that share a
+// report prefix and dimension, in month order, into one file per group in
+// dir. Files that are not monthly CSVs are ignored. The merged file keeps
+// the first file's header and encoding; a file whose header differs is an
+// error.
+func aggregateMonthlyCSVs(paths []string, dir string) ([]AggregatedFile, error) {
+	type monthlyFile struct {
+		path, month string
+	}
+	type group struct {
+		prefix, dimension string
+		files             []monthlyFile
+	}
+	groups := map[string]*group{}
+	var keys []string
+	for _, path := range paths {
+		m := monthlyCSVPattern.FindStringSubmatch(filepath.Base(path))
+		if m == nil {
+			continue
+		}
+		key := m[1] + "\x00" + m[3]
+		g, ok := groups[key]
+		if !ok {
+			g = &group{prefix: m[1], dimension: m[3]}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.files = append(g.files, monthlyFile{path: path, month: m[2]})
+	}
+	sort.Strings(keys)
+
+	aggregated := []AggregatedFile{}
+	for _, key := range keys {
+		g := groups[key]
+		sort.Slice(g.files, func(i, j int) bool { return g.files[i].month < g.files[j].month })
+		name := fmt.Sprintf("%s_%s_%s", g.prefix, g.files[0].month, g.files[len(g.files)-1].month)
+		if g.dimension != "" {
+			name += "_" + g.dimension
+		}
+		name += ".csv"
+
+		var (
+			enc    csvEncoding
+			header string
+			body   strings.Builder
+			rows   int
+			files  []string
+		)
+		for i, f := range g.files {
+			raw, err := os.ReadFile(f.path)
+			if err != nil {
+				return nil, err
+			}
+			fileEnc, text, err := decodeCSV(raw)
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", filepath.Base(f.path), err)
+			}
+			fileHeader, data := splitCSVHeader(text)
+			if i == 0 {
+				enc, header = fileEnc, fileHeader
+			} else if strings.TrimRight(fileHeader, "\r\n") != strings.TrimRight(header, "\r\n") {
+				return nil, fmt.Errorf("cannot aggregate %s: its header differs from %s", filepath.Base(f.path), filepath.Base(g.files[0].path))
+			}
+			if data != "" && !strings.HasSuffix(data, "\n") {
+				data += lineEnding(header)
+			}
+			body.WriteString(data)
+			rows += countLines(data)
+			files = append(files, filepath.Base(f.path))
+		}
+		if header != "" && !strings.HasSuffix(header, "\n") {
+			header += "\n"
+		}
+
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, encodeCSV(enc, header+body.String()), 0o644); err != nil {
+			return nil, fmt.Errorf("write %s: %w", name, err)
+		}
+		aggregated = append(aggregated, AggregatedFile{Path: path, Files: files, Rows: rows})
+	}
+	return aggregated, nil
+}
+
+// decodeCSV detects the encoding of raw from its byte order mark and
+// returns the text without the BOM.
+func decodeCSV(raw []byte) (csvEncoding, string, error) {
+	switch {
+	case bytes.HasPrefix(raw, []byte{0xFF, 0xFE}):
+		text, err := decodeUTF16(raw[2:], false)
+		return encodingUTF16LE, text, err
+	case bytes.HasPrefix(raw, []byte{0xFE, 0xFF}):
+		text, err := decodeUTF16(raw[2:], true)
+		return encodingUTF16BE, text, err
+	case bytes.HasPrefix(raw, []byte{0xEF, 0xBB, 0xBF}):
+		return encodingUTF8BOM, string(raw[3:]), nil
+	default:
+		return encodingUTF8, string(raw), nil
+	}
+}
+
+func decodeUTF16(raw []byte, bigEndian bool) (string, error) {
+	if len(raw)%2 != 0 {
+		return "", fmt.Errorf("UTF-16 content has an odd number of bytes")
+	}
+	units := make([]uint16, len(raw)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(raw[2*i])<<8 | uint16(raw[2*i+1])
+		} else {
+			units[i] = uint16(raw[2*i+1])<<8 | uint16(raw[2*i])
+		}
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// encodeCSV encodes text in enc, with the matching byte order mark.
+func encodeCSV(enc csvEncoding, text string) []byte {
+	switch enc {
+	case encodingUTF16LE, encodingUTF16BE:
+		units := utf16.Encode([]rune(text))
+		out := make([]byte, 0, 2+2*len(units))
+		if enc == encodingUTF16LE {
+			out = append(out, 0xFF, 0xFE)
+			for _, u := range units {
+				out = append(out, byte(u), byte(u>>8))
+			}
+		} else {
+			out = append(out, 0xFE, 0xFF)
+			for _, u := range units {
+				out = append(out, byte(u>>8), byte(u))
+			}
+		}
+		return out
+	case encodingUTF8BOM:
+		return append([]byte{0xEF, 0xBB, 0xBF}, text...)
+	default:
+		return []byte(text)
+	}
+}
+
+// splitCSVHeader returns the first line of text, with its line ending, and
+// the rest.
+func splitCSVHeader(text string) (header, data string) {
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		return text[:i+1], text[i+1:]
+	}
+	return text, ""
+}
+
+func lineEnding(header string) string {
+	if strings.HasSuffix(header, "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+func countLines(data string) int {
+	n := 0
+	for _, line := range strings.Split(data, "\n") {
+		if strings.TrimSpace(line) != "" {
+			n++
+		}
+	}
+	return n
+}
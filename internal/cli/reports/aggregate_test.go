@@ -0,0 +1,142 @@
+package reports
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+)
+
+// utf16LE encodes text as Play writes stats reports: UTF-16LE with a BOM.
+func utf16LE(text string) string {
+	out := []byte{0xFF, 0xFE}
+	for _, u := range utf16.Encode([]rune(text)) {
+		out = append(out, byte(u), byte(u>>8))
+	}
+	return string(out)
+}
+
+func TestStatsDownload_AggregateMergesMonths(t *testing.T) {
+	dir := t.TempDir()
+	header := "Date,Package Name,Daily Device Installs\r\n"
+	objects := map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_77/stats/installs/": {
+			{Name: "stats/installs/installs_com.example.app_202502_overview.csv"},
+			{Name: "stats/installs/installs_com.example.app_202501_overview.csv"},
+		},
+	}
+	fileContents := map[string]string{
+		"stats/installs/installs_com.example.app_202501_overview.csv": utf16LE(header + "2025-01-01,com.example.app,10\r\n2025-01-02,com.example.app,12\r\n"),
+		"stats/installs/installs_com.example.app_202502_overview.csv": utf16LE(header + "2025-02-01,com.example.app,20\r\n"),
+	}
+	setupMockGCS(t, objects, fileContents)
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := execCommand(t, []string{
+		"stats", "download",
+		"--bucket-id", "77",
+		"--package", "com.example.app",
+		"--from", "2025-01",
+		"--to", "2025-02",
+		"--type", "installs",
+		"--dir", dir,
+		"--aggregate",
+	})
+
+	w.Close()
+	os.Stdout = old
+	out, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	merged, err := os.ReadFile(filepath.Join(dir, "installs_com.example.app_202501_202502_overview.csv"))
+	if err != nil {
+		t.Fatalf("expected merged file: %v", err)
+	}
+	want := utf16LE(header + "2025-01-01,com.example.app,10\r\n2025-01-02,com.example.app,12\r\n2025-02-01,com.example.app,20\r\n")
+	if string(merged) != want {
+		_, got, _ := decodeCSV(merged)
+		t.Fatalf("unexpected merged content:\n%q", got)
+	}
+
+	for _, name := range []string{"installs_com.example.app_202501_overview.csv", "installs_com.example.app_202502_overview.csv"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected monthly file %s to be kept: %v", name, err)
+		}
+	}
+
+	var result struct {
+		Aggregated []AggregatedFile `json:"aggregated"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("failed to parse output JSON: %v", err)
+	}
+	if len(result.Aggregated) != 1 || result.Aggregated[0].Rows != 3 || len(result.Aggregated[0].Files) != 2 {
+		t.Fatalf("unexpected aggregated entries: %+v", result.Aggregated)
+	}
+	if result.Aggregated[0].Files[0] != "installs_com.example.app_202501_overview.csv" {
+		t.Errorf("expected months in order, got %v", result.Aggregated[0].Files)
+	}
+}
+
+func TestAggregateMonthlyCSVs_GroupsByDimension(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"ratings_com.example.app_202501_country.csv":  "Date,Country,Rating\n2025-01-01,US,4.5\n",
+		"ratings_com.example.app_202502_country.csv":  "Date,Country,Rating\n2025-02-01,US,4.6\n",
+		"ratings_com.example.app_202501_overview.csv": "\xEF\xBB\xBFDate,Rating\n2025-01-01,4.5",
+		"ratings_com.example.app_202502_overview.csv": "\xEF\xBB\xBFDate,Rating\n2025-02-01,4.4",
+		"notes.txt": "ignored",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	aggregated, err := aggregateMonthlyCSVs(paths, dir)
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if len(aggregated) != 2 {
+		t.Fatalf("expected 2 merged files, got %+v", aggregated)
+	}
+
+	country, _ := os.ReadFile(filepath.Join(dir, "ratings_com.example.app_202501_202502_country.csv"))
+	if string(country) != "Date,Country,Rating\n2025-01-01,US,4.5\n2025-02-01,US,4.6\n" {
+		t.Errorf("unexpected country merge: %q", country)
+	}
+	overview, _ := os.ReadFile(filepath.Join(dir, "ratings_com.example.app_202501_202502_overview.csv"))
+	if string(overview) != "\xEF\xBB\xBFDate,Rating\n2025-01-01,4.5\n2025-02-01,4.4\n" {
+		t.Errorf("unexpected overview merge: %q", overview)
+	}
+}
+
+func TestAggregateMonthlyCSVs_HeaderMismatch(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "crashes_com.example.app_202501_overview.csv")
+	second := filepath.Join(dir, "crashes_com.example.app_202502_overview.csv")
+	if err := os.WriteFile(first, []byte("Date,Crashes\n2025-01-01,1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte("Date,Crashes,ANRs\n2025-02-01,1,0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := aggregateMonthlyCSVs([]string{first, second}, dir)
+	if err == nil || !strings.Contains(err.Error(), "header differs") {
+		t.Fatalf("expected header mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package reports
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+// signedURLClient fetches signed URLs. It carries no credentials: the
+// signature in the URL is the authorization.
+var signedURLClient = http.DefaultClient
+
+// DownloadURLCommand downloads a report from a pre-signed URL.
+func DownloadURLCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("reports download-url", flag.ExitOnError)
+	rawURL := fs.String("url", "", "Signed URL of the report (required)")
+	dir := fs.String("dir", ".", "Output directory")
+	name := fs.String("name", "", "Output file name (default: last path segment of the URL)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "download-url",
+		ShortUsage: "gplay reports download-url --url <signed-url> [--dir <path>] [--name <file>]",
+		ShortHelp:  "Download a report from a signed URL without GCS auth.",
+		LongHelp: `Download a report from a signed Cloud Storage URL.
+
+Use this when your service account lacks the devstorage.read_only scope but
+someone can generate a signed URL for the report out-of-band (for example
+with gsutil signurl). No gplay credentials are sent with the request.
+
+The signature query string is never printed.
+
+Examples:
+  gplay reports download-url --url "https://storage.googleapis.com/pubsite_prod_rev_123/earnings/earnings_202601.zip?X-Goog-Signature=..."
+  gplay reports download-url --url "$SIGNED_URL" --dir reports --name earnings.zip`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*rawURL) == "" {
+				return fmt.Errorf("--url is required")
+			}
+			u, err := url.Parse(strings.TrimSpace(*rawURL))
+			if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				return fmt.Errorf("--url must be an http(s) URL")
+			}
+			fileName := strings.TrimSpace(*name)
+			if fileName == "" {
+				fileName = path.Base(u.Path)
+				if fileName == "." || fileName == "/" {
+					return fmt.Errorf("cannot derive a file name from --url; pass --name")
+				}
+			}
+			if filepath.Base(fileName) != fileName {
+				return fmt.Errorf("--name must be a file name, not a path")
+			}
+
+			cfg, _ := config.Load()
+			ctx, cancel := shared.ContextWithUploadTimeout(ctx, cfg)
+			defer cancel()
+
+			localPath := filepath.Join(*dir, fileName)
+			size, err := downloadSignedURL(ctx, u.String(), localPath)
+			if err != nil {
+				return fmt.Errorf("failed to download %s: %w", redactURL(u), err)
+			}
+
+			result := map[string]interface{}{
+				"url":  redactURL(u),
+				"path": localPath,
+				"size": size,
+			}
+			return shared.PrintOutput(result, *outputFlag, *pretty)
+		},
+	}
+}
+
+// downloadSignedURL streams rawURL to localPath and returns the bytes written.
+func downloadSignedURL(ctx context.Context, rawURL, localPath string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := signedURLClient.Do(req)
+	if err != nil {
+		// The error text embeds the full URL, signature included.
+		if uerr, ok := err.(*url.Error); ok {
+			return 0, uerr.Err
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		hint := ""
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusBadRequest {
+			hint = " (the signed URL may have expired)"
+		}
+		return 0, fmt.Errorf("server returned %s%s", resp.Status, hint)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return 0, fmt.Errorf("create directory: %w", err)
+	}
+	// Write to a temp file next to localPath and rename it into place, so a
+	// failed download never leaves a partial report behind.
+	f, err := os.CreateTemp(filepath.Dir(localPath), ".gplay-download-*")
+	if err != nil {
+		return 0, fmt.Errorf("create file: %w", err)
+	}
+	tmpPath := f.Name()
+	n, err := io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return n, fmt.Errorf("write file: %w", err)
+	}
+	if err := os.Rename(tmpPath, localPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return n, fmt.Errorf("write file: %w", err)
+	}
+	return n, nil
+}
+
+// redactURL drops the query string, which carries the signature.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = ""
+	redacted.Fragment = ""
+	return redacted.String()
+}
@@ -0,0 +1,98 @@
+package reports
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDownloadURL_MissingURL(t *testing.T) {
+	err := execCommand(t, []string{"download-url"})
+	if err == nil || !strings.Contains(err.Error(), "--url is required") {
+		t.Fatalf("expected --url is required, got %v", err)
+	}
+}
+
+func TestDownloadURL_RejectsNonHTTPURL(t *testing.T) {
+	err := execCommand(t, []string{"download-url", "--url", "gs://bucket/earnings.zip"})
+	if err == nil || !strings.Contains(err.Error(), "http(s)") {
+		t.Fatalf("expected http(s) URL error, got %v", err)
+	}
+}
+
+func TestDownloadURL_RejectsPathInName(t *testing.T) {
+	err := execCommand(t, []string{"download-url", "--url", "https://example.com/a.zip", "--name", "../a.zip"})
+	if err == nil || !strings.Contains(err.Error(), "--name") {
+		t.Fatalf("expected --name error, got %v", err)
+	}
+}
+
+func TestDownloadURL_DownloadsPlainURL(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if r.URL.Path != "/pubsite_prod_rev_123/earnings/earnings_202601.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("report-bytes"))
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	signed := srv.URL + "/pubsite_prod_rev_123/earnings/earnings_202601.zip?X-Goog-Signature=secret"
+	if err := execCommand(t, []string{"download-url", "--url", signed, "--dir", dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth != "" {
+		t.Errorf("expected no Authorization header, got %q", gotAuth)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "earnings_202601.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "report-bytes" {
+		t.Errorf("downloaded content = %q", data)
+	}
+}
+
+func TestDownloadURL_ExpiredSignatureRedactsURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(srv.Close)
+
+	err := execCommand(t, []string{"download-url", "--url", srv.URL + "/r.zip?X-Goog-Signature=secret", "--dir", t.TempDir()})
+	if err == nil {
+		t.Fatal("expected error for 403 response")
+	}
+	if !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected expiry hint, got %v", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error leaked the signature: %v", err)
+	}
+}
+
+func TestDownloadURL_FailedDownloadLeavesNoFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		_, _ = w.Write([]byte("partial"))
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	if err := execCommand(t, []string{"download-url", "--url", srv.URL + "/r.zip", "--dir", dir}); err == nil {
+		t.Fatal("expected error for truncated download")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no files after a failed download, found %v", entries)
+	}
+}
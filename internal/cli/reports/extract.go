@@ -0,0 +1,109 @@
+package reports
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const extractFlagUsage = "Extract downloaded .zip and .gz reports into --dir and list the extracted files"
+
+// extractReport unpacks a downloaded .zip or .gz report into dir and returns
+// the extracted file names relative to dir. Other files are left alone and
+// return no names. Entries are streamed to disk one at a time.
+func extractReport(archivePath, dir string) ([]string, error) {
+	switch strings.ToLower(filepath.Ext(archivePath)) {
+	case ".zip":
+		return extractZip(archivePath, dir)
+	case ".gz":
+		name, err := extractGzip(archivePath, dir)
+		if err != nil {
+			return nil, err
+		}
+		return []string{name}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func extractZip(archivePath, dir string) ([]string, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("open zip: %w", err)
+	}
+	defer zr.Close()
+
+	var names []string
+	for _, entry := range zr.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		if !entry.Mode().IsRegular() {
+			return nil, fmt.Errorf("zip entry %q is not a regular file", entry.Name)
+		}
+		name, err := safeEntryName(entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open zip entry %q: %w", entry.Name, err)
+		}
+		err = writeExtracted(filepath.Join(dir, name), rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, filepath.ToSlash(name))
+	}
+	return names, nil
+}
+
+func extractGzip(archivePath, dir string) (string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", fmt.Errorf("open gzip: %w", err)
+	}
+	defer gz.Close()
+
+	name := strings.TrimSuffix(filepath.Base(archivePath), filepath.Ext(archivePath))
+	if err := writeExtracted(filepath.Join(dir, name), gz); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// safeEntryName rejects archive entry names that would be written outside
+// the target directory (zip slip), such as "../x" or "/etc/x".
+func safeEntryName(name string) (string, error) {
+	cleaned := filepath.FromSlash(name)
+	if !filepath.IsLocal(cleaned) {
+		return "", fmt.Errorf("zip entry %q escapes the target directory", name)
+	}
+	return filepath.Clean(cleaned), nil
+}
+
+func writeExtracted(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("extract %s: %w", filepath.Base(path), err)
+	}
+	return f.Close()
+}
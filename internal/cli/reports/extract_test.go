@@ -0,0 +1,152 @@
+package reports
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+)
+
+func buildZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestFinancialDownload_ExtractWritesZipEntries(t *testing.T) {
+	dir := t.TempDir()
+	objects := map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_42/earnings/": {
+			{Name: "earnings/earnings_202401_42.zip", Size: 100},
+		},
+	}
+	fileContents := map[string]string{
+		"earnings/earnings_202401_42.zip": buildZip(t, map[string]string{
+			"PlayApps_202401.csv": "Description,Amount\nsale,1.99\n",
+		}),
+	}
+	setupMockGCS(t, objects, fileContents)
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := execCommand(t, []string{
+		"financial", "download",
+		"--bucket-id", "42",
+		"--from", "2024-01",
+		"--dir", dir,
+		"--extract",
+	})
+	w.Close()
+	os.Stdout = old
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "PlayApps_202401.csv"))
+	if err != nil {
+		t.Fatalf("expected extracted CSV: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "Description,Amount") {
+		t.Errorf("unexpected CSV content %q", content)
+	}
+
+	var result struct {
+		Files []struct {
+			Extracted []string `json:"extracted"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("failed to parse output JSON: %v", err)
+	}
+	if len(result.Files) != 1 || strings.Join(result.Files[0].Extracted, ",") != "PlayApps_202401.csv" {
+		t.Errorf("expected extracted [PlayApps_202401.csv], got %+v", result.Files)
+	}
+}
+
+func TestFinancialDownload_ExtractRejectsZipSlip(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "reports")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	objects := map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_42/earnings/": {
+			{Name: "earnings/earnings_202401_42.zip", Size: 100},
+		},
+	}
+	fileContents := map[string]string{
+		"earnings/earnings_202401_42.zip": buildZip(t, map[string]string{
+			"../evil.csv": "pwned",
+		}),
+	}
+	setupMockGCS(t, objects, fileContents)
+
+	err := execCommand(t, []string{
+		"financial", "download",
+		"--bucket-id", "42",
+		"--from", "2024-01",
+		"--dir", dir,
+		"--extract",
+	})
+	if err == nil || !strings.Contains(err.Error(), "escapes the target directory") {
+		t.Fatalf("expected zip slip error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "evil.csv")); !os.IsNotExist(err) {
+		t.Errorf("zip slip entry was written outside --dir")
+	}
+}
+
+func TestExtractReport_Gzip(t *testing.T) {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = io.WriteString(gz, "date,installs\n2024-01-01,5\n")
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(dir, "installs_202401.csv.gz")
+	if err := os.WriteFile(archive, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := extractReport(archive, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(names, ",") != "installs_202401.csv" {
+		t.Fatalf("names = %v, want [installs_202401.csv]", names)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "installs_202401.csv"))
+	if err != nil || !strings.HasPrefix(string(content), "date,installs") {
+		t.Errorf("unexpected extracted content %q (err %v)", content, err)
+	}
+}
+
+func TestExtractReport_LeavesOtherFiles(t *testing.T) {
+	names, err := extractReport(filepath.Join(t.TempDir(), "report.csv"), t.TempDir())
+	if err != nil || names != nil {
+		t.Fatalf("expected no extraction, got %v, %v", names, err)
+	}
+}
@@ -67,20 +67,9 @@ func validateReportType(value string) error {
 }
 
 // parseBucket resolves the --bucket-id flag into a GCS bucket name.
-// Accepts:
-//   - Full GCS URI: "gs://pubsite_prod_rev_12345/earnings/" → "pubsite_prod_rev_12345"
-//   - Full GCS URI: "gs://pubsite_prod_12345/" → "pubsite_prod_12345"
-//   - Plain numeric ID: "12345" → "pubsite_prod_rev_12345" (default prefix)
+// See gcsclient.ParseBucket for the accepted forms.
 func parseBucket(raw string) string {
-	raw = strings.TrimSpace(raw)
-	if strings.HasPrefix(raw, "gs://") {
-		raw = strings.TrimPrefix(raw, "gs://")
-		if idx := strings.Index(raw, "/"); idx >= 0 {
-			raw = raw[:idx]
-		}
-		return raw
-	}
-	return "pubsite_prod_rev_" + raw
+	return gcsclient.ParseBucket(raw)
 }
 
 // monthToCompact converts "2024-01" to "202401" for filename matching.
@@ -120,6 +109,7 @@ func FinancialCommand() *ffcli.Command {
 		Subcommands: []*ffcli.Command{
 			FinancialListCommand(),
 			FinancialDownloadCommand(),
+			FinancialSummaryCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -134,22 +124,32 @@ func FinancialListCommand() *ffcli.Command {
 	from := fs.String("from", "", "Start month in YYYY-MM format")
 	to := fs.String("to", "", "End month in YYYY-MM format")
 	reportType := fs.String("type", "all", "Report type: earnings, sales, payouts, play_balance, wht_statements, all")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	shared.BindOrderByFlag(fs)
+	cacheTTL := bindCacheTTLFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template, csv")
+	shared.DeclareOutputFormats(fs, "json", "table", "markdown", "yaml", "template", "csv")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
-		ShortUsage: "gplay reports financial list --bucket-id <id> [flags]",
+		ShortUsage: "gplay reports financial list --bucket-id <id> [--order-by <field>] [flags]",
 		ShortHelp:  "List available financial reports.",
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
+			csvOutput := isCSVOutput(*outputFlag)
+			if csvOutput && *pretty {
+				return fmt.Errorf("--pretty is only valid with JSON output")
+			}
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
 			if strings.TrimSpace(*bucketID) == "" {
 				return fmt.Errorf("--bucket-id is required")
 			}
+			if err := validateCacheTTL(*cacheTTL); err != nil {
+				return err
+			}
 			if *from != "" {
 				if err := validateMonth(*from, "from"); err != nil {
 					return err
@@ -174,7 +174,7 @@ func FinancialListCommand() *ffcli.Command {
 
 			var reports []gcsclient.ObjectInfo
 			for _, prefix := range prefixes {
-				objects, err := svc.ListObjects(ctx, bucket, prefix)
+				objects, err := listReportObjects(ctx, svc, bucket, prefix, *cacheTTL)
 				if err != nil {
 					return err
 				}
@@ -185,6 +185,12 @@ func FinancialListCommand() *ffcli.Command {
 				}
 			}
 
+			if csvOutput {
+				if err := shared.SortResult(reports, shared.OrderByFromEnv()); err != nil {
+					return err
+				}
+				return writeReportsCSV(os.Stdout, reports, financialPrefixes)
+			}
 			result := map[string]interface{}{
 				"bucket":  bucket,
 				"reports": reports,
@@ -202,15 +208,37 @@ func FinancialDownloadCommand() *ffcli.Command {
 	to := fs.String("to", "", "End month in YYYY-MM format (defaults to --from)")
 	reportType := fs.String("type", "earnings", "Report type: earnings, sales, payouts, play_balance, wht_statements")
 	dir := fs.String("dir", ".", "Output directory")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	incremental := bindIncrementalFlags(fs)
+	extract := fs.Bool("extract", false, extractFlagUsage)
+	stream := bindStreamFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "download",
 		ShortUsage: "gplay reports financial download --bucket-id <id> --from <YYYY-MM> [flags]",
 		ShortHelp:  "Download financial reports.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Download financial reports for a month range.
+
+With --incremental, the latest downloaded month per bucket and type is kept
+in a state file (default: <dir>/.gplay-reports-state.json). Later runs start
+from that month, so --from is only needed the first time and --to defaults
+to no upper bound. The latest month is fetched again because Play keeps
+updating it.
+
+With --extract, .zip reports are unpacked and .gz reports decompressed into
+--dir next to the downloaded file, and each file entry lists the extracted
+names. Archive entries that would land outside --dir are rejected.
+
+With --stream, each file entry is printed as a JSON line as soon as that
+file is downloaded (and extracted), so large ranges give feedback right
+away. No final JSON document is printed; the totals go to stderr.
+
+Examples:
+  gplay reports financial download --bucket-id <id> --from 2026-01 --type earnings --dir ./reports
+  gplay reports financial download --bucket-id <id> --type earnings --dir ./reports --incremental`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
@@ -218,19 +246,21 @@ func FinancialDownloadCommand() *ffcli.Command {
 			if strings.TrimSpace(*bucketID) == "" {
 				return fmt.Errorf("--bucket-id is required")
 			}
-			if strings.TrimSpace(*from) == "" {
+			if strings.TrimSpace(*from) == "" && !*incremental.enabled {
 				return fmt.Errorf("--from is required")
 			}
-			if err := validateMonth(*from, "from"); err != nil {
-				return err
+			if *from != "" {
+				if err := validateMonth(*from, "from"); err != nil {
+					return err
+				}
 			}
 			effectiveTo := *to
-			if effectiveTo == "" {
-				effectiveTo = *from
-			} else {
+			if effectiveTo != "" {
 				if err := validateMonth(effectiveTo, "to"); err != nil {
 					return err
 				}
+			} else if !*incremental.enabled {
+				effectiveTo = *from
 			}
 			if err := validateReportType(*reportType); err != nil {
 				return err
@@ -239,12 +269,21 @@ func FinancialDownloadCommand() *ffcli.Command {
 				return fmt.Errorf("--type must be one of: earnings, sales, payouts, play_balance, wht_statements (got \"all\")")
 			}
 
+			if err := validateStreamFlags(*stream, *outputFlag, *pretty); err != nil {
+				return err
+			}
+
+			bucket := parseBucket(*bucketID)
+			run, effectiveFrom, err := incremental.start(*dir, financialStateKey(bucket, *reportType), *from)
+			if err != nil {
+				return err
+			}
+
 			svc, err := newGCSServiceFunc(ctx)
 			if err != nil {
 				return err
 			}
 
-			bucket := parseBucket(*bucketID)
 			prefix := financialPrefixes[*reportType]
 
 			objects, err := svc.ListObjects(ctx, bucket, prefix)
@@ -253,29 +292,53 @@ func FinancialDownloadCommand() *ffcli.Command {
 			}
 
 			var downloaded []map[string]interface{}
+			var names []string
 			for _, obj := range objects {
-				if !matchesDateRange(obj.Name, *from, effectiveTo) {
+				if !matchesDateRange(obj.Name, effectiveFrom, effectiveTo) {
 					continue
 				}
 				localPath := filepath.Join(*dir, filepath.Base(obj.Name))
 				if err := downloadFile(ctx, svc, bucket, obj.Name, localPath); err != nil {
 					return fmt.Errorf("failed to download %s: %w", obj.Name, err)
 				}
-				downloaded = append(downloaded, map[string]interface{}{
+				entry := map[string]interface{}{
 					"name": obj.Name,
 					"path": localPath,
 					"size": obj.Size,
-				})
+				}
+				if *extract {
+					extracted, err := extractReport(localPath, *dir)
+					if err != nil {
+						return fmt.Errorf("failed to extract %s: %w", obj.Name, err)
+					}
+					if extracted != nil {
+						entry["extracted"] = extracted
+					}
+				}
+				if *stream {
+					if err := emitFileEntry(entry); err != nil {
+						return err
+					}
+				}
+				downloaded = append(downloaded, entry)
+				names = append(names, obj.Name)
 			}
 
 			result := map[string]interface{}{
 				"bucket": bucket,
 				"type":   *reportType,
-				"from":   *from,
+				"from":   effectiveFrom,
 				"to":     effectiveTo,
 				"dir":    *dir,
 				"files":  downloaded,
 			}
+			if err := run.finish(names, result); err != nil {
+				return err
+			}
+			if *stream {
+				finishStream(result, len(downloaded))
+				return nil
+			}
 			return shared.PrintOutput(result, *outputFlag, *pretty)
 		},
 	}
@@ -0,0 +1,237 @@
+package reports
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+)
+
+var currencyCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Earnings report columns used by the summary. Amounts are totalled in the
+// merchant currency, the currency Play pays out in.
+const (
+	earningsCurrencyColumn = "merchant currency"
+	earningsAmountColumn   = "amount (merchant currency)"
+	earningsTypeColumn     = "transaction type"
+)
+
+// currencyTotal is the summary of the rows in one merchant currency.
+type currencyTotal struct {
+	Currency          string            `json:"currency"`
+	Rows              int               `json:"rows"`
+	Amount            string            `json:"amount"`
+	ByTransactionType map[string]string `json:"byTransactionType"`
+}
+
+// earningsSummary is the result of reports financial summary.
+type earningsSummary struct {
+	File     string          `json:"file"`
+	Currency string          `json:"currency,omitempty"`
+	Rows     int             `json:"rows"`
+	Totals   []currencyTotal `json:"totals"`
+}
+
+// FinancialSummaryCommand returns the financial summary subcommand.
+func FinancialSummaryCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("financial summary", flag.ExitOnError)
+	file := fs.String("file", "", "Downloaded earnings report (.csv, or the .zip Play publishes) (required)")
+	currency := fs.String("currency", "", "Only include rows in this merchant currency (ISO 4217, e.g. USD)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "summary",
+		ShortUsage: "gplay reports financial summary --file <report> [--currency <code>] [flags]",
+		ShortHelp:  "Total a downloaded earnings report by currency.",
+		LongHelp: `Parse a downloaded earnings report and total its rows per merchant
+currency and transaction type.
+
+Accounts paid in several currencies get one total per currency; --currency
+restricts the parsed rows to one of them.
+
+Examples:
+  gplay reports financial download --bucket-id <id> --from 2026-01 --type earnings --dir ./reports
+  gplay reports financial summary --file ./reports/earnings_202601.zip --currency EUR`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*file) == "" {
+				return fmt.Errorf("--file is required")
+			}
+			code := strings.ToUpper(strings.TrimSpace(*currency))
+			if code != "" && !currencyCodeRegex.MatchString(code) {
+				return fmt.Errorf("--currency must be a 3-letter ISO 4217 code (got %q)", *currency)
+			}
+
+			summary, err := summarizeEarningsFile(*file, code)
+			if err != nil {
+				return err
+			}
+			return shared.PrintOutput(summary, *outputFlag, *pretty)
+		},
+	}
+}
+
+// summarizeEarningsFile totals an earnings report on disk. Zip archives are
+// read entry by entry and every CSV inside is included.
+func summarizeEarningsFile(path, currency string) (*earningsSummary, error) {
+	acc := newEarningsAccumulator(currency)
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, fmt.Errorf("open zip: %w", err)
+		}
+		defer zr.Close()
+		for _, entry := range zr.File {
+			if !strings.EqualFold(filepath.Ext(entry.Name), ".csv") {
+				continue
+			}
+			rc, err := entry.Open()
+			if err != nil {
+				return nil, fmt.Errorf("open zip entry %q: %w", entry.Name, err)
+			}
+			err = acc.add(rc)
+			rc.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", entry.Name, err)
+			}
+		}
+	} else {
+		f, err := os.Open(path) // #nosec G304 -- path is provided by the user
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if err := acc.add(f); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+	}
+	return acc.summary(path), nil
+}
+
+type earningsAccumulator struct {
+	currency string
+	rows     int
+	totals   map[string]*big.Rat
+	counts   map[string]int
+	byType   map[string]map[string]*big.Rat
+}
+
+func newEarningsAccumulator(currency string) *earningsAccumulator {
+	return &earningsAccumulator{
+		currency: currency,
+		totals:   map[string]*big.Rat{},
+		counts:   map[string]int{},
+		byType:   map[string]map[string]*big.Rat{},
+	}
+}
+
+// add parses one earnings CSV and adds its matching rows.
+func (a *earningsAccumulator) add(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return fmt.Errorf("read header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	currencyCol, ok := columns[earningsCurrencyColumn]
+	if !ok {
+		return fmt.Errorf("not an earnings report: missing %q column", "Merchant Currency")
+	}
+	amountCol, ok := columns[earningsAmountColumn]
+	if !ok {
+		return fmt.Errorf("not an earnings report: missing %q column", "Amount (Merchant Currency)")
+	}
+	typeCol, hasType := columns[earningsTypeColumn]
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if currencyCol >= len(record) || amountCol >= len(record) {
+			return fmt.Errorf("line %d: too few columns", line)
+		}
+		currency := strings.ToUpper(strings.TrimSpace(record[currencyCol]))
+		if a.currency != "" && currency != a.currency {
+			continue
+		}
+		amount, ok := new(big.Rat).SetString(strings.ReplaceAll(strings.TrimSpace(record[amountCol]), ",", ""))
+		if !ok {
+			return fmt.Errorf("line %d: invalid amount %q", line, record[amountCol])
+		}
+		txType := "unknown"
+		if hasType && typeCol < len(record) && strings.TrimSpace(record[typeCol]) != "" {
+			txType = strings.TrimSpace(record[typeCol])
+		}
+
+		a.rows++
+		a.counts[currency]++
+		if a.totals[currency] == nil {
+			a.totals[currency] = new(big.Rat)
+			a.byType[currency] = map[string]*big.Rat{}
+		}
+		a.totals[currency].Add(a.totals[currency], amount)
+		if a.byType[currency][txType] == nil {
+			a.byType[currency][txType] = new(big.Rat)
+		}
+		a.byType[currency][txType].Add(a.byType[currency][txType], amount)
+	}
+}
+
+func (a *earningsAccumulator) summary(path string) *earningsSummary {
+	currencies := make([]string, 0, len(a.totals))
+	for currency := range a.totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	totals := make([]currencyTotal, 0, len(currencies))
+	for _, currency := range currencies {
+		byType := make(map[string]string, len(a.byType[currency]))
+		for txType, amount := range a.byType[currency] {
+			byType[txType] = amount.FloatString(2)
+		}
+		totals = append(totals, currencyTotal{
+			Currency:          currency,
+			Rows:              a.counts[currency],
+			Amount:            a.totals[currency].FloatString(2),
+			ByTransactionType: byType,
+		})
+	}
+	return &earningsSummary{
+		File:     path,
+		Currency: a.currency,
+		Rows:     a.rows,
+		Totals:   totals,
+	}
+}
@@ -0,0 +1,102 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const multiCurrencyEarnings = "\ufeffDescription,Transaction Date,Transaction Type,Product id,Buyer Currency,Amount (Buyer Currency),Merchant Currency,Amount (Merchant Currency)\n" +
+	"GPA.1,Jan 1 2026,Charge,premium,USD,9.99,USD,9.99\n" +
+	"GPA.1,Jan 1 2026,Google fee,premium,USD,-1.50,USD,-1.4985\n" +
+	"GPA.2,Jan 2 2026,Charge,premium,EUR,8.99,EUR,8.99\n" +
+	"GPA.3,Jan 3 2026,Charge,premium,GBP,7.99,EUR,9.25\n" +
+	"GPA.4,Jan 4 2026,Charge,premium,USD,4.99,USD,4.99\n"
+
+func runFinancialSummary(t *testing.T, args ...string) (earningsSummary, error) {
+	t.Helper()
+	cmd := FinancialSummaryCommand()
+	if err := cmd.FlagSet.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := cmd.Exec(context.Background(), nil)
+	w.Close()
+	os.Stdout = old
+	out, _ := io.ReadAll(r)
+
+	var summary earningsSummary
+	if err == nil {
+		if decodeErr := json.Unmarshal(out, &summary); decodeErr != nil {
+			t.Fatalf("decode %q: %v", out, decodeErr)
+		}
+	}
+	return summary, err
+}
+
+func TestFinancialSummary_TotalsEveryCurrency(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "earnings_202601.csv")
+	if err := os.WriteFile(path, []byte(multiCurrencyEarnings), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := runFinancialSummary(t, "--file", path)
+	if err != nil {
+		t.Fatalf("summary: %v", err)
+	}
+	if summary.Rows != 5 || len(summary.Totals) != 2 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if got := summary.Totals[0]; got.Currency != "EUR" || got.Rows != 2 || got.Amount != "18.24" {
+		t.Fatalf("EUR total = %+v", got)
+	}
+	if got := summary.Totals[1]; got.Currency != "USD" || got.Rows != 3 || got.Amount != "13.48" {
+		t.Fatalf("USD total = %+v", got)
+	}
+}
+
+func TestFinancialSummary_CurrencyFilterFromZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "earnings_202601.zip")
+	archive := buildZip(t, map[string]string{"PlayApps_202601.csv": multiCurrencyEarnings})
+	if err := os.WriteFile(path, []byte(archive), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := runFinancialSummary(t, "--file", path, "--currency", "usd")
+	if err != nil {
+		t.Fatalf("summary: %v", err)
+	}
+	if summary.Currency != "USD" || summary.Rows != 3 || len(summary.Totals) != 1 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	total := summary.Totals[0]
+	if total.Currency != "USD" || total.Amount != "13.48" {
+		t.Fatalf("USD total = %+v", total)
+	}
+	if total.ByTransactionType["Charge"] != "14.98" || total.ByTransactionType["Google fee"] != "-1.50" {
+		t.Fatalf("by transaction type = %v", total.ByTransactionType)
+	}
+}
+
+func TestFinancialSummary_Validation(t *testing.T) {
+	if _, err := runFinancialSummary(t); err == nil || !strings.Contains(err.Error(), "--file is required") {
+		t.Fatalf("expected --file error, got %v", err)
+	}
+	if _, err := runFinancialSummary(t, "--file", "x.csv", "--currency", "dollars"); err == nil || !strings.Contains(err.Error(), "--currency must be") {
+		t.Fatalf("expected --currency error, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "sales.csv")
+	if err := os.WriteFile(path, []byte("Order Number,Charged Amount\n1,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runFinancialSummary(t, "--file", path); err == nil || !strings.Contains(err.Error(), "not an earnings report") {
+		t.Fatalf("expected not an earnings report error, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package reports
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+)
+
+// defaultStateFileName is the --state-file used when only --dir is given.
+const defaultStateFileName = ".gplay-reports-state.json"
+
+// reportState maps a report series (see financialStateKey, statsStateKey) to
+// the latest month downloaded for it, as YYYY-MM.
+type reportState map[string]string
+
+// incrementalFlags are the --incremental and --state-file flags shared by the
+// download commands.
+type incrementalFlags struct {
+	enabled   *bool
+	stateFile *string
+}
+
+func bindIncrementalFlags(fs *flag.FlagSet) incrementalFlags {
+	return incrementalFlags{
+		enabled:   fs.Bool("incremental", false, "Only download months from the stored high-water mark onward and advance it"),
+		stateFile: fs.String("state-file", "", "High-water mark state file for --incremental (default: <dir>/"+defaultStateFileName+")"),
+	}
+}
+
+func (f incrementalFlags) path(dir string) string {
+	if p := strings.TrimSpace(*f.stateFile); p != "" {
+		return p
+	}
+	return filepath.Join(dir, defaultStateFileName)
+}
+
+// incrementalRun tracks the high-water mark of one --incremental download.
+type incrementalRun struct {
+	path  string
+	key   string
+	state reportState
+}
+
+// start loads the state for key and returns the effective --from. It returns
+// a nil run and from unchanged when --incremental is not set.
+func (f incrementalFlags) start(dir, key, from string) (*incrementalRun, string, error) {
+	if !*f.enabled {
+		return nil, from, nil
+	}
+	run := &incrementalRun{path: f.path(dir), key: key}
+	state, err := loadReportState(run.path)
+	if err != nil {
+		return nil, "", err
+	}
+	run.state = state
+	from, err = applyHighWaterMark(from, state[key])
+	if err != nil {
+		return nil, "", err
+	}
+	return run, from, nil
+}
+
+// finish advances the mark past the downloaded reports, saves the state, and
+// records it in the command result. It is a no-op on a nil run.
+func (r *incrementalRun) finish(downloaded []string, result map[string]interface{}) error {
+	if r == nil {
+		return nil
+	}
+	mark := advanceHighWaterMark(r.state[r.key], downloaded)
+	if mark != "" {
+		r.state[r.key] = mark
+		if err := saveReportState(r.path, r.state); err != nil {
+			return err
+		}
+	}
+	result["incremental"] = true
+	result["state_file"] = r.path
+	result["high_water_mark"] = mark
+	return nil
+}
+
+func financialStateKey(bucket, reportType string) string {
+	return "financial/" + bucket + "/" + reportType
+}
+
+func statsStateKey(bucket, pkg, statsType string) string {
+	return "stats/" + bucket + "/" + pkg + "/" + statsType
+}
+
+// loadReportState reads the state file; a missing file is an empty state.
+func loadReportState(path string) (reportState, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the user
+	if errors.Is(err, os.ErrNotExist) {
+		return reportState{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read state file: %w", err)
+	}
+	state := reportState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse state file %s: %w", path, err)
+	}
+	return state, nil
+}
+
+func saveReportState(path string, state reportState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
+	return shared.AtomicWrite(path, append(data, '\n'), 0o644)
+}
+
+// applyHighWaterMark returns the lower bound for an incremental download:
+// the later of --from and the stored mark. The mark month itself is fetched
+// again because Play keeps regenerating the latest month's report.
+func applyHighWaterMark(from, mark string) (string, error) {
+	if from == "" && mark == "" {
+		return "", fmt.Errorf("--from is required until --incremental has recorded a high-water mark")
+	}
+	if mark > from {
+		return mark, nil
+	}
+	return from, nil
+}
+
+// advanceHighWaterMark returns the latest month among the downloaded report
+// names, never moving backwards from mark.
+func advanceHighWaterMark(mark string, names []string) string {
+	for _, name := range names {
+		if month := monthOfReport(name); month > mark {
+			mark = month
+		}
+	}
+	return mark
+}
+
+// monthOfReport returns the YYYY-MM embedded in a report file name, or "".
+func monthOfReport(name string) string {
+	matches := monthFromFilenameRegex.FindStringSubmatch(name)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1][:4] + "-" + matches[1][4:]
+}
@@ -0,0 +1,163 @@
+package reports
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+)
+
+func TestApplyHighWaterMark(t *testing.T) {
+	cases := []struct {
+		from, mark, want string
+	}{
+		{"2024-01", "", "2024-01"},
+		{"", "2024-03", "2024-03"},
+		{"2024-01", "2024-03", "2024-03"},
+		{"2024-05", "2024-03", "2024-05"},
+	}
+	for _, c := range cases {
+		got, err := applyHighWaterMark(c.from, c.mark)
+		if err != nil || got != c.want {
+			t.Errorf("applyHighWaterMark(%q, %q) = %q, %v; want %q", c.from, c.mark, got, err, c.want)
+		}
+	}
+	if _, err := applyHighWaterMark("", ""); err == nil {
+		t.Error("expected error without --from or a stored mark")
+	}
+}
+
+func TestAdvanceHighWaterMark(t *testing.T) {
+	names := []string{"earnings/earnings_202402_1.zip", "earnings/earnings_202401_1.zip", "earnings/readme.txt"}
+	if got := advanceHighWaterMark("", names); got != "2024-02" {
+		t.Errorf("expected 2024-02, got %q", got)
+	}
+	if got := advanceHighWaterMark("2024-05", names); got != "2024-05" {
+		t.Errorf("mark must not move backwards, got %q", got)
+	}
+}
+
+// runIncrementalDownload runs financial download with --incremental and
+// returns the names of the downloaded files.
+func runIncrementalDownload(t *testing.T, args ...string) []string {
+	t.Helper()
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := execCommand(t, append([]string{"financial", "download", "--bucket-id", "12345", "--type", "earnings", "--incremental"}, args...))
+
+	w.Close()
+	os.Stdout = old
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	var result struct {
+		Files []struct {
+			Name string `json:"name"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("failed to parse output JSON: %v\noutput: %s", err, out)
+	}
+	var names []string
+	for _, f := range result.Files {
+		names = append(names, filepath.Base(f.Name))
+	}
+	return names
+}
+
+func TestFinancialDownload_IncrementalSkipsDownloadedMonths(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, defaultStateFileName)
+	contents := map[string]string{
+		"earnings/earnings_202401_12345.zip": "jan",
+		"earnings/earnings_202402_12345.zip": "feb",
+		"earnings/earnings_202403_12345.zip": "mar",
+	}
+	objects := map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_12345/earnings/": {
+			{Name: "earnings/earnings_202401_12345.zip", Size: 3},
+			{Name: "earnings/earnings_202402_12345.zip", Size: 3},
+		},
+	}
+	setupMockGCS(t, objects, contents)
+
+	got := runIncrementalDownload(t, "--from", "2024-01", "--dir", dir)
+	if strings.Join(got, ",") != "earnings_202401_12345.zip,earnings_202402_12345.zip" {
+		t.Fatalf("first run downloaded %v", got)
+	}
+	state, err := loadReportState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := financialStateKey("pubsite_prod_rev_12345", "earnings")
+	if state[key] != "2024-02" {
+		t.Fatalf("expected high-water mark 2024-02, got %q", state[key])
+	}
+
+	// A new month appears; the next run needs no --from, skips January, and
+	// refreshes the mark month alongside the new one.
+	objects["pubsite_prod_rev_12345/earnings/"] = append(objects["pubsite_prod_rev_12345/earnings/"],
+		gcsclient.ObjectInfo{Name: "earnings/earnings_202403_12345.zip", Size: 3})
+	setupMockGCS(t, objects, contents)
+
+	got = runIncrementalDownload(t, "--dir", dir)
+	if strings.Join(got, ",") != "earnings_202402_12345.zip,earnings_202403_12345.zip" {
+		t.Fatalf("second run downloaded %v", got)
+	}
+	state, err = loadReportState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state[key] != "2024-03" {
+		t.Fatalf("expected high-water mark 2024-03, got %q", state[key])
+	}
+}
+
+func TestFinancialDownload_IncrementalRequiresFromWithoutMark(t *testing.T) {
+	setupMockGCSEmpty(t)
+	err := execCommand(t, []string{"financial", "download", "--bucket-id", "12345", "--incremental", "--state-file", filepath.Join(t.TempDir(), "state.json")})
+	if err == nil || !strings.Contains(err.Error(), "high-water mark") {
+		t.Fatalf("expected high-water mark error, got: %v", err)
+	}
+}
+
+func TestStatsDownload_IncrementalTracksPackageAndType(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state.json")
+	objects := map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_12345/stats/installs/": {
+			{Name: "stats/installs/installs_com.example.app_202401_overview.csv", Size: 1},
+			{Name: "stats/installs/installs_com.other.app_202405_overview.csv", Size: 1},
+		},
+	}
+	contents := map[string]string{
+		"stats/installs/installs_com.example.app_202401_overview.csv": "x",
+	}
+	setupMockGCS(t, objects, contents)
+
+	old := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+	err := execCommand(t, []string{"stats", "download", "--bucket-id", "12345", "--package", "com.example.app", "--type", "installs", "--from", "2024-01", "--dir", dir, "--incremental", "--state-file", statePath})
+	w.Close()
+	os.Stdout = old
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	state, err := loadReportState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := state[statsStateKey("pubsite_prod_rev_12345", "com.example.app", "installs")]; got != "2024-01" {
+		t.Fatalf("expected mark 2024-01 for com.example.app, got %q (state %v)", got, state)
+	}
+}
@@ -0,0 +1,53 @@
+package reports
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+	"github.com/tamtom/play-console-cli/internal/reportcache"
+)
+
+// openReportCache opens the listing cache; tests replace it to inject a
+// directory and clock.
+var openReportCache = reportcache.Open
+
+// bindCacheTTLFlag registers --cache-ttl on a list command.
+func bindCacheTTLFlag(fs *flag.FlagSet) *time.Duration {
+	return fs.Duration("cache-ttl", 0, "Reuse bucket listings cached within this age, e.g. 10m (0 = off; wipe with gplay cache clear)")
+}
+
+func validateCacheTTL(ttl time.Duration) error {
+	if ttl < 0 {
+		return fmt.Errorf("--cache-ttl must be 0 (off) or a positive duration, got %s", ttl)
+	}
+	return nil
+}
+
+// listReportObjects lists bucket objects under prefix. With a positive ttl,
+// a cached listing younger than ttl is returned instead, and a live listing
+// refreshes the cache. Cache errors fall back to the live listing.
+func listReportObjects(ctx context.Context, svc *gcsclient.Service, bucket, prefix string, ttl time.Duration) ([]gcsclient.ObjectInfo, error) {
+	if ttl <= 0 {
+		return svc.ListObjects(ctx, bucket, prefix)
+	}
+	cache, err := openReportCache()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: report listing cache unavailable: %v\n", err)
+		return svc.ListObjects(ctx, bucket, prefix)
+	}
+	if objects, ok := cache.Get(bucket, prefix, ttl); ok {
+		return objects, nil
+	}
+	objects, err := svc.ListObjects(ctx, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := cache.Put(bucket, prefix, objects); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache report listing: %v\n", err)
+	}
+	return objects, nil
+}
@@ -0,0 +1,102 @@
+package reports
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+	"github.com/tamtom/play-console-cli/internal/reportcache"
+)
+
+// setupCountingGCS serves one installs report and counts list requests.
+func setupCountingGCS(t *testing.T) *int {
+	t.Helper()
+	lists := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"storage#objects","items":[{"name":"stats/installs/installs_com.example.app_202501_overview.csv","size":"512"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	original := newGCSServiceFunc
+	newGCSServiceFunc = func(ctx context.Context) (*gcsclient.Service, error) {
+		return gcsclient.NewServiceWithClient(ctx, srv.Client(), srv.URL+"/storage/v1/")
+	}
+	t.Cleanup(func() { newGCSServiceFunc = original })
+	return &lists
+}
+
+// setupReportCache points the listing cache at a temp dir with a settable clock.
+func setupReportCache(t *testing.T) *time.Time {
+	t.Helper()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := &reportcache.Cache{Dir: t.TempDir(), Now: func() time.Time { return now }}
+	original := openReportCache
+	openReportCache = func() (*reportcache.Cache, error) { return cache, nil }
+	t.Cleanup(func() { openReportCache = original })
+	return &now
+}
+
+func runStatsList(t *testing.T, args ...string) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	runErr := execCommand(t, append([]string{"stats", "list", "--bucket-id", "55", "--type", "installs"}, args...))
+	os.Stdout = stdout
+	_ = w.Close()
+	out, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatalf("stats list: %v", runErr)
+	}
+	return string(out)
+}
+
+func TestStatsList_CacheTTLReusesListing(t *testing.T) {
+	lists := setupCountingGCS(t)
+	now := setupReportCache(t)
+
+	first := runStatsList(t, "--cache-ttl", "10m")
+	*now = now.Add(5 * time.Minute)
+	second := runStatsList(t, "--cache-ttl", "10m")
+
+	if *lists != 1 {
+		t.Fatalf("expected 1 live listing within the TTL, got %d", *lists)
+	}
+	if first != second || !strings.Contains(second, "installs_com.example.app_202501") {
+		t.Fatalf("cached output differs:\nfirst:  %s\nsecond: %s", first, second)
+	}
+
+	*now = now.Add(10 * time.Minute)
+	runStatsList(t, "--cache-ttl", "10m")
+	if *lists != 2 {
+		t.Fatalf("expected an expired entry to list again, got %d listings", *lists)
+	}
+}
+
+func TestStatsList_CacheOffByDefault(t *testing.T) {
+	lists := setupCountingGCS(t)
+	setupReportCache(t)
+
+	runStatsList(t)
+	runStatsList(t)
+	if *lists != 2 {
+		t.Fatalf("expected every run to list without --cache-ttl, got %d listings", *lists)
+	}
+}
+
+func TestStatsList_NegativeCacheTTL(t *testing.T) {
+	err := execCommand(t, []string{"stats", "list", "--bucket-id", "55", "--cache-ttl", "-1m"})
+	if err == nil || !strings.Contains(err.Error(), "--cache-ttl") {
+		t.Fatalf("expected --cache-ttl error, got %v", err)
+	}
+}
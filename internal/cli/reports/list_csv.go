@@ -0,0 +1,58 @@
+package reports
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+)
+
+// reportsCSVHeader is the header row of list --output csv.
+var reportsCSVHeader = []string{"type", "name", "size", "updated"}
+
+// isCSVOutput reports whether --output asks for CSV.
+func isCSVOutput(output string) bool {
+	return strings.EqualFold(strings.TrimSpace(output), "csv")
+}
+
+// writeReportsCSV writes one row per report. The type column is the report
+// type whose prefix (from prefixes, type to GCS prefix) the object name
+// starts with.
+func writeReportsCSV(w io.Writer, reports []gcsclient.ObjectInfo, prefixes map[string]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(reportsCSVHeader); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		row := []string{
+			reportTypeForName(report.Name, prefixes),
+			report.Name,
+			strconv.FormatUint(report.Size, 10),
+			report.Updated,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// reportTypeForName returns the type whose prefix name starts with, or ""
+// when none matches. Types are tried in sorted order so the result is stable.
+func reportTypeForName(name string, prefixes map[string]string) string {
+	types := make([]string, 0, len(prefixes))
+	for typ := range prefixes {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		if strings.HasPrefix(name, prefixes[typ]) {
+			return typ
+		}
+	}
+	return ""
+}
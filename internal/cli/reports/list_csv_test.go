@@ -0,0 +1,110 @@
+package reports
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+)
+
+func runListCSV(t *testing.T, args []string) [][]string {
+	t.Helper()
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := execCommand(t, args)
+
+	w.Close()
+	os.Stdout = old
+	out, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(string(out))).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v\noutput: %s", err, out)
+	}
+	return rows
+}
+
+func TestFinancialList_CSVOutput(t *testing.T) {
+	objects := map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_99/earnings/": {
+			{Name: "earnings/earnings_202501_99.zip", Size: 100, Updated: "2025-02-01T00:00:00Z"},
+			{Name: "earnings/earnings_202502_99.zip", Size: 150, Updated: "2025-03-01T00:00:00Z"},
+		},
+		"pubsite_prod_rev_99/play_balance_krw/": {
+			{Name: "play_balance_krw/balance_202501.csv", Size: 30, Updated: "2025-02-01T00:00:00Z"},
+		},
+	}
+	setupMockGCS(t, objects, nil)
+
+	rows := runListCSV(t, []string{"financial", "list", "--bucket-id", "99", "--output", "csv"})
+
+	if !reflect.DeepEqual(rows[0], reportsCSVHeader) {
+		t.Fatalf("header = %v, want %v", rows[0], reportsCSVHeader)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 3 rows after the header, got %d: %v", len(rows)-1, rows)
+	}
+	want := []string{"play_balance", "play_balance_krw/balance_202501.csv", "30", "2025-02-01T00:00:00Z"}
+	found := false
+	for _, row := range rows[1:] {
+		if reflect.DeepEqual(row, want) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("missing row %v in %v", want, rows)
+	}
+}
+
+func TestStatsList_CSVOutput(t *testing.T) {
+	objects := map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_55/stats/installs/": {
+			{Name: "stats/installs/installs_com.example.app_202501_overview.csv", Size: 512, Updated: "2025-02-01T00:00:00Z"},
+			{Name: "stats/installs/installs_com.other.app_202501_overview.csv", Size: 128, Updated: "2025-02-01T00:00:00Z"},
+		},
+	}
+	setupMockGCS(t, objects, nil)
+
+	rows := runListCSV(t, []string{"stats", "list", "--bucket-id", "55", "--type", "installs", "--package", "com.example.app", "--output", "csv"})
+
+	want := [][]string{
+		reportsCSVHeader,
+		{"installs", "stats/installs/installs_com.example.app_202501_overview.csv", "512", "2025-02-01T00:00:00Z"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+}
+
+func TestStatsList_CSVOutputHonoursOrderBy(t *testing.T) {
+	t.Setenv("GPLAY_ORDER_BY", "")
+	objects := map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_55/stats/installs/": {
+			{Name: "stats/installs/installs_com.example.app_202501_overview.csv", Size: 128, Updated: "2025-02-01T00:00:00Z"},
+			{Name: "stats/installs/installs_com.example.app_202502_overview.csv", Size: 512, Updated: "2025-03-01T00:00:00Z"},
+		},
+	}
+	setupMockGCS(t, objects, nil)
+
+	rows := runListCSV(t, []string{"stats", "list", "--bucket-id", "55", "--type", "installs", "--order-by", "-size", "--output", "csv"})
+
+	if len(rows) != 3 || rows[1][2] != "512" || rows[2][2] != "128" {
+		t.Errorf("expected rows sorted by size descending, got %v", rows)
+	}
+}
+
+func TestFinancialList_CSVRejectsPretty(t *testing.T) {
+	err := execCommand(t, []string{"financial", "list", "--bucket-id", "99", "--output", "csv", "--pretty"})
+	if err == nil || !strings.Contains(err.Error(), "--pretty") {
+		t.Fatalf("expected --pretty error, got %v", err)
+	}
+}
@@ -21,6 +21,7 @@ func ReportsCommand() *ffcli.Command {
 		Subcommands: []*ffcli.Command{
 			FinancialCommand(),
 			StatsCommand(),
+			DownloadURLCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -85,22 +86,32 @@ func StatsListCommand() *ffcli.Command {
 	from := fs.String("from", "", "Start month in YYYY-MM format")
 	to := fs.String("to", "", "End month in YYYY-MM format")
 	statsType := fs.String("type", "all", "Stats type: installs, ratings, crashes, store_performance, subscriptions, all")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	shared.BindOrderByFlag(fs)
+	cacheTTL := bindCacheTTLFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template, csv")
+	shared.DeclareOutputFormats(fs, "json", "table", "markdown", "yaml", "template", "csv")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
-		ShortUsage: "gplay reports stats list --bucket-id <id> [flags]",
+		ShortUsage: "gplay reports stats list --bucket-id <id> [--order-by <field>] [flags]",
 		ShortHelp:  "List available statistics reports.",
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
+			csvOutput := isCSVOutput(*outputFlag)
+			if csvOutput && *pretty {
+				return fmt.Errorf("--pretty is only valid with JSON output")
+			}
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
 			if strings.TrimSpace(*bucketID) == "" {
 				return fmt.Errorf("--bucket-id is required")
 			}
+			if err := validateCacheTTL(*cacheTTL); err != nil {
+				return err
+			}
 			if *from != "" {
 				if err := validateMonth(*from, "from"); err != nil {
 					return err
@@ -125,7 +136,7 @@ func StatsListCommand() *ffcli.Command {
 
 			var reports []gcsclient.ObjectInfo
 			for _, prefix := range prefixes {
-				objects, err := svc.ListObjects(ctx, bucket, prefix)
+				objects, err := listReportObjects(ctx, svc, bucket, prefix, *cacheTTL)
 				if err != nil {
 					return err
 				}
@@ -140,6 +151,12 @@ func StatsListCommand() *ffcli.Command {
 				}
 			}
 
+			if csvOutput {
+				if err := shared.SortResult(reports, shared.OrderByFromEnv()); err != nil {
+					return err
+				}
+				return writeReportsCSV(os.Stdout, reports, statsPrefixes)
+			}
 			result := map[string]interface{}{
 				"bucket":  bucket,
 				"reports": reports,
@@ -158,15 +175,47 @@ func StatsDownloadCommand() *ffcli.Command {
 	to := fs.String("to", "", "End month in YYYY-MM format (defaults to --from)")
 	statsType := fs.String("type", "", "Stats type: installs, ratings, crashes, store_performance, subscriptions (required)")
 	dir := fs.String("dir", ".", "Output directory")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	incremental := bindIncrementalFlags(fs)
+	extract := fs.Bool("extract", false, extractFlagUsage)
+	aggregate := fs.Bool("aggregate", false, aggregateFlagUsage)
+	aggregateDir := fs.String("aggregate-dir", "", "Directory for the merged CSVs (implies --aggregate; default: --dir)")
+	stream := bindStreamFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "download",
 		ShortUsage: "gplay reports stats download --bucket-id <id> --package <name> --from <YYYY-MM> --type <type> [flags]",
 		ShortHelp:  "Download statistics reports.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Download statistics reports for one package and type.
+
+With --incremental, the latest downloaded month per bucket, package, and type
+is kept in a state file (default: <dir>/.gplay-reports-state.json). Later
+runs start from that month, so --from is only needed the first time and --to
+defaults to no upper bound.
+
+With --extract, .zip reports are unpacked and .gz reports decompressed into
+--dir next to the downloaded file, and each file entry lists the extracted
+names. Archive entries that would land outside --dir are rejected.
+
+With --stream, each file entry is printed as a JSON line as soon as that
+file is downloaded (and extracted), so large ranges give feedback right
+away. No final JSON document is printed; the totals go to stderr.
+
+With --aggregate, the monthly CSVs downloaded by this run are also merged
+per report dimension into <type>_<package>_<from>_<to>[_<dimension>].csv
+in --dir, e.g. installs_com.example.app_202501_202512_overview.csv. The
+merged file keeps one header and the encoding of the source files
+(UTF-16LE with a byte order mark for Play stats); months whose header
+differs are an error. The individual monthly files are kept. Use
+--aggregate-dir to write the merged files elsewhere.
+
+Examples:
+  gplay reports stats download --bucket-id <id> --package com.example.app --from 2026-01 --type installs
+  gplay reports stats download --bucket-id <id> --package com.example.app --from 2025-01 --to 2025-12 --type installs --aggregate
+  gplay reports stats download --bucket-id <id> --package com.example.app --type installs --incremental`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
@@ -177,19 +226,21 @@ func StatsDownloadCommand() *ffcli.Command {
 			if strings.TrimSpace(*pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
-			if strings.TrimSpace(*from) == "" {
+			if strings.TrimSpace(*from) == "" && !*incremental.enabled {
 				return fmt.Errorf("--from is required")
 			}
-			if err := validateMonth(*from, "from"); err != nil {
-				return err
+			if *from != "" {
+				if err := validateMonth(*from, "from"); err != nil {
+					return err
+				}
 			}
 			effectiveTo := *to
-			if effectiveTo == "" {
-				effectiveTo = *from
-			} else {
+			if effectiveTo != "" {
 				if err := validateMonth(effectiveTo, "to"); err != nil {
 					return err
 				}
+			} else if !*incremental.enabled {
+				effectiveTo = *from
 			}
 			if strings.TrimSpace(*statsType) == "" {
 				return fmt.Errorf("--type is required")
@@ -201,12 +252,21 @@ func StatsDownloadCommand() *ffcli.Command {
 				return fmt.Errorf("--type must be one of: installs, ratings, crashes, store_performance, subscriptions (got \"all\")")
 			}
 
+			if err := validateStreamFlags(*stream, *outputFlag, *pretty); err != nil {
+				return err
+			}
+
+			bucket := parseBucket(*bucketID)
+			run, effectiveFrom, err := incremental.start(*dir, statsStateKey(bucket, *pkg, *statsType), *from)
+			if err != nil {
+				return err
+			}
+
 			svc, err := newGCSServiceFunc(ctx)
 			if err != nil {
 				return err
 			}
 
-			bucket := parseBucket(*bucketID)
 			prefix := statsPrefixes[*statsType]
 
 			objects, err := svc.ListObjects(ctx, bucket, prefix)
@@ -215,33 +275,72 @@ func StatsDownloadCommand() *ffcli.Command {
 			}
 
 			var downloaded []map[string]interface{}
+			var names, paths []string
 			for _, obj := range objects {
 				if !strings.Contains(obj.Name, *pkg) {
 					continue
 				}
-				if !matchesDateRange(obj.Name, *from, effectiveTo) {
+				if !matchesDateRange(obj.Name, effectiveFrom, effectiveTo) {
 					continue
 				}
 				localPath := filepath.Join(*dir, filepath.Base(obj.Name))
 				if err := downloadFile(ctx, svc, bucket, obj.Name, localPath); err != nil {
 					return fmt.Errorf("failed to download %s: %w", obj.Name, err)
 				}
-				downloaded = append(downloaded, map[string]interface{}{
+				entry := map[string]interface{}{
 					"name": obj.Name,
 					"path": localPath,
 					"size": obj.Size,
-				})
+				}
+				if *extract {
+					extracted, err := extractReport(localPath, *dir)
+					if err != nil {
+						return fmt.Errorf("failed to extract %s: %w", obj.Name, err)
+					}
+					if extracted != nil {
+						entry["extracted"] = extracted
+					}
+				}
+				if *stream {
+					if err := emitFileEntry(entry); err != nil {
+						return err
+					}
+				}
+				downloaded = append(downloaded, entry)
+				names = append(names, obj.Name)
+				paths = append(paths, localPath)
 			}
 
 			result := map[string]interface{}{
 				"bucket":  bucket,
 				"package": *pkg,
 				"type":    *statsType,
-				"from":    *from,
+				"from":    effectiveFrom,
 				"to":      effectiveTo,
 				"dir":     *dir,
 				"files":   downloaded,
 			}
+			if *aggregate || strings.TrimSpace(*aggregateDir) != "" {
+				target := *dir
+				if strings.TrimSpace(*aggregateDir) != "" {
+					target = *aggregateDir
+					if err := os.MkdirAll(target, 0o755); err != nil {
+						return fmt.Errorf("create --aggregate-dir: %w", err)
+					}
+				}
+				aggregated, err := aggregateMonthlyCSVs(paths, target)
+				if err != nil {
+					return err
+				}
+				result["aggregated"] = aggregated
+			}
+			if err := run.finish(names, result); err != nil {
+				return err
+			}
+			if *stream {
+				finishStream(result, len(downloaded))
+				return nil
+			}
 			return shared.PrintOutput(result, *outputFlag, *pretty)
 		},
 	}
@@ -0,0 +1,49 @@
+package reports
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const streamFlagUsage = "Print each file entry as a JSON line as soon as it is downloaded, instead of one JSON document at the end"
+
+func bindStreamFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("stream", false, streamFlagUsage)
+}
+
+// validateStreamFlags checks that --stream is only used with compact JSON
+// output, since each line must be a complete JSON value.
+func validateStreamFlags(stream bool, output string, pretty bool) error {
+	if !stream {
+		return nil
+	}
+	if format := strings.ToLower(strings.TrimSpace(output)); format != "json" && format != "" {
+		return fmt.Errorf("--stream requires --output json")
+	}
+	if pretty {
+		return fmt.Errorf("--stream cannot be used with --pretty")
+	}
+	return nil
+}
+
+// emitFileEntry writes one downloaded file's entry to stdout as a JSON line.
+func emitFileEntry(entry map[string]interface{}) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
+
+// finishStream reports the totals of a streamed download on stderr, where
+// they do not mix with the JSON lines.
+func finishStream(result map[string]interface{}, count int) {
+	fmt.Fprintf(os.Stderr, "Downloaded %d file(s) to %s\n", count, result["dir"])
+	if mark, ok := result["high_water_mark"]; ok {
+		fmt.Fprintf(os.Stderr, "High-water mark: %v\n", mark)
+	}
+}
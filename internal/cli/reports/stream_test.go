@@ -0,0 +1,97 @@
+package reports
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+)
+
+func runStreamedDownload(t *testing.T, args []string) []map[string]interface{} {
+	t.Helper()
+	oldOut, oldErr := os.Stdout, os.Stderr
+	r, w, _ := os.Pipe()
+	_, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = w, errW
+
+	err := execCommand(t, args)
+
+	w.Close()
+	errW.Close()
+	os.Stdout, os.Stderr = oldOut, oldErr
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestFinancialDownload_StreamPrintsOneLinePerFile(t *testing.T) {
+	dir := t.TempDir()
+	setupMockGCS(t, map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_12345/earnings/": {
+			{Name: "earnings/earnings_202401_12345.zip", Size: 3},
+			{Name: "earnings/earnings_202402_12345.zip", Size: 3},
+			{Name: "earnings/earnings_202405_12345.zip", Size: 3},
+		},
+	}, map[string]string{
+		"earnings/earnings_202401_12345.zip": "jan",
+		"earnings/earnings_202402_12345.zip": "feb",
+	})
+
+	entries := runStreamedDownload(t, []string{"financial", "download", "--bucket-id", "12345", "--from", "2024-01", "--to", "2024-02", "--dir", dir, "--stream"})
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 JSON lines, got %d: %v", len(entries), entries)
+	}
+	for i, want := range []string{"earnings/earnings_202401_12345.zip", "earnings/earnings_202402_12345.zip"} {
+		if entries[i]["name"] != want || entries[i]["path"] == nil {
+			t.Errorf("line %d = %v, want entry for %s", i+1, entries[i], want)
+		}
+	}
+}
+
+func TestStatsDownload_StreamPrintsOneLinePerFile(t *testing.T) {
+	dir := t.TempDir()
+	setupMockGCS(t, map[string][]gcsclient.ObjectInfo{
+		"pubsite_prod_rev_55/stats/installs/": {
+			{Name: "stats/installs/installs_com.example.app_202501_overview.csv", Size: 1},
+			{Name: "stats/installs/installs_com.other.app_202501_overview.csv", Size: 1},
+		},
+	}, map[string]string{
+		"stats/installs/installs_com.example.app_202501_overview.csv": "a",
+	})
+
+	entries := runStreamedDownload(t, []string{"stats", "download", "--bucket-id", "55", "--package", "com.example.app", "--from", "2025-01", "--type", "installs", "--dir", dir, "--stream"})
+	if len(entries) != 1 || entries[0]["name"] != "stats/installs/installs_com.example.app_202501_overview.csv" {
+		t.Fatalf("unexpected streamed entries: %v", entries)
+	}
+}
+
+func TestValidateStreamFlags(t *testing.T) {
+	if err := validateStreamFlags(true, "table", false); err == nil || !strings.Contains(err.Error(), "--output json") {
+		t.Errorf("expected --output json error, got %v", err)
+	}
+	if err := validateStreamFlags(true, "json", true); err == nil || !strings.Contains(err.Error(), "--pretty") {
+		t.Errorf("expected --pretty error, got %v", err)
+	}
+	if err := validateStreamFlags(true, "json", false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateStreamFlags(false, "table", true); err != nil {
+		t.Errorf("--stream unset should not validate, got %v", err)
+	}
+}
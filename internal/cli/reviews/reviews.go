@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
@@ -42,7 +43,8 @@ func ListCommand() *ffcli.Command {
 	maxResults := fs.Int64("max-results", 50, "Max results per page")
 	translation := fs.String("translation-language", "", "Translation language (e.g. en-US)")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	maxItems := fs.Int("max-items", 0, shared.MaxItemsFlagUsage)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -65,6 +67,9 @@ Examples:
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
+			if err := shared.ValidateMaxItems(*maxItems); err != nil {
+				return err
+			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
 				return err
@@ -77,7 +82,7 @@ Examples:
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			if !*paginate {
+			if !*paginate && *maxItems == 0 {
 				call := service.API.Reviews.List(pkg).Context(ctx).MaxResults(*maxResults)
 				if *startIndex > 0 {
 					call.StartIndex(*startIndex)
@@ -92,24 +97,29 @@ Examples:
 				return shared.PrintOutput(resp, *outputFlag, *pretty)
 			}
 
-			var all []*androidpublisher.Review
-			index := *startIndex
-			for {
+			// Reviews page by start index; the page token carries the next index.
+			all, truncated, err := shared.FetchListPages(ctx, *maxItems, func(ctx context.Context, token string) ([]*androidpublisher.Review, string, error) {
+				index := *startIndex
+				if token != "" {
+					index, _ = strconv.ParseInt(token, 10, 64)
+				}
 				call := service.API.Reviews.List(pkg).Context(ctx).MaxResults(*maxResults).StartIndex(index)
 				if strings.TrimSpace(*translation) != "" {
 					call.TranslationLanguage(*translation)
 				}
 				resp, err := call.Do()
 				if err != nil {
-					return err
+					return nil, "", err
 				}
-				all = append(all, resp.Reviews...)
 				if len(resp.Reviews) == 0 || int64(len(resp.Reviews)) < *maxResults {
-					break
+					return resp.Reviews, "", nil
 				}
-				index += int64(len(resp.Reviews))
+				return resp.Reviews, strconv.FormatInt(index+int64(len(resp.Reviews)), 10), nil
+			})
+			if err != nil {
+				return err
 			}
-			return shared.PrintOutput(all, *outputFlag, *pretty)
+			return shared.PrintPaginated(all, truncated, *maxItems, *outputFlag, *pretty)
 		},
 	}
 }
@@ -118,7 +128,7 @@ func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("reviews get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	reviewID := fs.String("review", "", "Review ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -158,7 +168,7 @@ func ReplyCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	reviewID := fs.String("review", "", "Review ID")
 	replyText := fs.String("text", "", "Reply text")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
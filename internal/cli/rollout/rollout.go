@@ -42,7 +42,7 @@ func HaltCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	track := fs.String("track", "production", "Track name")
 	changesNotSent := fs.Bool("changes-not-sent-for-review", false, "Changes not sent for review")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -68,7 +68,7 @@ func ResumeCommand() *ffcli.Command {
 	track := fs.String("track", "production", "Track name")
 	rolloutFraction := fs.Float64("rollout", 0, "New rollout fraction (0 = keep current)")
 	changesNotSent := fs.Bool("changes-not-sent-for-review", false, "Changes not sent for review")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -95,7 +95,7 @@ func UpdateCommand() *ffcli.Command {
 	track := fs.String("track", "production", "Track name")
 	rolloutFraction := fs.Float64("rollout", 0, "New rollout fraction (required)")
 	changesNotSent := fs.Bool("changes-not-sent-for-review", false, "Changes not sent for review")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -123,7 +123,7 @@ func CompleteCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	track := fs.String("track", "production", "Track name")
 	changesNotSent := fs.Bool("changes-not-sent-for-review", false, "Changes not sent for review")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -66,6 +66,7 @@ func setupCommand() *ffcli.Command {
 	topic := fs.String("topic", "play-rtdn", "Pub/Sub topic name")
 	dryRun := fs.Bool("dry-run", false, "Print gcloud commands without executing")
 	outputFlag := fs.String("output", "text", "Output format: text (default), json")
+	shared.DeclareOutputFormats(fs, "text", "json")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -191,6 +192,7 @@ func statusCommand() *ffcli.Command {
 	project := fs.String("project", "", "GCP project ID (required)")
 	topic := fs.String("topic", "play-rtdn", "Pub/Sub topic name")
 	outputFlag := fs.String("output", "text", "Output format: text (default), json")
+	shared.DeclareOutputFormats(fs, "text", "json")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -273,9 +275,9 @@ func runStatus(ctx context.Context, opts StatusOptions) error {
 // decodeCommand parses a Pub/Sub envelope (or inner payload) from --file, --data, or stdin.
 func decodeCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("rtdn decode", flag.ExitOnError)
-	file := fs.String("file", "", "Path to payload JSON file ('-' for stdin)")
+	file := fs.String("file", "", "Path to payload JSON file (- or @- for stdin)")
 	data := fs.String("data", "", "Inline payload JSON (overrides --file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", true, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -318,7 +320,7 @@ func loadPayload(file, inline string, stdin io.Reader) ([]byte, error) {
 	if strings.TrimSpace(file) == "" {
 		return nil, errors.New("one of --file or --data is required")
 	}
-	if file == "-" {
+	if shared.IsStdinInput(file) {
 		return io.ReadAll(stdin)
 	}
 	return os.ReadFile(file) // #nosec G304 -- user-supplied path
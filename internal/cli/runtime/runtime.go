@@ -50,12 +50,35 @@ func (rt *Runtime) ApplyRootContext(ctx context.Context) (context.Context, error
 	}
 
 	rt.RootFlags.Apply()
+	if err := rt.RootFlags.LoadEnvFile(); err != nil {
+		return ctx, err
+	}
 	if err := rt.RootFlags.ValidateReportFlags(); err != nil {
 		return ctx, err
 	}
+	if err := rt.RootFlags.ValidateQPS(); err != nil {
+		return ctx, err
+	}
+	if err := rt.RootFlags.ValidateRaw(); err != nil {
+		return ctx, err
+	}
+	if err := rt.RootFlags.ValidateTimeouts(); err != nil {
+		return ctx, err
+	}
+	if err := rt.RootFlags.ValidateAPIBaseURL(); err != nil {
+		return ctx, err
+	}
+	requestTimeout, uploadTimeout := rt.RootFlags.TimeoutOverrides()
+	ctx = shared.ContextWithTimeoutOverrides(ctx, requestTimeout, uploadTimeout)
 	if rt.RootFlags.DryRun != nil && *rt.RootFlags.DryRun {
 		ctx = shared.ContextWithDryRun(ctx, true)
 	}
+	if shared.QuietFromEnv() {
+		ctx = shared.ContextWithQuiet(ctx, true)
+	}
+	if rt.RootFlags.Trace != nil && *rt.RootFlags.Trace {
+		ctx = shared.ContextWithTracer(ctx, shared.NewTracer())
+	}
 
 	return ctx, nil
 }
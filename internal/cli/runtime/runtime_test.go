@@ -5,8 +5,10 @@ import (
 	"flag"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
 )
 
 func TestNewRoot_BindsRootFlags(t *testing.T) {
@@ -70,3 +72,56 @@ func TestApplyRootContext_ValidatesReportFlags(t *testing.T) {
 		t.Fatal("expected report flag validation error")
 	}
 }
+
+func TestApplyRootContext_Trace(t *testing.T) {
+	fs := flag.NewFlagSet("gplay", flag.ContinueOnError)
+	rt := NewRoot(fs)
+	if err := fs.Parse([]string{"--trace"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	ctx, err := rt.ApplyRootContext(context.Background())
+	if err != nil {
+		t.Fatalf("ApplyRootContext: %v", err)
+	}
+	if shared.TracerFromContext(ctx) == nil {
+		t.Fatal("expected tracer in context")
+	}
+}
+
+func TestApplyRootContext_Quiet(t *testing.T) {
+	t.Setenv("GPLAY_QUIET", "")
+
+	fs := flag.NewFlagSet("gplay", flag.ContinueOnError)
+	rt := NewRoot(fs)
+	if err := fs.Parse([]string{"--quiet"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	ctx, err := rt.ApplyRootContext(context.Background())
+	if err != nil {
+		t.Fatalf("ApplyRootContext: %v", err)
+	}
+	if !shared.IsQuiet(ctx) {
+		t.Fatal("expected quiet context")
+	}
+}
+
+func TestApplyRootContext_TimeoutOverride(t *testing.T) {
+	fs := flag.NewFlagSet("gplay", flag.ContinueOnError)
+	rt := NewRoot(fs)
+	if err := fs.Parse([]string{"--timeout", "2s"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	ctx, err := rt.ApplyRootContext(context.Background())
+	if err != nil {
+		t.Fatalf("ApplyRootContext: %v", err)
+	}
+	ctx, cancel := shared.ContextWithTimeout(ctx, &config.Config{Timeout: config.DurationValue{Duration: time.Hour}})
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > 2*time.Second {
+		t.Fatalf("expected --timeout 2s to win over config, deadline ok=%v in %s", ok, time.Until(deadline))
+	}
+}
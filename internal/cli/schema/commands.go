@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/deploy"
+	"github.com/tamtom/play-console-cli/internal/cli/validate"
+)
+
+// outputTypes maps a command path to the type it prints with --output json.
+// List commands are described by their single-page response; --paginate
+// prints the bare item array instead.
+var outputTypes = map[string]reflect.Type{
+	"apks addexternallyhosted": reflect.TypeOf(androidpublisher.ApksAddExternallyHostedResponse{}),
+	"apks list":                reflect.TypeOf(androidpublisher.ApksListResponse{}),
+	"apks upload":              reflect.TypeOf(androidpublisher.Apk{}),
+	"bundles list":             reflect.TypeOf(androidpublisher.BundlesListResponse{}),
+	"bundles upload":           reflect.TypeOf(androidpublisher.Bundle{}),
+	"deploy":                   reflect.TypeOf(deploy.Result{}),
+	"details get":              reflect.TypeOf(androidpublisher.AppDetails{}),
+	"details patch":            reflect.TypeOf(androidpublisher.AppDetails{}),
+	"details update":           reflect.TypeOf(androidpublisher.AppDetails{}),
+	"edits commit":             reflect.TypeOf(androidpublisher.AppEdit{}),
+	"edits create":             reflect.TypeOf(androidpublisher.AppEdit{}),
+	"edits get":                reflect.TypeOf(androidpublisher.AppEdit{}),
+	"edits validate":           reflect.TypeOf(androidpublisher.AppEdit{}),
+	"iap create":               reflect.TypeOf(androidpublisher.InAppProduct{}),
+	"iap get":                  reflect.TypeOf(androidpublisher.InAppProduct{}),
+	"iap list":                 reflect.TypeOf(androidpublisher.InappproductsListResponse{}),
+	"iap patch":                reflect.TypeOf(androidpublisher.InAppProduct{}),
+	"iap update":               reflect.TypeOf(androidpublisher.InAppProduct{}),
+	"listings get":             reflect.TypeOf(androidpublisher.Listing{}),
+	"listings list":            reflect.TypeOf(androidpublisher.ListingsListResponse{}),
+	"listings patch":           reflect.TypeOf(androidpublisher.Listing{}),
+	"listings update":          reflect.TypeOf(androidpublisher.Listing{}),
+	"onetimeproducts get":      reflect.TypeOf(androidpublisher.OneTimeProduct{}),
+	"onetimeproducts list":     reflect.TypeOf(androidpublisher.ListOneTimeProductsResponse{}),
+	"reviews get":              reflect.TypeOf(androidpublisher.Review{}),
+	"reviews list":             reflect.TypeOf(androidpublisher.ReviewsListResponse{}),
+	"reviews reply":            reflect.TypeOf(androidpublisher.ReviewsReplyResponse{}),
+	"subscriptions archive":    reflect.TypeOf(androidpublisher.Subscription{}),
+	"subscriptions create":     reflect.TypeOf(androidpublisher.Subscription{}),
+	"subscriptions get":        reflect.TypeOf(androidpublisher.Subscription{}),
+	"subscriptions list":       reflect.TypeOf(androidpublisher.ListSubscriptionsResponse{}),
+	"subscriptions update":     reflect.TypeOf(androidpublisher.Subscription{}),
+	"testers get":              reflect.TypeOf(androidpublisher.Testers{}),
+	"testers patch":            reflect.TypeOf(androidpublisher.Testers{}),
+	"testers update":           reflect.TypeOf(androidpublisher.Testers{}),
+	"tracks create":            reflect.TypeOf(androidpublisher.Track{}),
+	"tracks get":               reflect.TypeOf(androidpublisher.Track{}),
+	"tracks list":              reflect.TypeOf(androidpublisher.TracksListResponse{}),
+	"tracks patch":             reflect.TypeOf(androidpublisher.Track{}),
+	"tracks releases list":     reflect.TypeOf(androidpublisher.ListReleaseSummariesResponse{}),
+	"tracks update":            reflect.TypeOf(androidpublisher.Track{}),
+	"validate bundle":          reflect.TypeOf(validate.ValidationResult{}),
+	"validate listing":         reflect.TypeOf(validate.ValidationResult{}),
+	"validate screenshots":     reflect.TypeOf(validate.ValidationResult{}),
+}
+
+// Commands returns the command paths with a published output schema.
+func Commands() []string {
+	names := make([]string, 0, len(outputTypes))
+	for name := range outputTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForCommand returns the output schema for a command path such as
+// "tracks list". A leading "gplay" is ignored.
+func ForCommand(command string) (map[string]interface{}, error) {
+	path := strings.Join(strings.Fields(command), " ")
+	path = strings.TrimPrefix(path, "gplay ")
+	t, ok := outputTypes[path]
+	if !ok {
+		return nil, fmt.Errorf("no output schema for %q; commands with a schema: %s", command, strings.Join(Commands(), ", "))
+	}
+	s := Generate(t)
+	s["title"] = "gplay " + path
+	return s, nil
+}
@@ -0,0 +1,190 @@
+// Package schema derives JSON Schemas for command output from the Go types
+// the commands print, so the JSON contract can be checked by integrators.
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// Draft is the JSON Schema dialect emitted by Generate.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// quotedSlices are the googleapi slice types that encode each element as a
+// JSON string, e.g. TrackRelease.versionCodes ["41","42"].
+var quotedSlices = map[reflect.Type]bool{
+	reflect.TypeOf(googleapi.Int64s{}):  true,
+	reflect.TypeOf(googleapi.Int32s{}):  true,
+	reflect.TypeOf(googleapi.Uint64s{}): true,
+	reflect.TypeOf(googleapi.Uint32s{}): true,
+}
+
+// Generate returns the JSON Schema for the encoding/json representation of
+// t. Named struct types are emitted once under $defs and referenced, which
+// keeps recursive types finite.
+func Generate(t reflect.Type) map[string]interface{} {
+	g := &generator{defs: map[string]interface{}{}}
+	root := g.schemaFor(t)
+	out := map[string]interface{}{"$schema": Draft}
+	for k, v := range root {
+		out[k] = v
+	}
+	if len(g.defs) > 0 {
+		out["$defs"] = g.defs
+	}
+	return out
+}
+
+type generator struct {
+	defs map[string]interface{}
+}
+
+func (g *generator) schemaFor(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	if quotedSlices[t] {
+		return map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}
+	}
+	if t == reflect.TypeOf(googleapi.Float64s{}) {
+		return map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "number"}}
+	}
+	// Types such as json.RawMessage control their own encoding; their shape
+	// is not knowable from the Go type.
+	if t.Kind() != reflect.Struct && t.Implements(marshalerType) {
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := defName(t)
+		if _, ok := g.defs[name]; !ok {
+			// Reserve the name before descending so self-references resolve.
+			g.defs[name] = nil
+			g.defs[name] = g.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + name}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func (g *generator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	g.addFields(t, properties, &required)
+	s := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (g *generator) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		var prop map[string]interface{}
+		if hasOption(opts, "string") {
+			// The ",string" option quotes scalars, e.g. int64 version codes.
+			prop = map[string]interface{}{"type": "string"}
+		} else {
+			prop = g.schemaFor(field.Type)
+		}
+		properties[name] = prop
+		if !hasOption(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func hasOption(opts, want string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == want {
+			return true
+		}
+	}
+	return false
+}
+
+// defName qualifies the type name with its package so types from different
+// packages (deploy.Result, validate.Result) do not collide. A trailing major
+// version element is skipped: androidpublisher/v3 becomes androidpublisher.
+func defName(t reflect.Type) string {
+	parts := strings.Split(t.PkgPath(), "/")
+	pkg := parts[len(parts)-1]
+	if len(parts) > 1 && isMajorVersion(pkg) {
+		pkg = parts[len(parts)-2]
+	}
+	if pkg == "" {
+		return t.Name()
+	}
+	return pkg + "." + t.Name()
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
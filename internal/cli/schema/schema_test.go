@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+type simpleStep struct {
+	Name string `json:"name"`
+}
+
+type simpleResult struct {
+	ID        string            `json:"id"`
+	Count     int64             `json:"count,omitempty"`
+	Quoted    int64             `json:"quoted,omitempty,string"`
+	Fraction  float64           `json:"fraction,omitempty"`
+	Done      bool              `json:"done"`
+	Steps     []simpleStep      `json:"steps"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Codes     googleapi.Int64s  `json:"codes,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Next      *simpleResult     `json:"next,omitempty"`
+	Ignored   string            `json:"-"`
+	internal  string
+}
+
+func TestGenerate_SimpleResultType(t *testing.T) {
+	got := Generate(reflect.TypeOf(simpleResult{}))
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("marshal schema: %v", err)
+	}
+	const want = `{"$defs":{` +
+		`"schema.simpleResult":{"properties":{` +
+		`"codes":{"items":{"type":"string"},"type":"array"},` +
+		`"count":{"type":"integer"},` +
+		`"createdAt":{"format":"date-time","type":"string"},` +
+		`"done":{"type":"boolean"},` +
+		`"fraction":{"type":"number"},` +
+		`"id":{"type":"string"},` +
+		`"labels":{"additionalProperties":{"type":"string"},"type":"object"},` +
+		`"next":{"$ref":"#/$defs/schema.simpleResult"},` +
+		`"quoted":{"type":"string"},` +
+		`"steps":{"items":{"$ref":"#/$defs/schema.simpleStep"},"type":"array"}` +
+		`},"required":["id","done","steps","createdAt"],"type":"object"},` +
+		`"schema.simpleStep":{"properties":{"name":{"type":"string"}},"required":["name"],"type":"object"}` +
+		`},"$ref":"#/$defs/schema.simpleResult","$schema":"https://json-schema.org/draft/2020-12/schema"}`
+	if string(data) != want {
+		t.Fatalf("schema mismatch\n got: %s\nwant: %s", data, want)
+	}
+}
+
+func TestForCommand_AllRegisteredCommands(t *testing.T) {
+	for _, name := range Commands() {
+		s, err := ForCommand(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if s["title"] != "gplay "+name || s["$ref"] == nil {
+			t.Errorf("%s: unexpected schema header: title=%v ref=%v", name, s["title"], s["$ref"])
+		}
+		if _, err := json.Marshal(s); err != nil {
+			t.Errorf("%s: schema does not encode: %v", name, err)
+		}
+	}
+}
+
+func TestForCommand_NormalizesPath(t *testing.T) {
+	s, err := ForCommand("  gplay  tracks   list ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s["$ref"] != "#/$defs/androidpublisher.TracksListResponse" {
+		t.Fatalf("unexpected ref: %v", s["$ref"])
+	}
+}
+
+func TestForCommand_Unknown(t *testing.T) {
+	_, err := ForCommand("nope")
+	if err == nil || !strings.Contains(err.Error(), "tracks list") {
+		t.Fatalf("expected error listing known commands, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+// BatchGetLimit is the most items the API accepts in one batch request.
+const BatchGetLimit = 100
+
+// MaxBatchConcurrency caps --concurrency for chunked batch-get calls.
+const MaxBatchConcurrency = 16
+
+// ChunkFailure records a chunk of a chunked batch call that failed. Start and
+// End are 1-based positions in the input.
+type ChunkFailure struct {
+	Start int
+	End   int
+	Err   error
+}
+
+func (f ChunkFailure) Error() string {
+	return fmt.Sprintf("items %d-%d: %v", f.Start, f.End, f.Err)
+}
+
+// ValidateChunkFlags checks --chunk-size and --concurrency for chunked
+// batch-get commands.
+func ValidateChunkFlags(chunkSize, concurrency int) error {
+	if chunkSize < 1 || chunkSize > BatchGetLimit {
+		return fmt.Errorf("--chunk-size must be between 1 and %d", BatchGetLimit)
+	}
+	if concurrency < 1 || concurrency > MaxBatchConcurrency {
+		return fmt.Errorf("--concurrency must be between 1 and %d", MaxBatchConcurrency)
+	}
+	return nil
+}
+
+// FetchInChunks splits items into chunks of at most size and calls fetch for
+// each, with at most concurrency calls in flight. Every call gets its own
+// request timeout from cfg, so a long input is not cut off by one deadline.
+// Results are merged in input order. A failed chunk is reported in the
+// returned failures and the results of the other chunks are kept.
+func FetchInChunks[I, T any](ctx context.Context, cfg *config.Config, items []I, size, concurrency int, fetch func(ctx context.Context, chunk []I) ([]T, error)) ([]T, []ChunkFailure) {
+	if size < 1 {
+		size = len(items)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var chunks [][]I
+	for start := 0; start < len(items); start += size {
+		chunks = append(chunks, items[start:min(start+size, len(items))])
+	}
+
+	results := make([][]T, len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			chunkCtx, cancel := ContextWithTimeout(ctx, cfg)
+			defer cancel()
+			results[i], errs[i] = fetch(chunkCtx, chunk)
+		}()
+	}
+	wg.Wait()
+
+	var merged []T
+	var failures []ChunkFailure
+	for i, chunkResults := range results {
+		if errs[i] != nil {
+			start := i*size + 1
+			failures = append(failures, ChunkFailure{Start: start, End: start + len(chunks[i]) - 1, Err: errs[i]})
+			continue
+		}
+		merged = append(merged, chunkResults...)
+	}
+	return merged, failures
+}
+
+// ChunkFailuresError reports failed chunks of command on stderr and returns
+// an error summarising them, or nil when every chunk succeeded.
+func ChunkFailuresError(command string, failures []ChunkFailure, total int) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	failed := 0
+	for _, f := range failures {
+		fmt.Fprintf(os.Stderr, "Warning: %s %s\n", command, f.Error())
+		failed += f.End - f.Start + 1
+	}
+	return fmt.Errorf("%s: %d of %d items failed in %d chunk(s); the other chunks succeeded", command, failed, total, len(failures))
+}
@@ -0,0 +1,113 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchInChunks_ChunkBoundariesAndOrder(t *testing.T) {
+	ids := []string{"a", "b", "c", "d", "e", "f", "g"}
+	var mu sync.Mutex
+	var chunks [][]string
+	var inFlight, peak int32
+
+	got, failures := FetchInChunks(context.Background(), nil, ids, 3, 2, func(ctx context.Context, chunk []string) ([]string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+				break
+			}
+		}
+		mu.Lock()
+		chunks = append(chunks, append([]string(nil), chunk...))
+		mu.Unlock()
+		// Finish the first chunk last so merge order cannot follow completion order.
+		if chunk[0] == "a" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		out := make([]string, len(chunk))
+		for i, id := range chunk {
+			out[i] = strings.ToUpper(id)
+		}
+		return out, nil
+	})
+
+	if len(failures) != 0 {
+		t.Fatalf("unexpected failures: %v", failures)
+	}
+	if want := []string{"A", "B", "C", "D", "E", "F", "G"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("merged = %v, want %v", got, want)
+	}
+	sizes := map[string]int{}
+	for _, c := range chunks {
+		sizes[c[0]] = len(c)
+	}
+	if want := map[string]int{"a": 3, "d": 3, "g": 1}; !reflect.DeepEqual(sizes, want) {
+		t.Fatalf("chunks = %v, want starts/sizes %v", chunks, want)
+	}
+	if peak > 2 {
+		t.Fatalf("peak concurrency = %d, want at most 2", peak)
+	}
+}
+
+func TestFetchInChunks_KeepsSuccessfulChunks(t *testing.T) {
+	ids := []string{"a", "b", "c", "d", "e"}
+	got, failures := FetchInChunks(context.Background(), nil, ids, 2, 4, func(ctx context.Context, chunk []string) ([]string, error) {
+		if chunk[0] == "c" {
+			return nil, errors.New("server cap exceeded")
+		}
+		return chunk, nil
+	})
+
+	if want := []string{"a", "b", "e"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("merged = %v, want %v", got, want)
+	}
+	if len(failures) != 1 || failures[0].Start != 3 || failures[0].End != 4 {
+		t.Fatalf("failures = %+v, want items 3-4", failures)
+	}
+
+	err := ChunkFailuresError("batch-get", failures, len(ids))
+	if err == nil || !strings.Contains(err.Error(), "batch-get: 2 of 5 items failed in 1 chunk(s)") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ChunkFailuresError("batch-get", nil, len(ids)) != nil {
+		t.Fatal("expected nil error without failures")
+	}
+}
+
+func TestValidateChunkFlags(t *testing.T) {
+	if err := ValidateChunkFlags(100, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tc := range [][2]int{{0, 4}, {101, 4}, {10, 0}, {10, MaxBatchConcurrency + 1}} {
+		if err := ValidateChunkFlags(tc[0], tc[1]); err == nil {
+			t.Errorf("ValidateChunkFlags(%d, %d): expected error", tc[0], tc[1])
+		}
+	}
+}
+
+func TestFetchInChunks_TimeoutAppliesPerChunk(t *testing.T) {
+	ids := []string{"a", "b", "c", "d"}
+	// Four sequential 80ms chunks outlast the 200ms timeout only if it is
+	// shared by the whole batch.
+	ctx := ContextWithTimeoutOverrides(context.Background(), 200*time.Millisecond, 0)
+	_, failures := FetchInChunks(ctx, nil, ids, 1, 1, func(ctx context.Context, chunk []string) ([]string, error) {
+		select {
+		case <-time.After(80 * time.Millisecond):
+			return chunk, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	if len(failures) != 0 {
+		t.Fatalf("expected every chunk to finish within its own timeout, got %v", failures)
+	}
+}
@@ -0,0 +1,173 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	batchJournalEnvVar = "GPLAY_BATCH_JOURNAL"
+	// batchJournalRetention bounds how long applied batches are remembered.
+	batchJournalRetention = 30 * 24 * time.Hour
+)
+
+var batchJournalMu sync.Mutex
+
+// BatchJournalEntry records a batch write that completed successfully.
+// The Play batch endpoints accept no client request IDs, so replay safety is
+// provided by this local journal, keyed by the --idempotency-key the caller
+// chose.
+type BatchJournalEntry struct {
+	Key       string    `json:"key"`
+	Command   string    `json:"command"`
+	Package   string    `json:"package"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// IdempotencyFlags holds the replay-protection flags for batch writes.
+type IdempotencyFlags struct {
+	Key   *string
+	Force *bool
+}
+
+// BindIdempotencyFlags registers --idempotency-key and --force on fs.
+func BindIdempotencyFlags(fs *flag.FlagSet) *IdempotencyFlags {
+	return &IdempotencyFlags{
+		Key:   fs.String("idempotency-key", "", "Key naming this batch; a batch already applied under the key is skipped (default: no replay protection)"),
+		Force: fs.Bool("force", false, "Re-apply the batch even if the journal shows its --idempotency-key was already applied"),
+	}
+}
+
+// RunIdempotentBatch calls apply and reports whether it did. With an
+// explicit --idempotency-key that the journal shows was already applied, it
+// prints a notice to stderr and returns applied == false without calling
+// apply, so the caller prints nothing. Without a key, or on dry runs, the
+// journal is not used.
+func RunIdempotentBatch(ctx context.Context, flags *IdempotencyFlags, command, pkg string, apply func() (interface{}, error)) (resp interface{}, applied bool, err error) {
+	key := ""
+	force := false
+	if flags != nil {
+		if flags.Key != nil {
+			key = strings.TrimSpace(*flags.Key)
+		}
+		if flags.Force != nil {
+			force = *flags.Force
+		}
+	}
+	if key == "" || IsDryRun(ctx) {
+		resp, err := apply()
+		return resp, err == nil, err
+	}
+
+	if !force {
+		entry, err := LookupAppliedBatch(key, command, pkg)
+		if err != nil {
+			return nil, false, err
+		}
+		if entry != nil {
+			fmt.Fprintf(os.Stderr, "Skipped: batch with idempotency key %q was already applied at %s; use --force to re-apply.\n", key, entry.AppliedAt.Format(time.RFC3339))
+			return nil, false, nil
+		}
+	}
+
+	resp, err = apply()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := RecordAppliedBatch(key, command, pkg); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record batch in journal: %v\n", err)
+	}
+	return resp, true, nil
+}
+
+// BatchJournalPath returns the journal location, honoring GPLAY_BATCH_JOURNAL.
+func BatchJournalPath() (string, error) {
+	if env := strings.TrimSpace(os.Getenv(batchJournalEnvVar)); env != "" {
+		return filepath.Clean(env), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".gplay", "batch-journal.json"), nil
+}
+
+// LookupAppliedBatch returns the journal entry for key, if one exists. A key
+// recorded for a different command or package is an error rather than a
+// match, so reusing a key never skips a write that did not happen.
+func LookupAppliedBatch(key, command, pkg string) (*BatchJournalEntry, error) {
+	batchJournalMu.Lock()
+	defer batchJournalMu.Unlock()
+
+	entries, err := readBatchJournal()
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].Key != key {
+			continue
+		}
+		if entries[i].Command != command || entries[i].Package != pkg {
+			return nil, fmt.Errorf("--idempotency-key %q was already used for %s on %s; choose a different key or pass --force", key, entries[i].Command, entries[i].Package)
+		}
+		return &entries[i], nil
+	}
+	return nil, nil
+}
+
+// RecordAppliedBatch stores key in the journal after a successful batch write.
+func RecordAppliedBatch(key, command, pkg string) error {
+	batchJournalMu.Lock()
+	defer batchJournalMu.Unlock()
+
+	entries, err := readBatchJournal()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	kept := make([]BatchJournalEntry, 0, len(entries)+1)
+	for _, e := range entries {
+		if e.Key == key || now.Sub(e.AppliedAt) > batchJournalRetention {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	kept = append(kept, BatchJournalEntry{Key: key, Command: command, Package: pkg, AppliedAt: now})
+
+	path, err := BatchJournalPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return err
+	}
+	return AtomicWrite(path, data, 0o600)
+}
+
+func readBatchJournal() ([]BatchJournalEntry, error) {
+	path, err := BatchJournalPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read batch journal: %w", err)
+	}
+	var entries []BatchJournalEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse batch journal %s: %w", path, err)
+	}
+	return entries, nil
+}
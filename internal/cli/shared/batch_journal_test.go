@@ -0,0 +1,151 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func useTempBatchJournal(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "batch-journal.json")
+	t.Setenv(batchJournalEnvVar, path)
+	return path
+}
+
+func newIdempotencyFlags(t *testing.T, args ...string) *IdempotencyFlags {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := BindIdempotencyFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return flags
+}
+
+func TestRunIdempotentBatch_NoKeyAlwaysApplies(t *testing.T) {
+	path := useTempBatchJournal(t)
+	calls := 0
+	apply := func() (interface{}, error) {
+		calls++
+		return map[string]int{"updated": 1}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, applied, err := RunIdempotentBatch(context.Background(), newIdempotencyFlags(t), "iap batch-update", "com.example", apply)
+		if err != nil {
+			t.Fatalf("run %d: %v", i+1, err)
+		}
+		if !applied || resp == nil {
+			t.Fatalf("run %d: expected the API response, got %v (applied=%v)", i+1, resp, applied)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("apply called %d times, want 2", calls)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("runs without --idempotency-key should not write the journal, stat err = %v", err)
+	}
+}
+
+func TestRunIdempotentBatch_ExplicitKeyReplaySkipped(t *testing.T) {
+	useTempBatchJournal(t)
+	calls := 0
+	apply := func() (interface{}, error) {
+		calls++
+		return map[string]int{"updated": 1}, nil
+	}
+	flags := newIdempotencyFlags(t, "--idempotency-key", "release-42")
+	if _, applied, err := RunIdempotentBatch(context.Background(), flags, "offers batch-update", "com.example", apply); err != nil || !applied {
+		t.Fatalf("first run: applied=%v, err=%v", applied, err)
+	}
+	resp, applied, err := RunIdempotentBatch(context.Background(), flags, "offers batch-update", "com.example", apply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied || resp != nil {
+		t.Errorf("replay should be skipped without a result, got %v (applied=%v)", resp, applied)
+	}
+	if calls != 1 {
+		t.Errorf("apply called %d times, want 1", calls)
+	}
+}
+
+func TestRunIdempotentBatch_KeyReusedElsewhereFails(t *testing.T) {
+	useTempBatchJournal(t)
+	calls := 0
+	apply := func() (interface{}, error) {
+		calls++
+		return map[string]int{"updated": 1}, nil
+	}
+	flags := newIdempotencyFlags(t, "--idempotency-key", "release-42")
+	if _, applied, err := RunIdempotentBatch(context.Background(), flags, "offers batch-update", "com.example", apply); err != nil || !applied {
+		t.Fatalf("first run: applied=%v, err=%v", applied, err)
+	}
+	for _, run := range []struct{ command, pkg string }{
+		{"offers batch-update", "com.example.other"},
+		{"iap batch-update", "com.example"},
+	} {
+		_, applied, err := RunIdempotentBatch(context.Background(), flags, run.command, run.pkg, apply)
+		if err == nil || applied {
+			t.Errorf("%s on %s: expected a reused-key error, got applied=%v err=%v", run.command, run.pkg, applied, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("apply called %d times, want 1", calls)
+	}
+}
+
+func TestRunIdempotentBatch_ForceReapplies(t *testing.T) {
+	useTempBatchJournal(t)
+	calls := 0
+	apply := func() (interface{}, error) {
+		calls++
+		return nil, nil
+	}
+	if _, _, err := RunIdempotentBatch(context.Background(), newIdempotencyFlags(t, "--idempotency-key", "k"), "iap batch-update", "com.example", apply); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := RunIdempotentBatch(context.Background(), newIdempotencyFlags(t, "--idempotency-key", "k", "--force"), "iap batch-update", "com.example", apply); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("apply called %d times, want 2", calls)
+	}
+}
+
+func TestRunIdempotentBatch_FailureNotRecorded(t *testing.T) {
+	useTempBatchJournal(t)
+	flags := newIdempotencyFlags(t, "--idempotency-key", "k")
+	failing := func() (interface{}, error) { return nil, errors.New("network blip") }
+	if _, _, err := RunIdempotentBatch(context.Background(), flags, "iap batch-update", "com.example", failing); err == nil {
+		t.Fatal("expected error")
+	}
+
+	calls := 0
+	apply := func() (interface{}, error) {
+		calls++
+		return nil, nil
+	}
+	if _, _, err := RunIdempotentBatch(context.Background(), flags, "iap batch-update", "com.example", apply); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("retry after failure should apply, got %d calls", calls)
+	}
+}
+
+func TestRunIdempotentBatch_DryRunSkipsJournal(t *testing.T) {
+	path := useTempBatchJournal(t)
+	ctx := ContextWithDryRun(context.Background(), true)
+	flags := newIdempotencyFlags(t, "--idempotency-key", "k")
+	if _, _, err := RunIdempotentBatch(ctx, flags, "iap batch-update", "com.example", func() (interface{}, error) { return nil, nil }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("dry run should not write the journal, stat err = %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package shared
+
+import "flag"
+
+// BatchSummary condenses a batch write response into outcome counts.
+type BatchSummary struct {
+	Requested int      `json:"requested"`
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Created   int      `json:"created"`
+	Updated   int      `json:"updated"`
+	Deleted   int      `json:"deleted"`
+	FailedIDs []string `json:"failed_ids,omitempty"`
+}
+
+// BindSummaryFlag registers the --summary flag on fs.
+func BindSummaryFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("summary", false, "Print outcome counts instead of the full response")
+}
+
+// SummarizeBatchUpdate compares the requested IDs with the IDs echoed back by
+// the API. existing holds the IDs that existed before the write and is used to
+// split successes into created and updated; when nil every success counts as
+// an update.
+func SummarizeBatchUpdate(requested, returned []string, existing map[string]bool) *BatchSummary {
+	summary := &BatchSummary{Requested: len(requested)}
+	ok := make(map[string]bool, len(returned))
+	for _, id := range returned {
+		ok[id] = true
+	}
+	for _, id := range requested {
+		if !ok[id] {
+			summary.Failed++
+			summary.FailedIDs = append(summary.FailedIDs, id)
+			continue
+		}
+		summary.Succeeded++
+		if existing != nil && !existing[id] {
+			summary.Created++
+		} else {
+			summary.Updated++
+		}
+	}
+	return summary
+}
+
+// SummarizeBatchDelete summarizes a batch delete. The delete endpoints are
+// all-or-nothing, so a nil error means every requested ID was removed.
+func SummarizeBatchDelete(requested []string) *BatchSummary {
+	return &BatchSummary{
+		Requested: len(requested),
+		Succeeded: len(requested),
+		Deleted:   len(requested),
+	}
+}
@@ -0,0 +1,39 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeBatchUpdate_MixedOutcomes(t *testing.T) {
+	requested := []string{"coins_100", "coins_500", "gems_10", "gems_50"}
+	existing := map[string]bool{"coins_100": true, "coins_500": true}
+	returned := []string{"coins_100", "gems_10"}
+
+	got := SummarizeBatchUpdate(requested, returned, existing)
+	want := &BatchSummary{
+		Requested: 4,
+		Succeeded: 2,
+		Failed:    2,
+		Created:   1,
+		Updated:   1,
+		FailedIDs: []string{"coins_500", "gems_50"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestSummarizeBatchUpdate_UnknownExistingCountsUpdates(t *testing.T) {
+	got := SummarizeBatchUpdate([]string{"a", "b"}, []string{"a", "b"}, nil)
+	if got.Succeeded != 2 || got.Updated != 2 || got.Created != 0 || got.Failed != 0 {
+		t.Errorf("unexpected summary: %+v", got)
+	}
+}
+
+func TestSummarizeBatchDelete(t *testing.T) {
+	got := SummarizeBatchDelete([]string{"a", "b", "c"})
+	if got.Requested != 3 || got.Succeeded != 3 || got.Deleted != 3 || got.Failed != 0 {
+		t.Errorf("unexpected summary: %+v", got)
+	}
+}
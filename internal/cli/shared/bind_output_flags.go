@@ -18,7 +18,7 @@ type OutputFlags struct {
 // The GPLAY_DEFAULT_OUTPUT env var overrides the default.
 func BindOutputFlags(fs *flag.FlagSet) *OutputFlags {
 	defaultFormat := defaultOutputFormat()
-	output := fs.String("output", defaultFormat, "Output format: json, table, markdown")
+	output := fs.String("output", defaultFormat, "Output format: json, table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 	return &OutputFlags{Output: output, Pretty: pretty}
 }
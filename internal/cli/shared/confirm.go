@@ -0,0 +1,90 @@
+package shared
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ProductionTrack is the name of the Play Console production track.
+const ProductionTrack = "production"
+
+var confirmOutput io.Writer = os.Stderr
+
+// IsProductionTrack reports whether track names the production track.
+func IsProductionTrack(track string) bool {
+	return strings.EqualFold(strings.TrimSpace(track), ProductionTrack)
+}
+
+// ConfirmProductionTrack gates changes that target the production track.
+// Unless assumeYes is set, the user must type the track name on stdin.
+// Non-production tracks pass through without prompting.
+func ConfirmProductionTrack(track, action string, assumeYes bool) error {
+	if !IsProductionTrack(track) || assumeYes {
+		return nil
+	}
+	if !stdinIsTerminal() {
+		return fmt.Errorf("%s targets the production track; pass --assume-yes to confirm in non-interactive mode", action)
+	}
+
+	fmt.Fprintf(confirmOutput, "You are about to %s on the production track.\n", action)
+	fmt.Fprintf(confirmOutput, "Type %q to continue: ", ProductionTrack)
+
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("read confirmation: %w", err)
+	}
+	if strings.TrimSpace(line) != ProductionTrack {
+		return fmt.Errorf("production confirmation failed: expected %q", ProductionTrack)
+	}
+	return nil
+}
+
+// ErrCancelled is returned when the user declines a confirmation prompt.
+var ErrCancelled = errors.New("cancelled")
+
+// RequireConfirmation asks prompt on an interactive terminal and returns nil
+// when the user answers yes, or ErrCancelled when they decline. When stdin
+// is not a terminal it fails with "<flag> is required", so scripts must pass
+// the flag explicitly.
+func RequireConfirmation(ctx context.Context, prompt, flag string) error {
+	if !stdinIsTerminal() {
+		return fmt.Errorf("%s is required", flag)
+	}
+	ok, err := Confirm(ctx, prompt)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrCancelled
+	}
+	return nil
+}
+
+// Confirm asks a yes/no question on an interactive terminal and reports
+// whether the user answered yes. When stdin is not a terminal it returns
+// false without prompting, so scripts keep needing an explicit --confirm.
+func Confirm(ctx context.Context, prompt string) (bool, error) {
+	if !stdinIsTerminal() {
+		return false, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	fmt.Fprintf(confirmOutput, "%s [y/N] ", prompt)
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,174 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func withConfirmIO(t *testing.T, input string, terminal bool) *bytes.Buffer {
+	t.Helper()
+	var out bytes.Buffer
+	origIn, origOut, origTerm := stdin, confirmOutput, stdinIsTerminal
+	stdin = strings.NewReader(input)
+	confirmOutput = &out
+	stdinIsTerminal = func() bool { return terminal }
+	t.Cleanup(func() {
+		stdin, confirmOutput, stdinIsTerminal = origIn, origOut, origTerm
+	})
+	return &out
+}
+
+func TestConfirmProductionTrack_NonProductionSkipsPrompt(t *testing.T) {
+	out := withConfirmIO(t, "", true)
+	if err := ConfirmProductionTrack("beta", "update releases", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no prompt, got %q", out.String())
+	}
+}
+
+func TestConfirmProductionTrack_AssumeYesSkipsPrompt(t *testing.T) {
+	out := withConfirmIO(t, "", false)
+	if err := ConfirmProductionTrack("production", "update releases", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no prompt, got %q", out.String())
+	}
+}
+
+func TestConfirmProductionTrack_NonInteractiveFails(t *testing.T) {
+	withConfirmIO(t, "production\n", false)
+	err := ConfirmProductionTrack("production", "update releases", false)
+	if err == nil {
+		t.Fatal("expected error in non-interactive mode")
+	}
+	if !strings.Contains(err.Error(), "--assume-yes") {
+		t.Errorf("error should mention --assume-yes, got: %s", err.Error())
+	}
+}
+
+func TestConfirmProductionTrack_TypedTrackNameAccepted(t *testing.T) {
+	out := withConfirmIO(t, "production\n", true)
+	if err := ConfirmProductionTrack("production", "update releases", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Type "production"`) {
+		t.Errorf("expected prompt, got %q", out.String())
+	}
+}
+
+func TestConfirmProductionTrack_WrongInputRejected(t *testing.T) {
+	for _, input := range []string{"y\n", "yes\n", "Production\n", ""} {
+		t.Run(input, func(t *testing.T) {
+			withConfirmIO(t, input, true)
+			err := ConfirmProductionTrack("PRODUCTION", "update releases", false)
+			if err == nil {
+				t.Fatalf("expected error for input %q", input)
+			}
+			if !strings.Contains(err.Error(), "confirmation failed") {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfirmProductionTrack_ReadError(t *testing.T) {
+	withConfirmIO(t, "", true)
+	stdin = errReader{}
+	if err := ConfirmProductionTrack("production", "commit", false); err == nil {
+		t.Fatal("expected read error")
+	}
+}
+
+func TestConfirm_NonInteractiveReturnsFalseWithoutPrompt(t *testing.T) {
+	out := withConfirmIO(t, "y\n", false)
+	ok, err := Confirm(context.Background(), "Delete subscription premium_monthly?")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected false when stdin is not a terminal")
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no prompt, got %q", out.String())
+	}
+}
+
+func TestConfirm_Answers(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"Y\n", true},
+		{"yes\n", true},
+		{" YES \n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+		{"yep\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.input), func(t *testing.T) {
+			out := withConfirmIO(t, tt.input, true)
+			ok, err := Confirm(context.Background(), "Delete subscription premium_monthly?")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tt.want {
+				t.Errorf("Confirm(%q) = %v, want %v", tt.input, ok, tt.want)
+			}
+			if out.String() != "Delete subscription premium_monthly? [y/N] " {
+				t.Errorf("unexpected prompt %q", out.String())
+			}
+		})
+	}
+}
+
+func TestConfirm_ReadError(t *testing.T) {
+	withConfirmIO(t, "", true)
+	stdin = errReader{}
+	if _, err := Confirm(context.Background(), "Delete?"); err == nil {
+		t.Fatal("expected read error")
+	}
+}
+
+func TestConfirm_CancelledContext(t *testing.T) {
+	withConfirmIO(t, "y\n", true)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Confirm(ctx, "Delete?"); err == nil {
+		t.Fatal("expected context error")
+	}
+}
+
+func TestRequireConfirmation(t *testing.T) {
+	withConfirmIO(t, "y\n", true)
+	if err := RequireConfirmation(context.Background(), "Delete?", "--confirm"); err != nil {
+		t.Fatalf("expected yes to confirm, got %v", err)
+	}
+
+	withConfirmIO(t, "n\n", true)
+	if err := RequireConfirmation(context.Background(), "Delete?", "--confirm"); !errors.Is(err, ErrCancelled) {
+		t.Fatalf("expected ErrCancelled when declined, got %v", err)
+	}
+
+	out := withConfirmIO(t, "y\n", false)
+	err := RequireConfirmation(context.Background(), "Delete?", "--confirm")
+	if err == nil || err.Error() != "--confirm is required" {
+		t.Fatalf("expected --confirm is required without a terminal, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no prompt, got %q", out.String())
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, io.ErrUnexpectedEOF }
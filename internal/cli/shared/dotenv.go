@@ -0,0 +1,122 @@
+package shared
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var dotenvKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// EnvPair is one KEY=VALUE assignment from a dotenv file.
+type EnvPair struct {
+	Key   string
+	Value string
+}
+
+// ParseDotenv reads KEY=VALUE lines. Blank lines and lines starting with #
+// are skipped, and an optional "export " prefix is allowed. Values may be
+// double-quoted (with \n, \t, \", and \\ escapes), single-quoted (taken
+// literally), or bare, where a " #" starts a trailing comment.
+func ParseDotenv(r io.Reader) ([]EnvPair, error) {
+	var pairs []EnvPair
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		text = strings.TrimSpace(strings.TrimPrefix(text, "export "))
+		key, raw, ok := strings.Cut(text, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !dotenvKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", line)
+		}
+		value, err := parseDotenvValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", line, key, err)
+		}
+		pairs = append(pairs, EnvPair{Key: key, Value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+func parseDotenvValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	switch quote := raw[0]; quote {
+	case '"', '\'':
+		end := closingQuote(raw, quote)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated %c quote", quote)
+		}
+		if rest := strings.TrimSpace(raw[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected text after closing quote")
+		}
+		inner := raw[1:end]
+		if quote == '\'' {
+			return inner, nil
+		}
+		return strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(inner), nil
+	default:
+		if i := strings.Index(raw, " #"); i >= 0 {
+			raw = raw[:i]
+		}
+		return strings.TrimSpace(raw), nil
+	}
+}
+
+// closingQuote returns the index of the quote that closes raw[0], skipping
+// backslash-escaped quotes inside double quotes.
+func closingQuote(raw string, quote byte) int {
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// LoadEnvFile sets the variables in a dotenv file that are not already set
+// in the environment, so real environment variables take precedence. It
+// returns the keys it set.
+func LoadEnvFile(path string) ([]string, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("--env-file: %w", err)
+	}
+	defer f.Close()
+
+	pairs, err := ParseDotenv(f)
+	if err != nil {
+		return nil, fmt.Errorf("--env-file %s: %w", path, err)
+	}
+	var loaded []string
+	for _, pair := range pairs {
+		if _, exists := os.LookupEnv(pair.Key); exists {
+			continue
+		}
+		if err := os.Setenv(pair.Key, pair.Value); err != nil {
+			return loaded, fmt.Errorf("--env-file: set %s: %w", pair.Key, err)
+		}
+		loaded = append(loaded, pair.Key)
+	}
+	return loaded, nil
+}
@@ -0,0 +1,124 @@
+package shared
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv_QuotesAndComments(t *testing.T) {
+	input := strings.Join([]string{
+		"# CI credentials",
+		"",
+		"GPLAY_SERVICE_ACCOUNT_JSON=/secrets/sa.json",
+		"export GPLAY_PROFILE = ci ",
+		`GPLAY_PACKAGE_NAME="com.example.app" # trailing comment`,
+		`NOTE="line one\nsaid \"hi\""`,
+		`LITERAL='keep $HOME and \n as is'`,
+		"BARE=value # comment",
+		"HASH=a#b",
+		"EMPTY=",
+	}, "\n")
+
+	got, err := ParseDotenv(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDotenv: %v", err)
+	}
+	want := []EnvPair{
+		{"GPLAY_SERVICE_ACCOUNT_JSON", "/secrets/sa.json"},
+		{"GPLAY_PROFILE", "ci"},
+		{"GPLAY_PACKAGE_NAME", "com.example.app"},
+		{"NOTE", "line one\nsaid \"hi\""},
+		{"LITERAL", `keep $HOME and \n as is`},
+		{"BARE", "value"},
+		{"HASH", "a#b"},
+		{"EMPTY", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseDotenv =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestParseDotenv_Errors(t *testing.T) {
+	tests := map[string]string{
+		"no equals":         "JUST_A_WORD",
+		"bad key":           "1KEY=value",
+		"unterminated":      `KEY="open`,
+		"text after quotes": `KEY="a" b`,
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseDotenv(strings.NewReader("# header\n" + input)); err == nil || !strings.Contains(err.Error(), "line 2") {
+				t.Fatalf("expected a line 2 error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadEnvFile_DoesNotOverrideExistingEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := "GPLAY_TEST_FROM_FILE=file\nGPLAY_TEST_ALREADY_SET=file\nGPLAY_TEST_SET_EMPTY=file\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GPLAY_TEST_ALREADY_SET", "real")
+	t.Setenv("GPLAY_TEST_SET_EMPTY", "")
+	t.Setenv("GPLAY_TEST_FROM_FILE", "")
+	os.Unsetenv("GPLAY_TEST_FROM_FILE")
+
+	loaded, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatalf("LoadEnvFile: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, []string{"GPLAY_TEST_FROM_FILE"}) {
+		t.Fatalf("loaded = %v", loaded)
+	}
+	if got := os.Getenv("GPLAY_TEST_FROM_FILE"); got != "file" {
+		t.Errorf("GPLAY_TEST_FROM_FILE = %q, want file", got)
+	}
+	if got := os.Getenv("GPLAY_TEST_ALREADY_SET"); got != "real" {
+		t.Errorf("GPLAY_TEST_ALREADY_SET = %q, want real", got)
+	}
+	if got := os.Getenv("GPLAY_TEST_SET_EMPTY"); got != "" {
+		t.Errorf("GPLAY_TEST_SET_EMPTY = %q, want the explicitly empty value kept", got)
+	}
+}
+
+func TestRootFlags_EnvFileLosesToFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("GPLAY_PROFILE=from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GPLAY_PROFILE", "")
+	os.Unsetenv("GPLAY_PROFILE")
+
+	fs := flagSetForTest()
+	rf := BindRootFlags(fs)
+	if err := fs.Parse([]string{"--env-file", path, "--profile", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+	rf.Apply()
+	if err := rf.LoadEnvFile(); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv("GPLAY_PROFILE"); got != "from-flag" {
+		t.Fatalf("GPLAY_PROFILE = %q, want from-flag", got)
+	}
+
+	missing := "--env-file=" + filepath.Join(t.TempDir(), "missing.env")
+	fs = flagSetForTest()
+	rf = BindRootFlags(fs)
+	if err := fs.Parse([]string{missing}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rf.LoadEnvFile(); err == nil {
+		t.Fatal("expected an error for a missing env file")
+	}
+}
+
+func flagSetForTest() *flag.FlagSet {
+	return flag.NewFlagSet("test", flag.ContinueOnError)
+}
@@ -19,6 +19,7 @@ const (
 	CategoryTimeout     Category = "timeout"
 	CategoryMissingAuth Category = "missing_auth"
 	CategoryConflict    Category = "conflict"
+	CategoryQuota       Category = "quota"
 	CategoryGeneric     Category = "generic"
 )
 
@@ -43,6 +44,19 @@ func Classify(err error) *ClassifiedError {
 		return nil
 	}
 
+	// Quota failures can arrive as 403 or 429; the reason decides the advice.
+	if kind, ok := QuotaError(err); ok {
+		category := CategoryPermission
+		if kind == QuotaExhausted {
+			category = CategoryQuota
+		}
+		return &ClassifiedError{
+			Original: err,
+			Category: category,
+			Hint:     QuotaHint(kind),
+		}
+	}
+
 	// Check for Google API errors (401, 403, 404).
 	var gerr *googleapi.Error
 	if errors.As(err, &gerr) {
@@ -65,12 +79,6 @@ func Classify(err error) *ClassifiedError {
 				Category: CategoryNotFound,
 				Hint:     "Resource not found. Verify the package name and resource IDs are correct.",
 			}
-		case 429:
-			return &ClassifiedError{
-				Original: err,
-				Category: CategoryPermission,
-				Hint:     "API rate limit exceeded. Wait and retry, or increase GPLAY_RETRY_DELAY.",
-			}
 		case 409:
 			return &ClassifiedError{
 				Original: err,
@@ -94,6 +102,16 @@ func Classify(err error) *ClassifiedError {
 		}
 	}
 
+	// Check for expired configured timeouts, which know how to raise them.
+	var timeoutErr interface{ TimeoutHint() string }
+	if errors.As(err, &timeoutErr) {
+		return &ClassifiedError{
+			Original: err,
+			Category: CategoryTimeout,
+			Hint:     timeoutErr.TimeoutHint(),
+		}
+	}
+
 	// Check for timeout errors.
 	var netErr net.Error
 	if errors.As(err, &netErr) && netErr.Timeout() {
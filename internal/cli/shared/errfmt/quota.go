@@ -0,0 +1,72 @@
+package errfmt
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// QuotaKind tells a short-term rate limit apart from an exhausted quota.
+type QuotaKind string
+
+const (
+	// QuotaRateLimited means too many requests were sent in a short window;
+	// retrying after a delay succeeds.
+	QuotaRateLimited QuotaKind = "rate_limited"
+	// QuotaExhausted means the daily or project quota is used up; retries fail
+	// until the quota resets or is raised.
+	QuotaExhausted QuotaKind = "quota_exhausted"
+)
+
+// quotaReasons maps Google API error reasons to their quota kind. Legacy
+// errors carry the camelCase reason in error.errors[].reason; newer ones
+// carry the upper-case reason in a google.rpc.ErrorInfo detail.
+var quotaReasons = map[string]QuotaKind{
+	"rateLimitExceeded":     QuotaRateLimited,
+	"userRateLimitExceeded": QuotaRateLimited,
+	"RATE_LIMIT_EXCEEDED":   QuotaRateLimited,
+	"dailyLimitExceeded":    QuotaExhausted,
+	"quotaExceeded":         QuotaExhausted,
+	"RESOURCE_EXHAUSTED":    QuotaExhausted,
+}
+
+// QuotaError reports whether err is a Google API quota failure and which
+// kind. A 429 without a recognised reason counts as rate limiting.
+func QuotaError(err error) (QuotaKind, bool) {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return "", false
+	}
+	for _, item := range gerr.Errors {
+		if kind, ok := quotaReasons[item.Reason]; ok {
+			return kind, true
+		}
+	}
+	for _, detail := range gerr.Details {
+		fields, ok := detail.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		reason, _ := fields["reason"].(string)
+		if kind, ok := quotaReasons[reason]; ok {
+			return kind, true
+		}
+	}
+	if gerr.Code == http.StatusTooManyRequests {
+		return QuotaRateLimited, true
+	}
+	return "", false
+}
+
+// QuotaHint returns the user advice for a quota kind.
+func QuotaHint(kind QuotaKind) string {
+	switch kind {
+	case QuotaRateLimited:
+		return "API rate limit exceeded. Wait and retry, or increase GPLAY_RETRY_DELAY."
+	case QuotaExhausted:
+		return "API quota exhausted; retrying will not help until it resets. Request a higher quota for the Google Play Android Developer API in the Google Cloud console."
+	default:
+		return ""
+	}
+}
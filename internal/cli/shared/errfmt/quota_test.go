@@ -0,0 +1,100 @@
+package errfmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestQuotaError_MapsReasons(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantKind QuotaKind
+		wantOK   bool
+	}{
+		{
+			name:     "429 rateLimitExceeded",
+			err:      &googleapi.Error{Code: 429, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}},
+			wantKind: QuotaRateLimited,
+			wantOK:   true,
+		},
+		{
+			name:     "403 userRateLimitExceeded",
+			err:      &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "userRateLimitExceeded"}}},
+			wantKind: QuotaRateLimited,
+			wantOK:   true,
+		},
+		{
+			name:     "429 dailyLimitExceeded",
+			err:      &googleapi.Error{Code: 429, Errors: []googleapi.ErrorItem{{Reason: "dailyLimitExceeded"}}},
+			wantKind: QuotaExhausted,
+			wantOK:   true,
+		},
+		{
+			name:     "403 quotaExceeded",
+			err:      &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}}},
+			wantKind: QuotaExhausted,
+			wantOK:   true,
+		},
+		{
+			name: "ErrorInfo detail",
+			err: &googleapi.Error{Code: 429, Details: []interface{}{
+				map[string]interface{}{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "RESOURCE_EXHAUSTED"},
+			}},
+			wantKind: QuotaExhausted,
+			wantOK:   true,
+		},
+		{
+			name:     "429 without reason",
+			err:      &googleapi.Error{Code: 429},
+			wantKind: QuotaRateLimited,
+			wantOK:   true,
+		},
+		{
+			name:     "wrapped",
+			err:      fmt.Errorf("list: %w", &googleapi.Error{Code: 429, Errors: []googleapi.ErrorItem{{Reason: "dailyLimitExceeded"}}}),
+			wantKind: QuotaExhausted,
+			wantOK:   true,
+		},
+		{
+			name: "403 forbidden",
+			err:  &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}},
+		},
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, ok := QuotaError(tt.err)
+			if kind != tt.wantKind || ok != tt.wantOK {
+				t.Fatalf("QuotaError() = %q, %v; want %q, %v", kind, ok, tt.wantKind, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestClassify_QuotaReasonsGetDistinctHints(t *testing.T) {
+	rate := Classify(&googleapi.Error{Code: 429, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}})
+	if !strings.Contains(rate.Hint, "Wait and retry") {
+		t.Errorf("rate limit hint = %q; want retry advice", rate.Hint)
+	}
+
+	daily := Classify(&googleapi.Error{Code: 429, Errors: []googleapi.ErrorItem{{Reason: "dailyLimitExceeded"}}})
+	if daily.Category != CategoryQuota {
+		t.Errorf("Category = %q; want %q", daily.Category, CategoryQuota)
+	}
+	if !strings.Contains(daily.Hint, "Request a higher quota") || strings.Contains(daily.Hint, "Wait and retry") {
+		t.Errorf("daily limit hint = %q; want quota increase advice", daily.Hint)
+	}
+
+	forbiddenRate := Classify(&googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}})
+	if forbiddenRate.Hint != rate.Hint {
+		t.Errorf("403 rateLimitExceeded hint = %q; want %q", forbiddenRate.Hint, rate.Hint)
+	}
+}
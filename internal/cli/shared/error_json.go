@@ -0,0 +1,115 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared/errfmt"
+)
+
+// APIErrorInfo is the machine-readable form of a failed command, written to
+// stderr as {"error": ...} when the command's --output is json.
+type APIErrorInfo struct {
+	Code    int           `json:"code,omitempty"`
+	Message string        `json:"message"`
+	Status  string        `json:"status,omitempty"`
+	Details []interface{} `json:"details,omitempty"`
+	Hint    string        `json:"hint,omitempty"`
+}
+
+// newAPIErrorInfo captures the code, message, status, and details of a
+// Google API error.
+func newAPIErrorInfo(gerr *googleapi.Error) *APIErrorInfo {
+	info := &APIErrorInfo{
+		Code:    gerr.Code,
+		Message: gerr.Message,
+		Details: gerr.Details,
+	}
+	var body struct {
+		Error struct {
+			Status string `json:"status"`
+		} `json:"error"`
+	}
+	if json.Unmarshal([]byte(gerr.Body), &body) == nil {
+		info.Status = body.Error.Status
+	}
+	if info.Status == "" {
+		info.Status = http.StatusText(gerr.Code)
+	}
+	if info.Message == "" {
+		info.Message = gerr.Error()
+	}
+	return info
+}
+
+// ErrorInfo describes err for JSON output. Errors from WrapGoogleAPIError
+// keep the captured API fields and their hint; other Google API errors are
+// captured directly, and anything else carries just its message.
+func ErrorInfo(err error) *APIErrorInfo {
+	if err == nil {
+		return nil
+	}
+	var info *APIErrorInfo
+	var wrapped interface{ actionable() *ActionableError }
+	var gerr *googleapi.Error
+	switch {
+	case errors.As(err, &wrapped) && wrapped.actionable().API != nil:
+		captured := *wrapped.actionable().API
+		captured.Hint = wrapped.actionable().Hint
+		info = &captured
+	case errors.As(err, &gerr):
+		info = newAPIErrorInfo(gerr)
+	default:
+		info = &APIErrorInfo{Message: err.Error()}
+	}
+	if info.Hint == "" {
+		if classified := errfmt.Classify(err); classified != nil {
+			info.Hint = classified.Hint
+		}
+	}
+	return info
+}
+
+// WriteErrorJSON writes err to w as a single-line {"error": {...}} object.
+// The message, hint, and string values in details are scrubbed of secrets
+// unless masking is disabled.
+func WriteErrorJSON(w io.Writer, err error) error {
+	info := ErrorInfo(err)
+	if info != nil {
+		info.Message = MaskErrorText(info.Message)
+		info.Hint = MaskErrorText(info.Hint)
+		if len(info.Details) > 0 {
+			info.Details = maskErrorDetails(info.Details).([]interface{})
+		}
+	}
+	return json.NewEncoder(w).Encode(struct {
+		Error *APIErrorInfo `json:"error"`
+	}{info})
+}
+
+// maskErrorDetails returns a copy of v, decoded error details, with every
+// string value passed through MaskErrorText.
+func maskErrorDetails(v interface{}) interface{} {
+	switch typed := v.(type) {
+	case string:
+		return MaskErrorText(typed)
+	case []interface{}:
+		masked := make([]interface{}, len(typed))
+		for i, item := range typed {
+			masked[i] = maskErrorDetails(item)
+		}
+		return masked
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			masked[key] = maskErrorDetails(item)
+		}
+		return masked
+	default:
+		return v
+	}
+}
@@ -0,0 +1,59 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestWriteErrorJSON_WrappedForbidden(t *testing.T) {
+	gerr := &googleapi.Error{
+		Code:    403,
+		Message: "The caller does not have permission",
+		Details: []interface{}{map[string]interface{}{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "PERMISSION_DENIED"}},
+		Body:    `{"error":{"code":403,"message":"The caller does not have permission","status":"PERMISSION_DENIED"}}`,
+	}
+	err := WrapGoogleAPIError("list tracks", gerr)
+
+	var buf bytes.Buffer
+	if werr := WriteErrorJSON(&buf, err); werr != nil {
+		t.Fatal(werr)
+	}
+	var got struct {
+		Error struct {
+			Code    int                      `json:"code"`
+			Message string                   `json:"message"`
+			Status  string                   `json:"status"`
+			Details []map[string]interface{} `json:"details"`
+			Hint    string                   `json:"hint"`
+		} `json:"error"`
+	}
+	if uerr := json.Unmarshal(buf.Bytes(), &got); uerr != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), uerr)
+	}
+	e := got.Error
+	if e.Code != 403 || e.Message != "The caller does not have permission" || e.Status != "PERMISSION_DENIED" {
+		t.Fatalf("unexpected error object: %s", buf.String())
+	}
+	if len(e.Details) != 1 || e.Details[0]["reason"] != "PERMISSION_DENIED" {
+		t.Fatalf("expected details to be captured, got %s", buf.String())
+	}
+	if e.Hint == "" {
+		t.Fatalf("expected the permission hint, got %s", buf.String())
+	}
+}
+
+func TestErrorInfo_UnwrappedAndPlainErrors(t *testing.T) {
+	info := ErrorInfo(&googleapi.Error{Code: 404, Body: "not json"})
+	if info.Code != 404 || info.Status != "Not Found" || info.Message == "" {
+		t.Fatalf("unexpected info for raw API error: %+v", info)
+	}
+
+	info = ErrorInfo(errors.New("--token is required"))
+	if info.Code != 0 || info.Message != "--token is required" {
+		t.Fatalf("unexpected info for plain error: %+v", info)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"google.golang.org/api/googleapi"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared/errfmt"
 )
 
 // ActionableError adds context and an optional hint to an error.
@@ -14,6 +16,8 @@ type ActionableError struct {
 	Op    string
 	Cause error
 	Hint  string
+	// API holds the Google API error fields captured by WrapGoogleAPIError.
+	API *APIErrorInfo
 }
 
 func (e *ActionableError) Error() string {
@@ -24,6 +28,12 @@ func (e *ActionableError) Unwrap() error {
 	return e.Cause
 }
 
+// actionable is promoted to the typed errors below so errors.As can find the
+// embedded ActionableError.
+func (e *ActionableError) actionable() *ActionableError {
+	return e
+}
+
 // AuthError represents authentication failures.
 type AuthError struct{ ActionableError }
 
@@ -90,17 +100,22 @@ func WrapGoogleAPIError(op string, err error) error {
 		return nil
 	}
 	hint, kind := hintForGoogleAPIError(err)
+	base := ActionableError{Op: op, Cause: err, Hint: hint}
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		base.API = newAPIErrorInfo(gerr)
+	}
 	switch kind {
 	case "auth":
-		return NewAuthError(op, err, hint)
+		return &AuthError{ActionableError: base}
 	case "permission":
-		return NewPermissionError(op, err, hint)
+		return &PermissionError{ActionableError: base}
 	case "not_found":
-		return NewNotFoundError(op, err, hint)
+		return &NotFoundError{ActionableError: base}
 	case "validation":
-		return NewValidationError(op, err, hint)
+		return &ValidationError{ActionableError: base}
 	default:
-		return WrapActionable(err, op, hint)
+		return &base
 	}
 }
 
@@ -109,6 +124,9 @@ func hintForGoogleAPIError(err error) (string, string) {
 	if !errors.As(err, &gerr) {
 		return "", ""
 	}
+	if kind, ok := errfmt.QuotaError(err); ok {
+		return errfmt.QuotaHint(kind), ""
+	}
 	switch gerr.Code {
 	case http.StatusUnauthorized:
 		return "Check that the service account or OAuth token is valid and has access to the Play Console.", "auth"
@@ -0,0 +1,111 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const fieldsEnvVar = "GPLAY_FIELDS"
+
+// ParseFieldPaths splits a comma-separated list of dotted JSON paths such as
+// "productId,basePlans.basePlanId" into path segments. Empty entries are
+// ignored.
+func ParseFieldPaths(value string) [][]string {
+	var paths [][]string
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		var segments []string
+		for _, segment := range strings.Split(raw, ".") {
+			if segment = strings.TrimSpace(segment); segment != "" {
+				segments = append(segments, segment)
+			}
+		}
+		if len(segments) > 0 {
+			paths = append(paths, segments)
+		}
+	}
+	return paths
+}
+
+// ProjectFields round-trips data through JSON and keeps only the requested
+// paths. Arrays are projected element by element, so "basePlans.state"
+// selects the state of every base plan. Paths that do not exist are omitted.
+func ProjectFields(data interface{}, paths [][]string) (interface{}, error) {
+	if len(paths) == 0 {
+		return data, nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("project fields: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, fmt.Errorf("project fields: %w", err)
+	}
+	projected, _ := projectValue(generic, paths)
+	return projected, nil
+}
+
+// projectValue returns the projection of v and whether anything matched.
+func projectValue(v interface{}, paths [][]string) (interface{}, bool) {
+	switch typed := v.(type) {
+	case map[string]interface{}:
+		return projectMap(typed, paths)
+	case []interface{}:
+		result := make([]interface{}, 0, len(typed))
+		matched := false
+		for _, item := range typed {
+			projected, ok := projectValue(item, paths)
+			if ok {
+				matched = true
+				result = append(result, projected)
+			}
+		}
+		return result, matched
+	default:
+		return nil, false
+	}
+}
+
+func projectMap(m map[string]interface{}, paths [][]string) (interface{}, bool) {
+	whole := map[string]bool{}
+	nested := map[string][][]string{}
+	var order []string
+	for _, path := range paths {
+		key := path[0]
+		if _, seen := nested[key]; !seen && !whole[key] {
+			order = append(order, key)
+		}
+		if len(path) == 1 {
+			whole[key] = true
+			continue
+		}
+		nested[key] = append(nested[key], path[1:])
+	}
+
+	result := map[string]interface{}{}
+	for _, key := range order {
+		value, ok := m[key]
+		if !ok {
+			continue
+		}
+		if whole[key] {
+			result[key] = value
+			continue
+		}
+		if projected, ok := projectValue(value, nested[key]); ok {
+			result[key] = projected
+		}
+	}
+	return result, len(result) > 0
+}
+
+// fieldPathsFromEnv returns the projection requested via --fields.
+func fieldPathsFromEnv() [][]string {
+	return ParseFieldPaths(os.Getenv(fieldsEnvVar))
+}
@@ -0,0 +1,121 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func testSubscription() *androidpublisher.Subscription {
+	return &androidpublisher.Subscription{
+		PackageName: "com.example.app",
+		ProductId:   "premium",
+		BasePlans: []*androidpublisher.BasePlan{
+			{BasePlanId: "monthly", State: "ACTIVE", RegionalConfigs: []*androidpublisher.RegionalBasePlanConfig{{RegionCode: "US"}}},
+			{BasePlanId: "yearly", State: "DRAFT"},
+		},
+	}
+}
+
+func projectToJSON(t *testing.T, data interface{}, fields string) string {
+	t.Helper()
+	projected, err := ProjectFields(data, ParseFieldPaths(fields))
+	if err != nil {
+		t.Fatalf("ProjectFields: %v", err)
+	}
+	raw, err := json.Marshal(projected)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(raw)
+}
+
+func TestParseFieldPaths(t *testing.T) {
+	got := ParseFieldPaths(" productId, basePlans.state ,,basePlans..basePlanId")
+	want := [][]string{{"productId"}, {"basePlans", "state"}, {"basePlans", "basePlanId"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseFieldPaths = %v, want %v", got, want)
+	}
+	if got := ParseFieldPaths(""); got != nil {
+		t.Fatalf("expected no paths for empty input, got %v", got)
+	}
+}
+
+func TestProjectFields_NestedArrays(t *testing.T) {
+	got := projectToJSON(t, testSubscription(), "productId,basePlans.basePlanId,basePlans.state")
+	want := `{"basePlans":[{"basePlanId":"monthly","state":"ACTIVE"},{"basePlanId":"yearly","state":"DRAFT"}],"productId":"premium"}`
+	if got != want {
+		t.Fatalf("projection = %s, want %s", got, want)
+	}
+}
+
+func TestProjectFields_DeepPathSkipsNonMatchingElements(t *testing.T) {
+	got := projectToJSON(t, testSubscription(), "basePlans.regionalConfigs.regionCode")
+	want := `{"basePlans":[{"regionalConfigs":[{"regionCode":"US"}]}]}`
+	if got != want {
+		t.Fatalf("projection = %s, want %s", got, want)
+	}
+}
+
+func TestProjectFields_WholeSubtreeAndMissingPaths(t *testing.T) {
+	got := projectToJSON(t, testSubscription(), "basePlans,basePlans.state,missing,productId.nested")
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("expected only basePlans, got %s", got)
+	}
+	plans := decoded["basePlans"].([]interface{})
+	if first := plans[0].(map[string]interface{}); first["basePlanId"] != "monthly" {
+		t.Fatalf("whole basePlans should be kept, got %s", got)
+	}
+}
+
+func TestProjectFields_TopLevelArray(t *testing.T) {
+	data := []map[string]interface{}{{"sku": "a", "status": "active"}, {"sku": "b", "status": "inactive"}}
+	if got, want := projectToJSON(t, data, "sku"), `[{"sku":"a"},{"sku":"b"}]`; got != want {
+		t.Fatalf("projection = %s, want %s", got, want)
+	}
+}
+
+func TestPrintOutput_AppliesFieldsToJSON(t *testing.T) {
+	t.Setenv(fieldsEnvVar, "productId")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := PrintOutput(testSubscription(), "json", false)
+	w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("PrintOutput: %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	if got, want := buf.String(), "{\"productId\":\"premium\"}\n"; got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestApply_SetsFields(t *testing.T) {
+	t.Setenv(fieldsEnvVar, "")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	rf := BindRootFlags(fs)
+	if err := fs.Parse([]string{"--fields", " productId,basePlans.state "}); err != nil {
+		t.Fatal(err)
+	}
+
+	rf.Apply()
+
+	if got := os.Getenv(fieldsEnvVar); got != "productId,basePlans.state" {
+		t.Errorf("%s = %q, want %q", fieldsEnvVar, got, "productId,basePlans.state")
+	}
+}
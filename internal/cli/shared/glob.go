@@ -0,0 +1,42 @@
+package shared
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// MatchGlob reports whether id matches the shell-style glob pattern
+// (*, ?, and [...] classes). An empty pattern matches everything and a
+// malformed pattern matches nothing; use ValidateGlob to reject it early.
+func MatchGlob(pattern, id string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return true
+	}
+	matched, err := path.Match(pattern, id)
+	return err == nil && matched
+}
+
+// ValidateGlob returns an error naming flagName when pattern is malformed.
+func ValidateGlob(flagName, pattern string) error {
+	if _, err := path.Match(strings.TrimSpace(pattern), ""); err != nil {
+		return fmt.Errorf("--%s: invalid glob pattern %q", flagName, pattern)
+	}
+	return nil
+}
+
+// FilterByID keeps the items whose ID, as returned by id, matches pattern.
+// With an empty pattern items is returned unchanged.
+func FilterByID[T any](items []T, pattern string, id func(T) string) []T {
+	if strings.TrimSpace(pattern) == "" {
+		return items
+	}
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		if MatchGlob(pattern, id(item)) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
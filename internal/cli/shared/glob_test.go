@@ -0,0 +1,56 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		id      string
+		want    bool
+	}{
+		{"", "anything", true},
+		{"premium_*", "premium_monthly", true},
+		{"premium_*", "basic_monthly", false},
+		{"*_monthly", "premium_monthly", true},
+		{"coins_??", "coins_10", true},
+		{"coins_??", "coins_100", false},
+		{"tier_[abc]", "tier_b", true},
+		{"tier_[abc]", "tier_d", false},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+		{"bad[", "bad[", false},
+	}
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.id); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestValidateGlob(t *testing.T) {
+	if err := ValidateGlob("filter", "premium_*"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := ValidateGlob("filter", "premium_[")
+	if err == nil || err.Error() != `--filter: invalid glob pattern "premium_["` {
+		t.Fatalf("expected invalid pattern error, got %v", err)
+	}
+}
+
+func TestFilterByID(t *testing.T) {
+	items := []string{"premium_monthly", "premium_yearly", "basic_monthly"}
+	identity := func(s string) string { return s }
+
+	if got := FilterByID(items, "premium_*", identity); !reflect.DeepEqual(got, []string{"premium_monthly", "premium_yearly"}) {
+		t.Errorf("FilterByID = %v", got)
+	}
+	if got := FilterByID(items, "", identity); !reflect.DeepEqual(got, items) {
+		t.Errorf("empty pattern should keep all items, got %v", got)
+	}
+	if got := FilterByID(items, "none_*", identity); got == nil || len(got) != 0 {
+		t.Errorf("expected empty non-nil slice, got %#v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package shared
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPTimeout_FromRootFlag(t *testing.T) {
+	t.Setenv(httpTimeoutEnvVar, "")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	rf := BindRootFlags(fs)
+	if err := fs.Parse([]string{"--http-timeout", "7s"}); err != nil {
+		t.Fatal(err)
+	}
+	rf.Apply()
+
+	if got := HTTPTimeout(); got != 7*time.Second {
+		t.Fatalf("HTTPTimeout() = %v, want 7s", got)
+	}
+	transport := NewHTTPTransport(HTTPTimeout())
+	if transport.TLSHandshakeTimeout != 7*time.Second || transport.ResponseHeaderTimeout != 7*time.Second {
+		t.Fatalf("transport timeouts = %v/%v, want 7s", transport.TLSHandshakeTimeout, transport.ResponseHeaderTimeout)
+	}
+}
+
+func TestHTTPTimeout_UnsetOrInvalid(t *testing.T) {
+	for _, value := range []string{"", "soon", "-1s"} {
+		t.Setenv(httpTimeoutEnvVar, value)
+		if got := HTTPTimeout(); got != 0 {
+			t.Errorf("HTTPTimeout() with %q = %v, want 0", value, got)
+		}
+	}
+}
+
+func TestNewHTTPTransport_ZeroKeepsDefaults(t *testing.T) {
+	transport := NewHTTPTransport(0)
+	if transport.ResponseHeaderTimeout != 0 {
+		t.Fatalf("ResponseHeaderTimeout = %v, want unset", transport.ResponseHeaderTimeout)
+	}
+	if transport.TLSHandshakeTimeout == 0 {
+		t.Fatal("expected the default TLS handshake timeout to be kept")
+	}
+}
+
+func TestNewAPITransport_InsecureSkipVerifyOnlyWithFlag(t *testing.T) {
+	t.Setenv("GPLAY_INSECURE_SKIP_VERIFY", "1")
+	t.Setenv(httpTimeoutEnvVar, "")
+	t.Cleanup(func() { insecureSkipVerify = false })
+
+	transport := NewAPITransport()
+	if transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("expected TLS verification to be enabled without the flag")
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	rf := BindRootFlags(fs)
+	if err := fs.Parse([]string{"--insecure-skip-verify"}); err != nil {
+		t.Fatal(err)
+	}
+	rf.Apply()
+
+	transport = NewAPITransport()
+	if transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("expected InsecureSkipVerify with --insecure-skip-verify")
+	}
+	if http.DefaultTransport.(*http.Transport).TLSClientConfig != nil && http.DefaultTransport.(*http.Transport).TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("http.DefaultTransport must not be modified")
+	}
+}
+
+func TestNewAPITransport_InsecureSkipVerifyKeepsGoogleHostsVerified(t *testing.T) {
+	t.Setenv(httpTimeoutEnvVar, "")
+	insecureSkipVerify = true
+	t.Cleanup(func() { insecureSkipVerify = false })
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport := NewAPITransport()
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected self-signed test endpoint to be reachable, got %v", err)
+	}
+	resp.Body.Close()
+
+	for _, host := range []string{"androidpublisher.googleapis.com", "oauth2.googleapis.com", "accounts.google.com"} {
+		state := tls.ConnectionState{ServerName: host, PeerCertificates: []*x509.Certificate{server.Certificate()}}
+		if err := transport.TLSClientConfig.VerifyConnection(state); err == nil {
+			t.Fatalf("expected self-signed certificate for %s to be rejected", host)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const includeEmptyEnvVar = "GPLAY_INCLUDE_EMPTY"
+
+// includeEmptyFromEnv reports whether --include-empty was set.
+func includeEmptyFromEnv() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(includeEmptyEnvVar))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// IncludeEmptyFields round-trips data through JSON and puts back every key
+// that omitempty (or the API structs' own marshalers) dropped. Missing
+// pointers, slices, maps, and interfaces become null; missing scalars get
+// their zero value, and missing nested structs are filled out recursively.
+func IncludeEmptyFields(data interface{}) (interface{}, error) {
+	generic, err := toGenericJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("include empty fields: %w", err)
+	}
+	return fillEmpty(reflect.ValueOf(data), generic)
+}
+
+func toGenericJSON(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// jsonField is a struct field as encoding/json sees it.
+type jsonField struct {
+	name   string
+	index  []int
+	quoted bool
+}
+
+// jsonFields lists the JSON fields of t, flattening untagged embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for _, inner := range jsonFields(embedded) {
+					inner.index = append([]int{i}, inner.index...)
+					fields = append(fields, inner)
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{
+			name:   name,
+			index:  []int{i},
+			quoted: strings.Contains(","+opts+",", ",string,"),
+		})
+	}
+	return fields
+}
+
+// fillEmpty walks v alongside its JSON form and adds the keys that were
+// omitted from objects.
+func fillEmpty(v reflect.Value, generic interface{}) (interface{}, error) {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return generic, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return generic, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		obj, ok := generic.(map[string]interface{})
+		if !ok {
+			return generic, nil
+		}
+		for _, field := range jsonFields(v.Type()) {
+			fv, err := v.FieldByIndexErr(field.index)
+			if err != nil {
+				// Nil embedded pointer: its fields cannot be reached.
+				continue
+			}
+			var filled interface{}
+			if existing, present := obj[field.name]; present {
+				filled, err = fillEmpty(fv, existing)
+			} else {
+				filled, err = emptyValue(fv, field.quoted)
+			}
+			if err != nil {
+				return nil, err
+			}
+			obj[field.name] = filled
+		}
+		return obj, nil
+	case reflect.Slice, reflect.Array:
+		items, ok := generic.([]interface{})
+		if !ok || len(items) != v.Len() {
+			return generic, nil
+		}
+		for i := range items {
+			filled, err := fillEmpty(v.Index(i), items[i])
+			if err != nil {
+				return nil, err
+			}
+			items[i] = filled
+		}
+		return items, nil
+	case reflect.Map:
+		obj, ok := generic.(map[string]interface{})
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return generic, nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			existing, present := obj[key]
+			if !present {
+				continue
+			}
+			filled, err := fillEmpty(iter.Value(), existing)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = filled
+		}
+		return obj, nil
+	default:
+		return generic, nil
+	}
+}
+
+// emptyValue returns the JSON value for a field that was left out.
+func emptyValue(fv reflect.Value, quoted bool) (interface{}, error) {
+	switch fv.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		if fv.IsNil() {
+			return nil, nil
+		}
+	}
+	if !fv.CanInterface() {
+		return nil, nil
+	}
+	generic, err := toGenericJSON(fv.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("include empty fields: %w", err)
+	}
+	if quoted {
+		if _, isString := generic.(string); !isString {
+			generic = fmt.Sprint(generic)
+		}
+	}
+	return fillEmpty(fv, generic)
+}
@@ -0,0 +1,116 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func printJSONForTest(t *testing.T, data interface{}) map[string]interface{} {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := PrintOutput(data, "json", false)
+	w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("PrintOutput: %v", err)
+	}
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal %q: %v", buf.String(), err)
+	}
+	return got
+}
+
+func TestPrintOutput_DefaultOmitsEmptyFields(t *testing.T) {
+	t.Setenv(includeEmptyEnvVar, "")
+
+	got := printJSONForTest(t, &androidpublisher.Subscription{ProductId: "premium"})
+	for _, key := range []string{"packageName", "basePlans", "listings", "taxAndComplianceSettings"} {
+		if _, ok := got[key]; ok {
+			t.Errorf("expected %q to be omitted, got %v", key, got)
+		}
+	}
+}
+
+func TestPrintOutput_IncludeEmptyKeepsAllKeys(t *testing.T) {
+	t.Setenv(includeEmptyEnvVar, "1")
+
+	got := printJSONForTest(t, &androidpublisher.Subscription{
+		ProductId: "premium",
+		BasePlans: []*androidpublisher.BasePlan{{BasePlanId: "monthly"}},
+	})
+	if got["productId"] != "premium" {
+		t.Fatalf("productId = %v", got["productId"])
+	}
+	for _, key := range []string{"packageName", "archived"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("expected %q to be present, got %v", key, got)
+		}
+	}
+	if got["packageName"] != "" || got["archived"] != false {
+		t.Errorf("expected zero scalars, got packageName=%v archived=%v", got["packageName"], got["archived"])
+	}
+	for _, key := range []string{"listings", "taxAndComplianceSettings"} {
+		value, ok := got[key]
+		if !ok || value != nil {
+			t.Errorf("expected %q to be null, got %v (present=%v)", key, value, ok)
+		}
+	}
+
+	plans, _ := got["basePlans"].([]interface{})
+	if len(plans) != 1 {
+		t.Fatalf("basePlans = %v", got["basePlans"])
+	}
+	plan := plans[0].(map[string]interface{})
+	if plan["basePlanId"] != "monthly" {
+		t.Errorf("basePlanId = %v", plan["basePlanId"])
+	}
+	if value, ok := plan["state"]; !ok || value != "" {
+		t.Errorf("expected nested state to be \"\", got %v (present=%v)", value, ok)
+	}
+	if value, ok := plan["autoRenewingBasePlanType"]; !ok || value != nil {
+		t.Errorf("expected nested autoRenewingBasePlanType to be null, got %v (present=%v)", value, ok)
+	}
+	if _, ok := plan["ForceSendFields"]; ok {
+		t.Errorf("internal fields leaked: %v", plan)
+	}
+}
+
+func TestIncludeEmptyFields_QuotedIntegersStayStrings(t *testing.T) {
+	filled, err := IncludeEmptyFields(&androidpublisher.Money{CurrencyCode: "USD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := filled.(map[string]interface{})
+	if got["units"] != "0" {
+		t.Fatalf("units = %#v, want \"0\"", got["units"])
+	}
+	if got["nanos"] != float64(0) {
+		t.Fatalf("nanos = %#v, want 0", got["nanos"])
+	}
+}
+
+func TestApply_SetsIncludeEmpty(t *testing.T) {
+	t.Setenv(includeEmptyEnvVar, "")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	rf := BindRootFlags(fs)
+	if err := fs.Parse([]string{"--include-empty"}); err != nil {
+		t.Fatal(err)
+	}
+
+	rf.Apply()
+
+	if !includeEmptyFromEnv() {
+		t.Errorf("%s = %q, want it enabled", includeEmptyEnvVar, os.Getenv(includeEmptyEnvVar))
+	}
+}
@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+// OpenInput opens the input named by a file-accepting flag. "-" and "@-"
+// read from stdin; any other value is a file path, with an optional leading
+// "@" to match the --json convention. A file whose name really starts with
+// "@" is opened as is.
+func OpenInput(arg string) (io.ReadCloser, error) {
+	if isStdinArg(arg) {
+		data, err := readStdin()
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return os.Open(inputPath(arg)) // #nosec G304 -- path is provided by the user
+}
+
+// inputPath returns the file OpenInput reads for arg. A leading "@" is an
+// @file reference and is stripped, unless a file by the literal name exists.
+func inputPath(arg string) string {
+	if !strings.HasPrefix(arg, "@") {
+		return arg
+	}
+	if _, err := os.Stat(arg); err == nil {
+		return arg
+	}
+	return strings.TrimPrefix(arg, "@")
+}
+
+// IsStdinInput reports whether arg asks OpenInput to read from stdin.
+func IsStdinInput(arg string) bool {
+	return isStdinArg(arg)
+}
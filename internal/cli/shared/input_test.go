@@ -0,0 +1,87 @@
+package shared
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenInput_Stdin(t *testing.T) {
+	for _, arg := range []string{"-", "@-"} {
+		t.Run(arg, func(t *testing.T) {
+			withStdin(t, "from stdin", false)
+			rc, err := OpenInput(arg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer rc.Close()
+			data, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != "from stdin" {
+				t.Errorf("got %q", data)
+			}
+		})
+	}
+}
+
+func TestOpenInput_StdinTerminalRejected(t *testing.T) {
+	withStdin(t, "", true)
+	if _, err := OpenInput("-"); err == nil {
+		t.Fatal("expected error when stdin is a terminal")
+	}
+}
+
+func TestOpenInput_FilePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.txt")
+	if err := os.WriteFile(path, []byte("from file"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, arg := range []string{path, "@" + path} {
+		t.Run(arg, func(t *testing.T) {
+			rc, err := OpenInput(arg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer rc.Close()
+			data, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != "from file" {
+				t.Errorf("got %q", data)
+			}
+		})
+	}
+}
+
+func TestOpenInput_FileNamedWithAt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "@mapping.txt"), []byte("literal"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "mapping.txt"), []byte("stripped"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	rc, err := OpenInput("@mapping.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "literal" {
+		t.Errorf("got %q, want the file named with @", data)
+	}
+}
+
+func TestOpenInput_MissingFile(t *testing.T) {
+	if _, err := OpenInput(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Fatalf("expected not-exist error, got %v", err)
+	}
+}
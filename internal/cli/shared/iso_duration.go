@@ -0,0 +1,47 @@
+package shared
+
+import (
+	"regexp"
+	"strings"
+)
+
+var isoDurationRegex = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// isoDurationUnits names the capture groups of isoDurationRegex in order.
+var isoDurationUnits = []string{"year", "month", "week", "day", "hour", "minute", "second"}
+
+// HumanizeISODuration renders an ISO 8601 duration such as the billing
+// periods Play uses for table output: P1M -> "1 month", P7D -> "7 days",
+// P1Y6M -> "1 year 6 months". Strings it cannot parse are returned as is.
+func HumanizeISODuration(d string) string {
+	trimmed := strings.TrimSpace(d)
+	matches := isoDurationRegex.FindStringSubmatch(trimmed)
+	if matches == nil || strings.HasSuffix(trimmed, "T") {
+		return d
+	}
+	var parts []string
+	for i, unit := range isoDurationUnits {
+		n := matches[i+1]
+		if n == "" {
+			continue
+		}
+		n = strings.TrimLeft(n, "0")
+		if n == "" {
+			n = "0"
+		}
+		if n != "1" {
+			unit += "s"
+		}
+		parts = append(parts, n+" "+unit)
+	}
+	if len(parts) == 0 {
+		return d
+	}
+	return strings.Join(parts, " ")
+}
+
+// IsISODuration reports whether d is an ISO 8601 duration with at least one
+// component, such as P1M, P7D, or PT12H.
+func IsISODuration(d string) bool {
+	return isoDurationRegex.MatchString(d) && d != "P" && !strings.HasSuffix(d, "T")
+}
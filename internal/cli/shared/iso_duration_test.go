@@ -0,0 +1,48 @@
+package shared
+
+import "testing"
+
+func TestHumanizeISODuration(t *testing.T) {
+	tests := map[string]string{
+		"P1M":     "1 month",
+		"P3M":     "3 months",
+		"P7D":     "7 days",
+		"P1W":     "1 week",
+		"P1Y":     "1 year",
+		"P1Y6M":   "1 year 6 months",
+		"P0D":     "0 days",
+		"PT12H":   "12 hours",
+		"P1DT30M": "1 day 30 minutes",
+		"P":       "P",
+		"PT":      "PT",
+		"P1T":     "P1T",
+		"1 month": "1 month",
+		"":        "",
+		"p1m":     "p1m",
+	}
+	for in, want := range tests {
+		if got := HumanizeISODuration(in); got != want {
+			t.Errorf("HumanizeISODuration(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestIsISODuration(t *testing.T) {
+	tests := map[string]bool{
+		"P1M":   true,
+		"P7D":   true,
+		"PT12H": true,
+		"P1Y6M": true,
+		"P":     false,
+		"PT":    false,
+		"P1DT":  false,
+		"1M":    false,
+		"p1m":   false,
+		"":      false,
+	}
+	for input, want := range tests {
+		if got := IsISODuration(input); got != want {
+			t.Errorf("IsISODuration(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
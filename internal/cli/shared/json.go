@@ -1,19 +1,32 @@
 package shared
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
 )
 
-// LoadJSONArg parses JSON from a literal string or @file path.
+// LoadJSONArg parses JSON from a literal string, @file path, or stdin
+// when value is "-" or "@-".
 func LoadJSONArg(value string, out interface{}) error {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
 		return fmt.Errorf("empty json value")
 	}
+	if isStdinArg(trimmed) {
+		data, err := readStdin()
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, out)
+	}
 	if strings.HasPrefix(trimmed, "@") {
 		path := strings.TrimSpace(strings.TrimPrefix(trimmed, "@"))
 		if path == "" {
@@ -28,14 +41,90 @@ func LoadJSONArg(value string, out interface{}) error {
 	return json.Unmarshal([]byte(trimmed), out)
 }
 
-// LoadJSONArgRaw returns the raw JSON bytes from a literal string or @file path
-// without unmarshaling. Use this when you need to inspect the JSON keys before
-// parsing into a typed struct.
+// LoadJSONArgStrict is LoadJSONArg for request bodies: a key that does not
+// exist on out is an error rather than being silently dropped, so a typo such
+// as "reccurrenceCount" is caught before the request is sent.
+func LoadJSONArgStrict(value string, out interface{}) error {
+	data, err := LoadJSONArgRaw(value)
+	if err != nil {
+		return err
+	}
+	return UnmarshalStrict(data, out)
+}
+
+// UnmarshalStrict decodes data into out, rejecting keys that do not match a
+// field of out. The error names the unknown key and, when one is close, the
+// field that was probably meant.
+func UnmarshalStrict(data []byte, out interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		if field, ok := unknownJSONField(err); ok {
+			if suggestion := SuggestCommand(field, jsonFieldNames(reflect.TypeOf(out)), 2); suggestion != "" {
+				return fmt.Errorf("unknown field %q (did you mean %q?)", field, suggestion)
+			}
+			return fmt.Errorf("unknown field %q", field)
+		}
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unexpected data after top-level JSON value")
+	}
+	return nil
+}
+
+// unknownJSONField extracts the key from a DisallowUnknownFields error.
+func unknownJSONField(err error) (string, bool) {
+	const prefix = "json: unknown field "
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return "", false
+	}
+	return strings.Trim(strings.TrimPrefix(msg, prefix), `"`), true
+}
+
+// jsonFieldNames returns the JSON keys of t and of every struct reachable
+// from it, for suggesting the field an unknown key was meant to be.
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	seen := map[reflect.Type]bool{}
+	var walk func(reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || seen[t] {
+			return
+		}
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			names = append(names, name)
+			walk(field.Type)
+		}
+	}
+	walk(t)
+	return names
+}
+
+// LoadJSONArgRaw returns the raw JSON bytes from a literal string, @file path,
+// or stdin without unmarshaling. Use this when you need to inspect the JSON
+// keys before parsing into a typed struct.
 func LoadJSONArgRaw(value string) ([]byte, error) {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
 		return nil, fmt.Errorf("empty json value")
 	}
+	if isStdinArg(trimmed) {
+		return readStdin()
+	}
 	if strings.HasPrefix(trimmed, "@") {
 		path := strings.TrimSpace(strings.TrimPrefix(trimmed, "@"))
 		if path == "" {
@@ -46,6 +135,22 @@ func LoadJSONArgRaw(value string) ([]byte, error) {
 	return []byte(trimmed), nil
 }
 
+// BindAssumeFromJSONFlag registers --assume-package-from-json, which lets a
+// create or update command take an empty --package or ID flag, such as
+// --product-id or --sku, from the matching field of its JSON body.
+func BindAssumeFromJSONFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("assume-package-from-json", false, "Use packageName and the product IDs from --json when --package or the ID flags are empty")
+}
+
+// ValueOrJSON returns flagValue, falling back to jsonValue when flagValue is
+// empty and assume is set.
+func ValueOrJSON(flagValue, jsonValue string, assume bool) string {
+	if strings.TrimSpace(flagValue) != "" || !assume {
+		return flagValue
+	}
+	return strings.TrimSpace(jsonValue)
+}
+
 // DeriveUpdateMask extracts top-level keys from raw JSON and returns a sorted,
 // comma-separated update mask containing only keys that appear in mutableFields.
 //
@@ -78,3 +183,75 @@ func DeriveUpdateMask(raw []byte, mutableFields []string) (string, error) {
 	sort.Strings(mask)
 	return strings.Join(mask, ","), nil
 }
+
+// ValidateUpdateMask checks that every comma-separated field in mask names a
+// JSON field of target, a struct or pointer to one. Dotted paths such as
+// listings.title are followed through nested structs, slices, and maps, and
+// snake_case names such as restricted_payment_countries match their camelCase
+// JSON field, as the API accepts both. The error for an unknown field lists
+// the valid fields at that level, so typos fail locally instead of as a
+// silent no-op or an API error.
+func ValidateUpdateMask(mask string, target interface{}) error {
+	root := reflect.TypeOf(target)
+	for _, path := range SplitCSV(mask) {
+		typ := root
+		for i, part := range strings.Split(path, ".") {
+			fields := jsonFieldTypes(typ)
+			next, ok := fields[snakeToCamel(part)]
+			if !ok {
+				prefix := strings.Join(strings.Split(path, ".")[:i], ".")
+				valid := make([]string, 0, len(fields))
+				for name := range fields {
+					if prefix != "" {
+						name = prefix + "." + name
+					}
+					valid = append(valid, name)
+				}
+				sort.Strings(valid)
+				return fmt.Errorf("unknown --update-mask field %q; valid fields: %s", path, strings.Join(valid, ", "))
+			}
+			typ = next
+		}
+	}
+	return nil
+}
+
+// snakeToCamel turns a snake_case field name into camelCase; other names are
+// returned unchanged.
+func snakeToCamel(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// jsonFieldTypes maps the JSON names of the exported fields of t, after
+// unwrapping pointers, slices, and maps, to their types. It is empty when t
+// is not a struct.
+func jsonFieldTypes(t reflect.Type) map[string]reflect.Type {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Map) {
+		t = t.Elem()
+	}
+	fields := map[string]reflect.Type{}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
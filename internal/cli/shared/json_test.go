@@ -153,3 +153,185 @@ func TestDeriveUpdateMask_UnknownFieldsIgnored(t *testing.T) {
 		t.Errorf("got %q, want %q (unknown fields should be excluded)", mask, "listings")
 	}
 }
+
+func withStdin(t *testing.T, input string, terminal bool) {
+	t.Helper()
+	origIn, origTerm := stdin, stdinIsTerminal
+	stdin = strings.NewReader(input)
+	stdinIsTerminal = func() bool { return terminal }
+	t.Cleanup(func() {
+		stdin, stdinIsTerminal = origIn, origTerm
+	})
+}
+
+func TestLoadJSONArg_Stdin(t *testing.T) {
+	for _, arg := range []string{"-", "@-", " - "} {
+		t.Run(arg, func(t *testing.T) {
+			withStdin(t, `{"offerId":"trial"}`, false)
+			var out struct {
+				OfferID string `json:"offerId"`
+			}
+			if err := LoadJSONArg(arg, &out); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.OfferID != "trial" {
+				t.Errorf("offerId = %q, want %q", out.OfferID, "trial")
+			}
+		})
+	}
+}
+
+func TestLoadJSONArgRaw_Stdin(t *testing.T) {
+	withStdin(t, `{"listings":[]}`, false)
+	raw, err := LoadJSONArgRaw("-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(raw) != `{"listings":[]}` {
+		t.Errorf("got %q", string(raw))
+	}
+}
+
+func TestLoadJSONArg_StdinTerminal(t *testing.T) {
+	withStdin(t, "", true)
+	var out map[string]interface{}
+	err := LoadJSONArg("-", &out)
+	if err == nil {
+		t.Fatal("expected error when stdin is a terminal")
+	}
+	if !strings.Contains(err.Error(), "stdin is a terminal") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestLoadJSONArg_StdinInvalidJSON(t *testing.T) {
+	withStdin(t, "{not json", false)
+	var out map[string]interface{}
+	if err := LoadJSONArg("@-", &out); err == nil {
+		t.Fatal("expected error for invalid JSON on stdin")
+	}
+}
+
+func TestValueOrJSON(t *testing.T) {
+	if got := ValueOrJSON("com.flag", "com.json", true); got != "com.flag" {
+		t.Errorf("flag value should win, got %q", got)
+	}
+	if got := ValueOrJSON("", " com.json ", true); got != "com.json" {
+		t.Errorf("expected JSON value, got %q", got)
+	}
+	if got := ValueOrJSON("", "com.json", false); got != "" {
+		t.Errorf("JSON value should be ignored without the flag, got %q", got)
+	}
+}
+
+type strictPhase struct {
+	Duration        string `json:"duration,omitempty"`
+	RecurrenceCount int64  `json:"recurrenceCount,omitempty"`
+}
+
+type strictOffer struct {
+	OfferID string         `json:"offerId,omitempty"`
+	Phases  []*strictPhase `json:"phases,omitempty"`
+	Ignored string         `json:"-"`
+}
+
+func TestUnmarshalStrict_ValidJSON(t *testing.T) {
+	var offer strictOffer
+	if err := UnmarshalStrict([]byte(`{"offerId":"trial","phases":[{"duration":"P7D","recurrenceCount":1}]}`), &offer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if offer.OfferID != "trial" || len(offer.Phases) != 1 || offer.Phases[0].RecurrenceCount != 1 {
+		t.Fatalf("unexpected result: %+v", offer)
+	}
+}
+
+func TestUnmarshalStrict_UnknownField(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"nested typo", `{"phases":[{"reccurrenceCount":1}]}`, `unknown field "reccurrenceCount" (did you mean "recurrenceCount"?)`},
+		{"top-level typo", `{"offerID":"trial","ofer":"x"}`, `unknown field "ofer"`},
+		{"no close match", `{"somethingElse":true}`, `unknown field "somethingElse"`},
+		{"json-ignored field", `{"Ignored":"x"}`, `unknown field "Ignored"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var offer strictOffer
+			err := UnmarshalStrict([]byte(tt.json), &offer)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+			if tt.name == "no close match" && strings.Contains(err.Error(), "did you mean") {
+				t.Fatalf("unexpected suggestion: %v", err)
+			}
+		})
+	}
+}
+
+func TestUnmarshalStrict_TrailingData(t *testing.T) {
+	var offer strictOffer
+	if err := UnmarshalStrict([]byte(`{"offerId":"a"} {"offerId":"b"}`), &offer); err == nil {
+		t.Fatal("expected error for trailing data")
+	}
+}
+
+func TestLoadJSONArgStrict_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "offer.json")
+	if err := os.WriteFile(path, []byte(`{"offerId":"trial","phase":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var offer strictOffer
+	err := LoadJSONArgStrict("@"+path, &offer)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "phase" (did you mean "phases"?)`) {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+}
+
+type maskTarget struct {
+	Listings   []*maskListing `json:"listings,omitempty"`
+	Tags       []string       `json:"tags,omitempty"`
+	RegionTags []string       `json:"regionTags,omitempty"`
+	Hidden     string         `json:"-"`
+}
+
+type maskListing struct {
+	Title string `json:"title,omitempty"`
+}
+
+func TestValidateUpdateMask_AcceptsKnownFields(t *testing.T) {
+	if err := ValidateUpdateMask("listings, tags,listings.title", &maskTarget{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateUpdateMask_AcceptsSnakeCase(t *testing.T) {
+	if err := ValidateUpdateMask("region_tags,listings.title", &maskTarget{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateUpdateMask("region_tag", &maskTarget{}); err == nil {
+		t.Fatal("expected error for unknown snake_case field")
+	}
+}
+
+func TestValidateUpdateMask_RejectsUnknownField(t *testing.T) {
+	err := ValidateUpdateMask("tags,listing", &maskTarget{})
+	if err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+	want := `unknown --update-mask field "listing"; valid fields: listings, regionTags, tags`
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestValidateUpdateMask_RejectsUnknownNestedField(t *testing.T) {
+	err := ValidateUpdateMask("listings.name", &maskTarget{})
+	if err == nil || !strings.Contains(err.Error(), "valid fields: listings.title") {
+		t.Fatalf("expected nested field error, got %v", err)
+	}
+	if err := ValidateUpdateMask("Hidden", &maskTarget{}); err == nil {
+		t.Fatal("expected fields tagged json:\"-\" to be rejected")
+	}
+}
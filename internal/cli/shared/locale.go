@@ -0,0 +1,53 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CanonicalizeLocale returns locale with canonical BCP-47 casing: the
+// language subtag lowercase, a four-letter script subtag title case and a
+// two-letter region subtag uppercase (en-us -> en-US, zh-hant-tw ->
+// zh-Hant-TW). Numeric regions such as es-419 are unchanged. Values using
+// underscores are returned trimmed but otherwise untouched so the locale
+// validator can point out the separator.
+func CanonicalizeLocale(locale string) string {
+	locale = strings.TrimSpace(locale)
+	if locale == "" || strings.Contains(locale, "_") {
+		return locale
+	}
+	parts := strings.Split(locale, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		part := parts[i]
+		switch {
+		case len(part) == 2 && isAlpha(part):
+			parts[i] = strings.ToUpper(part)
+		case len(part) == 4 && isAlpha(part):
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		default:
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// NormalizeLocaleFlag canonicalizes the value of a locale flag and prints a
+// warning to stderr when the casing had to be corrected.
+func NormalizeLocaleFlag(flagName, value string) string {
+	canonical := CanonicalizeLocale(value)
+	if canonical != strings.TrimSpace(value) {
+		fmt.Fprintf(os.Stderr, "Warning: %s %q normalized to %q\n", flagName, value, canonical)
+	}
+	return canonical
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
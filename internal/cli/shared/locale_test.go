@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalizeLocale(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"en-US", "en-US"},
+		{"en-us", "en-US"},
+		{"EN-us", "en-US"},
+		{"PT-br", "pt-BR"},
+		{"pt-Br", "pt-BR"},
+		{"FIL", "fil"},
+		{"es-419", "es-419"},
+		{"ES-419", "es-419"},
+		{"zh-hant-tw", "zh-Hant-TW"},
+		{"ZH-HANT-TW", "zh-Hant-TW"},
+		{"  de-de ", "de-DE"},
+		{"en_us", "en_us"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := CanonicalizeLocale(tt.in); got != tt.want {
+			t.Errorf("CanonicalizeLocale(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func captureLocaleStderr(fn func()) string {
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	fn()
+	w.Close()
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String()
+}
+
+func TestNormalizeLocaleFlag_WarnsOnCorrection(t *testing.T) {
+	var got string
+	stderr := captureLocaleStderr(func() {
+		got = NormalizeLocaleFlag("--locale", "en-us")
+	})
+	if got != "en-US" {
+		t.Fatalf("NormalizeLocaleFlag = %q, want en-US", got)
+	}
+	if !strings.Contains(stderr, `--locale "en-us" normalized to "en-US"`) {
+		t.Fatalf("expected correction warning, got %q", stderr)
+	}
+}
+
+func TestNormalizeLocaleFlag_SilentWhenCanonical(t *testing.T) {
+	stderr := captureLocaleStderr(func() {
+		NormalizeLocaleFlag("--locale", "pt-BR")
+	})
+	if stderr != "" {
+		t.Fatalf("expected no warning, got %q", stderr)
+	}
+}
@@ -0,0 +1,155 @@
+package shared
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const maskSecretsEnvVar = "GPLAY_MASK_SECRETS_IN_ERRORS"
+
+var (
+	secretURLPattern    = regexp.MustCompile(`https?://[^\s"'<>]+`)
+	bearerPattern       = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	googleTokenPattern  = regexp.MustCompile(`\b(ya29\.|1//)[0-9A-Za-z\-_.]+`)
+	secretParamPattern  = regexp.MustCompile(`(?i)\b(access_token|refresh_token|id_token|client_secret|private_key_id)(["']?\s*[:=]\s*["']?)[^\s"'&,}]+`)
+	privateKeyPattern   = regexp.MustCompile(`-----BEGIN ([A-Z ]*)PRIVATE KEY-----(?s:.*?)(?:-----END [A-Z ]*PRIVATE KEY-----|$)`)
+	secretFilePattern   = regexp.MustCompile(`(?:[A-Za-z]:|~|\.{1,2}|[^\s/\\"':]+)?(?:[/\\][^\s/\\"':]+)+\.(?:json|p12|pem|key)\b`)
+	secretFileNameWords = []string{"key", "credential", "service-account", "service_account", "serviceaccount", "secret", "token"}
+)
+
+// secretQueryParams are query parameters that carry credentials or URL
+// signatures.
+var secretQueryParams = map[string]bool{
+	"access_token":         true,
+	"token":                true,
+	"key":                  true,
+	"sig":                  true,
+	"signature":            true,
+	"x-goog-signature":     true,
+	"x-goog-credential":    true,
+	"x-amz-signature":      true,
+	"x-amz-credential":     true,
+	"x-amz-security-token": true,
+	"googleaccessid":       true,
+	"client_secret":        true,
+}
+
+// webhookHosts are hosts whose URL path is itself the secret.
+var webhookHosts = []string{"hooks.slack.com", "discord.com", "discordapp.com", "webhook.office.com", "outlook.office.com"}
+
+// MaskSecretsEnabled reports whether error output is scrubbed of secrets.
+// It is on unless --mask-secrets-in-errors=false or
+// GPLAY_MASK_SECRETS_IN_ERRORS=0 turns it off.
+func MaskSecretsEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(maskSecretsEnvVar))) {
+	case "0", "false", "no", "off":
+		return false
+	default:
+		return true
+	}
+}
+
+// MaskErrorText scrubs secrets from s when masking is enabled.
+func MaskErrorText(s string) string {
+	if !MaskSecretsEnabled() {
+		return s
+	}
+	return MaskSecrets(s)
+}
+
+// MaskSecrets replaces bearer and OAuth tokens, credential parameters,
+// private keys, signed URL query parameters, webhook URLs, and key file
+// directories in s with "***".
+func MaskSecrets(s string) string {
+	s = privateKeyPattern.ReplaceAllString(s, "-----BEGIN ${1}PRIVATE KEY-----***")
+	s = secretURLPattern.ReplaceAllStringFunc(s, maskSecretURL)
+	s = bearerPattern.ReplaceAllString(s, "${1}***")
+	s = googleTokenPattern.ReplaceAllString(s, "${1}***")
+	s = secretParamPattern.ReplaceAllString(s, "${1}${2}***")
+	return secretFilePattern.ReplaceAllStringFunc(s, maskSecretFile)
+}
+
+// maskSecretURL masks webhook URLs with MaskURL and replaces the values of
+// secret query parameters.
+func maskSecretURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range webhookHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return MaskURL(raw)
+		}
+	}
+	if u.RawQuery == "" {
+		return raw
+	}
+	params := strings.Split(u.RawQuery, "&")
+	changed := false
+	for i, param := range params {
+		name, _, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		decoded, err := url.QueryUnescape(name)
+		if err != nil {
+			decoded = name
+		}
+		if secretQueryParams[strings.ToLower(decoded)] {
+			params[i] = name + "=***"
+			changed = true
+		}
+	}
+	if !changed {
+		return raw
+	}
+	u.RawQuery = strings.Join(params, "&")
+	return u.String()
+}
+
+// maskSecretFile hides the directory of a file path that looks like a key
+// or credentials file, keeping the file name.
+func maskSecretFile(path string) string {
+	base := filepath.Base(strings.ReplaceAll(path, `\`, "/"))
+	ext := strings.ToLower(filepath.Ext(base))
+	if ext == ".json" {
+		lower := strings.ToLower(base)
+		match := false
+		for _, word := range secretFileNameWords {
+			if strings.Contains(lower, word) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return path
+		}
+	}
+	return "***/" + base
+}
+
+// MaskURL redacts all but the last 6 characters of the URL path for safe display.
+// The leading "/" of the path is always preserved.
+func MaskURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "***"
+	}
+	path := u.Path
+	// Strip leading slash for masking, then re-add it.
+	trimmed := strings.TrimPrefix(path, "/")
+	if len(trimmed) > 6 {
+		trimmed = "***" + trimmed[len(trimmed)-6:]
+	}
+	if strings.HasPrefix(path, "/") {
+		path = "/" + trimmed
+	} else {
+		path = trimmed
+	}
+	return fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, path)
+}
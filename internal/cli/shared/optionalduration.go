@@ -0,0 +1,39 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OptionalDuration is a duration flag that records whether it was set, so an
+// explicit 0 can be told apart from the flag being omitted.
+type OptionalDuration struct {
+	val time.Duration
+	set bool
+}
+
+// IsSet reports whether the flag was explicitly set.
+func (o *OptionalDuration) IsSet() bool { return o.set }
+
+// Value returns the duration (only meaningful when IsSet is true).
+func (o *OptionalDuration) Value() time.Duration { return o.val }
+
+// String implements flag.Value.
+func (o *OptionalDuration) String() string {
+	if o == nil || !o.set {
+		return ""
+	}
+	return o.val.String()
+}
+
+// Set implements flag.Value. Accepts Go durations such as 30s or 2m.
+func (o *OptionalDuration) Set(s string) error {
+	parsed, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q (e.g. 30s, 2m)", s)
+	}
+	o.val = parsed
+	o.set = true
+	return nil
+}
@@ -0,0 +1,286 @@
+package shared
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const orderByEnvVar = "GPLAY_ORDER_BY"
+
+// OrderBy is a parsed --order-by value.
+type OrderBy struct {
+	Path       []string
+	Descending bool
+}
+
+// ParseOrderBy parses "field[:asc|desc]", where field is a dotted JSON path
+// such as productId or purchaseTime. A leading "-" (-updated) also sorts
+// descending. An empty value returns nil.
+func ParseOrderBy(value string) (*OrderBy, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	field, direction, hasDirection := strings.Cut(value, ":")
+	order := &OrderBy{}
+	if strings.HasPrefix(field, "-") {
+		if hasDirection {
+			return nil, fmt.Errorf("--order-by takes either a leading - or :asc|desc, not both (got %q)", value)
+		}
+		field = strings.TrimPrefix(field, "-")
+		order.Descending = true
+	}
+	switch strings.ToLower(strings.TrimSpace(direction)) {
+	case "", "asc":
+	case "desc":
+		order.Descending = true
+	default:
+		return nil, fmt.Errorf("--order-by direction must be asc or desc (got %q)", direction)
+	}
+	paths := ParseFieldPaths(field)
+	if len(paths) != 1 {
+		return nil, fmt.Errorf("--order-by must name one field, as field[:asc|desc] or -field (got %q)", value)
+	}
+	order.Path = paths[0]
+	return order, nil
+}
+
+// orderByAlias is the --order-by flag of a list command. It validates the
+// value and stores it where the root --order-by does, so both spellings
+// feed the same sort in PrintOutput.
+type orderByAlias struct{ value string }
+
+func (o *orderByAlias) String() string { return o.value }
+
+func (o *orderByAlias) Set(value string) error {
+	if _, err := ParseOrderBy(value); err != nil {
+		return err
+	}
+	o.value = strings.TrimSpace(value)
+	return os.Setenv(orderByEnvVar, o.value)
+}
+
+// BindOrderByFlag registers --order-by on a list command as an alias of the
+// root flag, so "gplay iap list --order-by sku" and "gplay --order-by sku
+// iap list" sort the same way. The root flag wins when both are given.
+func BindOrderByFlag(fs *flag.FlagSet) {
+	fs.Var(&orderByAlias{}, "order-by", "Same as the root --order-by: sort results by a dotted JSON field, as field[:asc|desc] or -field (e.g. productId, -updated)")
+}
+
+// OrderByFromEnv returns the sort requested via --order-by.
+func OrderByFromEnv() string {
+	return strings.TrimSpace(os.Getenv(orderByEnvVar))
+}
+
+// SortResult sorts the list inside data in place by field, a --order-by
+// value such as productId, -updated, or purchaseTime:desc. data may be a
+// slice, or a list response (a struct or map) holding exactly one slice,
+// such as {"subscriptions": [...], "nextPageToken": ...}; any other result
+// is left as is, so a GPLAY_ORDER_BY export does not break get or update
+// commands. The items are sorted in their JSON form by SortByField and the
+// typed slice is reordered to match, so results still render as tables.
+func SortResult(data interface{}, field string) error {
+	order, err := ParseOrderBy(field)
+	if err != nil || order == nil {
+		return err
+	}
+	list, ok := findResultSlice(reflect.ValueOf(data))
+	if !ok || list.Len() < 2 {
+		return nil
+	}
+
+	raw, err := json.Marshal(list.Interface())
+	if err != nil {
+		return fmt.Errorf("--order-by: %w", err)
+	}
+	var items []interface{}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return fmt.Errorf("--order-by: %w", err)
+	}
+	if len(items) != list.Len() {
+		return fmt.Errorf("--order-by: result is not a list")
+	}
+	// Objects are matched back to their position by identity; anything else
+	// (null entries) has no sort key and keeps its relative order at the end.
+	origin := make(map[uintptr]int, len(items))
+	var others []int
+	for i, item := range items {
+		if obj, ok := item.(map[string]interface{}); ok {
+			origin[reflect.ValueOf(obj).Pointer()] = i
+		} else {
+			others = append(others, i)
+		}
+	}
+	if err := SortByField(items, field); err != nil {
+		return err
+	}
+
+	sorted := reflect.MakeSlice(list.Type(), list.Len(), list.Len())
+	for i, item := range items {
+		var from int
+		if obj, ok := item.(map[string]interface{}); ok {
+			from = origin[reflect.ValueOf(obj).Pointer()]
+		} else {
+			from, others = others[0], others[1:]
+		}
+		sorted.Index(i).Set(list.Index(from))
+	}
+	reflect.Copy(list, sorted)
+	return nil
+}
+
+// SortByField sorts items, the JSON form of a list (a slice of maps), in
+// place by field, a --order-by value such as productId, -updated, or
+// basePlans.state:desc. A column whose values are all numbers or numeric
+// strings compares numerically; items missing the field sort last.
+func SortByField(items []interface{}, field string) error {
+	order, err := ParseOrderBy(field)
+	if err != nil || order == nil || len(items) < 2 {
+		return err
+	}
+	indexes, err := orderIndexes(items, order)
+	if err != nil {
+		return err
+	}
+	sorted := make([]interface{}, len(items))
+	for i, from := range indexes {
+		sorted[i] = items[from]
+	}
+	copy(items, sorted)
+	return nil
+}
+
+// orderIndexes returns the positions of items in sorted order. The sort is
+// stable, and it fails when no item has the field.
+func orderIndexes(items []interface{}, order *OrderBy) ([]int, error) {
+	keys := make([]interface{}, len(items))
+	found := false
+	numeric := true
+	for i, item := range items {
+		keys[i] = orderKey(item, order.Path)
+		if keys[i] == nil {
+			continue
+		}
+		found = true
+		if _, ok := orderNumber(keys[i]); !ok {
+			numeric = false
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("--order-by: no item has field %q", strings.Join(order.Path, "."))
+	}
+
+	indexes := make([]int, len(keys))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		ka, kb := keys[indexes[a]], keys[indexes[b]]
+		if ka == nil || kb == nil {
+			return kb == nil && ka != nil
+		}
+		cmp := compareOrderKeys(ka, kb, numeric)
+		if order.Descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return indexes, nil
+}
+
+// findResultSlice locates the slice to sort: data itself, or the only slice
+// held by a struct or map.
+func findResultSlice(v reflect.Value) (reflect.Value, bool) {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return reflect.Value{}, false
+	}
+	var candidates []reflect.Value
+	switch v.Kind() {
+	case reflect.Slice:
+		return v, true
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.IsExported() && f.Tag.Get("json") != "-" && v.Field(i).Kind() == reflect.Slice {
+				candidates = append(candidates, v.Field(i))
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			value := iter.Value()
+			for value.Kind() == reflect.Interface && !value.IsNil() {
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Slice {
+				candidates = append(candidates, value)
+			}
+		}
+	}
+	if len(candidates) != 1 {
+		return reflect.Value{}, false
+	}
+	return candidates[0], true
+}
+
+// orderKey reads the value at path from item, the JSON form of a list item.
+func orderKey(item interface{}, path []string) interface{} {
+	current := item
+	for _, segment := range path {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = obj[segment]
+	}
+	switch current.(type) {
+	case string, float64, bool:
+		return current
+	default:
+		return nil
+	}
+}
+
+// compareOrderKeys compares two sort keys of one column, numerically when
+// numeric is set (every key in the column is a number or numeric string;
+// int64 fields are JSON strings) and as strings otherwise. Using one mode
+// for the whole column keeps the ordering transitive.
+func compareOrderKeys(a, b interface{}, numeric bool) int {
+	if numeric {
+		fa, _ := orderNumber(a)
+		fb, _ := orderNumber(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func orderNumber(v interface{}) (float64, bool) {
+	switch typed := v.(type) {
+	case float64:
+		return typed, true
+	case string:
+		f, err := strconv.ParseFloat(typed, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
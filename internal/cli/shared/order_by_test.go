@@ -0,0 +1,209 @@
+package shared
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func mockSubscriptionList() *androidpublisher.ListSubscriptionsResponse {
+	return &androidpublisher.ListSubscriptionsResponse{
+		Subscriptions: []*androidpublisher.Subscription{
+			{ProductId: "pro"},
+			{ProductId: "basic"},
+			{ProductId: "max"},
+		},
+		NextPageToken: "next",
+	}
+}
+
+func productIDs(resp *androidpublisher.ListSubscriptionsResponse) []string {
+	var ids []string
+	for _, s := range resp.Subscriptions {
+		ids = append(ids, s.ProductId)
+	}
+	return ids
+}
+
+func TestParseOrderBy(t *testing.T) {
+	order, err := ParseOrderBy(" basePlans.state:DESC ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(order.Path, []string{"basePlans", "state"}) || !order.Descending {
+		t.Fatalf("unexpected order: %+v", order)
+	}
+	if order, err := ParseOrderBy(""); order != nil || err != nil {
+		t.Fatalf("expected nil order for empty value, got %+v, %v", order, err)
+	}
+	for _, bad := range []string{"productId:sideways", "a,b", ":asc"} {
+		if _, err := ParseOrderBy(bad); err == nil {
+			t.Errorf("ParseOrderBy(%q): expected error", bad)
+		}
+	}
+}
+
+func TestParseOrderBy_LeadingDash(t *testing.T) {
+	order, err := ParseOrderBy("-updated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(order.Path, []string{"updated"}) || !order.Descending {
+		t.Fatalf("unexpected order: %+v", order)
+	}
+	if _, err := ParseOrderBy("-updated:asc"); err == nil {
+		t.Fatal("expected error combining - with a direction")
+	}
+}
+
+func itemsField(items []interface{}, field string) []string {
+	var values []string
+	for _, item := range items {
+		values = append(values, item.(map[string]interface{})[field].(string))
+	}
+	return values
+}
+
+func TestSortByField_AscendingAndDescending(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{"productId": "pro", "updated": "2024-03-01"},
+		map[string]interface{}{"productId": "basic", "updated": "2024-01-01"},
+		map[string]interface{}{"productId": "max", "updated": "2024-02-01"},
+	}
+	if err := SortByField(items, "productId"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := itemsField(items, "productId"), []string{"basic", "max", "pro"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ascending = %v, want %v", got, want)
+	}
+	if err := SortByField(items, "-updated"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := itemsField(items, "productId"), []string{"pro", "max", "basic"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("descending = %v, want %v", got, want)
+	}
+}
+
+func TestSortByField_NestedField(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{"productId": "a", "listing": map[string]interface{}{"title": "Zeta"}},
+		map[string]interface{}{"productId": "b"},
+		map[string]interface{}{"productId": "c", "listing": map[string]interface{}{"title": "Alpha"}},
+	}
+	if err := SortByField(items, "listing.title"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := itemsField(items, "productId"), []string{"c", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("nested = %v, want %v", got, want)
+	}
+	if err := SortByField(items, "listing.missing"); err == nil {
+		t.Fatal("expected error when no item has the field")
+	}
+}
+
+func TestSortResult_ListResponseAscendingAndDescending(t *testing.T) {
+	resp := mockSubscriptionList()
+	if err := SortResult(resp, "productId"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := productIDs(resp), []string{"basic", "max", "pro"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ascending = %v, want %v", got, want)
+	}
+
+	if err := SortResult(resp, "-productId"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := productIDs(resp), []string{"pro", "max", "basic"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("descending = %v, want %v", got, want)
+	}
+}
+
+func TestSortResult_NumericStringsAndMissingValues(t *testing.T) {
+	voided := []*androidpublisher.VoidedPurchase{
+		{OrderId: "b", VoidedTimeMillis: 900},
+		{OrderId: "none"},
+		{OrderId: "a", VoidedTimeMillis: 10000},
+	}
+	if err := SortResult(voided, "voidedTimeMillis:desc"); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range voided {
+		got = append(got, v.OrderId)
+	}
+	if want := []string{"a", "b", "none"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("order = %v, want %v", got, want)
+	}
+}
+
+func TestSortResult_NullEntriesSortLast(t *testing.T) {
+	subs := []*androidpublisher.Subscription{{ProductId: "pro"}, nil, {ProductId: "basic"}}
+	if err := SortResult(subs, "-productId"); err != nil {
+		t.Fatal(err)
+	}
+	if subs[0].ProductId != "pro" || subs[1].ProductId != "basic" || subs[2] != nil {
+		t.Fatalf("unexpected order: %+v", subs)
+	}
+}
+
+func TestSortResult_Errors(t *testing.T) {
+	if err := SortResult(mockSubscriptionList(), "nope"); err == nil || !strings.Contains(err.Error(), "no item has field") {
+		t.Fatalf("expected missing field error, got %v", err)
+	}
+}
+
+func TestSortResult_NonListIsNoOp(t *testing.T) {
+	sub := &androidpublisher.Subscription{ProductId: "pro"}
+	if err := SortResult(sub, "productId"); err != nil {
+		t.Fatalf("expected non-list result to be left alone, got %v", err)
+	}
+	if sub.ProductId != "pro" {
+		t.Fatalf("non-list result was modified: %+v", sub)
+	}
+}
+
+func TestSortByField_MixedColumnComparesAsStrings(t *testing.T) {
+	// Mixing numeric and string comparison would give 9 < 10 < 1a < 9,
+	// which is not transitive; a column with any non-numeric value sorts as
+	// strings throughout.
+	items := []interface{}{
+		map[string]interface{}{"v": "1a"},
+		map[string]interface{}{"v": "10"},
+		map[string]interface{}{"v": "9"},
+	}
+	if err := SortByField(items, "v"); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, item := range items {
+		got = append(got, item.(map[string]interface{})["v"].(string))
+	}
+	if want := []string{"10", "1a", "9"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("order = %v, want %v", got, want)
+	}
+}
+
+func TestPrintOutput_AppliesOrderBy(t *testing.T) {
+	t.Setenv(orderByEnvVar, "productId:desc")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := PrintOutput(map[string]interface{}{"subscriptions": mockSubscriptionList().Subscriptions}, "json", false)
+	w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("PrintOutput: %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	out := buf.String()
+	if !(strings.Index(out, "pro") < strings.Index(out, "max") && strings.Index(out, "max") < strings.Index(out, "basic")) {
+		t.Fatalf("expected descending productId order, got %s", out)
+	}
+}
@@ -0,0 +1,103 @@
+package shared
+
+import (
+	"flag"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+// ApplyOutputDefaults seeds --output and --pretty on fs when the user did not
+// pass them. The output format comes from GPLAY_DEFAULT_OUTPUT, then
+// default_output in cfg; pretty comes from default_pretty and only applies
+// when the resulting format is JSON. Flags set on the command line are left
+// untouched, and a default format the command's --output flag does not accept
+// (such as table for a text/json command, see DeclareOutputFormats) is
+// ignored.
+func ApplyOutputDefaults(fs *flag.FlagSet, cfg *config.Config) error {
+	if fs == nil {
+		return nil
+	}
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	outputFlag := fs.Lookup("output")
+	if outputFlag != nil && !explicit["output"] {
+		format := OutputFormatFromEnv()
+		if format == "" && cfg != nil {
+			format = strings.ToLower(strings.TrimSpace(cfg.DefaultOutput))
+		}
+		if format != "" && outputFlagSupports(fs, format) {
+			if err := fs.Set("output", format); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cfg == nil || !cfg.DefaultPretty || explicit["pretty"] || fs.Lookup("pretty") == nil {
+		return nil
+	}
+	if outputFlag != nil {
+		if format := strings.ToLower(strings.TrimSpace(outputFlag.Value.String())); format != "" && format != "json" {
+			return nil
+		}
+	}
+	return fs.Set("pretty", "true")
+}
+
+// ApplyConfiguredOutputDefaults applies the output defaults from the
+// environment and the loaded config to fs.
+func ApplyConfiguredOutputDefaults(fs *flag.FlagSet) error {
+	if fs == nil || (fs.Lookup("output") == nil && fs.Lookup("pretty") == nil) {
+		return nil
+	}
+	return ApplyOutputDefaults(fs, outputDefaultsConfig())
+}
+
+// standardOutputFormats are the formats PrintOutput renders, accepted by any
+// --output flag that does not declare its own list.
+var standardOutputFormats = []string{"json", "table", "markdown", "yaml", "template"}
+
+var (
+	outputFormatsMu sync.Mutex
+	outputFormats   = map[*flag.FlagSet][]string{}
+)
+
+// DeclareOutputFormats records the formats accepted by the --output flag on
+// fs, for commands that support other than the standard json, table,
+// markdown, yaml and template. Declaring no formats marks --output as
+// something else, such as a download directory, so defaults never apply.
+func DeclareOutputFormats(fs *flag.FlagSet, formats ...string) {
+	outputFormatsMu.Lock()
+	defer outputFormatsMu.Unlock()
+	outputFormats[fs] = append([]string{}, formats...)
+}
+
+// outputFlagSupports reports whether the --output flag on fs accepts format.
+func outputFlagSupports(fs *flag.FlagSet, format string) bool {
+	switch format {
+	case "md":
+		format = "markdown"
+	case "yml":
+		format = "yaml"
+	}
+	outputFormatsMu.Lock()
+	formats, declared := outputFormats[fs]
+	outputFormatsMu.Unlock()
+	if !declared {
+		formats = standardOutputFormats
+	}
+	return slices.Contains(formats, format)
+}
+
+// outputDefaultsConfig loads the config used for output defaults. A missing
+// or unreadable config simply means no defaults.
+func outputDefaultsConfig() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
@@ -0,0 +1,126 @@
+package shared
+
+import (
+	"context"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+func newOutputDefaultsFlagSet() (*flag.FlagSet, *string, *bool) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	output := fs.String("output", "json", "Output format")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+	return fs, output, pretty
+}
+
+func TestApplyOutputDefaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		env        string
+		args       []string
+		wantOutput string
+		wantPretty bool
+	}{
+		{"no config", nil, "", nil, "json", false},
+		{"config output", &config.Config{DefaultOutput: "table"}, "", nil, "table", false},
+		{"config pretty", &config.Config{DefaultPretty: true}, "", nil, "json", true},
+		{"pretty skipped for table", &config.Config{DefaultOutput: "table", DefaultPretty: true}, "", nil, "table", false},
+		{"explicit output wins", &config.Config{DefaultOutput: "table"}, "", []string{"--output", "json"}, "json", false},
+		{"explicit json keeps pretty default", &config.Config{DefaultOutput: "table", DefaultPretty: true}, "", []string{"--output", "json"}, "json", true},
+		{"explicit pretty false wins", &config.Config{DefaultPretty: true}, "", []string{"--pretty=false"}, "json", false},
+		{"env beats config", &config.Config{DefaultOutput: "table"}, "yaml", nil, "yaml", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(defaultOutputEnvVar, tt.env)
+			fs, output, pretty := newOutputDefaultsFlagSet()
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if err := ApplyOutputDefaults(fs, tt.cfg); err != nil {
+				t.Fatalf("ApplyOutputDefaults: %v", err)
+			}
+			if *output != tt.wantOutput {
+				t.Errorf("output = %q, want %q", *output, tt.wantOutput)
+			}
+			if *pretty != tt.wantPretty {
+				t.Errorf("pretty = %v, want %v", *pretty, tt.wantPretty)
+			}
+		})
+	}
+}
+
+func TestApplyOutputDefaults_SkipsUnlistedFormat(t *testing.T) {
+	t.Setenv(defaultOutputEnvVar, "")
+	fs := flag.NewFlagSet("auth doctor", flag.ContinueOnError)
+	output := fs.String("output", "text", "Output format: text (default), json")
+	DeclareOutputFormats(fs, "text", "json")
+	if err := fs.Parse(nil); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := ApplyOutputDefaults(fs, &config.Config{DefaultOutput: "table"}); err != nil {
+		t.Fatalf("ApplyOutputDefaults: %v", err)
+	}
+	if *output != "text" {
+		t.Fatalf("output = %q, want text kept", *output)
+	}
+	if err := ApplyOutputDefaults(fs, &config.Config{DefaultOutput: "json"}); err != nil {
+		t.Fatalf("ApplyOutputDefaults: %v", err)
+	}
+	if *output != "json" {
+		t.Fatalf("output = %q, want json", *output)
+	}
+}
+
+func TestApplyOutputDefaults_SkipsNonFormatOutputFlag(t *testing.T) {
+	t.Setenv(defaultOutputEnvVar, "json")
+	fs := flag.NewFlagSet("generatedapks download", flag.ContinueOnError)
+	output := fs.String("output", ".", "Output directory for downloaded APK")
+	DeclareOutputFormats(fs)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := ApplyOutputDefaults(fs, nil); err != nil {
+		t.Fatalf("ApplyOutputDefaults: %v", err)
+	}
+	if *output != "." {
+		t.Fatalf("output = %q, want directory kept", *output)
+	}
+}
+
+func TestWrapCommandOutputValidation_AppliesConfigDefaults(t *testing.T) {
+	t.Setenv(defaultOutputEnvVar, "")
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := config.SaveAt(path, &config.Config{DefaultOutput: "markdown"}); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("GPLAY_CONFIG_PATH", path)
+
+	fs, output, _ := newOutputDefaultsFlagSet()
+	var got string
+	cmd := &ffcli.Command{
+		Name:    "test",
+		FlagSet: fs,
+		Exec: func(ctx context.Context, args []string) error {
+			got = *output
+			return nil
+		},
+	}
+	WrapCommandOutputValidation(cmd)
+
+	if err := fs.Parse(nil); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if got != "markdown" {
+		t.Fatalf("output = %q, want markdown", got)
+	}
+}
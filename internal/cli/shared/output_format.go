@@ -12,6 +12,7 @@ var validOutputFormats = map[string]bool{
 	"json":     true,
 	"table":    true,
 	"markdown": true,
+	"yaml":     true,
 }
 
 // ResolveOutputFormat returns the output format to use based on flag and env var.
@@ -39,3 +40,13 @@ func ResolveOutputFormat(flagValue string, flagDefault string) string {
 
 	return "json"
 }
+
+// OutputFormatFromEnv returns GPLAY_DEFAULT_OUTPUT when it names a valid
+// format, or "".
+func OutputFormatFromEnv() string {
+	envVal := strings.ToLower(strings.TrimSpace(os.Getenv(defaultOutputEnvVar)))
+	if validOutputFormats[envVal] {
+		return envVal
+	}
+	return ""
+}
@@ -10,8 +10,8 @@ import (
 )
 
 // WrapCommandOutputValidation recursively wraps all commands' Exec functions
-// to validate output format flags before execution. This prevents API calls
-// when invalid output flags are passed.
+// to apply configured output defaults and validate output format flags before
+// execution. This prevents API calls when invalid output flags are passed.
 func WrapCommandOutputValidation(cmd *ffcli.Command) {
 	if cmd == nil {
 		return
@@ -34,16 +34,20 @@ func WrapCommandOutputValidation(cmd *ffcli.Command) {
 			outputFlag := cmd.FlagSet.Lookup("output")
 			prettyFlag := cmd.FlagSet.Lookup("pretty")
 
+			if err := ApplyConfiguredOutputDefaults(cmd.FlagSet); err != nil {
+				return err
+			}
+
 			if outputFlag != nil {
 				format := strings.ToLower(strings.TrimSpace(outputFlag.Value.String()))
-				validFormats := map[string]bool{"json": true, "table": true, "markdown": true, "md": true, "": true}
+				validFormats := map[string]bool{"json": true, "table": true, "markdown": true, "md": true, "yaml": true, "yml": true, "template": true, "": true}
 				if !validFormats[format] {
 					fmt.Fprintf(os.Stderr, "Error: unsupported output format %q\n", format)
 					return fmt.Errorf("unsupported output format: %s", format)
 				}
 
 				if prettyFlag != nil && prettyFlag.Value.String() == "true" {
-					if format == "table" || format == "markdown" || format == "md" {
+					if format == "table" || format == "markdown" || format == "md" || format == "yaml" || format == "yml" || format == "template" {
 						fmt.Fprintln(os.Stderr, "Error: --pretty is only valid with JSON output")
 						return fmt.Errorf("--pretty is only valid with JSON output")
 					}
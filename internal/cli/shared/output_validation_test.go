@@ -153,3 +153,44 @@ func TestWrapCommandOutputValidation_RecursiveSubcommands(t *testing.T) {
 		t.Error("parent Exec should have been called")
 	}
 }
+
+func TestWrapCommandOutputValidation_PrettyWithYAML(t *testing.T) {
+	executed := false
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("output", "json", "Output format")
+	fs.Bool("pretty", false, "Pretty-print")
+
+	cmd := &ffcli.Command{
+		Name:    "test",
+		FlagSet: fs,
+		Exec: func(ctx context.Context, args []string) error {
+			executed = true
+			return nil
+		},
+	}
+
+	WrapCommandOutputValidation(cmd)
+
+	if err := fs.Parse([]string{"--output", "yaml", "--pretty"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for --pretty with yaml output")
+	}
+	if executed {
+		t.Error("original Exec should NOT have been called")
+	}
+}
+
+func TestValidateOutputFlags_YAML(t *testing.T) {
+	if err := ValidateOutputFlags("yaml", false); err != nil {
+		t.Errorf("unexpected error for yaml: %v", err)
+	}
+	if err := ValidateOutputFlags("yaml", true); err == nil {
+		t.Error("expected error for --pretty with yaml")
+	}
+	if err := PrintOutput(map[string]string{}, "yaml", true); err == nil {
+		t.Error("expected PrintOutput to reject --pretty with yaml")
+	}
+}
@@ -0,0 +1,140 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared/errfmt"
+)
+
+// MaxItemsFlagUsage is the help text for the --max-items flag of paginated
+// list commands.
+const MaxItemsFlagUsage = "Stop after this many items and mark the output truncated (implies --paginate; 0 = no limit)"
+
+const partialOKEnvVar = "GPLAY_PARTIAL_OK"
+
+// partialOKFromEnv reports whether --partial-ok was set.
+func partialOKFromEnv() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(partialOKEnvVar))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// pageAttempts is how many times a single page is requested before a
+// transient error is returned.
+const pageAttempts = 3
+
+// pageRetryDelay returns the wait before retrying a page; a variable so tests
+// do not sleep.
+var pageRetryDelay = func(attempt int) time.Duration {
+	return time.Duration(attempt) * time.Second
+}
+
+// PageFetcher fetches the page identified by token ("" for the first page)
+// and returns its items and the token of the next page ("" on the last page).
+type PageFetcher[T any] func(ctx context.Context, token string) ([]T, string, error)
+
+// PaginatedResult is the --output json shape of a list capped with
+// --max-items.
+type PaginatedResult[T any] struct {
+	Items     []T  `json:"items"`
+	Truncated bool `json:"truncated"`
+}
+
+// ValidateMaxItems checks the --max-items flag value.
+func ValidateMaxItems(maxItems int) error {
+	if maxItems < 0 {
+		return fmt.Errorf("--max-items must be 0 or greater")
+	}
+	return nil
+}
+
+// FetchAllPages follows page tokens until the last page or until maxItems
+// items have been collected (0 means no limit). truncated reports that the
+// cap cut the listing short. A page that fails with a rate limit or server
+// error is retried before the error is returned. Any failure is returned, so
+// callers that act on the listing never see an incomplete one.
+func FetchAllPages[T any](ctx context.Context, maxItems int, fetch PageFetcher[T]) (items []T, truncated bool, err error) {
+	return fetchAllPages(ctx, maxItems, false, fetch)
+}
+
+// FetchListPages is FetchAllPages for list commands that print the result
+// with PrintPaginated. With --partial-ok, a failure after the first page
+// returns the items fetched so far as truncated and warns on stderr instead.
+func FetchListPages[T any](ctx context.Context, maxItems int, fetch PageFetcher[T]) (items []T, truncated bool, err error) {
+	return fetchAllPages(ctx, maxItems, partialOKFromEnv(), fetch)
+}
+
+func fetchAllPages[T any](ctx context.Context, maxItems int, partialOK bool, fetch PageFetcher[T]) (items []T, truncated bool, err error) {
+	token := ""
+	for pageNum := 1; ; pageNum++ {
+		page, next, err := fetchPage(ctx, token, fetch)
+		if err != nil {
+			if pageNum > 1 && partialOK {
+				fmt.Fprintf(os.Stderr, "Warning: page %d failed, returning %d items from the first %d pages (--partial-ok): %v\n", pageNum, len(items), pageNum-1, err)
+				return items, true, nil
+			}
+			return nil, false, err
+		}
+		items = append(items, page...)
+		if maxItems > 0 && len(items) >= maxItems {
+			truncated = len(items) > maxItems || next != ""
+			return items[:maxItems], truncated, nil
+		}
+		if next == "" {
+			return items, false, nil
+		}
+		token = next
+	}
+}
+
+func fetchPage[T any](ctx context.Context, token string, fetch PageFetcher[T]) ([]T, string, error) {
+	for attempt := 1; ; attempt++ {
+		page, next, err := fetch(ctx, token)
+		if err == nil || attempt == pageAttempts || !isTransientPageError(err) {
+			return page, next, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, "", err
+		case <-time.After(pageRetryDelay(attempt)):
+		}
+	}
+}
+
+func isTransientPageError(err error) bool {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false
+	}
+	if kind, ok := errfmt.QuotaError(err); ok {
+		return kind == errfmt.QuotaRateLimited
+	}
+	return gerr.Code >= http.StatusInternalServerError
+}
+
+// PrintPaginated prints items collected with FetchAllPages. Without a cap the
+// output stays the bare item array; with --max-items it is wrapped in
+// PaginatedResult so callers can see whether results were cut off.
+func PrintPaginated[T any](items []T, truncated bool, maxItems int, format string, pretty bool) error {
+	if maxItems <= 0 {
+		return PrintOutput(items, format, pretty)
+	}
+	if truncated && len(items) == maxItems {
+		fmt.Fprintf(os.Stderr, "Warning: stopped after %d items (--max-items); more results are available\n", maxItems)
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return PrintOutput(PaginatedResult[T]{Items: items, Truncated: truncated}, format, pretty)
+}
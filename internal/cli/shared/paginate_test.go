@@ -0,0 +1,224 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// twoPages serves ["a","b"] then ["c"] and counts the requests.
+func twoPages(calls *int) PageFetcher[string] {
+	return func(ctx context.Context, token string) ([]string, string, error) {
+		*calls++
+		if token == "" {
+			return []string{"a", "b"}, "page-2", nil
+		}
+		return []string{"c"}, "", nil
+	}
+}
+
+func TestFetchAllPages_NoCap(t *testing.T) {
+	var calls int
+	items, truncated, err := FetchAllPages(context.Background(), 0, twoPages(&calls))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(items, []string{"a", "b", "c"}) || truncated || calls != 2 {
+		t.Fatalf("items=%v truncated=%v calls=%d", items, truncated, calls)
+	}
+}
+
+func TestFetchAllPages_CapOfOneAcrossTwoPages(t *testing.T) {
+	var calls int
+	items, truncated, err := FetchAllPages(context.Background(), 1, twoPages(&calls))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(items, []string{"a"}) || !truncated {
+		t.Fatalf("items=%v truncated=%v, want [a] truncated", items, truncated)
+	}
+	if calls != 1 {
+		t.Fatalf("expected to stop after the first page, got %d calls", calls)
+	}
+}
+
+func TestFetchAllPages_CapMatchingTotalIsNotTruncated(t *testing.T) {
+	var calls int
+	items, truncated, err := FetchAllPages(context.Background(), 3, twoPages(&calls))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 3 || truncated {
+		t.Fatalf("items=%v truncated=%v, want all items untruncated", items, truncated)
+	}
+}
+
+func TestFetchAllPages_RetriesTransientErrors(t *testing.T) {
+	original := pageRetryDelay
+	pageRetryDelay = func(int) time.Duration { return 0 }
+	t.Cleanup(func() { pageRetryDelay = original })
+
+	attempts := 0
+	items, _, err := FetchAllPages(context.Background(), 0, func(ctx context.Context, token string) ([]string, string, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, "", &googleapi.Error{Code: http.StatusServiceUnavailable}
+		}
+		return []string{"a"}, "", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 || len(items) != 1 {
+		t.Fatalf("attempts=%d items=%v", attempts, items)
+	}
+}
+
+func TestFetchAllPages_DoesNotRetryPermanentErrors(t *testing.T) {
+	attempts := 0
+	_, _, err := FetchAllPages(context.Background(), 0, func(ctx context.Context, token string) ([]string, string, error) {
+		attempts++
+		return nil, "", &googleapi.Error{Code: http.StatusForbidden}
+	})
+	if err == nil || attempts != 1 {
+		t.Fatalf("expected a single failed attempt, got attempts=%d err=%v", attempts, err)
+	}
+}
+
+func TestFetchAllPages_DoesNotRetryExhaustedQuota(t *testing.T) {
+	attempts := 0
+	_, _, err := FetchAllPages(context.Background(), 0, func(ctx context.Context, token string) ([]string, string, error) {
+		attempts++
+		return nil, "", &googleapi.Error{
+			Code:   http.StatusTooManyRequests,
+			Errors: []googleapi.ErrorItem{{Reason: "dailyLimitExceeded"}},
+		}
+	})
+	if err == nil || attempts != 1 {
+		t.Fatalf("expected a single failed attempt, got attempts=%d err=%v", attempts, err)
+	}
+}
+
+func TestFetchAllPages_GivesUpAfterRetries(t *testing.T) {
+	original := pageRetryDelay
+	pageRetryDelay = func(int) time.Duration { return 0 }
+	t.Cleanup(func() { pageRetryDelay = original })
+
+	attempts := 0
+	_, _, err := FetchAllPages(context.Background(), 0, func(ctx context.Context, token string) ([]string, string, error) {
+		attempts++
+		return nil, "", &googleapi.Error{Code: http.StatusTooManyRequests}
+	})
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) || attempts != pageAttempts {
+		t.Fatalf("attempts=%d err=%v", attempts, err)
+	}
+}
+
+// failingThirdPage serves two pages of items and then a permanent error.
+func failingThirdPage(ctx context.Context, token string) ([]string, string, error) {
+	switch token {
+	case "":
+		return []string{"a"}, "page-2", nil
+	case "page-2":
+		return []string{"b"}, "page-3", nil
+	}
+	return nil, "", &googleapi.Error{Code: http.StatusForbidden}
+}
+
+func TestFetchAllPages_MidStreamFailureFailsByDefault(t *testing.T) {
+	t.Setenv(partialOKEnvVar, "")
+	items, _, err := FetchAllPages(context.Background(), 0, failingThirdPage)
+	if err == nil || items != nil {
+		t.Fatalf("expected error and no items, got items=%v err=%v", items, err)
+	}
+}
+
+func TestFetchAllPages_IgnoresPartialOK(t *testing.T) {
+	t.Setenv(partialOKEnvVar, "1")
+	items, _, err := FetchAllPages(context.Background(), 0, failingThirdPage)
+	if err == nil || items != nil {
+		t.Fatalf("expected error and no items, got items=%v err=%v", items, err)
+	}
+}
+
+func TestFetchListPages_PartialOKKeepsFetchedPages(t *testing.T) {
+	t.Setenv(partialOKEnvVar, "1")
+	var items []string
+	var truncated bool
+	var err error
+	stderr := captureLocaleStderr(func() {
+		items, truncated, err = FetchListPages(context.Background(), 0, failingThirdPage)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(items, []string{"a", "b"}) || !truncated {
+		t.Fatalf("items=%v truncated=%v, want [a b] truncated", items, truncated)
+	}
+	if !strings.Contains(stderr, "page 3 failed") || !strings.Contains(stderr, "--partial-ok") {
+		t.Fatalf("expected truncation warning, got %q", stderr)
+	}
+}
+
+func TestFetchListPages_PartialOKStillFailsOnFirstPage(t *testing.T) {
+	t.Setenv(partialOKEnvVar, "1")
+	_, _, err := FetchListPages(context.Background(), 0, func(ctx context.Context, token string) ([]string, string, error) {
+		return nil, "", &googleapi.Error{Code: http.StatusForbidden}
+	})
+	if err == nil {
+		t.Fatal("expected first-page failure to be returned")
+	}
+}
+
+func TestValidateMaxItems(t *testing.T) {
+	if err := ValidateMaxItems(0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateMaxItems(-1); err == nil || !strings.Contains(err.Error(), "--max-items") {
+		t.Fatalf("expected --max-items error, got %v", err)
+	}
+}
+
+func capturePaginatedStdout(fn func()) string {
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	r, w, _ := os.Pipe()
+	_, devNull, _ := os.Pipe()
+	os.Stdout, os.Stderr = w, devNull
+	fn()
+	w.Close()
+	devNull.Close()
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String()
+}
+
+func TestPrintPaginated_Shapes(t *testing.T) {
+	uncapped := capturePaginatedStdout(func() {
+		if err := PrintPaginated([]string{"a"}, false, 0, "json", false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if strings.TrimSpace(uncapped) != `["a"]` {
+		t.Fatalf("uncapped output = %s, want bare array", uncapped)
+	}
+
+	capped := capturePaginatedStdout(func() {
+		if err := PrintPaginated([]string{"a"}, true, 1, "json", false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if strings.TrimSpace(capped) != `{"items":["a"],"truncated":true}` {
+		t.Fatalf("capped output = %s", capped)
+	}
+}
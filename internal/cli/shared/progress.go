@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 )
 
-// ProgressReader wraps an io.Reader and reports progress to stderr.
+// ProgressReader wraps an io.Reader, counts the bytes read through it, and
+// reports progress to stderr. It is safe for concurrent use: BytesRead may
+// be called while another goroutine (such as an API upload) reads.
 type ProgressReader struct {
 	reader    io.Reader
 	total     int64
@@ -18,14 +21,15 @@ type ProgressReader struct {
 	mu        sync.Mutex
 	startTime time.Time
 	lastPrint time.Time
+	finished  bool
 }
 
 // NewProgressReader creates a progress-reporting reader.
-// If stderr is not a TTY, output is disabled.
+// If stderr is not a TTY or --quiet is set, output is disabled.
 // total can be 0 if unknown.
 func NewProgressReader(r io.Reader, total int64, filename string) *ProgressReader {
 	var w io.Writer
-	if info, err := os.Stderr.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+	if info, err := os.Stderr.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 && !QuietFromEnv() {
 		w = os.Stderr
 	}
 	return &ProgressReader{
@@ -37,22 +41,43 @@ func NewProgressReader(r io.Reader, total int64, filename string) *ProgressReade
 	}
 }
 
+// NewFileProgressReader wraps an open file for upload, using its size as
+// the total.
+func NewFileProgressReader(file *os.File) *ProgressReader {
+	var total int64
+	if info, err := file.Stat(); err == nil {
+		total = info.Size()
+	}
+	return NewProgressReader(file, total, filepath.Base(file.Name()))
+}
+
 func (pr *ProgressReader) Read(p []byte) (int, error) {
 	n, err := pr.reader.Read(p)
 	pr.mu.Lock()
+	defer pr.mu.Unlock()
 	pr.read += int64(n)
+	if pr.writer == nil || pr.finished {
+		return n, err
+	}
 	now := time.Now()
-	if pr.writer != nil && (now.Sub(pr.lastPrint) > 100*time.Millisecond || err == io.EOF) {
+	if err == io.EOF {
 		pr.printProgress()
-		pr.lastPrint = now
-	}
-	pr.mu.Unlock()
-	if err == io.EOF && pr.writer != nil {
 		pr.printFinal()
+		pr.finished = true
+	} else if now.Sub(pr.lastPrint) > 100*time.Millisecond {
+		pr.printProgress()
+		pr.lastPrint = now
 	}
 	return n, err
 }
 
+// BytesRead returns the number of bytes read so far.
+func (pr *ProgressReader) BytesRead() int64 {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	return pr.read
+}
+
 func (pr *ProgressReader) printProgress() {
 	elapsed := time.Since(pr.startTime).Seconds()
 	speed := float64(pr.read) / elapsed / 1024 / 1024 // MB/s
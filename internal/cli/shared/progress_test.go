@@ -3,6 +3,7 @@ package shared
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 	"testing"
@@ -94,3 +95,65 @@ func TestFormatBytes(t *testing.T) {
 		}
 	}
 }
+
+func TestProgressReaderForwardsReadsAndTotals(t *testing.T) {
+	data := strings.Repeat("0123456789", 10000)
+	pr := &ProgressReader{reader: strings.NewReader(data)}
+
+	// BytesRead is safe to poll while the upload reads.
+	stop := make(chan struct{})
+	polled := make(chan error)
+	go func() {
+		var last int64
+		for {
+			select {
+			case <-stop:
+				polled <- nil
+				return
+			default:
+			}
+			n := pr.BytesRead()
+			if n < last {
+				polled <- fmt.Errorf("BytesRead went backwards: %d after %d", n, last)
+				return
+			}
+			last = n
+		}
+	}()
+	got, err := io.ReadAll(pr)
+	close(stop)
+	if pollErr := <-polled; pollErr != nil {
+		t.Fatal(pollErr)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != data {
+		t.Fatalf("forwarded %d bytes that differ from the source", len(got))
+	}
+	if pr.BytesRead() != int64(len(data)) {
+		t.Errorf("BytesRead = %d, want %d", pr.BytesRead(), len(data))
+	}
+}
+
+func TestProgressReaderPrintsFinalLineOnce(t *testing.T) {
+	var buf bytes.Buffer
+	pr := &ProgressReader{reader: strings.NewReader("abc"), filename: "app.aab", writer: &buf}
+	if _, err := io.ReadAll(pr); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pr.Read(make([]byte, 8)); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected EOF on a drained reader, got %v", err)
+	}
+	if count := strings.Count(buf.String(), "Uploaded app.aab"); count != 1 {
+		t.Errorf("final line printed %d times, want 1: %q", count, buf.String())
+	}
+}
+
+func TestNewProgressReaderQuietDisablesOutput(t *testing.T) {
+	t.Setenv(quietEnvVar, "1")
+	if pr := NewProgressReader(strings.NewReader("x"), 1, "x"); pr.writer != nil {
+		t.Error("expected no progress writer with --quiet")
+	}
+}
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const quietEnvVar = "GPLAY_QUIET"
+
+type quietKey struct{}
+
+// QuietFromEnv reports whether --quiet (GPLAY_QUIET) is set.
+func QuietFromEnv() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(quietEnvVar))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// ContextWithQuiet returns a context with the quiet flag set.
+func ContextWithQuiet(ctx context.Context, quiet bool) context.Context {
+	return context.WithValue(ctx, quietKey{}, quiet)
+}
+
+// IsQuiet returns true if the context has quiet enabled.
+func IsQuiet(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	v, ok := ctx.Value(quietKey{}).(bool)
+	return ok && v
+}
+
+// Infof prints an informational note, such as progress or a summary, to
+// stderr. It prints nothing when ctx is quiet, so use it only for output a
+// script can do without; errors and warnings should be printed directly.
+func Infof(ctx context.Context, format string, args ...interface{}) {
+	Finfof(ctx, os.Stderr, format, args...)
+}
+
+// Finfof is Infof writing to w.
+func Finfof(ctx context.Context, w io.Writer, format string, args ...interface{}) {
+	if IsQuiet(ctx) {
+		return
+	}
+	fmt.Fprintf(w, format, args...)
+}
@@ -0,0 +1,46 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestFinfof_WritesWhenNotQuiet(t *testing.T) {
+	var buf bytes.Buffer
+	Finfof(context.Background(), &buf, "Imported %d listings\n", 3)
+	if got := buf.String(); got != "Imported 3 listings\n" {
+		t.Fatalf("Finfof wrote %q", got)
+	}
+}
+
+func TestFinfof_SilentWhenQuiet(t *testing.T) {
+	var buf bytes.Buffer
+	Finfof(ContextWithQuiet(context.Background(), true), &buf, "Imported %d listings\n", 3)
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output with quiet set, got %q", buf.String())
+	}
+}
+
+func TestIsQuiet(t *testing.T) {
+	if IsQuiet(context.Background()) {
+		t.Error("background context should not be quiet")
+	}
+	if IsQuiet(ContextWithQuiet(context.Background(), false)) {
+		t.Error("quiet=false context should not be quiet")
+	}
+	if !IsQuiet(ContextWithQuiet(context.Background(), true)) {
+		t.Error("quiet=true context should be quiet")
+	}
+}
+
+func TestQuietFromEnv(t *testing.T) {
+	t.Setenv(quietEnvVar, "true")
+	if !QuietFromEnv() {
+		t.Error("expected GPLAY_QUIET=true to be quiet")
+	}
+	t.Setenv(quietEnvVar, "0")
+	if QuietFromEnv() {
+		t.Error("expected GPLAY_QUIET=0 not to be quiet")
+	}
+}
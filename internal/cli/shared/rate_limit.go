@@ -0,0 +1,99 @@
+package shared
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+const qpsEnvVar = "GPLAY_QPS"
+
+// ResolveQPS returns the request rate limit from --qps or GPLAY_QPS, falling
+// back to max_qps in the config. 0 means unlimited.
+func ResolveQPS(cfg *config.Config) float64 {
+	if env := strings.TrimSpace(os.Getenv(qpsEnvVar)); env != "" {
+		if parsed, err := strconv.ParseFloat(env, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+		return 0
+	}
+	if cfg != nil && cfg.MaxQPS > 0 {
+		return cfg.MaxQPS
+	}
+	return 0
+}
+
+// RateLimiter is a token bucket holding a single token that refills at qps
+// tokens per second, so successive requests are spaced 1/qps apart.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	now      func() time.Time
+}
+
+// NewRateLimiter returns a limiter allowing qps requests per second.
+func NewRateLimiter(qps float64) *RateLimiter {
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / qps),
+		now:      time.Now,
+	}
+}
+
+// Wait blocks until the next token is available or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := l.now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RateLimitTransport waits on Limiter before sending each request through
+// Base.
+type RateLimitTransport struct {
+	Base    http.RoundTripper
+	Limiter *RateLimiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// WithRateLimit wraps client's transport with a limiter when qps is positive.
+func WithRateLimit(client *http.Client, qps float64) {
+	if qps <= 0 {
+		return
+	}
+	client.Transport = &RateLimitTransport{Base: client.Transport, Limiter: NewRateLimiter(qps)}
+}
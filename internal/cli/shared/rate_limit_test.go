@@ -0,0 +1,96 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/config"
+)
+
+func TestRateLimitTransport_SpacesRequests(t *testing.T) {
+	var mu sync.Mutex
+	var stamps []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		stamps = append(stamps, time.Now())
+		mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+
+	client := server.Client()
+	WithRateLimit(client, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("request failed: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if len(stamps) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(stamps))
+	}
+	// Allow some scheduling slack below the 500ms interval.
+	const minGap = 450 * time.Millisecond
+	for i := 1; i < len(stamps); i++ {
+		if gap := stamps[i].Sub(stamps[i-1]); gap < minGap {
+			t.Fatalf("requests %d and %d were %s apart, want at least %s", i-1, i, gap, minGap)
+		}
+	}
+}
+
+func TestRateLimiter_WaitRespectsCancellation(t *testing.T) {
+	limiter := NewRateLimiter(0.1)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("first wait should not block: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := limiter.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("wait ignored cancellation, took %s", elapsed)
+	}
+}
+
+func TestWithRateLimit_ZeroLeavesTransport(t *testing.T) {
+	client := &http.Client{}
+	WithRateLimit(client, 0)
+	if client.Transport != nil {
+		t.Fatalf("expected transport to stay unset, got %T", client.Transport)
+	}
+}
+
+func TestResolveQPS(t *testing.T) {
+	cfg := &config.Config{MaxQPS: 5}
+
+	t.Setenv(qpsEnvVar, "")
+	if got := ResolveQPS(nil); got != 0 {
+		t.Fatalf("default = %g, want 0", got)
+	}
+	if got := ResolveQPS(cfg); got != 5 {
+		t.Fatalf("config = %g, want 5", got)
+	}
+
+	t.Setenv(qpsEnvVar, "0.5")
+	if got := ResolveQPS(cfg); got != 0.5 {
+		t.Fatalf("env = %g, want 0.5", got)
+	}
+}
@@ -0,0 +1,33 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+const rawEnvVar = "GPLAY_RAW"
+
+// rawFromEnv reports whether --raw was set.
+func rawFromEnv() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(rawEnvVar))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// printRaw writes the json.Marshal of data with no sorting, projection, or
+// empty-field filling. Only JSON output is allowed.
+func printRaw(data interface{}, format string, pretty bool) error {
+	if format != "json" && format != "" {
+		return fmt.Errorf("--raw requires --output json, got %q", format)
+	}
+	if pretty {
+		return output.PrintPrettyJSON(data)
+	}
+	return output.PrintJSON(data)
+}
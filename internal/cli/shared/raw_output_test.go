@@ -0,0 +1,81 @@
+package shared
+
+import (
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func TestPrintOutput_RawIgnoresFieldsAndIncludeEmpty(t *testing.T) {
+	t.Setenv(rawEnvVar, "1")
+	t.Setenv(fieldsEnvVar, "productId")
+	t.Setenv(includeEmptyEnvVar, "1")
+
+	got := printJSONForTest(t, &androidpublisher.Subscription{ProductId: "premium", PackageName: "com.example.app"})
+	if got["packageName"] != "com.example.app" {
+		t.Errorf("--raw should ignore --fields, got %v", got)
+	}
+	if _, ok := got["basePlans"]; ok {
+		t.Errorf("--raw should not fill empty fields, got %v", got)
+	}
+}
+
+func TestPrintOutput_RawRejectsNonJSONOutput(t *testing.T) {
+	t.Setenv(rawEnvVar, "1")
+
+	err := PrintOutput(map[string]string{"a": "b"}, "table", false)
+	if err == nil || !strings.Contains(err.Error(), "--raw requires --output json") {
+		t.Fatalf("expected --raw output error, got %v", err)
+	}
+	if err := ValidateOutputFlags("yaml", false); err == nil {
+		t.Fatal("expected ValidateOutputFlags to reject --raw with yaml")
+	}
+}
+
+func TestValidateRaw_RejectsReshapingFlags(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--raw", "--fields", "productId"}, "--fields"},
+		{[]string{"--raw", "--order-by", "productId"}, "--order-by"},
+		{[]string{"--raw", "--include-empty"}, "--include-empty"},
+		{[]string{"--raw", "--template", "{{.productId}}"}, "--template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			for _, env := range []string{rawEnvVar, fieldsEnvVar, orderByEnvVar, includeEmptyEnvVar, templateEnvVar} {
+				t.Setenv(env, "")
+			}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			rf := BindRootFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			rf.Apply()
+			var err error
+			stderr := captureLocaleStderr(func() { err = rf.ValidateRaw() })
+			if !errors.Is(err, flag.ErrHelp) || !strings.Contains(stderr, "--raw cannot be combined with "+tt.want) {
+				t.Fatalf("expected conflict with %s, got err=%v stderr=%q", tt.want, err, stderr)
+			}
+		})
+	}
+}
+
+func TestValidateRaw_AloneIsValid(t *testing.T) {
+	for _, env := range []string{rawEnvVar, fieldsEnvVar, orderByEnvVar, includeEmptyEnvVar, templateEnvVar} {
+		t.Setenv(env, "")
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	rf := BindRootFlags(fs)
+	if err := fs.Parse([]string{"--raw"}); err != nil {
+		t.Fatal(err)
+	}
+	rf.Apply()
+	if err := rf.ValidateRaw(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
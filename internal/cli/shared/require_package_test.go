@@ -82,3 +82,69 @@ func TestRequirePackageName_WhitespaceOnlyIsEmpty(t *testing.T) {
 		t.Fatal("expected error when package name is whitespace-only")
 	}
 }
+
+func profileDefaultsConfig() *config.Config {
+	return &config.Config{
+		DefaultProfile: "work",
+		PackageName:    "com.example.global",
+		DeveloperID:    "1000",
+		Profiles: []config.Profile{
+			{Name: "work", DefaultPackage: "com.example.work", DefaultDeveloper: "2000"},
+			{Name: "personal", DefaultPackage: "com.example.personal"},
+		},
+	}
+}
+
+func TestResolvePackageName_Precedence(t *testing.T) {
+	t.Setenv("GPLAY_PACKAGE_NAME", "")
+	t.Setenv("GPLAY_PROFILE", "")
+	cfg := profileDefaultsConfig()
+
+	if got := ResolvePackageName("com.example.flag", cfg); got != "com.example.flag" {
+		t.Errorf("flag: got %q", got)
+	}
+	if got := ResolvePackageName("", cfg); got != "com.example.work" {
+		t.Errorf("profile: got %q, want com.example.work", got)
+	}
+	cfg.Profiles[0].DefaultPackage = ""
+	if got := ResolvePackageName("", cfg); got != "com.example.global" {
+		t.Errorf("global: got %q, want com.example.global", got)
+	}
+}
+
+func TestResolvePackageName_FollowsProfileSwitch(t *testing.T) {
+	t.Setenv("GPLAY_PACKAGE_NAME", "")
+	t.Setenv("GPLAY_PROFILE", "")
+	cfg := profileDefaultsConfig()
+
+	if got := ResolvePackageName("", cfg); got != "com.example.work" {
+		t.Fatalf("before switch: got %q", got)
+	}
+	cfg.DefaultProfile = "personal"
+	if got := ResolvePackageName("", cfg); got != "com.example.personal" {
+		t.Fatalf("after switch: got %q", got)
+	}
+	t.Setenv("GPLAY_PROFILE", "work")
+	if got := ResolvePackageName("", cfg); got != "com.example.work" {
+		t.Fatalf("with GPLAY_PROFILE=work: got %q", got)
+	}
+}
+
+func TestResolveDeveloperID_Precedence(t *testing.T) {
+	t.Setenv("GPLAY_PROFILE", "")
+	cfg := profileDefaultsConfig()
+
+	if got := ResolveDeveloperID(" 3000 ", cfg); got != "3000" {
+		t.Errorf("flag: got %q", got)
+	}
+	if got := ResolveDeveloperID("", cfg); got != "2000" {
+		t.Errorf("profile: got %q, want 2000", got)
+	}
+	cfg.DefaultProfile = "personal"
+	if got := ResolveDeveloperID("", cfg); got != "1000" {
+		t.Errorf("global: got %q, want 1000", got)
+	}
+	if got := ResolveDeveloperID("", nil); got != "" {
+		t.Errorf("nil config: got %q", got)
+	}
+}
@@ -2,28 +2,68 @@ package shared
 
 import (
 	"flag"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // RootFlags holds the parsed root-level flags.
 type RootFlags struct {
-	Profile    *string
-	Debug      *bool
-	DryRun     *bool
-	Report     *string
-	ReportFile *string
+	Profile       *string
+	EnvFile       *string
+	Debug         *bool
+	DryRun        *bool
+	Report        *string
+	ReportFile    *string
+	Trace         *bool
+	Fields        *string
+	IncludeEmpty  *bool
+	OrderBy       *string
+	PartialOK     *bool
+	Raw           *bool
+	Template      *string
+	Schema        *string
+	HTTPTimeout   *time.Duration
+	Timeout       *OptionalDuration
+	UploadTimeout *OptionalDuration
+	QPS           *float64
+	APIBaseURL    *string
+	Insecure      *bool
+	MaskSecrets   *bool
+	Quiet         *bool
 }
 
 // BindRootFlags registers root-level flags on the given FlagSet.
 func BindRootFlags(fs *flag.FlagSet) *RootFlags {
-	return &RootFlags{
-		Profile:    fs.String("profile", "", "Config profile to use (overrides GPLAY_PROFILE)"),
-		Debug:      fs.Bool("debug", false, "Enable debug logging (overrides GPLAY_DEBUG)"),
-		DryRun:     fs.Bool("dry-run", false, "Preview write operations without executing them"),
-		Report:     fs.String("report", "", "CI report format (junit)"),
-		ReportFile: fs.String("report-file", "", "CI report output file path"),
+	rf := &RootFlags{
+		Profile:       fs.String("profile", "", "Config profile to use (overrides GPLAY_PROFILE)"),
+		EnvFile:       fs.String("env-file", "", "Load KEY=VALUE pairs (e.g. GPLAY_SERVICE_ACCOUNT_JSON) from a dotenv file; variables already set in the environment win"),
+		Debug:         fs.Bool("debug", false, "Enable debug logging (overrides GPLAY_DEBUG)"),
+		DryRun:        fs.Bool("dry-run", false, "Preview write operations without executing them"),
+		Report:        fs.String("report", "", "CI report format (junit)"),
+		ReportFile:    fs.String("report-file", "", "CI report output file path"),
+		Trace:         fs.Bool("trace", false, "Print a timing breakdown of command phases to stderr"),
+		Fields:        fs.String("fields", "", "Comma-separated dotted JSON paths to keep in JSON output (e.g. productId,basePlans.state)"),
+		OrderBy:       fs.String("order-by", "", "Sort list results by a dotted JSON field before printing, as field[:asc|desc] or -field (e.g. productId, purchaseTime:desc, -updated)"),
+		PartialOK:     fs.Bool("partial-ok", false, "With --paginate on list commands, print the pages fetched before a failing page, with a warning, instead of failing; exports, imports and diffs still fail (overrides GPLAY_PARTIAL_OK)"),
+		Raw:           fs.Bool("raw", false, "Print JSON output exactly as the API returned it, with no sorting, projection, or humanization; cannot be combined with --fields, --order-by, --include-empty, or --template (overrides GPLAY_RAW)"),
+		IncludeEmpty:  fs.Bool("include-empty", false, "Keep empty fields in JSON output, as null or zero values, instead of omitting them (overrides GPLAY_INCLUDE_EMPTY)"),
+		Template:      fs.String("template", "", "Go text/template (or @file) applied to the JSON result with --output template (e.g. '{{.productId}}')"),
+		Schema:        fs.String("schema", "", "Print the JSON Schema of a command's --output json result (e.g. \"tracks list\") and exit"),
+		HTTPTimeout:   fs.Duration("http-timeout", 0, "Transport timeout for connecting, TLS handshake, and response headers, separate from the request deadline (overrides GPLAY_HTTP_TIMEOUT)"),
+		QPS:           fs.Float64("qps", 0, "Maximum API requests per second across the command, e.g. 2 or 0.5 (0 = unlimited; overrides GPLAY_QPS and max_qps in config)"),
+		MaskSecrets:   fs.Bool("mask-secrets-in-errors", true, "Scrub tokens, private keys, signed URL parameters, webhook URLs, and key file paths from error output; pass =false to see them (overrides GPLAY_MASK_SECRETS_IN_ERRORS)"),
+		Quiet:         fs.Bool("quiet", false, "Suppress upload progress and informational notes on stderr; errors, warnings, and dry-run results still print (overrides GPLAY_QUIET)"),
+		APIBaseURL:    fs.String("api-base-url", "", "Send Play Developer API requests to this base URL instead of Google, e.g. a local emulator; testing only (overrides GPLAY_API_BASE_URL)"),
+		Insecure:      fs.Bool("insecure-skip-verify", false, "Disable TLS certificate verification for a self-signed --api-base-url; Google hosts stay verified; testing only"),
+		Timeout:       &OptionalDuration{},
+		UploadTimeout: &OptionalDuration{},
 	}
+	fs.Var(rf.Timeout, "timeout", "Request timeout for this invocation, e.g. 90s or 5m (overrides GPLAY_TIMEOUT and timeout in config)")
+	fs.Var(rf.UploadTimeout, "upload-timeout", "Upload timeout for this invocation, e.g. 20m (overrides GPLAY_UPLOAD_TIMEOUT and upload_timeout in config)")
+	return rf
 }
 
 // Apply sets environment variables based on parsed root flags.
@@ -35,6 +75,121 @@ func (rf *RootFlags) Apply() {
 	if rf.Debug != nil && *rf.Debug {
 		os.Setenv("GPLAY_DEBUG", "1")
 	}
+	if rf.Fields != nil && strings.TrimSpace(*rf.Fields) != "" {
+		os.Setenv(fieldsEnvVar, strings.TrimSpace(*rf.Fields))
+	}
+	if rf.OrderBy != nil && strings.TrimSpace(*rf.OrderBy) != "" {
+		os.Setenv(orderByEnvVar, strings.TrimSpace(*rf.OrderBy))
+	}
+	if rf.PartialOK != nil && *rf.PartialOK {
+		os.Setenv(partialOKEnvVar, "1")
+	}
+	if rf.Raw != nil && *rf.Raw {
+		os.Setenv(rawEnvVar, "1")
+	}
+	if rf.IncludeEmpty != nil && *rf.IncludeEmpty {
+		os.Setenv(includeEmptyEnvVar, "1")
+	}
+	if rf.Template != nil && strings.TrimSpace(*rf.Template) != "" {
+		os.Setenv(templateEnvVar, *rf.Template)
+	}
+	if rf.HTTPTimeout != nil && *rf.HTTPTimeout > 0 {
+		os.Setenv(httpTimeoutEnvVar, rf.HTTPTimeout.String())
+	}
+	if rf.QPS != nil && *rf.QPS > 0 {
+		os.Setenv(qpsEnvVar, strconv.FormatFloat(*rf.QPS, 'g', -1, 64))
+	}
+	if rf.APIBaseURL != nil && strings.TrimSpace(*rf.APIBaseURL) != "" {
+		os.Setenv(apiBaseURLEnvVar, strings.TrimSpace(*rf.APIBaseURL))
+	}
+	if rf.Insecure != nil && *rf.Insecure {
+		insecureSkipVerify = true
+	}
+	if rf.Quiet != nil && *rf.Quiet {
+		os.Setenv(quietEnvVar, "1")
+	}
+	if rf.MaskSecrets != nil && !*rf.MaskSecrets {
+		os.Setenv(maskSecretsEnvVar, "0")
+	}
+}
+
+// ValidateQPS checks that --qps is not negative.
+func (rf *RootFlags) ValidateQPS() error {
+	if rf.QPS != nil && *rf.QPS < 0 {
+		return UsageErrorf("--qps must be 0 (unlimited) or greater, got %g", *rf.QPS)
+	}
+	return nil
+}
+
+// ValidateAPIBaseURL checks that --api-base-url is an http or https URL and
+// that --insecure-skip-verify is only used with it. It reads the
+// environment, so call it after Apply.
+func (rf *RootFlags) ValidateAPIBaseURL() error {
+	base := APIBaseURL()
+	if base == "" {
+		if InsecureSkipVerify() {
+			return UsageError("--insecure-skip-verify requires --api-base-url; Google endpoints are always verified")
+		}
+		return nil
+	}
+	parsed, err := url.Parse(base)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return UsageErrorf("--api-base-url must be an http or https URL such as https://localhost:8443/, got %q", strings.TrimSuffix(base, "/"))
+	}
+	return nil
+}
+
+// ValidateTimeouts checks that --timeout and --upload-timeout are positive
+// when set.
+func (rf *RootFlags) ValidateTimeouts() error {
+	if rf.Timeout != nil && rf.Timeout.IsSet() && rf.Timeout.Value() <= 0 {
+		return UsageErrorf("--timeout must be a positive duration such as 90s or 5m, got %s", rf.Timeout.Value())
+	}
+	if rf.UploadTimeout != nil && rf.UploadTimeout.IsSet() && rf.UploadTimeout.Value() <= 0 {
+		return UsageErrorf("--upload-timeout must be a positive duration such as 20m, got %s", rf.UploadTimeout.Value())
+	}
+	return nil
+}
+
+// TimeoutOverrides returns the --timeout and --upload-timeout values, zero
+// when unset.
+func (rf *RootFlags) TimeoutOverrides() (request, upload time.Duration) {
+	if rf.Timeout != nil && rf.Timeout.IsSet() {
+		request = rf.Timeout.Value()
+	}
+	if rf.UploadTimeout != nil && rf.UploadTimeout.IsSet() {
+		upload = rf.UploadTimeout.Value()
+	}
+	return request, upload
+}
+
+// ValidateRaw rejects --raw combined with flags that reshape JSON output.
+// It reads the environment, so call it after Apply.
+func (rf *RootFlags) ValidateRaw() error {
+	if !rawFromEnv() {
+		return nil
+	}
+	switch {
+	case strings.TrimSpace(os.Getenv(fieldsEnvVar)) != "":
+		return UsageError("--raw cannot be combined with --fields")
+	case strings.TrimSpace(os.Getenv(orderByEnvVar)) != "":
+		return UsageError("--raw cannot be combined with --order-by")
+	case includeEmptyFromEnv():
+		return UsageError("--raw cannot be combined with --include-empty")
+	case strings.TrimSpace(os.Getenv(templateEnvVar)) != "":
+		return UsageError("--raw cannot be combined with --template")
+	}
+	return nil
+}
+
+// LoadEnvFile loads --env-file into the environment. Call it after Apply so
+// root flags, like real environment variables, take precedence over the file.
+func (rf *RootFlags) LoadEnvFile() error {
+	if rf.EnvFile == nil || strings.TrimSpace(*rf.EnvFile) == "" {
+		return nil
+	}
+	_, err := LoadEnvFile(strings.TrimSpace(*rf.EnvFile))
+	return err
 }
 
 // ValidateReportFlags checks that --report and --report-file are used together.
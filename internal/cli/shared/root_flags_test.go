@@ -2,7 +2,9 @@ package shared
 
 import (
 	"flag"
+	"io"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -69,6 +71,22 @@ func TestApply_SetsDebug(t *testing.T) {
 	}
 }
 
+func TestApply_SetsQuiet(t *testing.T) {
+	t.Setenv(quietEnvVar, "")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	rf := BindRootFlags(fs)
+	if err := fs.Parse([]string{"--quiet"}); err != nil {
+		t.Fatal(err)
+	}
+
+	rf.Apply()
+
+	if !QuietFromEnv() {
+		t.Errorf("%s = %q, want quiet", quietEnvVar, os.Getenv(quietEnvVar))
+	}
+}
+
 func TestApply_EmptyProfile_DoesNotSetEnv(t *testing.T) {
 	orig := os.Getenv("GPLAY_PROFILE")
 	os.Setenv("GPLAY_PROFILE", "original")
@@ -151,3 +169,86 @@ func TestValidateReportFlags_NeitherSet_Success(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestValidateTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"unset", nil, ""},
+		{"positive", []string{"--timeout", "90s", "--upload-timeout", "20m"}, ""},
+		{"zero timeout", []string{"--timeout", "0s"}, "--timeout must be a positive duration"},
+		{"negative upload timeout", []string{"--upload-timeout", "-1m"}, "--upload-timeout must be a positive duration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			rf := BindRootFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			var err error
+			stderr := captureLocaleStderr(func() { err = rf.ValidateTimeouts() })
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(stderr, tt.wantErr) {
+				t.Fatalf("expected %q, got err=%v stderr=%q", tt.wantErr, err, stderr)
+			}
+		})
+	}
+}
+
+func TestValidateAPIBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"unset", nil, ""},
+		{"https", []string{"--api-base-url", "https://localhost:8443"}, ""},
+		{"insecure with base URL", []string{"--api-base-url", "https://localhost:8443/", "--insecure-skip-verify"}, ""},
+		{"no scheme", []string{"--api-base-url", "localhost:8443"}, "--api-base-url must be an http or https URL"},
+		{"insecure alone", []string{"--insecure-skip-verify"}, "--insecure-skip-verify requires --api-base-url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(apiBaseURLEnvVar, "")
+			t.Cleanup(func() { insecureSkipVerify = false })
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			rf := BindRootFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			rf.Apply()
+			var err error
+			stderr := captureLocaleStderr(func() { err = rf.ValidateAPIBaseURL() })
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(stderr, tt.wantErr) {
+				t.Fatalf("expected %q, got err=%v stderr=%q", tt.wantErr, err, stderr)
+			}
+		})
+	}
+	t.Setenv(apiBaseURLEnvVar, "http://127.0.0.1:9000")
+	if got := APIBaseURL(); got != "http://127.0.0.1:9000/" {
+		t.Fatalf("APIBaseURL() = %q, want a trailing slash", got)
+	}
+}
+
+func TestBindRootFlags_RejectsInvalidTimeout(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	BindRootFlags(fs)
+	if err := fs.Parse([]string{"--timeout", "soon"}); err == nil {
+		t.Fatal("expected parse error for invalid --timeout")
+	}
+}
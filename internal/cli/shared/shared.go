@@ -27,8 +27,28 @@ const (
 // PrintOutput renders output in the requested format.
 func PrintOutput(data interface{}, format string, pretty bool) error {
 	format = strings.ToLower(strings.TrimSpace(format))
+	if rawFromEnv() {
+		return printRaw(data, format, pretty)
+	}
+	if err := SortResult(data, OrderByFromEnv()); err != nil {
+		return err
+	}
 	switch format {
 	case "json", "":
+		if includeEmptyFromEnv() {
+			filled, err := IncludeEmptyFields(data)
+			if err != nil {
+				return err
+			}
+			data = filled
+		}
+		if paths := fieldPathsFromEnv(); len(paths) > 0 {
+			projected, err := ProjectFields(data, paths)
+			if err != nil {
+				return err
+			}
+			data = projected
+		}
 		if pretty {
 			return output.PrintPrettyJSON(data)
 		}
@@ -43,6 +63,20 @@ func PrintOutput(data interface{}, format string, pretty bool) error {
 			return fmt.Errorf("--pretty is only valid with JSON output")
 		}
 		return output.PrintTable(data)
+	case "yaml", "yml":
+		if pretty {
+			return fmt.Errorf("--pretty is only valid with JSON output")
+		}
+		return output.PrintYAML(data)
+	case "template":
+		if pretty {
+			return fmt.Errorf("--pretty is only valid with JSON output")
+		}
+		text, err := templateFromEnv()
+		if err != nil {
+			return err
+		}
+		return printTemplate(data, text)
 	default:
 		return fmt.Errorf("unsupported format: %s", format)
 	}
@@ -62,7 +96,22 @@ func ResolveProfileName(cfg *config.Config) string {
 	return ""
 }
 
-// ResolvePackageName returns a package name from flags/env/config.
+// activeProfile returns the selected profile, or nil if none is configured.
+func activeProfile(cfg *config.Config) *config.Profile {
+	name := ResolveProfileName(cfg)
+	if cfg == nil || name == "" {
+		return nil
+	}
+	for i := range cfg.Profiles {
+		if cfg.Profiles[i].Name == name {
+			return &cfg.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// ResolvePackageName returns a package name from flags/env/config. The active
+// profile's default_package wins over the global package_name.
 func ResolvePackageName(flagValue string, cfg *config.Config) string {
 	if strings.TrimSpace(flagValue) != "" {
 		return strings.TrimSpace(flagValue)
@@ -70,12 +119,30 @@ func ResolvePackageName(flagValue string, cfg *config.Config) string {
 	if env := strings.TrimSpace(os.Getenv(packageEnvVar)); env != "" {
 		return env
 	}
+	if p := activeProfile(cfg); p != nil && strings.TrimSpace(p.DefaultPackage) != "" {
+		return strings.TrimSpace(p.DefaultPackage)
+	}
 	if cfg != nil && strings.TrimSpace(cfg.PackageName) != "" {
 		return strings.TrimSpace(cfg.PackageName)
 	}
 	return ""
 }
 
+// ResolveDeveloperID returns a developer account ID from the --developer flag,
+// the active profile's default_developer, or the global developer_id.
+func ResolveDeveloperID(flagValue string, cfg *config.Config) string {
+	if strings.TrimSpace(flagValue) != "" {
+		return strings.TrimSpace(flagValue)
+	}
+	if p := activeProfile(cfg); p != nil && strings.TrimSpace(p.DefaultDeveloper) != "" {
+		return strings.TrimSpace(p.DefaultDeveloper)
+	}
+	if cfg != nil && strings.TrimSpace(cfg.DeveloperID) != "" {
+		return strings.TrimSpace(cfg.DeveloperID)
+	}
+	return ""
+}
+
 // RequirePackageName resolves the package name and returns an error if not found.
 func RequirePackageName(flagValue string, cfg *config.Config) (string, error) {
 	pkg := ResolvePackageName(flagValue, cfg)
@@ -125,22 +192,47 @@ func parseTimeout(envVar, envSecondsVar string, value config.DurationValue, seco
 	return 0
 }
 
-// ContextWithTimeout applies request timeouts.
+// timeoutOverridesKey is the context key for the --timeout and
+// --upload-timeout root flags.
+type timeoutOverridesKey struct{}
+
+type timeoutOverrides struct {
+	request time.Duration
+	upload  time.Duration
+}
+
+// ContextWithTimeoutOverrides returns a context carrying per-invocation
+// request and upload timeouts. Zero leaves the configured value in effect.
+func ContextWithTimeoutOverrides(ctx context.Context, request, upload time.Duration) context.Context {
+	if request <= 0 && upload <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, timeoutOverridesKey{}, timeoutOverrides{request: request, upload: upload})
+}
+
+func timeoutOverridesFromContext(ctx context.Context) timeoutOverrides {
+	v, _ := ctx.Value(timeoutOverridesKey{}).(timeoutOverrides)
+	return v
+}
+
+// ContextWithTimeout applies request timeouts. --timeout wins over the
+// environment and config.
 func ContextWithTimeout(ctx context.Context, cfg *config.Config) (context.Context, context.CancelFunc) {
-	requestTimeout, _ := ParseTimeouts(cfg)
-	if requestTimeout <= 0 {
-		return ctx, func() {}
+	if override := timeoutOverridesFromContext(ctx).request; override > 0 {
+		return contextWithConfiguredTimeout(ctx, override, "timeout", timeoutEnvVar, "--timeout")
 	}
-	return context.WithTimeout(ctx, requestTimeout) // #nosec G118 -- cancel is returned to caller
+	requestTimeout, _ := ParseTimeouts(cfg)
+	return contextWithConfiguredTimeout(ctx, requestTimeout, "timeout", timeoutEnvVar, "")
 }
 
-// ContextWithUploadTimeout applies upload timeouts.
+// ContextWithUploadTimeout applies upload timeouts. --upload-timeout wins
+// over the environment and config.
 func ContextWithUploadTimeout(ctx context.Context, cfg *config.Config) (context.Context, context.CancelFunc) {
-	_, uploadTimeout := ParseTimeouts(cfg)
-	if uploadTimeout <= 0 {
-		return ctx, func() {}
+	if override := timeoutOverridesFromContext(ctx).upload; override > 0 {
+		return contextWithConfiguredTimeout(ctx, override, "upload_timeout", uploadTimeoutEnvVar, "--upload-timeout")
 	}
-	return context.WithTimeout(ctx, uploadTimeout) // #nosec G118 -- cancel is returned to caller
+	_, uploadTimeout := ParseTimeouts(cfg)
+	return contextWithConfiguredTimeout(ctx, uploadTimeout, "upload_timeout", uploadTimeoutEnvVar, "")
 }
 
 // RequireFlags ensures the required flags are provided.
@@ -165,9 +257,17 @@ func RequireFlags(flagSet *flag.FlagSet, required ...string) error {
 // ValidateOutputFlags enforces output/pretty compatibility.
 func ValidateOutputFlags(output string, pretty bool) error {
 	normalized := strings.ToLower(strings.TrimSpace(output))
-	if (normalized == "table" || normalized == "markdown" || normalized == "md") && pretty {
+	if (normalized == "table" || normalized == "markdown" || normalized == "md" || normalized == "yaml" || normalized == "yml" || normalized == "template") && pretty {
 		return fmt.Errorf("--pretty is only valid with JSON output")
 	}
+	if rawFromEnv() && normalized != "json" && normalized != "" {
+		return fmt.Errorf("--raw requires --output json")
+	}
+	if normalized == "template" {
+		if _, err := templateFromEnv(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,33 @@
+package shared
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// stdin and stdinIsTerminal are swapped out by tests.
+var (
+	stdin           io.Reader = os.Stdin
+	stdinIsTerminal           = func() bool { return term.IsTerminal(int(os.Stdin.Fd())) }
+)
+
+// isStdinArg reports whether value asks for input from stdin ("-" or "@-").
+func isStdinArg(value string) bool {
+	return value == "-" || value == "@-"
+}
+
+// readStdin reads all of stdin. It refuses to block on an interactive
+// terminal, since that almost always means the user forgot to pipe input.
+func readStdin() ([]byte, error) {
+	if stdinIsTerminal() {
+		return nil, fmt.Errorf("stdin is a terminal; pipe input in (e.g. cat file.json | gplay ... -) or pass @file")
+	}
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, fmt.Errorf("read stdin: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,61 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const templateEnvVar = "GPLAY_TEMPLATE"
+
+// templateFromEnv returns the --template value (GPLAY_TEMPLATE), reading it
+// from a file when it starts with "@".
+func templateFromEnv() (string, error) {
+	value := os.Getenv(templateEnvVar)
+	if strings.TrimSpace(value) == "" {
+		return "", fmt.Errorf("--template is required with --output template")
+	}
+	if path, ok := strings.CutPrefix(strings.TrimSpace(value), "@"); ok {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return "", fmt.Errorf("--template: invalid @file path")
+		}
+		data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the user
+		if err != nil {
+			return "", fmt.Errorf("--template: %w", err)
+		}
+		return string(data), nil
+	}
+	return value, nil
+}
+
+// printTemplate renders data with a text/template. The template sees the
+// JSON form of data, so fields use their JSON names ({{.productId}}), and a
+// misspelled key is an error rather than "<no value>".
+func printTemplate(data interface{}, text string) error {
+	tmpl, err := template.New("output").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("--template: %w", err)
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("--template: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return fmt.Errorf("--template: %w", err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, generic); err != nil {
+		return fmt.Errorf("--template: %w", err)
+	}
+	rendered := out.String()
+	if !strings.HasSuffix(rendered, "\n") {
+		rendered += "\n"
+	}
+	_, err = fmt.Fprint(os.Stdout, rendered)
+	return err
+}
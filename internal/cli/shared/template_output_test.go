@@ -0,0 +1,93 @@
+package shared
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func renderTemplateOutput(t *testing.T, data interface{}) (string, error) {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := PrintOutput(data, "template", false)
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+func TestPrintOutput_TemplateFieldAccess(t *testing.T) {
+	t.Setenv(templateEnvVar, "{{.productId}}: {{len .basePlans}} plans")
+
+	got, err := renderTemplateOutput(t, testSubscription())
+	if err != nil {
+		t.Fatalf("PrintOutput: %v", err)
+	}
+	if want := "premium: 2 plans\n"; got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestPrintOutput_TemplateRangeFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plans.tmpl")
+	if err := os.WriteFile(path, []byte("{{range .basePlans}}{{.basePlanId}}={{.state}}\n{{end}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(templateEnvVar, "@"+path)
+
+	got, err := renderTemplateOutput(t, testSubscription())
+	if err != nil {
+		t.Fatalf("PrintOutput: %v", err)
+	}
+	if want := "monthly=ACTIVE\nyearly=DRAFT\n"; got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestPrintOutput_TemplateMissingKeyFails(t *testing.T) {
+	t.Setenv(templateEnvVar, "{{.productID}}")
+
+	_, err := renderTemplateOutput(t, testSubscription())
+	if err == nil || !strings.Contains(err.Error(), "productID") {
+		t.Fatalf("expected missing key error, got %v", err)
+	}
+}
+
+func TestValidateOutputFlags_TemplateRequiresTemplateFlag(t *testing.T) {
+	t.Setenv(templateEnvVar, "")
+
+	err := ValidateOutputFlags("template", false)
+	if err == nil || !strings.Contains(err.Error(), "--template is required") {
+		t.Fatalf("expected --template required error, got %v", err)
+	}
+
+	t.Setenv(templateEnvVar, "{{.productId}}")
+	if err := ValidateOutputFlags("template", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateOutputFlags("template", true); err == nil {
+		t.Fatal("expected error for --pretty with template output")
+	}
+}
+
+func TestApply_SetsTemplate(t *testing.T) {
+	t.Setenv(templateEnvVar, "")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	rf := BindRootFlags(fs)
+	if err := fs.Parse([]string{"--template", "{{.productId}}"}); err != nil {
+		t.Fatal(err)
+	}
+	rf.Apply()
+
+	if got := os.Getenv(templateEnvVar); got != "{{.productId}}" {
+		t.Errorf("%s = %q, want %q", templateEnvVar, got, "{{.productId}}")
+	}
+}
@@ -0,0 +1,94 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// TimeoutError reports that a configured request or upload timeout expired.
+type TimeoutError struct {
+	Timeout time.Duration
+	// Setting is the config key that controls the timeout.
+	Setting string
+	// EnvVar is the environment variable that overrides Setting.
+	EnvVar string
+	// Flag is the root flag that set the timeout for this invocation, if any.
+	Flag string
+	Err  error
+}
+
+func (e *TimeoutError) Error() string {
+	source := e.Setting
+	if e.Flag != "" {
+		source = e.Flag
+	}
+	msg := fmt.Sprintf("timed out after %s (configured %s)", e.Timeout, source)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *TimeoutError) Unwrap() error {
+	if e.Err == nil {
+		return context.DeadlineExceeded
+	}
+	return e.Err
+}
+
+// TimeoutHint suggests how to raise the timeout that expired.
+func (e *TimeoutError) TimeoutHint() string {
+	longer := (2 * e.Timeout).Round(time.Second)
+	if longer <= 0 {
+		longer = time.Minute
+	}
+	if e.Flag != "" {
+		return fmt.Sprintf("The operation exceeded %s %s. Raise it with %s=%s.", e.Flag, e.Timeout, e.Flag, longer)
+	}
+	return fmt.Sprintf("The operation exceeded the configured %s of %s. Raise it with %s=%s or `gplay config set %s %s`.",
+		e.Setting, e.Timeout, e.EnvVar, longer, e.Setting, longer)
+}
+
+// firedTimeout is the last timeout from ContextWithTimeout or
+// ContextWithUploadTimeout whose deadline expired.
+var firedTimeout atomic.Pointer[TimeoutError]
+
+// contextWithConfiguredTimeout applies timeout to ctx. If the deadline expires
+// before cancel is called, cancel records it for ExplainTimeout.
+func contextWithConfiguredTimeout(ctx context.Context, timeout time.Duration, setting, envVar, flagName string) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	fired := &TimeoutError{Timeout: timeout, Setting: setting, EnvVar: envVar, Flag: flagName}
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, fired) // #nosec G118 -- cancel is returned to caller
+	return ctx, func() {
+		if context.Cause(ctx) == error(fired) {
+			firedTimeout.Store(fired)
+		}
+		cancel()
+	}
+}
+
+// ExplainTimeout wraps a deadline-exceeded err in a TimeoutError naming the
+// configured timeout that expired. Other errors are returned unchanged.
+func ExplainTimeout(err error) error {
+	if err == nil {
+		return nil
+	}
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return err
+	}
+	fired := firedTimeout.Load()
+	if fired == nil {
+		return err
+	}
+	if !errors.Is(err, context.DeadlineExceeded) && !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		return err
+	}
+	return &TimeoutError{Timeout: fired.Timeout, Setting: fired.Setting, EnvVar: fired.EnvVar, Err: err}
+}
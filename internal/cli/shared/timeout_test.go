@@ -2,7 +2,14 @@ package shared
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared/errfmt"
+	"github.com/tamtom/play-console-cli/internal/config"
 )
 
 func TestContextWithTimeout_NilConfigDoesNotPanic(t *testing.T) {
@@ -22,3 +29,111 @@ func TestContextWithUploadTimeout_NilConfigDoesNotPanic(t *testing.T) {
 		t.Fatal("expected context")
 	}
 }
+
+func TestContextWithTimeout_DeadlineYieldsActionableError(t *testing.T) {
+	t.Setenv("GPLAY_TIMEOUT", "10ms")
+	t.Cleanup(func() { firedTimeout.Store(nil) })
+
+	ctx, cancel := ContextWithTimeout(context.Background(), nil)
+	<-ctx.Done()
+	opErr := fmt.Errorf("list tracks: %w", ctx.Err())
+	cancel()
+
+	err := ExplainTimeout(opErr)
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected TimeoutError, got %T: %v", err, err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("expected the error to still match context.DeadlineExceeded")
+	}
+	if !strings.Contains(err.Error(), "timed out after 10ms (configured timeout)") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+	formatted := errfmt.FormatStderr(err)
+	if !strings.Contains(formatted, "GPLAY_TIMEOUT=") || !strings.Contains(formatted, "gplay config set timeout") {
+		t.Fatalf("expected hint naming how to raise the timeout, got:\n%s", formatted)
+	}
+}
+
+func TestExplainTimeout_LeavesOtherErrorsAlone(t *testing.T) {
+	t.Cleanup(func() { firedTimeout.Store(nil) })
+	firedTimeout.Store(&TimeoutError{Timeout: time.Second, Setting: "timeout", EnvVar: "GPLAY_TIMEOUT"})
+
+	plain := errors.New("boom")
+	if got := ExplainTimeout(plain); got != plain {
+		t.Fatalf("expected unrelated error unchanged, got %v", got)
+	}
+
+	// A timeout that was cancelled before its deadline is never recorded.
+	firedTimeout.Store(nil)
+	t.Setenv("GPLAY_TIMEOUT", "1h")
+	_, cancel := ContextWithTimeout(context.Background(), nil)
+	cancel()
+	deadline := fmt.Errorf("request: %w", context.DeadlineExceeded)
+	if got := ExplainTimeout(deadline); got != deadline {
+		t.Fatalf("expected error unchanged without a fired timeout, got %v", got)
+	}
+}
+
+func TestContextWithTimeout_OverrideBeatsConfig(t *testing.T) {
+	t.Setenv("GPLAY_TIMEOUT", "1h")
+	cfg := &config.Config{Timeout: config.DurationValue{Duration: 2 * time.Hour}}
+
+	ctx := ContextWithTimeoutOverrides(context.Background(), 5*time.Second, 0)
+	ctx, cancel := ContextWithTimeout(ctx, cfg)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected a deadline")
+	}
+	if remaining := time.Until(deadline); remaining > 5*time.Second {
+		t.Fatalf("deadline in %s, want at most 5s from --timeout", remaining)
+	}
+}
+
+func TestContextWithUploadTimeout_OverrideBeatsConfig(t *testing.T) {
+	cfg := &config.Config{UploadTimeout: config.DurationValue{Duration: 2 * time.Hour}}
+
+	ctx := ContextWithTimeoutOverrides(context.Background(), 0, 3*time.Second)
+	ctx, cancel := ContextWithUploadTimeout(ctx, cfg)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected a deadline")
+	}
+	if remaining := time.Until(deadline); remaining > 3*time.Second {
+		t.Fatalf("deadline in %s, want at most 3s from --upload-timeout", remaining)
+	}
+}
+
+func TestContextWithTimeout_FallsBackToConfigWithoutOverride(t *testing.T) {
+	t.Setenv("GPLAY_TIMEOUT", "")
+	t.Setenv("GPLAY_TIMEOUT_SECONDS", "")
+	cfg := &config.Config{Timeout: config.DurationValue{Duration: 4 * time.Second}}
+
+	// An upload-only override leaves the request timeout to config.
+	ctx := ContextWithTimeoutOverrides(context.Background(), 0, time.Hour)
+	ctx, cancel := ContextWithTimeout(ctx, cfg)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected a deadline from config")
+	}
+	if remaining := time.Until(deadline); remaining > 4*time.Second {
+		t.Fatalf("deadline in %s, want at most 4s from config", remaining)
+	}
+}
+
+func TestTimeoutError_FlagHint(t *testing.T) {
+	err := &TimeoutError{Timeout: 30 * time.Second, Setting: "timeout", EnvVar: "GPLAY_TIMEOUT", Flag: "--timeout"}
+	if !strings.Contains(err.Error(), "(configured --timeout)") {
+		t.Fatalf("unexpected message: %s", err.Error())
+	}
+	if hint := err.TimeoutHint(); !strings.Contains(hint, "--timeout=1m0s") {
+		t.Fatalf("unexpected hint: %s", hint)
+	}
+}
@@ -0,0 +1,84 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// traceNow is swapped out by tests.
+var traceNow = time.Now
+
+// traceKey is the context key for the active Tracer.
+type traceKey struct{}
+
+// TraceSpan is one timed phase of a command.
+type TraceSpan struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Tracer collects phase timings for --trace.
+type Tracer struct {
+	mu    sync.Mutex
+	start time.Time
+	spans []TraceSpan
+}
+
+// NewTracer returns a Tracer whose total is measured from now.
+func NewTracer() *Tracer {
+	return &Tracer{start: traceNow()}
+}
+
+// ContextWithTracer returns a context carrying t.
+func ContextWithTracer(ctx context.Context, t *Tracer) context.Context {
+	return context.WithValue(ctx, traceKey{}, t)
+}
+
+// TracerFromContext returns the Tracer in ctx, or nil when tracing is off.
+func TracerFromContext(ctx context.Context) *Tracer {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(traceKey{}).(*Tracer)
+	return t
+}
+
+// StartSpan starts timing a phase and returns the function that ends it.
+// It is a no-op when ctx carries no Tracer, so call sites need no guard:
+//
+//	defer shared.StartSpan(ctx, "auth")()
+func StartSpan(ctx context.Context, name string) func() {
+	t := TracerFromContext(ctx)
+	if t == nil {
+		return func() {}
+	}
+	start := traceNow()
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.spans = append(t.spans, TraceSpan{Name: name, Duration: traceNow().Sub(start)})
+	}
+}
+
+// Spans returns the recorded spans in completion order.
+func (t *Tracer) Spans() []TraceSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TraceSpan(nil), t.spans...)
+}
+
+// Report writes the recorded spans and the total wall time to w.
+func (t *Tracer) Report(w io.Writer) {
+	spans := t.Spans()
+	fmt.Fprintln(w, "Trace:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, s := range spans {
+		fmt.Fprintf(tw, "  %s\t%s\n", s.Name, s.Duration.Round(time.Millisecond))
+	}
+	fmt.Fprintf(tw, "  total\t%s\n", traceNow().Sub(t.start).Round(time.Millisecond))
+	_ = tw.Flush()
+}
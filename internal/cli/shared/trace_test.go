@@ -0,0 +1,65 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func withTraceClock(t *testing.T, step time.Duration) {
+	t.Helper()
+	orig := traceNow
+	current := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	traceNow = func() time.Time {
+		current = current.Add(step)
+		return current
+	}
+	t.Cleanup(func() { traceNow = orig })
+}
+
+func TestStartSpan_NoTracerIsNoop(t *testing.T) {
+	end := StartSpan(context.Background(), "auth")
+	end()
+	if TracerFromContext(context.Background()) != nil {
+		t.Fatal("expected no tracer")
+	}
+}
+
+func TestTracer_RecordsSpansInOrder(t *testing.T) {
+	withTraceClock(t, 100*time.Millisecond)
+	tracer := NewTracer()
+	ctx := ContextWithTracer(context.Background(), tracer)
+
+	StartSpan(ctx, "auth")()
+	end := StartSpan(ctx, "list listings")
+	end()
+
+	spans := tracer.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name != "auth" || spans[1].Name != "list listings" {
+		t.Errorf("unexpected span order: %+v", spans)
+	}
+	if spans[0].Duration != 100*time.Millisecond {
+		t.Errorf("duration = %s, want 100ms", spans[0].Duration)
+	}
+}
+
+func TestTracer_Report(t *testing.T) {
+	withTraceClock(t, 50*time.Millisecond)
+	tracer := NewTracer()
+	ctx := ContextWithTracer(context.Background(), tracer)
+	StartSpan(ctx, "upload en-US")()
+
+	var buf bytes.Buffer
+	tracer.Report(&buf)
+	out := buf.String()
+	for _, want := range []string{"Trace:", "upload en-US", "50ms", "total"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package shared
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CheckUploadFile verifies that path is a readable regular file before an
+// upload starts. A mismatched extension is reported as a warning on w rather
+// than an error, since the Play API is the final authority on file type.
+func CheckUploadFile(path, wantExt string, w io.Writer) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return NewValidationError(fmt.Sprintf("file not found: %s", path), nil, "Check the --file path.")
+		}
+		return WrapActionable(err, "failed to read upload file", "Check that the file exists and is readable.")
+	}
+	if info.IsDir() {
+		return NewValidationError(fmt.Sprintf("%s is a directory", path), nil, "Pass a file path to --file.")
+	}
+	if w != nil && !strings.EqualFold(filepath.Ext(path), wantExt) {
+		fmt.Fprintf(w, "Warning: file does not have %s extension: %s\n", wantExt, path)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package shared
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckUploadFile_NotFound(t *testing.T) {
+	err := CheckUploadFile(filepath.Join(t.TempDir(), "missing.aab"), ".aab", nil)
+	if err == nil {
+		t.Fatal("expected error for missing file")
+	}
+	if !strings.Contains(err.Error(), "file not found") {
+		t.Errorf("error should mention file not found, got: %s", err.Error())
+	}
+}
+
+func TestCheckUploadFile_Directory(t *testing.T) {
+	err := CheckUploadFile(t.TempDir(), ".aab", nil)
+	if err == nil {
+		t.Fatal("expected error for directory")
+	}
+	if !strings.Contains(err.Error(), "is a directory") {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
+
+func TestCheckUploadFile_WrongExtensionWarns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.zip")
+	if err := os.WriteFile(path, []byte("PK"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := CheckUploadFile(path, ".aab", &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Warning: file does not have .aab extension") {
+		t.Errorf("expected extension warning, got %q", buf.String())
+	}
+}
+
+func TestCheckUploadFile_MatchingExtensionIsQuiet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.APK")
+	if err := os.WriteFile(path, []byte("PK"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := CheckUploadFile(path, ".apk", &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no warning, got %q", buf.String())
+	}
+}
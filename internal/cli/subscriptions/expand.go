@@ -0,0 +1,92 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+// expandOffers is the --expand value that inlines base plan offers.
+const expandOffers = "offers"
+
+// ExpandedSubscription is a subscription whose base plans carry their offers
+// inline, under basePlans[].offers.
+type ExpandedSubscription struct {
+	Subscription *androidpublisher.Subscription
+	// Offers maps a base plan ID to its offers.
+	Offers map[string][]*androidpublisher.SubscriptionOffer
+}
+
+// MarshalJSON renders the subscription as the API does, adding an "offers"
+// array to each base plan.
+func (e *ExpandedSubscription) MarshalJSON() ([]byte, error) {
+	raw, err := json.Marshal(e.Subscription)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	basePlans, _ := doc["basePlans"].([]interface{})
+	for i, bp := range basePlans {
+		plan, ok := bp.(map[string]interface{})
+		if !ok || i >= len(e.Subscription.BasePlans) {
+			continue
+		}
+		offers := e.Offers[e.Subscription.BasePlans[i].BasePlanId]
+		if offers == nil {
+			offers = []*androidpublisher.SubscriptionOffer{}
+		}
+		plan["offers"] = offers
+	}
+	return json.Marshal(doc)
+}
+
+// validateExpand checks the --expand value.
+func validateExpand(value string) error {
+	switch strings.TrimSpace(value) {
+	case "", expandOffers:
+		return nil
+	default:
+		return fmt.Errorf("--expand must be %q, got %q", expandOffers, value)
+	}
+}
+
+// expandSubscription fetches the offers of every base plan of sub.
+func expandSubscription(ctx context.Context, service *playclient.Service, pkg string, sub *androidpublisher.Subscription) (*ExpandedSubscription, error) {
+	expanded := &ExpandedSubscription{Subscription: sub, Offers: map[string][]*androidpublisher.SubscriptionOffer{}}
+	for _, bp := range sub.BasePlans {
+		offers, err := listBasePlanOffers(ctx, service, pkg, sub.ProductId, bp.BasePlanId)
+		if err != nil {
+			return nil, err
+		}
+		expanded.Offers[bp.BasePlanId] = offers
+	}
+	return expanded, nil
+}
+
+// listBasePlanOffers returns every offer of a base plan.
+func listBasePlanOffers(ctx context.Context, service *playclient.Service, pkg, productID, basePlanID string) ([]*androidpublisher.SubscriptionOffer, error) {
+	offers, _, err := shared.FetchAllPages(ctx, 0, func(ctx context.Context, token string) ([]*androidpublisher.SubscriptionOffer, string, error) {
+		call := service.API.Monetization.Subscriptions.BasePlans.Offers.List(pkg, productID, basePlanID).Context(ctx)
+		if token != "" {
+			call.PageToken(token)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.SubscriptionOffers, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list offers of base plan %s: %w", basePlanID, err)
+	}
+	return offers, nil
+}
@@ -0,0 +1,79 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetCommand_ExpandOffers(t *testing.T) {
+	installMockSubscriptionsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/subscriptions/premium"):
+			_, _ = io.WriteString(w, `{"packageName":"com.example.app","productId":"premium","basePlans":[{"basePlanId":"monthly","state":"ACTIVE"},{"basePlanId":"yearly","state":"ACTIVE"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/basePlans/monthly/offers"):
+			if r.URL.Query().Get("pageToken") == "" {
+				_, _ = io.WriteString(w, `{"subscriptionOffers":[{"basePlanId":"monthly","offerId":"trial"}],"nextPageToken":"p2"}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"subscriptionOffers":[{"basePlanId":"monthly","offerId":"intro"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/basePlans/yearly/offers"):
+			_, _ = io.WriteString(w, `{}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+
+	cmd := GetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--product-id", "premium", "--expand", "offers"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	out, err := captureSubscriptionsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+
+	var got struct {
+		ProductID string `json:"productId"`
+		BasePlans []struct {
+			BasePlanID string `json:"basePlanId"`
+			Offers     []struct {
+				OfferID string `json:"offerId"`
+			} `json:"offers"`
+		} `json:"basePlans"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal %q: %v", out, err)
+	}
+	if got.ProductID != "premium" || len(got.BasePlans) != 2 {
+		t.Fatalf("unexpected subscription: %s", out)
+	}
+	monthly := got.BasePlans[0]
+	if monthly.BasePlanID != "monthly" || len(monthly.Offers) != 2 || monthly.Offers[0].OfferID != "trial" || monthly.Offers[1].OfferID != "intro" {
+		t.Fatalf("expected monthly offers trial and intro, got %+v", monthly)
+	}
+	if yearly := got.BasePlans[1]; yearly.BasePlanID != "yearly" || yearly.Offers == nil || len(yearly.Offers) != 0 {
+		t.Fatalf("expected yearly with an empty offers array, got %s", out)
+	}
+	if !strings.Contains(out, `"offers":[]`) {
+		t.Fatalf("expected an empty offers array for yearly, got %s", out)
+	}
+}
+
+func TestGetCommand_ExpandRejectsUnknown(t *testing.T) {
+	cmd := GetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--product-id", "premium", "--expand", "prices"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--expand") {
+		t.Fatalf("expected --expand error, got %v", err)
+	}
+}
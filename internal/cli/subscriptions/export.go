@@ -0,0 +1,289 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+// ExportCommand writes every subscription of a package to a directory.
+func ExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("subscriptions export", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	outputDir := fs.String("dir", "./subscriptions", "Output directory for subscription JSON files")
+	showArchived := fs.Bool("show-archived", false, "Include archived subscriptions")
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "gplay subscriptions export --package <name> --dir <path> [--show-archived]",
+		ShortHelp:  "Export subscriptions to JSON files.",
+		LongHelp: `Export subscriptions to a local directory.
+
+Each subscription is written to <dir>/<productId>.json with its listings,
+base plans and tax settings, in the format accepted by
+"gplay subscriptions import". packageName is left out so the files can be
+imported into another package.
+
+Examples:
+  gplay subscriptions export --package com.example.app --dir ./subscriptions`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if strings.TrimSpace(*outputDir) == "" {
+				return fmt.Errorf("--dir is required")
+			}
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			all, err := listAllSubscriptions(ctx, service, pkg, *showArchived)
+			if err != nil {
+				return fmt.Errorf("failed to list subscriptions: %w", err)
+			}
+
+			if err := os.MkdirAll(*outputDir, 0o755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+
+			for _, sub := range all {
+				sub.PackageName = ""
+				data, err := json.MarshalIndent(sub, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal %s: %w", sub.ProductId, err)
+				}
+				path := filepath.Join(*outputDir, sub.ProductId+".json")
+				if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", path, err)
+				}
+				fmt.Fprintf(os.Stderr, "Exported: %s\n", sub.ProductId)
+			}
+
+			fmt.Fprintf(os.Stderr, "Exported %d subscriptions to %s\n", len(all), *outputDir)
+			return nil
+		},
+	}
+}
+
+// ImportCommand creates or patches subscriptions from a directory written by
+// ExportCommand.
+func ImportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("subscriptions import", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	inputDir := fs.String("dir", "./subscriptions", "Input directory with subscription JSON files")
+	regionsVersion := fs.String("regions-version", "", "Regions version for regional prices in the files")
+	pruneBasePlans := fs.Bool("prune-base-plans", false, "Delete draft / deactivate active base plans missing from a file")
+	pruneOffers := fs.Bool("prune-offers", false, "Delete draft / deactivate active offers missing from a file's \"offers\" array")
+	dryRun := fs.Bool("dry-run", false, "Show which subscriptions would be created, updated or pruned without importing")
+
+	return &ffcli.Command{
+		Name:       "import",
+		ShortUsage: "gplay subscriptions import --package <name> --dir <path> [--regions-version <v>] [--prune-base-plans] [--prune-offers] [--dry-run]",
+		ShortHelp:  "Import subscriptions from JSON files.",
+		LongHelp: `Import subscriptions from a local directory.
+
+Every <productId>.json file in --dir is read as a Subscription. The product
+ID comes from the file's "productId", or from the file name when that is
+empty. Subscriptions that already exist are patched with allow-missing, using
+an update mask derived from the file's keys; new ones are created.
+
+Pruning treats each file as the full definition of an existing subscription,
+as "gplay subscriptions update" does:
+  --prune-base-plans  base plans not in "basePlans" are removed
+  --prune-offers      offers of kept base plans that are not in the file's
+                      top-level "offers" array are removed; every file needs
+                      the array, [] prunes all
+Draft children are deleted and active ones deactivated. Pruning runs after
+the subscription is patched.
+
+Use --dry-run to list what would be created, updated or pruned without
+changing anything.
+
+Examples:
+  gplay subscriptions import --package com.example.app --dir ./subscriptions --dry-run
+  gplay subscriptions import --package com.example.app --dir ./subscriptions --regions-version 2022/02
+  gplay subscriptions import --package com.example.app --dir ./subscriptions --prune-base-plans --prune-offers --dry-run`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if strings.TrimSpace(*inputDir) == "" {
+				return fmt.Errorf("--dir is required")
+			}
+			files, err := readSubscriptionFiles(*inputDir)
+			if err != nil {
+				return err
+			}
+			if *pruneOffers {
+				for _, file := range files {
+					if !file.hasOffers {
+						return fmt.Errorf("%s: --prune-offers requires a top-level \"offers\" array (use [] to prune every offer)", file.path)
+					}
+				}
+			}
+
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			if strings.TrimSpace(pkg) == "" {
+				return fmt.Errorf("--package is required")
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			current, err := listAllSubscriptions(ctx, service, pkg, true)
+			if err != nil {
+				return fmt.Errorf("failed to list subscriptions: %w", err)
+			}
+			existing := make(map[string]bool, len(current))
+			for _, sub := range current {
+				existing[sub.ProductId] = true
+			}
+
+			for _, file := range files {
+				sub := file.subscription
+				sub.PackageName = pkg
+
+				var ops []pruneOp
+				if existing[sub.ProductId] && (*pruneBasePlans || *pruneOffers) {
+					current, currentOffers, err := loadPruneState(ctx, service, pkg, sub.ProductId, sub, *pruneOffers)
+					if err != nil {
+						return fmt.Errorf("failed to load %s for pruning: %w", sub.ProductId, err)
+					}
+					ops = planPrune(current, currentOffers, file.offers, sub, *pruneBasePlans, *pruneOffers)
+				}
+
+				if *dryRun {
+					if existing[sub.ProductId] {
+						fmt.Fprintf(os.Stderr, "Would update: %s (%s)\n", sub.ProductId, file.mask)
+					} else {
+						fmt.Fprintf(os.Stderr, "Would create: %s\n", sub.ProductId)
+					}
+					for _, op := range ops {
+						fmt.Fprintf(os.Stderr, "Would %s: %s %s\n", op.Action, sub.ProductId, op.target())
+					}
+					continue
+				}
+
+				if existing[sub.ProductId] {
+					call := service.API.Monetization.Subscriptions.Patch(pkg, sub.ProductId, sub).Context(ctx).UpdateMask(file.mask).AllowMissing(true)
+					if strings.TrimSpace(*regionsVersion) != "" {
+						call.RegionsVersionVersion(*regionsVersion)
+					}
+					if _, err := call.Do(); err != nil {
+						return fmt.Errorf("failed to update %s: %w", sub.ProductId, err)
+					}
+					fmt.Fprintf(os.Stderr, "Updated: %s\n", sub.ProductId)
+					if err := applyPrune(ctx, service, pkg, sub.ProductId, ops); err != nil {
+						return fmt.Errorf("failed to prune %s: %w", sub.ProductId, err)
+					}
+					for _, op := range ops {
+						fmt.Fprintf(os.Stderr, "Pruned (%s): %s %s\n", op.Action, sub.ProductId, op.target())
+					}
+					continue
+				}
+
+				call := service.API.Monetization.Subscriptions.Create(pkg, sub).Context(ctx).ProductId(sub.ProductId)
+				if strings.TrimSpace(*regionsVersion) != "" {
+					call.RegionsVersionVersion(*regionsVersion)
+				}
+				if _, err := call.Do(); err != nil {
+					return fmt.Errorf("failed to create %s: %w", sub.ProductId, err)
+				}
+				fmt.Fprintf(os.Stderr, "Created: %s\n", sub.ProductId)
+			}
+
+			if *dryRun {
+				fmt.Fprintf(os.Stderr, "Dry run: would import %d subscriptions\n", len(files))
+			} else {
+				fmt.Fprintf(os.Stderr, "Imported %d subscriptions\n", len(files))
+			}
+			return nil
+		},
+	}
+}
+
+// subscriptionFile is one parsed file from an import directory.
+type subscriptionFile struct {
+	path         string
+	subscription *androidpublisher.Subscription
+	mask         string
+	// offers is the file's optional top-level "offers" array, the offers
+	// to keep with --prune-offers.
+	offers    []*androidpublisher.SubscriptionOffer
+	hasOffers bool
+}
+
+// readSubscriptionFiles parses every .json file in dir, in name order.
+func readSubscriptionFiles(dir string) ([]subscriptionFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input directory: %w", err)
+	}
+	var files []subscriptionFile
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		var sub androidpublisher.Subscription
+		if err := json.Unmarshal(raw, &sub); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if strings.TrimSpace(sub.ProductId) == "" {
+			sub.ProductId = strings.TrimSuffix(entry.Name(), ".json")
+		}
+		mask, err := shared.DeriveUpdateMask(raw, subscriptionMutableFields)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		offers, hasOffers, err := desiredOffers(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		files = append(files, subscriptionFile{path: path, subscription: &sub, mask: mask, offers: offers, hasOffers: hasOffers})
+	}
+	return files, nil
+}
+
+// listAllSubscriptions fetches every subscription of pkg across pages.
+func listAllSubscriptions(ctx context.Context, service *playclient.Service, pkg string, showArchived bool) ([]*androidpublisher.Subscription, error) {
+	all, _, err := shared.FetchAllPages(ctx, 0, func(ctx context.Context, token string) ([]*androidpublisher.Subscription, string, error) {
+		call := service.API.Monetization.Subscriptions.List(pkg).Context(ctx)
+		if token != "" {
+			call.PageToken(token)
+		}
+		if showArchived {
+			call.ShowArchived(true)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Subscriptions, resp.NextPageToken, nil
+	})
+	return all, err
+}
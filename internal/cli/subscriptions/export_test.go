@@ -0,0 +1,149 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+const subscriptionsPath = "/androidpublisher/v3/applications/com.example.app/subscriptions"
+
+func TestExportCommand_WritesFilePerSubscription(t *testing.T) {
+	installMockSubscriptionsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != subscriptionsPath {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = io.WriteString(w, `{"subscriptions":[{"packageName":"com.example.app","productId":"monthly","basePlans":[{"basePlanId":"p1m"}]}],"nextPageToken":"next"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"subscriptions":[{"packageName":"com.example.app","productId":"yearly","listings":[{"languageCode":"en-US","title":"Yearly"}]}]}`)
+	})
+
+	dir := t.TempDir()
+	cmd := ExportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "monthly.json"))
+	if err != nil {
+		t.Fatalf("read monthly.json: %v", err)
+	}
+	var monthly androidpublisher.Subscription
+	if err := json.Unmarshal(data, &monthly); err != nil {
+		t.Fatalf("parse monthly.json: %v", err)
+	}
+	if monthly.ProductId != "monthly" || len(monthly.BasePlans) != 1 || monthly.BasePlans[0].BasePlanId != "p1m" {
+		t.Fatalf("monthly.json missing base plans: %s", data)
+	}
+	if monthly.PackageName != "" {
+		t.Fatalf("expected packageName to be dropped, got %q", monthly.PackageName)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "yearly.json")); err != nil {
+		t.Fatalf("expected yearly.json from second page: %v", err)
+	}
+}
+
+func writeSubscriptionFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func importServer(t *testing.T) *[]string {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []string
+	installMockSubscriptionsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"subscriptions":[{"productId":"monthly"}]}`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, subscriptionsPath)+"?"+r.URL.RawQuery+" "+string(body))
+		mu.Unlock()
+		_, _ = w.Write(body)
+	})
+	return &calls
+}
+
+func TestImportCommand_PatchesExistingAndCreatesNew(t *testing.T) {
+	calls := importServer(t)
+	dir := t.TempDir()
+	writeSubscriptionFile(t, dir, "monthly.json", `{"productId":"monthly","listings":[{"languageCode":"en-US","title":"Monthly"}]}`)
+	writeSubscriptionFile(t, dir, "yearly.json", `{"basePlans":[{"basePlanId":"p1y"}]}`)
+	writeSubscriptionFile(t, dir, "notes.txt", `ignored`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := append([]string(nil), *calls...)
+	sort.Strings(got)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 writes, got %v", got)
+	}
+	patch, create := got[0], got[1]
+	if !strings.HasPrefix(patch, "PATCH /monthly?") || !strings.Contains(patch, "allowMissing=true") || !strings.Contains(patch, "updateMask=listings") {
+		t.Fatalf("expected patch with allow-missing for monthly, got %s", patch)
+	}
+	if !strings.HasPrefix(create, "POST ?") || !strings.Contains(create, "productId=yearly") {
+		t.Fatalf("expected create for yearly, got %s", create)
+	}
+	if !strings.Contains(create, `"productId":"yearly"`) || !strings.Contains(create, `"packageName":"com.example.app"`) {
+		t.Fatalf("expected product ID from file name and package in body, got %s", create)
+	}
+}
+
+func TestImportCommand_DryRunMakesNoWrites(t *testing.T) {
+	calls := importServer(t)
+	dir := t.TempDir()
+	writeSubscriptionFile(t, dir, "monthly.json", `{"productId":"monthly","listings":[]}`)
+	writeSubscriptionFile(t, dir, "yearly.json", `{"productId":"yearly","basePlans":[]}`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir, "--dry-run"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("dry run should not write, got %v", *calls)
+	}
+}
+
+func TestImportCommand_RejectsFileWithoutMutableFields(t *testing.T) {
+	dir := t.TempDir()
+	writeSubscriptionFile(t, dir, "monthly.json", `{"productId":"monthly"}`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "monthly.json") {
+		t.Fatalf("expected error naming the file, got %v", err)
+	}
+}
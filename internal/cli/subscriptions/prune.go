@@ -0,0 +1,168 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/api/androidpublisher/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+// Prune actions. The API only deletes base plans and offers that are still
+// drafts; published ones can only be deactivated.
+const (
+	pruneActionDelete     = "delete"
+	pruneActionDeactivate = "deactivate"
+)
+
+// pruneOp is a base plan or offer that exists remotely but is absent from the
+// source file.
+type pruneOp struct {
+	BasePlanID string `json:"basePlanId"`
+	OfferID    string `json:"offerId,omitempty"`
+	State      string `json:"state"`
+	Action     string `json:"action"`
+}
+
+// pruneResult is the output of subscriptions update when pruning is enabled.
+type pruneResult struct {
+	Subscription *androidpublisher.Subscription `json:"subscription,omitempty"`
+	DryRun       bool                           `json:"dryRun,omitempty"`
+	Pruned       []pruneOp                      `json:"pruned"`
+}
+
+// desiredOffers reads the optional top-level "offers" array of a subscription
+// file. It lists the offers to keep when pruning; ok is false if the key is
+// absent.
+func desiredOffers(raw []byte) (offers []*androidpublisher.SubscriptionOffer, ok bool, err error) {
+	var doc struct {
+		Offers *[]*androidpublisher.SubscriptionOffer `json:"offers"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, false, err
+	}
+	if doc.Offers == nil {
+		return nil, false, nil
+	}
+	return *doc.Offers, true, nil
+}
+
+// planPrune lists the remote base plans and offers missing from the desired
+// subscription. Offers are only considered under base plans that are kept;
+// inactive children are left alone because there is nothing left to do.
+func planPrune(current *androidpublisher.Subscription, currentOffers, wantOffers []*androidpublisher.SubscriptionOffer, want *androidpublisher.Subscription, pruneBasePlans, pruneOffers bool) []pruneOp {
+	if current == nil {
+		return nil
+	}
+	keepBasePlans := make(map[string]bool, len(want.BasePlans))
+	for _, bp := range want.BasePlans {
+		keepBasePlans[bp.BasePlanId] = true
+	}
+	keepOffers := make(map[string]bool, len(wantOffers))
+	for _, o := range wantOffers {
+		keepOffers[o.BasePlanId+"/"+o.OfferId] = true
+	}
+
+	var ops []pruneOp
+	if pruneBasePlans {
+		for _, bp := range current.BasePlans {
+			if keepBasePlans[bp.BasePlanId] {
+				continue
+			}
+			if action := pruneAction(bp.State); action != "" {
+				ops = append(ops, pruneOp{BasePlanID: bp.BasePlanId, State: bp.State, Action: action})
+			}
+		}
+	}
+	if pruneOffers {
+		for _, o := range currentOffers {
+			if !keepBasePlans[o.BasePlanId] || keepOffers[o.BasePlanId+"/"+o.OfferId] {
+				continue
+			}
+			if action := pruneAction(o.State); action != "" {
+				ops = append(ops, pruneOp{BasePlanID: o.BasePlanId, OfferID: o.OfferId, State: o.State, Action: action})
+			}
+		}
+	}
+	return ops
+}
+
+func pruneAction(state string) string {
+	switch state {
+	case "DRAFT":
+		return pruneActionDelete
+	case "ACTIVE":
+		return pruneActionDeactivate
+	default:
+		return ""
+	}
+}
+
+// loadPruneState fetches the remote subscription and, when offers are being
+// pruned, the offers of every base plan it keeps. A subscription that does not
+// exist yet yields a nil subscription.
+func loadPruneState(ctx context.Context, service *playclient.Service, pkg, productID string, want *androidpublisher.Subscription, withOffers bool) (*androidpublisher.Subscription, []*androidpublisher.SubscriptionOffer, error) {
+	current, err := service.API.Monetization.Subscriptions.Get(pkg, productID).Context(ctx).Do()
+	if err != nil {
+		var gerr *googleapi.Error
+		if errors.As(err, &gerr) && gerr.Code == http.StatusNotFound {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if !withOffers {
+		return current, nil, nil
+	}
+
+	remote := make(map[string]bool, len(current.BasePlans))
+	for _, bp := range current.BasePlans {
+		remote[bp.BasePlanId] = true
+	}
+	var offers []*androidpublisher.SubscriptionOffer
+	for _, bp := range want.BasePlans {
+		if !remote[bp.BasePlanId] {
+			continue
+		}
+		page, err := listBasePlanOffers(ctx, service, pkg, productID, bp.BasePlanId)
+		if err != nil {
+			return nil, nil, err
+		}
+		offers = append(offers, page...)
+	}
+	return current, offers, nil
+}
+
+// applyPrune deletes or deactivates each planned base plan and offer.
+func applyPrune(ctx context.Context, service *playclient.Service, pkg, productID string, ops []pruneOp) error {
+	basePlans := service.API.Monetization.Subscriptions.BasePlans
+	for _, op := range ops {
+		var err error
+		switch {
+		case op.OfferID != "" && op.Action == pruneActionDelete:
+			err = basePlans.Offers.Delete(pkg, productID, op.BasePlanID, op.OfferID).Context(ctx).Do()
+		case op.OfferID != "":
+			_, err = basePlans.Offers.Deactivate(pkg, productID, op.BasePlanID, op.OfferID, &androidpublisher.DeactivateSubscriptionOfferRequest{}).Context(ctx).Do()
+		case op.Action == pruneActionDelete:
+			err = basePlans.Delete(pkg, productID, op.BasePlanID).Context(ctx).Do()
+		default:
+			_, err = basePlans.Deactivate(pkg, productID, op.BasePlanID, &androidpublisher.DeactivateBasePlanRequest{}).Context(ctx).Do()
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", op.Action, op.target(), err)
+		}
+	}
+	return nil
+}
+
+// target names the base plan or offer the operation applies to.
+func (op pruneOp) target() string {
+	if op.OfferID != "" {
+		return fmt.Sprintf("offer %s/%s", op.BasePlanID, op.OfferID)
+	}
+	return "base plan " + op.BasePlanID
+}
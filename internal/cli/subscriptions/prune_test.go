@@ -0,0 +1,239 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func TestPlanPrune(t *testing.T) {
+	current := &androidpublisher.Subscription{BasePlans: []*androidpublisher.BasePlan{
+		{BasePlanId: "monthly", State: "ACTIVE"},
+		{BasePlanId: "yearly", State: "ACTIVE"},
+		{BasePlanId: "weekly", State: "DRAFT"},
+		{BasePlanId: "legacy", State: "INACTIVE"},
+	}}
+	currentOffers := []*androidpublisher.SubscriptionOffer{
+		{BasePlanId: "monthly", OfferId: "trial", State: "ACTIVE"},
+		{BasePlanId: "monthly", OfferId: "promo", State: "DRAFT"},
+		{BasePlanId: "monthly", OfferId: "old", State: "INACTIVE"},
+		{BasePlanId: "monthly", OfferId: "intro", State: "ACTIVE"},
+		{BasePlanId: "yearly", OfferId: "winback", State: "ACTIVE"},
+	}
+	want := &androidpublisher.Subscription{BasePlans: []*androidpublisher.BasePlan{{BasePlanId: "monthly"}}}
+	wantOffers := []*androidpublisher.SubscriptionOffer{{BasePlanId: "monthly", OfferId: "intro"}}
+
+	tests := []struct {
+		name                        string
+		pruneBasePlans, pruneOffers bool
+		want                        []pruneOp
+	}{
+		{
+			name:           "base plans",
+			pruneBasePlans: true,
+			want: []pruneOp{
+				{BasePlanID: "yearly", State: "ACTIVE", Action: pruneActionDeactivate},
+				{BasePlanID: "weekly", State: "DRAFT", Action: pruneActionDelete},
+			},
+		},
+		{
+			name:        "offers of kept base plans only",
+			pruneOffers: true,
+			want: []pruneOp{
+				{BasePlanID: "monthly", OfferID: "trial", State: "ACTIVE", Action: pruneActionDeactivate},
+				{BasePlanID: "monthly", OfferID: "promo", State: "DRAFT", Action: pruneActionDelete},
+			},
+		},
+		{
+			name:           "both",
+			pruneBasePlans: true,
+			pruneOffers:    true,
+			want: []pruneOp{
+				{BasePlanID: "yearly", State: "ACTIVE", Action: pruneActionDeactivate},
+				{BasePlanID: "weekly", State: "DRAFT", Action: pruneActionDelete},
+				{BasePlanID: "monthly", OfferID: "trial", State: "ACTIVE", Action: pruneActionDeactivate},
+				{BasePlanID: "monthly", OfferID: "promo", State: "DRAFT", Action: pruneActionDelete},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planPrune(current, currentOffers, wantOffers, want, tt.pruneBasePlans, tt.pruneOffers)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("planPrune() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+
+	if ops := planPrune(nil, nil, nil, want, true, true); ops != nil {
+		t.Fatalf("expected no ops for a new subscription, got %+v", ops)
+	}
+}
+
+// pruneServer mocks a subscription with base plans monthly (kept) and yearly
+// (removed), where monthly has offers intro (kept) and trial (removed).
+func pruneServer(t *testing.T) *[]string {
+	t.Helper()
+	var mu sync.Mutex
+	var writes []string
+	installMockSubscriptionsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := r.URL.Path[strings.Index(r.URL.Path, "/subscriptions"):]
+		if r.Method != http.MethodGet {
+			mu.Lock()
+			writes = append(writes, r.Method+" "+path)
+			mu.Unlock()
+		}
+		switch {
+		case r.Method == http.MethodGet && path == "/subscriptions":
+			_, _ = io.WriteString(w, `{"subscriptions":[{"productId":"premium"}]}`)
+		case r.Method == http.MethodGet && path == "/subscriptions/premium":
+			_, _ = io.WriteString(w, `{"productId":"premium","basePlans":[{"basePlanId":"monthly","state":"ACTIVE"},{"basePlanId":"yearly","state":"ACTIVE"}]}`)
+		case r.Method == http.MethodGet && path == "/subscriptions/premium/basePlans/monthly/offers":
+			_, _ = io.WriteString(w, `{"subscriptionOffers":[{"basePlanId":"monthly","offerId":"intro","state":"ACTIVE"},{"basePlanId":"monthly","offerId":"trial","state":"DRAFT"}]}`)
+		case r.Method == http.MethodPatch:
+			_, _ = io.WriteString(w, `{"productId":"premium","basePlans":[{"basePlanId":"monthly","state":"ACTIVE"}]}`)
+		default:
+			_, _ = io.WriteString(w, `{}`)
+		}
+	})
+	return &writes
+}
+
+const pruneSubscriptionJSON = `{"basePlans":[{"basePlanId":"monthly"}],"offers":[{"basePlanId":"monthly","offerId":"intro"}]}`
+
+func TestUpdateCommand_PruneDryRunMakesNoWrites(t *testing.T) {
+	writes := pruneServer(t)
+
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--product-id", "premium", "--json", pruneSubscriptionJSON, "--prune-base-plans", "--prune-offers", "--dry-run"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureSubscriptionsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(*writes) != 0 {
+		t.Fatalf("dry run must not write, got %v", *writes)
+	}
+
+	var result pruneResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, stdout)
+	}
+	want := []pruneOp{
+		{BasePlanID: "yearly", State: "ACTIVE", Action: pruneActionDeactivate},
+		{BasePlanID: "monthly", OfferID: "trial", State: "DRAFT", Action: pruneActionDelete},
+	}
+	if !result.DryRun || !reflect.DeepEqual(result.Pruned, want) {
+		t.Fatalf("unexpected dry-run result: %+v", result)
+	}
+}
+
+func TestUpdateCommand_PruneAppliesAfterPatch(t *testing.T) {
+	writes := pruneServer(t)
+
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--product-id", "premium", "--json", pruneSubscriptionJSON, "--prune-base-plans", "--prune-offers"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if _, err := captureSubscriptionsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{
+		"PATCH /subscriptions/premium",
+		"POST /subscriptions/premium/basePlans/yearly:deactivate",
+		"DELETE /subscriptions/premium/basePlans/monthly/offers/trial",
+	}
+	if !reflect.DeepEqual(*writes, want) {
+		t.Fatalf("writes = %v, want %v", *writes, want)
+	}
+}
+
+func TestUpdateCommand_PruneOffersRequiresOffersArray(t *testing.T) {
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "premium", "--json", `{"basePlans":[]}`, "--prune-offers"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), `"offers"`) {
+		t.Fatalf("expected offers array error, got %v", err)
+	}
+}
+
+func TestUpdateCommand_DryRunRequiresPrune(t *testing.T) {
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "premium", "--json", `{"listings":[]}`, "--dry-run"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--dry-run") {
+		t.Fatalf("expected --dry-run error, got %v", err)
+	}
+}
+
+func TestImportCommand_PrunesExistingSubscriptions(t *testing.T) {
+	writes := pruneServer(t)
+	dir := t.TempDir()
+	writeSubscriptionFile(t, dir, "premium.json", pruneSubscriptionJSON)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir, "--prune-base-plans", "--prune-offers"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"PATCH /subscriptions/premium",
+		"POST /subscriptions/premium/basePlans/yearly:deactivate",
+		"DELETE /subscriptions/premium/basePlans/monthly/offers/trial",
+	}
+	if !reflect.DeepEqual(*writes, want) {
+		t.Fatalf("writes = %v, want %v", *writes, want)
+	}
+}
+
+func TestImportCommand_PruneDryRunMakesNoWrites(t *testing.T) {
+	writes := pruneServer(t)
+	dir := t.TempDir()
+	writeSubscriptionFile(t, dir, "premium.json", pruneSubscriptionJSON)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir, "--prune-base-plans", "--prune-offers", "--dry-run"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*writes) != 0 {
+		t.Fatalf("dry run must not write, got %v", *writes)
+	}
+}
+
+func TestImportCommand_PruneOffersRequiresOffersArray(t *testing.T) {
+	dir := t.TempDir()
+	writeSubscriptionFile(t, dir, "premium.json", `{"basePlans":[]}`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir, "--prune-offers"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "premium.json") || !strings.Contains(err.Error(), `"offers"`) {
+		t.Fatalf("expected offers array error naming the file, got %v", err)
+	}
+}
@@ -2,7 +2,6 @@ package subscriptions
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"strings"
@@ -15,6 +14,8 @@ import (
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+var newPlayService = playclient.NewService
+
 // subscriptionMutableFields are the top-level fields on Subscription that can
 // be set via update_mask. Must match the fields the SDK can serialize.
 var subscriptionMutableFields = []string{
@@ -49,6 +50,9 @@ Use the "baseplans" and "offers" commands to manage those resources.`,
 			ArchiveCommand(),
 			BatchGetCommand(),
 			BatchUpdateCommand(),
+			ExportCommand(),
+			ImportCommand(),
+			monetizationpricing.PreviewPricesCommand("subscriptions"),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if len(args) == 0 {
@@ -65,12 +69,15 @@ func ListCommand() *ffcli.Command {
 	pageSize := fs.Int("page-size", 100, "Page size")
 	showArchived := fs.Bool("show-archived", false, "Include archived subscriptions")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	maxItems := fs.Int("max-items", 0, shared.MaxItemsFlagUsage)
+	filter := fs.String("filter", "", "Only include items whose product ID matches this glob (e.g. premium_*)")
+	shared.BindOrderByFlag(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "list",
-		ShortUsage: "gplay subscriptions list --package <name> [--page-size <n>] [--show-archived]",
+		ShortUsage: "gplay subscriptions list --package <name> [--page-size <n>] [--show-archived] [--filter <glob>] [--order-by <field>]",
 		ShortHelp:  "List all subscriptions.",
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
@@ -78,7 +85,13 @@ func ListCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			service, err := playclient.NewService(ctx)
+			if err := shared.ValidateGlob("filter", *filter); err != nil {
+				return err
+			}
+			if err := shared.ValidateMaxItems(*maxItems); err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -90,31 +103,37 @@ func ListCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			var all []*androidpublisher.Subscription
-			pageToken := ""
-			for {
+			productID := func(item *androidpublisher.Subscription) string { return item.ProductId }
+			fetch := func(ctx context.Context, token string) (*androidpublisher.ListSubscriptionsResponse, error) {
 				call := service.API.Monetization.Subscriptions.List(pkg).Context(ctx).PageSize(int64(*pageSize))
-				if pageToken != "" {
-					call.PageToken(pageToken)
+				if token != "" {
+					call.PageToken(token)
 				}
 				if *showArchived {
 					call.ShowArchived(true)
 				}
-				resp, err := call.Do()
+				return call.Do()
+			}
+			if !*paginate && *maxItems == 0 {
+				resp, err := fetch(ctx, "")
 				if err != nil {
 					return err
 				}
-				if !*paginate {
-					return shared.PrintOutput(resp, *outputFlag, *pretty)
-				}
-				all = append(all, resp.Subscriptions...)
-				if resp.NextPageToken == "" {
-					break
-				}
-				pageToken = resp.NextPageToken
+				resp.Subscriptions = shared.FilterByID(resp.Subscriptions, *filter, productID)
+				return shared.PrintOutput(resp, *outputFlag, *pretty)
 			}
 
-			return shared.PrintOutput(all, *outputFlag, *pretty)
+			all, truncated, err := shared.FetchListPages(ctx, *maxItems, func(ctx context.Context, token string) ([]*androidpublisher.Subscription, string, error) {
+				resp, err := fetch(ctx, token)
+				if err != nil {
+					return nil, "", err
+				}
+				return shared.FilterByID(resp.Subscriptions, *filter, productID), resp.NextPageToken, nil
+			})
+			if err != nil {
+				return err
+			}
+			return shared.PrintPaginated(all, truncated, *maxItems, *outputFlag, *pretty)
 		},
 	}
 }
@@ -123,15 +142,24 @@ func GetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("subscriptions get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	expand := fs.String("expand", "", "Inline referenced resources: offers (adds basePlans[].offers)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "get",
-		ShortUsage: "gplay subscriptions get --package <name> --product-id <id>",
+		ShortUsage: "gplay subscriptions get --package <name> --product-id <id> [--expand offers]",
 		ShortHelp:  "Get a subscription.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Get a subscription.
+
+With --expand offers, the offers of each base plan are fetched and added
+to it as basePlans[].offers, saving an offers list call per base plan.
+
+Examples:
+  gplay subscriptions get --package com.example.app --product-id premium
+  gplay subscriptions get --package com.example.app --product-id premium --expand offers --pretty`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
@@ -139,7 +167,10 @@ func GetCommand() *ffcli.Command {
 			if strings.TrimSpace(*productID) == "" {
 				return fmt.Errorf("--product-id is required")
 			}
-			service, err := playclient.NewService(ctx)
+			if err := validateExpand(*expand); err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -155,6 +186,13 @@ func GetCommand() *ffcli.Command {
 			if err != nil {
 				return err
 			}
+			if strings.TrimSpace(*expand) == expandOffers {
+				expanded, err := expandSubscription(ctx, service, pkg, resp)
+				if err != nil {
+					return err
+				}
+				return shared.PrintOutput(expanded, *outputFlag, *pretty)
+			}
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
@@ -164,12 +202,13 @@ func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("subscriptions create", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
-	jsonFlag := fs.String("json", "", "Subscription JSON (or @file)")
+	jsonFlag := fs.String("json", "", "Subscription JSON (or @file, - for stdin)")
+	assumeFromJSON := shared.BindAssumeFromJSONFlag(fs)
 	regionsVersion := fs.String("regions-version", "", "Regions version for price migration")
 	autoConvertRegionalPrices := fs.Bool("auto-convert-regional-prices", false, "Generate regionalConfigs from --base-price-json")
-	basePriceJSON := fs.String("base-price-json", "", "Base Money JSON for --auto-convert-regional-prices (or @file)")
+	basePriceJSON := fs.String("base-price-json", "", "Base Money JSON for --auto-convert-regional-prices (or @file, - for stdin)")
 	productTaxCategoryCode := fs.String("product-tax-category-code", "", "Product tax category code for price conversion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -187,6 +226,10 @@ generate valid regionalConfigs and regionsVersion from one base price. This
 replaces any regionalConfigs in the JSON with the billable regions returned by
 Google for the current regionVersion.
 
+With --assume-package-from-json, an empty --package or --product-id is taken
+from the packageName or productId field of the JSON. Files written by
+"gplay subscriptions export" leave packageName out, so pass --package or set a default package for them.
+
 JSON format:
 {
   "productId": "premium_monthly",
@@ -230,16 +273,20 @@ Examples:
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*productID) == "" {
+			if !*assumeFromJSON && strings.TrimSpace(*productID) == "" {
 				return fmt.Errorf("--product-id is required")
 			}
 			if strings.TrimSpace(*jsonFlag) == "" {
 				return fmt.Errorf("--json is required")
 			}
 			var subscription androidpublisher.Subscription
-			if err := shared.LoadJSONArg(*jsonFlag, &subscription); err != nil {
+			if err := shared.LoadJSONArgStrict(*jsonFlag, &subscription); err != nil {
 				return fmt.Errorf("invalid JSON: %w", err)
 			}
+			*productID = shared.ValueOrJSON(*productID, subscription.ProductId, *assumeFromJSON)
+			if strings.TrimSpace(*productID) == "" {
+				return fmt.Errorf("--product-id is required (not set in --json either)")
+			}
 			var basePrice *androidpublisher.Money
 			resolvedRegionsVersion := strings.TrimSpace(*regionsVersion)
 			if *autoConvertRegionalPrices {
@@ -256,11 +303,11 @@ Examples:
 				}
 			}
 
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
-			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			pkg := shared.ResolvePackageName(shared.ValueOrJSON(*packageName, subscription.PackageName, *assumeFromJSON), service.Cfg)
 			if strings.TrimSpace(pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
@@ -304,16 +351,20 @@ func UpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("subscriptions update", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
-	jsonFlag := fs.String("json", "", "Subscription JSON (or @file)")
+	jsonFlag := fs.String("json", "", "Subscription JSON (or @file, - for stdin)")
+	assumeFromJSON := shared.BindAssumeFromJSONFlag(fs)
 	updateMask := fs.String("update-mask", "", "Fields to update (comma-separated, e.g., listings)")
 	regionsVersion := fs.String("regions-version", "", "Regions version for price migration")
 	allowMissing := fs.Bool("allow-missing", false, "Create if not exists")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	pruneBasePlans := fs.Bool("prune-base-plans", false, "Delete draft / deactivate active base plans missing from --json")
+	pruneOffers := fs.Bool("prune-offers", false, "Delete draft / deactivate active offers missing from the \"offers\" array in --json")
+	dryRun := fs.Bool("dry-run", false, "With --prune-*, print the prune plan without making changes")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "update",
-		ShortUsage: "gplay subscriptions update --package <name> --product-id <id> --json <json>",
+		ShortUsage: "gplay subscriptions update --package <name> --product-id <id> --json <json> [--prune-base-plans] [--prune-offers] [--dry-run]",
 		ShortHelp:  "Update a subscription.",
 		LongHelp: `Update a subscription.
 
@@ -335,16 +386,32 @@ JSON format:
 If --allow-missing is set and the subscription does not exist, it will
 be created. In that case, --update-mask is ignored.
 
+With --assume-package-from-json, an empty --package or --product-id is taken
+from the packageName or productId field of the JSON. Files written by
+"gplay subscriptions export" leave packageName out, so pass --package or set a default package for them.
+
+Pruning treats --json as the full definition of the subscription:
+  --prune-base-plans  base plans not in "basePlans" are removed
+  --prune-offers      offers of kept base plans that are not in a top-level
+                      "offers" array (entries with basePlanId and offerId)
+                      are removed; the array is required, [] prunes all
+Draft children are deleted and active ones deactivated; inactive ones are
+left as is. Pruning runs after the update succeeds. Add --dry-run to print
+the planned operations without updating or pruning. With pruning enabled
+the output is {"subscription": ..., "pruned": [...]}.
+
 Examples:
   gplay subscriptions update --package com.example --product-id premium --json @subscription.json
-  gplay subscriptions update --package com.example --product-id premium --json '{"listings":[...]}' --update-mask listings`,
+  gplay subscriptions update --package com.example --product-id premium --json '{"listings":[...]}' --update-mask listings
+  gplay subscriptions update --package com.example --json @exported/premium.json --assume-package-from-json
+  gplay subscriptions update --package com.example --product-id premium --json @subscription.json --prune-base-plans --prune-offers --dry-run`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*productID) == "" {
+			if !*assumeFromJSON && strings.TrimSpace(*productID) == "" {
 				return fmt.Errorf("--product-id is required")
 			}
 			if strings.TrimSpace(*jsonFlag) == "" {
@@ -354,6 +421,15 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("invalid JSON: %w", err)
 			}
+			// The file may carry a top-level "offers" array for --prune-offers.
+			var body struct {
+				androidpublisher.Subscription
+				Offers []*androidpublisher.SubscriptionOffer `json:"offers"`
+			}
+			if err := shared.UnmarshalStrict(raw, &body); err != nil {
+				return fmt.Errorf("invalid JSON: %w", err)
+			}
+			subscription := body.Subscription
 			mask := strings.TrimSpace(*updateMask)
 			if mask == "" {
 				derived, err := shared.DeriveUpdateMask(raw, subscriptionMutableFields)
@@ -361,17 +437,30 @@ Examples:
 					return err
 				}
 				mask = derived
+			} else if err := shared.ValidateUpdateMask(mask, &subscription); err != nil {
+				return err
 			}
-			var subscription androidpublisher.Subscription
-			if err := json.Unmarshal(raw, &subscription); err != nil {
+			*productID = shared.ValueOrJSON(*productID, subscription.ProductId, *assumeFromJSON)
+			if strings.TrimSpace(*productID) == "" {
+				return fmt.Errorf("--product-id is required (not set in --json either)")
+			}
+			prune := *pruneBasePlans || *pruneOffers
+			if *dryRun && !prune {
+				return fmt.Errorf("--dry-run requires --prune-base-plans or --prune-offers")
+			}
+			keepOffers, hasOffers, err := desiredOffers(raw)
+			if err != nil {
 				return fmt.Errorf("invalid JSON: %w", err)
 			}
+			if *pruneOffers && !hasOffers {
+				return fmt.Errorf("--prune-offers requires a top-level \"offers\" array in --json (use [] to prune every offer)")
+			}
 
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
-			pkg := shared.ResolvePackageName(*packageName, service.Cfg)
+			pkg := shared.ResolvePackageName(shared.ValueOrJSON(*packageName, subscription.PackageName, *assumeFromJSON), service.Cfg)
 			if strings.TrimSpace(pkg) == "" {
 				return fmt.Errorf("--package is required")
 			}
@@ -381,6 +470,21 @@ Examples:
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
+			var ops []pruneOp
+			if prune {
+				current, currentOffers, err := loadPruneState(ctx, service, pkg, *productID, &subscription, *pruneOffers)
+				if err != nil {
+					return err
+				}
+				ops = planPrune(current, currentOffers, keepOffers, &subscription, *pruneBasePlans, *pruneOffers)
+				if ops == nil {
+					ops = []pruneOp{}
+				}
+				if *dryRun {
+					return shared.PrintOutput(pruneResult{DryRun: true, Pruned: ops}, *outputFlag, *pretty)
+				}
+			}
+
 			call := service.API.Monetization.Subscriptions.Patch(pkg, *productID, &subscription).Context(ctx).UpdateMask(mask)
 			if strings.TrimSpace(*regionsVersion) != "" {
 				call.RegionsVersionVersion(*regionsVersion)
@@ -392,7 +496,13 @@ Examples:
 			if err != nil {
 				return err
 			}
-			return shared.PrintOutput(resp, *outputFlag, *pretty)
+			if !prune {
+				return shared.PrintOutput(resp, *outputFlag, *pretty)
+			}
+			if err := applyPrune(ctx, service, pkg, *productID, ops); err != nil {
+				return err
+			}
+			return shared.PrintOutput(pruneResult{Subscription: resp, Pruned: ops}, *outputFlag, *pretty)
 		},
 	}
 }
@@ -401,8 +511,8 @@ func DeleteCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("subscriptions delete", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
-	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	confirm := fs.Bool("confirm", false, "Confirm deletion (prompts when omitted on a terminal)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -419,7 +529,9 @@ func DeleteCommand() *ffcli.Command {
 				return fmt.Errorf("--product-id is required")
 			}
 			if !*confirm {
-				return fmt.Errorf("--confirm is required")
+				if err := shared.RequireConfirmation(ctx, fmt.Sprintf("Delete subscription %s?", *productID), "--confirm"); err != nil {
+					return err
+				}
 			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
@@ -451,7 +563,7 @@ func ArchiveCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("subscriptions archive", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productID := fs.String("product-id", "", "Subscription product ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -493,7 +605,7 @@ func BatchGetCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("subscriptions batch-get", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	productIDs := fs.String("product-ids", "", "Comma-separated subscription product IDs")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -534,8 +646,9 @@ func BatchGetCommand() *ffcli.Command {
 func BatchUpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("subscriptions batch-update", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
-	jsonFlag := fs.String("json", "", "BatchUpdateSubscriptionsRequest JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "BatchUpdateSubscriptionsRequest JSON (or @file, - for stdin)")
+	idem := shared.BindIdempotencyFlags(fs)
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -564,7 +677,11 @@ JSON format:
       "regionsVersion": {"version": "2025/02"}
     }
   ]
-}`,
+}
+
+With --idempotency-key, the batch is recorded in a local journal
+(~/.gplay/batch-journal.json) once applied, and a later run with the same key
+is skipped with a notice on stderr and no output; pass --force to re-apply.`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -591,10 +708,15 @@ JSON format:
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			resp, err := service.API.Monetization.Subscriptions.BatchUpdate(pkg, &req).Context(ctx).Do()
+			resp, applied, err := shared.RunIdempotentBatch(ctx, idem, "subscriptions batch-update", pkg, func() (interface{}, error) {
+				return service.API.Monetization.Subscriptions.BatchUpdate(pkg, &req).Context(ctx).Do()
+			})
 			if err != nil {
 				return err
 			}
+			if !applied {
+				return nil
+			}
 			return shared.PrintOutput(resp, *outputFlag, *pretty)
 		},
 	}
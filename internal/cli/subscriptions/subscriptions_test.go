@@ -1,11 +1,19 @@
 package subscriptions
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
 func TestSubscriptionsCommand_Name(t *testing.T) {
@@ -46,14 +54,17 @@ func TestSubscriptionsCommand_HasSubcommands(t *testing.T) {
 func TestSubscriptionsCommand_SubcommandNames(t *testing.T) {
 	cmd := SubscriptionsCommand()
 	expected := map[string]bool{
-		"list":         false,
-		"get":          false,
-		"create":       false,
-		"update":       false,
-		"delete":       false,
-		"archive":      false,
-		"batch-get":    false,
-		"batch-update": false,
+		"list":           false,
+		"get":            false,
+		"create":         false,
+		"update":         false,
+		"delete":         false,
+		"archive":        false,
+		"batch-get":      false,
+		"batch-update":   false,
+		"export":         false,
+		"import":         false,
+		"preview-prices": false,
 	}
 	for _, sub := range cmd.Subcommands {
 		if _, ok := expected[sub.Name]; ok {
@@ -221,6 +232,20 @@ func TestUpdateCommand_WithExplicitUpdateMask_SkipsDeriving(t *testing.T) {
 	}
 }
 
+func TestUpdateCommand_UnknownUpdateMaskField(t *testing.T) {
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "test", "--json", `{"listings":[]}`, "--update-mask", "listing"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for unknown --update-mask field")
+	}
+	if !strings.Contains(err.Error(), `unknown --update-mask field "listing"`) || !strings.Contains(err.Error(), "listings") {
+		t.Errorf("error should name the field and list valid fields, got: %s", err.Error())
+	}
+}
+
 func TestUpdateCommand_MissingProductID(t *testing.T) {
 	cmd := UpdateCommand()
 	if err := cmd.FlagSet.Parse([]string{"--json", `{}`}); err != nil {
@@ -363,3 +388,177 @@ func TestBatchUpdateCommand_WhitespaceJson(t *testing.T) {
 		t.Errorf("error should mention --json, got: %s", err.Error())
 	}
 }
+
+func TestSubscriptionsListCommand_FilterSinglePage(t *testing.T) {
+	installMockSubscriptionsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"subscriptions":[{"productId":"premium"},{"productId":"premium_family"},{"productId":"basic"}],"nextPageToken":"p2"}`)
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--filter", "premium*"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureSubscriptionsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(stdout, `"premium_family"`) || strings.Contains(stdout, `"basic"`) {
+		t.Fatalf("unexpected filtered output: %s", stdout)
+	}
+	if !strings.Contains(stdout, `"nextPageToken":"p2"`) {
+		t.Fatalf("single-page output should keep the page token, got %s", stdout)
+	}
+}
+
+func TestSubscriptionsListCommand_MaxItemsStopsAcrossPages(t *testing.T) {
+	var requests int
+	installMockSubscriptionsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = io.WriteString(w, `{"subscriptions":[{"productId":"premium"}],"nextPageToken":"page-2"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"subscriptions":[{"productId":"basic"}]}`)
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--max-items", "1"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureSubscriptionsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := strings.TrimSpace(stdout), `{"items":[{"productId":"premium"}],"truncated":true}`; got != want {
+		t.Fatalf("output = %s, want %s", got, want)
+	}
+	if requests != 1 {
+		t.Fatalf("expected the cap to stop before page 2, got %d requests", requests)
+	}
+}
+
+func TestSubscriptionsListCommand_PartialOKKeepsPagesBeforeFailure(t *testing.T) {
+	t.Setenv("GPLAY_PARTIAL_OK", "1")
+	installMockSubscriptionsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			_, _ = io.WriteString(w, `{"subscriptions":[{"productId":"premium"}],"nextPageToken":"page-2"}`)
+		case "page-2":
+			_, _ = io.WriteString(w, `{"subscriptions":[{"productId":"basic"}],"nextPageToken":"page-3"}`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"error":{"code":403,"message":"denied"}}`)
+		}
+	})
+
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--paginate"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureSubscriptionsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := strings.TrimSpace(stdout), `[{"productId":"premium"},{"productId":"basic"}]`; got != want {
+		t.Fatalf("output = %s, want %s", got, want)
+	}
+}
+
+func TestSubscriptionsListCommand_RejectsNegativeMaxItems(t *testing.T) {
+	cmd := ListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--max-items", "-1"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "--max-items") {
+		t.Fatalf("expected --max-items error, got %v", err)
+	}
+}
+
+func installMockSubscriptionsPlayService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() {
+		newPlayService = original
+	})
+}
+
+func captureSubscriptionsStdout(fn func() error) (string, error) {
+	origStdout := os.Stdout
+	rOut, wOut, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+
+	os.Stdout = wOut
+
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&buf, rOut)
+	}()
+
+	runErr := fn()
+
+	_ = wOut.Close()
+	os.Stdout = origStdout
+	wg.Wait()
+	_ = rOut.Close()
+
+	return buf.String(), runErr
+}
+
+func TestSubscriptionsCreateCommand_AssumePackageFromJSON(t *testing.T) {
+	t.Setenv("GPLAY_PACKAGE", "")
+	var gotPath, gotProductID string
+	installMockSubscriptionsPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotProductID = r.URL.Query().Get("productId")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	cmd := CreateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--assume-package-from-json",
+		"--json", `{"packageName":"com.example.json","productId":"premium","listings":[{"languageCode":"en-US","title":"Premium"}]}`,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureSubscriptionsStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(gotPath, "/applications/com.example.json/subscriptions") || gotProductID != "premium" {
+		t.Fatalf("request = %q productId=%q, want package and product from JSON", gotPath, gotProductID)
+	}
+}
+
+func TestSubscriptionsCreateCommand_ProductIDRequiredWithoutAssumeFlag(t *testing.T) {
+	cmd := CreateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--json", `{"packageName":"com.example.json","productId":"premium"}`}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--product-id is required") {
+		t.Fatalf("expected --product-id error, got %v", err)
+	}
+}
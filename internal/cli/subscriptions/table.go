@@ -0,0 +1,88 @@
+package subscriptions
+
+import (
+	"strings"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+// subscriptionTableHeaders lists one row per base plan; the baseplans commands
+// print whole subscriptions and share this layout.
+var subscriptionTableHeaders = []string{"Product ID", "Base Plan", "State", "Type", "Billing Period", "Grace Period"}
+
+func init() {
+	output.RegisterType(&androidpublisher.Subscription{}, subscriptionTableHeaders, func(data any) [][]string {
+		return subscriptionRows([]*androidpublisher.Subscription{data.(*androidpublisher.Subscription)})
+	})
+	output.RegisterType(&androidpublisher.ListSubscriptionsResponse{}, subscriptionTableHeaders, func(data any) [][]string {
+		return subscriptionRows(data.(*androidpublisher.ListSubscriptionsResponse).Subscriptions)
+	})
+	output.RegisterType([]*androidpublisher.Subscription{}, subscriptionTableHeaders, func(data any) [][]string {
+		return subscriptionRows(data.([]*androidpublisher.Subscription))
+	})
+	output.RegisterType(&ExpandedSubscription{}, append(append([]string{}, subscriptionTableHeaders...), "Offers"), func(data any) [][]string {
+		return expandedSubscriptionRows(data.(*ExpandedSubscription))
+	})
+}
+
+// expandedSubscriptionRows adds the offer IDs of each base plan to the
+// subscription rows.
+func expandedSubscriptionRows(e *ExpandedSubscription) [][]string {
+	rows := subscriptionRows([]*androidpublisher.Subscription{e.Subscription})
+	if e.Subscription == nil || len(e.Subscription.BasePlans) == 0 {
+		for i := range rows {
+			rows[i] = append(rows[i], "")
+		}
+		return rows
+	}
+	for i, bp := range e.Subscription.BasePlans {
+		var ids []string
+		for _, offer := range e.Offers[bp.BasePlanId] {
+			ids = append(ids, offer.OfferId)
+		}
+		rows[i] = append(rows[i], strings.Join(ids, ", "))
+	}
+	return rows
+}
+
+func subscriptionRows(subs []*androidpublisher.Subscription) [][]string {
+	var rows [][]string
+	for _, sub := range subs {
+		if sub == nil {
+			continue
+		}
+		if len(sub.BasePlans) == 0 {
+			rows = append(rows, []string{sub.ProductId, "", "", "", "", ""})
+			continue
+		}
+		for _, bp := range sub.BasePlans {
+			kind, billing, grace := basePlanPeriods(bp)
+			rows = append(rows, []string{
+				sub.ProductId,
+				bp.BasePlanId,
+				bp.State,
+				kind,
+				shared.HumanizeISODuration(billing),
+				shared.HumanizeISODuration(grace),
+			})
+		}
+	}
+	return rows
+}
+
+// basePlanPeriods returns the base plan type with its raw ISO 8601 billing
+// and grace periods.
+func basePlanPeriods(bp *androidpublisher.BasePlan) (kind, billing, grace string) {
+	switch {
+	case bp.AutoRenewingBasePlanType != nil:
+		return "auto-renewing", bp.AutoRenewingBasePlanType.BillingPeriodDuration, bp.AutoRenewingBasePlanType.GracePeriodDuration
+	case bp.PrepaidBasePlanType != nil:
+		return "prepaid", bp.PrepaidBasePlanType.BillingPeriodDuration, ""
+	case bp.InstallmentsBasePlanType != nil:
+		return "installments", bp.InstallmentsBasePlanType.BillingPeriodDuration, bp.InstallmentsBasePlanType.GracePeriodDuration
+	}
+	return "", "", ""
+}
@@ -0,0 +1,63 @@
+package subscriptions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+func testSubscription() *androidpublisher.Subscription {
+	return &androidpublisher.Subscription{
+		ProductId: "premium",
+		BasePlans: []*androidpublisher.BasePlan{
+			{BasePlanId: "monthly", State: "ACTIVE", AutoRenewingBasePlanType: &androidpublisher.AutoRenewingBasePlanType{BillingPeriodDuration: "P1M", GracePeriodDuration: "P7D"}},
+			{BasePlanId: "pass", State: "DRAFT", PrepaidBasePlanType: &androidpublisher.PrepaidBasePlanType{BillingPeriodDuration: "P1W"}},
+		},
+	}
+}
+
+func TestSubscriptionRows_HumanizesPeriods(t *testing.T) {
+	rows := subscriptionRows([]*androidpublisher.Subscription{testSubscription()})
+	want := [][]string{
+		{"premium", "monthly", "ACTIVE", "auto-renewing", "1 month", "7 days"},
+		{"premium", "pass", "DRAFT", "prepaid", "1 week", ""},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %v", len(rows), len(want), rows)
+	}
+	for i := range want {
+		if strings.Join(rows[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %v, want %v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestSubscriptionTable_RegisteredForMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	rendered, err := output.RenderRegistered(&buf, &androidpublisher.ListSubscriptionsResponse{
+		Subscriptions: []*androidpublisher.Subscription{testSubscription()},
+	}, "markdown")
+	if err != nil || !rendered {
+		t.Fatalf("expected registered markdown rendering, got rendered=%v err=%v", rendered, err)
+	}
+	if !strings.Contains(buf.String(), "1 month") || strings.Contains(buf.String(), "P1M") {
+		t.Fatalf("expected humanized periods, got:\n%s", buf.String())
+	}
+}
+
+func TestSubscriptionJSON_KeepsISOPeriods(t *testing.T) {
+	stdout, err := captureSubscriptionsStdout(func() error {
+		return shared.PrintOutput(testSubscription(), "json", false)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout, `"billingPeriodDuration":"P1M"`) {
+		t.Fatalf("expected raw ISO period in JSON, got %s", stdout)
+	}
+}
@@ -0,0 +1,112 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+// syncDiff is the --output json document of diff-listings and
+// import-listings --dry-run, keyed by resource type.
+type syncDiff struct {
+	Drift     bool                     `json:"drift"`
+	Resources map[string]*resourceDiff `json:"resources"`
+}
+
+// resourceDiff lists the IDs present only locally (added), only remotely
+// (removed), and present on both sides with differing fields (changed).
+type resourceDiff struct {
+	Added   []string        `json:"added"`
+	Removed []string        `json:"removed"`
+	Changed []resourceDelta `json:"changed"`
+}
+
+type resourceDelta struct {
+	ID     string        `json:"id"`
+	Fields []fieldChange `json:"fields"`
+}
+
+type fieldChange struct {
+	Field  string `json:"field"`
+	Remote string `json:"remote"`
+	Local  string `json:"local"`
+}
+
+// listingFieldChanges returns each text field that differs between the
+// remote and local listing, with full values.
+func listingFieldChanges(remote, local *androidpublisher.Listing) []fieldChange {
+	fields := []fieldChange{
+		{"title", remote.Title, local.Title},
+		{"short_description", remote.ShortDescription, local.ShortDescription},
+		{"full_description", remote.FullDescription, local.FullDescription},
+		{"video", remote.Video, local.Video},
+	}
+	var changes []fieldChange
+	for _, f := range fields {
+		if f.Remote != f.Local {
+			changes = append(changes, f)
+		}
+	}
+	return changes
+}
+
+// diffListings compares listings by locale. With includeRemoved false,
+// remote-only locales are not reported, as an import never deletes them.
+func diffListings(remote, local map[string]*androidpublisher.Listing, includeRemoved bool) *resourceDiff {
+	diff := &resourceDiff{Added: []string{}, Removed: []string{}, Changed: []resourceDelta{}}
+	for _, locale := range sortedLocales(local) {
+		r, ok := remote[locale]
+		if !ok {
+			diff.Added = append(diff.Added, locale)
+			continue
+		}
+		if changes := listingFieldChanges(r, local[locale]); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, resourceDelta{ID: locale, Fields: changes})
+		}
+	}
+	if includeRemoved {
+		for _, locale := range sortedLocales(remote) {
+			if _, ok := local[locale]; !ok {
+				diff.Removed = append(diff.Removed, locale)
+			}
+		}
+	}
+	return diff
+}
+
+func (d *resourceDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// newListingsSyncDiff wraps a listings diff in the document printed by
+// --output json.
+func newListingsSyncDiff(listings *resourceDiff) *syncDiff {
+	return &syncDiff{
+		Drift:     !listings.empty(),
+		Resources: map[string]*resourceDiff{"listings": listings},
+	}
+}
+
+func sortedLocales(listings map[string]*androidpublisher.Listing) []string {
+	locales := make([]string, 0, len(listings))
+	for locale := range listings {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// validateDiffOutput checks the --output flag of the drift commands.
+func validateDiffOutput(output string, pretty bool) error {
+	switch output {
+	case "text":
+		if pretty {
+			return fmt.Errorf("--pretty is only valid with --output json")
+		}
+		return nil
+	case "json":
+		return nil
+	}
+	return fmt.Errorf("--output must be text or json (got %q)", output)
+}
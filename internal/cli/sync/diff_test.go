@@ -0,0 +1,183 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeDriftFixture writes local listings that drift from driftRemote:
+// en-US has a new title, de-DE exists only locally and es-ES is unchanged.
+func writeDriftFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"en-US/" + titleFile:    "New Title",
+		"en-US/" + fullDescFile: "Same",
+		"de-DE/" + titleFile:    "Titel",
+		"es-ES/" + titleFile:    "Título",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// driftRemote serves the edit and its listings; fr-FR exists only remotely.
+func driftRemote(t *testing.T) {
+	t.Helper()
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/listings") {
+			_, _ = io.WriteString(w, `{"listings":[
+				{"language":"en-US","title":"Old Title","fullDescription":"Same"},
+				{"language":"es-ES","title":"Título"},
+				{"language":"fr-FR","title":"Titre"}
+			]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":"edit-1"}`)
+	})
+}
+
+func captureSyncStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+
+	orig := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	runErr := fn()
+	_ = w.Close()
+	os.Stdout = orig
+	return <-done, runErr
+}
+
+func TestDiffListingsCommand_JSONOutput(t *testing.T) {
+	dir := writeDriftFixture(t)
+	driftRemote(t)
+
+	cmd := DiffListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir, "--output", "json"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureSyncStdout(t, func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got syncDiff
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("output is not a diff document: %v\n%s", err, stdout)
+	}
+	want := syncDiff{
+		Drift: true,
+		Resources: map[string]*resourceDiff{
+			"listings": {
+				Added:   []string{"de-DE"},
+				Removed: []string{"fr-FR"},
+				Changed: []resourceDelta{{
+					ID:     "en-US",
+					Fields: []fieldChange{{Field: "title", Remote: "Old Title", Local: "New Title"}},
+				}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("diff = %s", stdout)
+	}
+}
+
+func TestDiffListingsCommand_JSONOutputWithoutDrift(t *testing.T) {
+	dir := t.TempDir()
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/listings") {
+			_, _ = io.WriteString(w, `{"listings":[]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":"edit-1"}`)
+	})
+
+	cmd := DiffListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir, "--output", "json"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureSyncStdout(t, func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"drift":false,"resources":{"listings":{"added":[],"removed":[],"changed":[]}}}`
+	if strings.TrimSpace(stdout) != want {
+		t.Fatalf("output = %s, want %s", stdout, want)
+	}
+}
+
+func TestImportListingsCommand_DryRunJSONOutput(t *testing.T) {
+	dir := writeDriftFixture(t)
+	driftRemote(t)
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir, "--dry-run", "--output", "json"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureSyncStdout(t, func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got syncDiff
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("output is not a diff document: %v\n%s", err, stdout)
+	}
+	listings := got.Resources["listings"]
+	if !got.Drift || listings == nil {
+		t.Fatalf("expected listings drift, got %s", stdout)
+	}
+	if !reflect.DeepEqual(listings.Added, []string{"de-DE"}) || len(listings.Removed) != 0 {
+		t.Fatalf("added=%v removed=%v", listings.Added, listings.Removed)
+	}
+	if len(listings.Changed) != 1 || listings.Changed[0].ID != "en-US" {
+		t.Fatalf("changed = %+v", listings.Changed)
+	}
+}
+
+func TestImportListingsCommand_JSONOutputRequiresDryRun(t *testing.T) {
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--edit", "edit-1", "--output", "json"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "--dry-run") {
+		t.Fatalf("expected --dry-run error, got %v", err)
+	}
+}
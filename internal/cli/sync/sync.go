@@ -7,15 +7,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 	"google.golang.org/api/androidpublisher/v3"
 
+	"github.com/tamtom/play-console-cli/internal/cli/listings"
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+var newPlayService = playclient.NewService
+
 // FastLane metadata file names
 const (
 	titleFile           = "title.txt"
@@ -89,15 +93,20 @@ func ExportListingsCommand() *ffcli.Command {
 	editID := fs.String("edit", "", "Edit ID (optional, creates temporary edit if not provided)")
 	outputDir := fs.String("dir", "./metadata", "Output directory for metadata")
 	format := fs.String("format", "fastlane", "Output format: fastlane (default), json")
+	locales := fs.String("locales", "", "Comma-separated locales to process (default: all)")
 
 	return &ffcli.Command{
 		Name:       "export-listings",
-		ShortUsage: "gplay sync export-listings --package <name> --dir <path> [--edit <id>]",
+		ShortUsage: "gplay sync export-listings --package <name> --dir <path> [--edit <id>] [--locales <list>]",
 		ShortHelp:  "Export store listings to local directory.",
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			service, err := playclient.NewService(ctx)
+			filter, err := parseLocaleFilter(*locales)
+			if err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -129,7 +138,9 @@ func ExportListingsCommand() *ffcli.Command {
 			}
 
 			// Get all listings
+			endList := shared.StartSpan(ctx, "list listings")
 			listingsResp, err := service.API.Edits.Listings.List(pkg, edit.Id).Context(ctx).Do()
+			endList()
 			if err != nil {
 				return fmt.Errorf("failed to list listings: %w", err)
 			}
@@ -140,7 +151,11 @@ func ExportListingsCommand() *ffcli.Command {
 			}
 
 			// Export each listing
+			exported := 0
 			for _, listing := range listingsResp.Listings {
+				if !filter.includes(listing.Language) {
+					continue
+				}
 				localeDir := filepath.Join(*outputDir, listing.Language)
 				if err := os.MkdirAll(localeDir, 0o755); err != nil {
 					return fmt.Errorf("failed to create locale directory: %w", err)
@@ -179,14 +194,15 @@ func ExportListingsCommand() *ffcli.Command {
 					}
 				}
 
-				fmt.Fprintf(os.Stderr, "Exported: %s\n", listing.Language)
+				shared.Infof(ctx, "Exported: %s\n", listing.Language)
+				exported++
 			}
 
 			if tempEdit {
-				fmt.Fprintf(os.Stderr, "Note: Used temporary edit (deleted automatically)\n")
+				shared.Infof(ctx, "Note: Used temporary edit (deleted automatically)\n")
 			}
 
-			fmt.Fprintf(os.Stderr, "Exported %d listings to %s\n", len(listingsResp.Listings), *outputDir)
+			shared.Infof(ctx, "Exported %d listings to %s\n", exported, *outputDir)
 			return nil
 		},
 	}
@@ -198,20 +214,64 @@ func ImportListingsCommand() *ffcli.Command {
 	editID := fs.String("edit", "", "Edit ID (required)")
 	inputDir := fs.String("dir", "./metadata", "Input directory with metadata")
 	format := fs.String("format", "fastlane", "Input format: fastlane (default), json")
-	dryRun := fs.Bool("dry-run", false, "Show what would be imported without making changes")
+	locales := fs.String("locales", "", "Comma-separated locales to process (default: all)")
+	dryRun := fs.Bool("dry-run", false, "Show the per-field changes against the edit without importing")
+	appendMode := fs.Bool("append", false, "Append local descriptions to the edit's current descriptions instead of replacing listings")
+	localeFallback := fs.String("locale-fallback", "", "Fill fields a locale is missing from this locale's local files (e.g. en-US)")
+	outputFlag := fs.String("output", "text", "Dry-run output format: text (default), json")
+	shared.DeclareOutputFormats(fs, "text", "json")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "import-listings",
-		ShortUsage: "gplay sync import-listings --package <name> --edit <id> --dir <path> [--dry-run]",
+		ShortUsage: "gplay sync import-listings --package <name> --edit <id> --dir <path> [--locales <list>] [--locale-fallback <locale>] [--append] [--dry-run]",
 		ShortHelp:  "Import store listings from local directory.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Import store listings from a local directory into an edit.
+
+With --append, each locale's local full and short descriptions are appended
+to the descriptions already in the edit, as with gplay listings update
+--append; local titles and videos are ignored. Every locale is checked
+against the description length limits before anything is imported.
+
+With --locale-fallback, a title, short description, full description, or
+video that a locale does not have locally is taken from the fallback
+locale's files in --dir, so partially translated locales are not imported
+with blank fields. The fallback locale does not have to be in --locales.
+
+With --dry-run --output json, the planned changes are printed as the diff
+document described in gplay sync diff-listings --help. Locales only in the
+edit are never listed as removed, since an import does not delete them.
+
+Examples:
+  gplay sync import-listings --package com.example.app --edit EDIT_ID --dir ./metadata --dry-run
+  gplay sync import-listings --package com.example.app --edit EDIT_ID --dir ./metadata --dry-run --output json
+  gplay sync import-listings --package com.example.app --edit EDIT_ID --dir ./metadata --locale-fallback en-US
+  gplay sync import-listings --package com.example.app --edit EDIT_ID --dir ./promo --append`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			if strings.TrimSpace(*editID) == "" {
 				return fmt.Errorf("--edit is required")
 			}
+			if err := validateDiffOutput(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if *outputFlag == "json" && !*dryRun {
+				return fmt.Errorf("--output json requires --dry-run")
+			}
+			filter, err := parseLocaleFilter(*locales)
+			if err != nil {
+				return err
+			}
+			fallbackLocale := ""
+			if strings.TrimSpace(*localeFallback) != "" {
+				if *appendMode {
+					return fmt.Errorf("--locale-fallback cannot be combined with --append")
+				}
+				fallbackLocale = shared.NormalizeLocaleFlag("--locale-fallback", *localeFallback)
+			}
 
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -225,64 +285,113 @@ func ImportListingsCommand() *ffcli.Command {
 			if err != nil {
 				return fmt.Errorf("failed to read input directory: %w", err)
 			}
+			var fallback *androidpublisher.Listing
+			if fallbackLocale != "" {
+				fallback, err = readLocalListing(*inputDir, fallbackLocale, *format)
+				if err != nil {
+					return err
+				}
+				if fallback == nil {
+					return fmt.Errorf("--locale-fallback: no local listing for %s in %s", fallbackLocale, *inputDir)
+				}
+			}
 
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			imported := 0
+			// A dry run compares against what the edit currently holds so
+			// each locale shows exactly which fields would change; --append
+			// needs the current text to append to.
+			var remoteListings map[string]*androidpublisher.Listing
+			if *dryRun || *appendMode {
+				endList := shared.StartSpan(ctx, "list listings")
+				listingsResp, err := service.API.Edits.Listings.List(pkg, *editID).Context(ctx).Do()
+				endList()
+				if err != nil {
+					return fmt.Errorf("failed to list listings: %w", err)
+				}
+				remoteListings = make(map[string]*androidpublisher.Listing)
+				for _, l := range listingsResp.Listings {
+					remoteListings[l.Language] = l
+				}
+			}
+
+			// Read every locale first so --append length errors stop the
+			// import before any listing is written.
+			type localListing struct {
+				locale  string
+				listing *androidpublisher.Listing
+			}
+			var pending []localListing
 			for _, entry := range entries {
-				if !entry.IsDir() {
+				if !entry.IsDir() || !filter.includes(entry.Name()) {
 					continue
 				}
 				locale := entry.Name()
-				localeDir := filepath.Join(*inputDir, locale)
 
-				var listing *androidpublisher.Listing
-
-				if *format == "json" {
-					// Read from JSON
-					data, err := os.ReadFile(filepath.Join(localeDir, "listing.json"))
-					if err != nil {
-						if os.IsNotExist(err) {
-							continue
-						}
-						return fmt.Errorf("failed to read listing.json for %s: %w", locale, err)
-					}
-					listing = &androidpublisher.Listing{}
-					if err := json.Unmarshal(data, listing); err != nil {
-						return fmt.Errorf("failed to parse listing.json for %s: %w", locale, err)
+				listing, err := readLocalListing(*inputDir, locale, *format)
+				if err != nil {
+					return err
+				}
+				if listing == nil {
+					continue
+				}
+				if fallback != nil {
+					if filled := applyLocaleFallback(listing, fallback); len(filled) > 0 {
+						shared.Infof(ctx, "Using %s for %s: %s\n", fallbackLocale, locale, strings.Join(filled, ", "))
 					}
-				} else {
-					// Read from FastLane format
-					listing = &androidpublisher.Listing{}
+				}
 
-					if data, err := os.ReadFile(filepath.Join(localeDir, titleFile)); err == nil {
-						listing.Title = strings.TrimSpace(string(data))
-					}
-					if data, err := os.ReadFile(filepath.Join(localeDir, shortDescFile)); err == nil {
-						listing.ShortDescription = strings.TrimSpace(string(data))
-					}
-					if data, err := os.ReadFile(filepath.Join(localeDir, fullDescFile)); err == nil {
-						listing.FullDescription = strings.TrimSpace(string(data))
+				if *appendMode {
+					current, ok := remoteListings[locale]
+					if !ok {
+						current = &androidpublisher.Listing{Language: locale}
 					}
-					if data, err := os.ReadFile(filepath.Join(localeDir, videoFile)); err == nil {
-						listing.Video = strings.TrimSpace(string(data))
+					merged, err := listings.AppendToListing(current, locale, listing.FullDescription, listing.ShortDescription)
+					if err != nil {
+						return err
 					}
+					listing = merged
+				}
+				pending = append(pending, localListing{locale: locale, listing: listing})
+			}
 
-					// Skip if no content
-					if listing.Title == "" && listing.ShortDescription == "" && listing.FullDescription == "" {
-						continue
-					}
+			if *outputFlag == "json" {
+				local := make(map[string]*androidpublisher.Listing, len(pending))
+				for _, p := range pending {
+					local[p.locale] = p.listing
 				}
+				return shared.PrintOutput(newListingsSyncDiff(diffListings(remoteListings, local, false)), "json", *pretty)
+			}
 
+			imported := 0
+			for _, p := range pending {
+				locale, listing := p.locale, p.listing
 				if *dryRun {
-					fmt.Fprintf(os.Stderr, "Would import: %s (title: %q)\n", locale, truncate(listing.Title, 30))
+					remote, ok := remoteListings[locale]
+					if !ok {
+						remote = &androidpublisher.Listing{}
+					}
+					diffs := listingFieldDiffs(remote, listing)
+					switch {
+					case !ok:
+						fmt.Fprintf(os.Stderr, "Would import: %s (new locale)\n", locale)
+					case len(diffs) == 0:
+						fmt.Fprintf(os.Stderr, "Would import: %s (no changes)\n", locale)
+					default:
+						fmt.Fprintf(os.Stderr, "Would import: %s\n", locale)
+					}
+					for _, d := range diffs {
+						fmt.Fprintf(os.Stderr, "  %s\n", d)
+					}
 				} else {
+					endUpload := shared.StartSpan(ctx, "upload "+locale)
 					_, err := service.API.Edits.Listings.Update(pkg, *editID, locale, listing).Context(ctx).Do()
+					endUpload()
 					if err != nil {
 						return fmt.Errorf("failed to update listing for %s: %w", locale, err)
 					}
-					fmt.Fprintf(os.Stderr, "Imported: %s\n", locale)
+					shared.Infof(ctx, "Imported: %s\n", locale)
 				}
 				imported++
 			}
@@ -290,13 +399,67 @@ func ImportListingsCommand() *ffcli.Command {
 			if *dryRun {
 				fmt.Fprintf(os.Stderr, "Dry run: would import %d listings\n", imported)
 			} else {
-				fmt.Fprintf(os.Stderr, "Imported %d listings\n", imported)
+				shared.Infof(ctx, "Imported %d listings\n", imported)
 			}
 			return nil
 		},
 	}
 }
 
+// readLocalListing reads the listing of locale under dir, as listing.json or
+// as fastlane text files. It returns nil when the locale has no listing.
+func readLocalListing(dir, locale, format string) (*androidpublisher.Listing, error) {
+	localeDir := filepath.Join(dir, locale)
+	listing := &androidpublisher.Listing{}
+	if format == "json" {
+		data, err := os.ReadFile(filepath.Join(localeDir, "listing.json"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to read listing.json for %s: %w", locale, err)
+		}
+		if err := json.Unmarshal(data, listing); err != nil {
+			return nil, fmt.Errorf("failed to parse listing.json for %s: %w", locale, err)
+		}
+		return listing, nil
+	}
+
+	if data, err := os.ReadFile(filepath.Join(localeDir, titleFile)); err == nil {
+		listing.Title = strings.TrimSpace(string(data))
+	}
+	if data, err := os.ReadFile(filepath.Join(localeDir, shortDescFile)); err == nil {
+		listing.ShortDescription = strings.TrimSpace(string(data))
+	}
+	if data, err := os.ReadFile(filepath.Join(localeDir, fullDescFile)); err == nil {
+		listing.FullDescription = strings.TrimSpace(string(data))
+	}
+	if data, err := os.ReadFile(filepath.Join(localeDir, videoFile)); err == nil {
+		listing.Video = strings.TrimSpace(string(data))
+	}
+	if listing.Title == "" && listing.ShortDescription == "" && listing.FullDescription == "" {
+		return nil, nil
+	}
+	return listing, nil
+}
+
+// applyLocaleFallback copies each field listing leaves empty from fallback
+// and returns the names of the fields it filled.
+func applyLocaleFallback(listing, fallback *androidpublisher.Listing) []string {
+	var filled []string
+	fill := func(name string, field *string, value string) {
+		if strings.TrimSpace(*field) == "" && value != "" {
+			*field = value
+			filled = append(filled, name)
+		}
+	}
+	fill("title", &listing.Title, fallback.Title)
+	fill("short_description", &listing.ShortDescription, fallback.ShortDescription)
+	fill("full_description", &listing.FullDescription, fallback.FullDescription)
+	fill("video", &listing.Video, fallback.Video)
+	return filled
+}
+
 func ExportImagesCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("sync export-images", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
@@ -311,7 +474,7 @@ func ExportImagesCommand() *ffcli.Command {
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -345,9 +508,11 @@ func ExportImagesCommand() *ffcli.Command {
 			// Get locales to export
 			var locales []string
 			if strings.TrimSpace(*locale) != "" {
-				locales = []string{*locale}
+				locales = []string{shared.NormalizeLocaleFlag("--locale", *locale)}
 			} else {
+				endList := shared.StartSpan(ctx, "list listings")
 				listingsResp, err := service.API.Edits.Listings.List(pkg, edit.Id).Context(ctx).Do()
+				endList()
 				if err != nil {
 					return fmt.Errorf("failed to list listings: %w", err)
 				}
@@ -370,6 +535,7 @@ func ExportImagesCommand() *ffcli.Command {
 
 			exported := 0
 			for _, loc := range locales {
+				endLocale := shared.StartSpan(ctx, "list images "+loc)
 				for _, imageType := range imageTypes {
 					images, err := service.API.Edits.Images.List(pkg, edit.Id, loc, imageType).Context(ctx).Do()
 					if err != nil {
@@ -405,16 +571,17 @@ func ExportImagesCommand() *ffcli.Command {
 					}
 
 					exported += len(images.Images)
-					fmt.Fprintf(os.Stderr, "Exported metadata for %d %s images in %s\n", len(images.Images), imageType, loc)
+					shared.Infof(ctx, "Exported metadata for %d %s images in %s\n", len(images.Images), imageType, loc)
 				}
+				endLocale()
 			}
 
 			if tempEdit {
-				fmt.Fprintf(os.Stderr, "Note: Used temporary edit (deleted automatically)\n")
+				shared.Infof(ctx, "Note: Used temporary edit (deleted automatically)\n")
 			}
 
-			fmt.Fprintf(os.Stderr, "Exported metadata for %d images to %s\n", exported, *outputDir)
-			fmt.Fprintf(os.Stderr, "Note: Image files must be downloaded manually from the Play Console\n")
+			shared.Infof(ctx, "Exported metadata for %d images to %s\n", exported, *outputDir)
+			shared.Infof(ctx, "Note: Image files must be downloaded manually from the Play Console\n")
 			return nil
 		},
 	}
@@ -439,7 +606,7 @@ func ImportImagesCommand() *ffcli.Command {
 				return fmt.Errorf("--edit is required")
 			}
 
-			service, err := playclient.NewService(ctx)
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -451,7 +618,7 @@ func ImportImagesCommand() *ffcli.Command {
 			// Get locales to import
 			var locales []string
 			if strings.TrimSpace(*locale) != "" {
-				locales = []string{*locale}
+				locales = []string{strings.TrimSpace(*locale)}
 			} else {
 				entries, err := os.ReadDir(*inputDir)
 				if err != nil {
@@ -489,6 +656,10 @@ func ImportImagesCommand() *ffcli.Command {
 				if _, err := os.Stat(imagesPath); os.IsNotExist(err) {
 					continue
 				}
+				// Directory names are used as typed on disk; Play needs the
+				// canonical casing.
+				apiLocale := shared.NormalizeLocaleFlag("locale directory", loc)
+				endLocale := shared.StartSpan(ctx, "upload images "+loc)
 
 				// Import screenshot directories
 				for dirName, imageType := range imageTypeMappings {
@@ -509,13 +680,13 @@ func ImportImagesCommand() *ffcli.Command {
 
 						filePath := filepath.Join(screenshotDir, file.Name())
 						if *dryRun {
-							fmt.Fprintf(os.Stderr, "Would upload: %s -> %s/%s\n", filePath, loc, imageType)
+							fmt.Fprintf(os.Stderr, "Would upload: %s -> %s/%s\n", filePath, apiLocale, imageType)
 						} else {
-							if err := uploadImage(ctx, service, pkg, *editID, loc, imageType, filePath); err != nil {
+							if err := uploadImage(ctx, service, pkg, *editID, apiLocale, imageType, filePath); err != nil {
 								fmt.Fprintf(os.Stderr, "Warning: failed to upload %s: %v\n", filePath, err)
 								continue
 							}
-							fmt.Fprintf(os.Stderr, "Uploaded: %s -> %s/%s\n", file.Name(), loc, imageType)
+							shared.Infof(ctx, "Uploaded: %s -> %s/%s\n", file.Name(), apiLocale, imageType)
 						}
 						imported++
 					}
@@ -529,22 +700,23 @@ func ImportImagesCommand() *ffcli.Command {
 					}
 
 					if *dryRun {
-						fmt.Fprintf(os.Stderr, "Would upload: %s -> %s/%s\n", filePath, loc, imageType)
+						fmt.Fprintf(os.Stderr, "Would upload: %s -> %s/%s\n", filePath, apiLocale, imageType)
 					} else {
-						if err := uploadImage(ctx, service, pkg, *editID, loc, imageType, filePath); err != nil {
+						if err := uploadImage(ctx, service, pkg, *editID, apiLocale, imageType, filePath); err != nil {
 							fmt.Fprintf(os.Stderr, "Warning: failed to upload %s: %v\n", filePath, err)
 							continue
 						}
-						fmt.Fprintf(os.Stderr, "Uploaded: %s -> %s/%s\n", fileName, loc, imageType)
+						shared.Infof(ctx, "Uploaded: %s -> %s/%s\n", fileName, apiLocale, imageType)
 					}
 					imported++
 				}
+				endLocale()
 			}
 
 			if *dryRun {
 				fmt.Fprintf(os.Stderr, "Dry run: would upload %d images\n", imported)
 			} else {
-				fmt.Fprintf(os.Stderr, "Uploaded %d images\n", imported)
+				shared.Infof(ctx, "Uploaded %d images\n", imported)
 			}
 			return nil
 		},
@@ -557,15 +729,50 @@ func DiffListingsCommand() *ffcli.Command {
 	editID := fs.String("edit", "", "Edit ID (optional, creates temporary edit if not provided)")
 	localDir := fs.String("dir", "./metadata", "Local metadata directory")
 	format := fs.String("format", "fastlane", "Local format: fastlane (default), json")
+	locales := fs.String("locales", "", "Comma-separated locales to process (default: all)")
+	outputFlag := fs.String("output", "text", "Output format: text (default), json")
+	shared.DeclareOutputFormats(fs, "text", "json")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "diff-listings",
-		ShortUsage: "gplay sync diff-listings --package <name> --dir <path> [--edit <id>]",
+		ShortUsage: "gplay sync diff-listings --package <name> --dir <path> [--edit <id>] [--locales <list>] [--output json]",
 		ShortHelp:  "Show differences between local and remote listings.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Show differences between local and remote listings.
+
+With --output json, a diff document is printed instead of the text summary,
+for example to post drift in a pull request:
+
+  {
+    "drift": true,
+    "resources": {
+      "listings": {
+        "added": ["de-DE"],
+        "removed": ["fr-FR"],
+        "changed": [
+          {"id": "en-US", "fields": [{"field": "title", "remote": "Old", "local": "New"}]}
+        ]
+      }
+    }
+  }
+
+"added" are locales only in --dir, "removed" locales only in the edit, and
+"changed" lists the fields whose remote and local values differ, untruncated.
+
+Examples:
+  gplay sync diff-listings --package com.example.app --dir ./metadata
+  gplay sync diff-listings --package com.example.app --dir ./metadata --output json --pretty`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			service, err := playclient.NewService(ctx)
+			if err := validateDiffOutput(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			filter, err := parseLocaleFilter(*locales)
+			if err != nil {
+				return err
+			}
+			service, err := newPlayService(ctx)
 			if err != nil {
 				return err
 			}
@@ -597,14 +804,18 @@ func DiffListingsCommand() *ffcli.Command {
 			}
 
 			// Get remote listings
+			endList := shared.StartSpan(ctx, "list listings")
 			listingsResp, err := service.API.Edits.Listings.List(pkg, edit.Id).Context(ctx).Do()
+			endList()
 			if err != nil {
 				return fmt.Errorf("failed to list listings: %w", err)
 			}
 
 			remoteListings := make(map[string]*androidpublisher.Listing)
 			for _, l := range listingsResp.Listings {
-				remoteListings[l.Language] = l
+				if filter.includes(l.Language) {
+					remoteListings[l.Language] = l
+				}
 			}
 
 			// Read local listings
@@ -615,7 +826,7 @@ func DiffListingsCommand() *ffcli.Command {
 			}
 
 			for _, entry := range entries {
-				if !entry.IsDir() {
+				if !entry.IsDir() || !filter.includes(entry.Name()) {
 					continue
 				}
 				locale := entry.Name()
@@ -649,50 +860,23 @@ func DiffListingsCommand() *ffcli.Command {
 				localListings[locale] = listing
 			}
 
-			// Compare
-			hasDiff := false
-
-			// Check for locales only in remote
-			for locale := range remoteListings {
-				if _, ok := localListings[locale]; !ok {
-					fmt.Printf("- %s (only in remote)\n", locale)
-					hasDiff = true
+			diff := diffListings(remoteListings, localListings, true)
+			if *outputFlag == "json" {
+				if tempEdit {
+					shared.Infof(ctx, "Note: Used temporary edit (deleted automatically)\n")
 				}
+				return shared.PrintOutput(newListingsSyncDiff(diff), "json", *pretty)
 			}
 
-			// Check for locales only in local
-			for locale := range localListings {
-				if _, ok := remoteListings[locale]; !ok {
-					fmt.Printf("+ %s (only in local)\n", locale)
-					hasDiff = true
-				}
+			hasDiff := !diff.empty()
+			for _, locale := range diff.Removed {
+				fmt.Printf("- %s (only in remote)\n", locale)
 			}
-
-			// Check for differences in shared locales
-			for locale, local := range localListings {
-				remote, ok := remoteListings[locale]
-				if !ok {
-					continue
-				}
-
-				diffs := []string{}
-				if local.Title != remote.Title {
-					diffs = append(diffs, fmt.Sprintf("title: %q -> %q", truncate(remote.Title, 20), truncate(local.Title, 20)))
-				}
-				if local.ShortDescription != remote.ShortDescription {
-					diffs = append(diffs, "short_description changed")
-				}
-				if local.FullDescription != remote.FullDescription {
-					diffs = append(diffs, "full_description changed")
-				}
-				if local.Video != remote.Video {
-					diffs = append(diffs, fmt.Sprintf("video: %q -> %q", remote.Video, local.Video))
-				}
-
-				if len(diffs) > 0 {
-					fmt.Printf("~ %s: %s\n", locale, strings.Join(diffs, ", "))
-					hasDiff = true
-				}
+			for _, locale := range diff.Added {
+				fmt.Printf("+ %s (only in local)\n", locale)
+			}
+			for _, changed := range diff.Changed {
+				fmt.Printf("~ %s: %s\n", changed.ID, strings.Join(listingFieldDiffs(remoteListings[changed.ID], localListings[changed.ID]), ", "))
 			}
 
 			if !hasDiff {
@@ -700,7 +884,7 @@ func DiffListingsCommand() *ffcli.Command {
 			}
 
 			if tempEdit {
-				fmt.Fprintf(os.Stderr, "\nNote: Used temporary edit (deleted automatically)\n")
+				shared.Infof(ctx, "\nNote: Used temporary edit (deleted automatically)\n")
 			}
 
 			return nil
@@ -716,7 +900,7 @@ func uploadImage(ctx context.Context, service *playclient.Service, pkg, editID,
 	defer file.Close()
 
 	call := service.API.Edits.Images.Upload(pkg, editID, locale, imageType)
-	call.Media(file)
+	call.Media(shared.NewFileProgressReader(file))
 	_, err = call.Context(ctx).Do()
 	if err != nil {
 		return shared.WrapGoogleAPIError("failed to upload image", err)
@@ -724,6 +908,49 @@ func uploadImage(ctx context.Context, service *playclient.Service, pkg, editID,
 	return nil
 }
 
+// localeFilterPattern matches the language[-region] codes Play uses, such as
+// "fil", "en-US" and "es-419".
+var localeFilterPattern = regexp.MustCompile(`^[a-z]{2,3}(-([A-Z]{2}|[0-9]{3}))?$`)
+
+// localeFilter restricts a sync command to a set of locales. A nil filter
+// includes every locale.
+type localeFilter map[string]bool
+
+// parseLocaleFilter parses the comma-separated --locales value. Casing is
+// canonicalized (en-us -> en-US); anything that still isn't a language or
+// language-region code is rejected so a typo doesn't silently match nothing.
+func parseLocaleFilter(value string) (localeFilter, error) {
+	codes := shared.SplitUniqueCSV(value)
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	filter := make(localeFilter, len(codes))
+	for _, code := range codes {
+		canonical := shared.CanonicalizeLocale(code)
+		if !localeFilterPattern.MatchString(canonical) {
+			return nil, fmt.Errorf("--locales: invalid locale %q (expected a code like en or en-US)", code)
+		}
+		filter[canonical] = true
+	}
+	return filter, nil
+}
+
+func (f localeFilter) includes(locale string) bool {
+	return f == nil || f[shared.CanonicalizeLocale(locale)]
+}
+
+// listingFieldDiffs describes each text field that differs between the
+// remote and local listing as `field: "before" -> "after"`. Descriptions are
+// truncated so a changed full description stays on one line.
+func listingFieldDiffs(remote, local *androidpublisher.Listing) []string {
+	maxLen := map[string]int{"title": 50, "short_description": 40, "full_description": 40, "video": 100}
+	var diffs []string
+	for _, c := range listingFieldChanges(remote, local) {
+		diffs = append(diffs, fmt.Sprintf("%s: %q -> %q", c.Field, truncate(c.Remote, maxLen[c.Field]), truncate(c.Local, maxLen[c.Field])))
+	}
+	return diffs
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
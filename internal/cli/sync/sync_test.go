@@ -0,0 +1,464 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+func installMockSyncPlayService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() {
+		newPlayService = original
+	})
+}
+
+func TestImportListingsCommand_TraceRecordsPerLocaleUploads(t *testing.T) {
+	dir := t.TempDir()
+	for _, locale := range []string{"de-DE", "en-US"} {
+		localeDir := filepath.Join(dir, locale)
+		if err := os.MkdirAll(localeDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(localeDir, titleFile), []byte("Title "+locale), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	tracer := shared.NewTracer()
+	ctx := shared.ContextWithTracer(context.Background(), tracer)
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if err := cmd.Exec(ctx, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var names []string
+	for _, span := range tracer.Spans() {
+		names = append(names, span.Name)
+	}
+	want := []string{"upload de-DE", "upload en-US"}
+	if len(names) != len(want) {
+		t.Fatalf("spans = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("spans = %v, want %v", names, want)
+		}
+	}
+}
+
+func captureSyncStderr(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+
+	orig := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	runErr := fn()
+	_ = w.Close()
+	os.Stderr = orig
+	return <-done, runErr
+}
+
+func TestImportListingsCommand_DryRunShowsFieldDiffs(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en-US")
+	if err := os.MkdirAll(localeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		titleFile:     "New Title",
+		shortDescFile: "Same short",
+		fullDescFile:  "Same full description",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(localeDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"listings":[{"language":"en-US","title":"Old Title","shortDescription":"Same short","fullDescription":"Same full description"}]}`)
+	})
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir, "--dry-run"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stderr, err := captureSyncStderr(t, func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.Contains(stderr, `title: "Old Title" -> "New Title"`) {
+		t.Errorf("expected title diff, got:\n%s", stderr)
+	}
+	if strings.Contains(stderr, "short_description") || strings.Contains(stderr, "full_description") {
+		t.Errorf("unchanged descriptions should not be listed, got:\n%s", stderr)
+	}
+}
+
+func TestImportListingsCommand_AppendAppendsToEditDescriptions(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en-US")
+	if err := os.MkdirAll(localeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(localeDir, fullDescFile), []byte("Summer sale!\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var put androidpublisher.Listing
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			if err := json.NewDecoder(r.Body).Decode(&put); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"listings":[{"language":"en-US","title":"App","shortDescription":"Short","fullDescription":"Existing."}]}`)
+	})
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir, "--append"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if _, err := captureSyncStderr(t, func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if put.FullDescription != "Existing.\n\nSummer sale!" || put.Title != "App" || put.ShortDescription != "Short" {
+		t.Fatalf("unexpected appended listing: %+v", put)
+	}
+}
+
+func TestListingFieldDiffs(t *testing.T) {
+	remote := &androidpublisher.Listing{Title: "App", ShortDescription: "Short", Video: "https://youtu.be/a"}
+	local := &androidpublisher.Listing{Title: "App", ShortDescription: "Shorter", Video: "https://youtu.be/b"}
+
+	got := listingFieldDiffs(remote, local)
+	want := []string{
+		`short_description: "Short" -> "Shorter"`,
+		`video: "https://youtu.be/a" -> "https://youtu.be/b"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diffs = %q, want %q", got, want)
+	}
+	if diffs := listingFieldDiffs(remote, remote); len(diffs) != 0 {
+		t.Errorf("expected no diffs for identical listings, got %q", diffs)
+	}
+}
+
+func TestParseLocaleFilter(t *testing.T) {
+	filter, err := parseLocaleFilter("en-us, de-DE,fil,es-419")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, locale := range []string{"en-US", "de-de", "fil", "es-419"} {
+		if !filter.includes(locale) {
+			t.Errorf("expected %q to be included", locale)
+		}
+	}
+	if filter.includes("fr-FR") {
+		t.Error("expected fr-FR to be excluded")
+	}
+
+	for _, bad := range []string{"en_US", "english", "en-USA", "e"} {
+		if _, err := parseLocaleFilter(bad); err == nil || !strings.Contains(err.Error(), "--locales") {
+			t.Errorf("parseLocaleFilter(%q): expected --locales error, got %v", bad, err)
+		}
+	}
+
+	if filter, err := parseLocaleFilter(""); err != nil || !filter.includes("anything") {
+		t.Errorf("empty filter should include everything, got err=%v", err)
+	}
+}
+
+func TestExportListingsCommand_LocalesFiltersRemoteListings(t *testing.T) {
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			_, _ = io.WriteString(w, `{"id":"edit-1"}`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = io.WriteString(w, `{"listings":[{"language":"en-US","title":"App"},{"language":"de-DE","title":"App DE"},{"language":"fr-FR","title":"App FR"}]}`)
+		}
+	})
+
+	dir := t.TempDir()
+	cmd := ExportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--dir", dir, "--locales", "en-US,fr-FR"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if _, err := captureSyncStderr(t, func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	if strings.Join(got, ",") != "en-US,fr-FR" {
+		t.Errorf("exported locales = %v, want [en-US fr-FR]", got)
+	}
+}
+
+func TestImportListingsCommand_LocalesFiltersLocalDirectories(t *testing.T) {
+	dir := t.TempDir()
+	for _, locale := range []string{"de-DE", "en-US", "fr-FR"} {
+		localeDir := filepath.Join(dir, locale)
+		if err := os.MkdirAll(localeDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(localeDir, titleFile), []byte("Title "+locale), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var uploaded []string
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		uploaded = append(uploaded, filepath.Base(r.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir, "--locales", "de-de"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if _, err := captureSyncStderr(t, func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Join(uploaded, ",") != "de-DE" {
+		t.Errorf("uploaded locales = %v, want [de-DE]", uploaded)
+	}
+}
+
+func TestDiffListingsCommand_LocalesRejectsTypos(t *testing.T) {
+	cmd := DiffListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--locales", "en_US"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), `invalid locale "en_US"`) {
+		t.Fatalf("expected invalid locale error, got %v", err)
+	}
+}
+
+func writeSyncLocaleFiles(t *testing.T, dir, locale string, files map[string]string) {
+	t.Helper()
+	localeDir := filepath.Join(dir, locale)
+	if err := os.MkdirAll(localeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(localeDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestImportListingsCommand_LocaleFallbackFillsMissingFields(t *testing.T) {
+	dir := t.TempDir()
+	writeSyncLocaleFiles(t, dir, "en-US", map[string]string{
+		titleFile:     "Example",
+		shortDescFile: "The best example",
+		fullDescFile:  "A long description",
+	})
+	writeSyncLocaleFiles(t, dir, "de-DE", map[string]string{
+		titleFile:    "Beispiel",
+		fullDescFile: "Eine lange Beschreibung",
+	})
+
+	uploads := map[string]androidpublisher.Listing{}
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var listing androidpublisher.Listing
+		if err := json.NewDecoder(r.Body).Decode(&listing); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		uploads[filepath.Base(r.URL.Path)] = listing
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir, "--locales", "de-DE", "--locale-fallback", "en-US"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stderr, err := captureSyncStderr(t, func() error { return cmd.Exec(context.Background(), nil) })
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(uploads) != 1 {
+		t.Fatalf("expected only de-DE to be uploaded, got %v", uploads)
+	}
+	got := uploads["de-DE"]
+	if got.Title != "Beispiel" || got.FullDescription != "Eine lange Beschreibung" || got.ShortDescription != "The best example" {
+		t.Errorf("unexpected de-DE payload: %+v", got)
+	}
+	if !strings.Contains(stderr, "Using en-US for de-DE: short_description") {
+		t.Errorf("expected fallback note on stderr, got %q", stderr)
+	}
+}
+
+func TestImportListingsCommand_WithoutLocaleFallbackLeavesFieldsBlank(t *testing.T) {
+	dir := t.TempDir()
+	writeSyncLocaleFiles(t, dir, "en-US", map[string]string{titleFile: "Example", shortDescFile: "The best example"})
+	writeSyncLocaleFiles(t, dir, "de-DE", map[string]string{titleFile: "Beispiel"})
+
+	var got androidpublisher.Listing
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/de-DE") {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if _, err := captureSyncStderr(t, func() error { return cmd.Exec(context.Background(), nil) }); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Title != "Beispiel" || got.ShortDescription != "" {
+		t.Errorf("fallback applied without --locale-fallback: %+v", got)
+	}
+}
+
+func TestImportListingsCommand_QuietSuppressesProgress(t *testing.T) {
+	dir := t.TempDir()
+	writeSyncLocaleFiles(t, dir, "en-US", map[string]string{titleFile: "Example"})
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	ctx := shared.ContextWithQuiet(context.Background(), true)
+	stderr, err := captureSyncStderr(t, func() error { return cmd.Exec(ctx, nil) })
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if stderr != "" {
+		t.Errorf("expected no stderr output with quiet set, got %q", stderr)
+	}
+}
+
+func TestImportListingsCommand_QuietKeepsDryRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	writeSyncLocaleFiles(t, dir, "en-US", map[string]string{titleFile: "New Title"})
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"listings":[{"language":"en-US","title":"Old Title"}]}`)
+	})
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir, "--dry-run"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	ctx := shared.ContextWithQuiet(context.Background(), true)
+	stderr, err := captureSyncStderr(t, func() error { return cmd.Exec(ctx, nil) })
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(stderr, "Would import: en-US") || !strings.Contains(stderr, `title: "Old Title" -> "New Title"`) {
+		t.Errorf("expected dry-run diff with quiet set, got:\n%s", stderr)
+	}
+}
+
+func TestImportListingsCommand_LocaleFallbackMissingLocale(t *testing.T) {
+	dir := t.TempDir()
+	writeSyncLocaleFiles(t, dir, "de-DE", map[string]string{titleFile: "Beispiel"})
+	installMockSyncPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--edit", "edit-1", "--dir", dir, "--locale-fallback", "en-US"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "no local listing for en-US") {
+		t.Fatalf("expected missing fallback error, got %v", err)
+	}
+}
+
+func TestImportListingsCommand_LocaleFallbackRejectsAppend(t *testing.T) {
+	cmd := ImportListingsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--edit", "edit-1", "--append", "--locale-fallback", "en-US"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--append") {
+		t.Fatalf("expected --append conflict error, got %v", err)
+	}
+}
@@ -48,8 +48,8 @@ func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("system-apks create", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	versionCode := fs.Int64("version-code", 0, "Version code of the app bundle")
-	jsonFlag := fs.String("json", "", "SystemApkOptions JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "SystemApkOptions JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -113,7 +113,7 @@ func ListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("system-apks list", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	versionCode := fs.Int64("version-code", 0, "Version code of the app bundle")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -155,7 +155,7 @@ func GetCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	versionCode := fs.Int64("version-code", 0, "Version code of the app bundle")
 	variantID := fs.Int64("variant-id", 0, "Variant ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -201,7 +201,8 @@ func DownloadCommand() *ffcli.Command {
 	versionCode := fs.Int64("version-code", 0, "Version code of the app bundle")
 	variantID := fs.Int64("variant-id", 0, "Variant ID")
 	outputDir := fs.String("output", ".", "Output directory for downloaded APK")
-	outputFlag := fs.String("format", "json", "Output format: json (default), table, markdown")
+	shared.DeclareOutputFormats(fs)
+	outputFlag := fs.String("format", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -40,7 +40,7 @@ func GetCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	track := fs.String("track", "", "Track name (e.g., internal, alpha, beta, or custom track name)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -85,8 +85,8 @@ func UpdateCommand() *ffcli.Command {
 	track := fs.String("track", "", "Track name")
 	emails := fs.String("emails", "", "Comma-separated list of tester email addresses")
 	googleGroups := fs.String("google-groups", "", "Comma-separated list of Google Group email addresses")
-	jsonFlag := fs.String("json", "", "Full Testers JSON (or @file) - overrides other flags")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "Full Testers JSON (or @file, - for stdin) - overrides other flags")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -123,8 +123,8 @@ func PatchCommand() *ffcli.Command {
 	track := fs.String("track", "", "Track name")
 	emails := fs.String("emails", "", "Comma-separated list of tester email addresses")
 	googleGroups := fs.String("google-groups", "", "Comma-separated list of Google Group email addresses")
-	jsonFlag := fs.String("json", "", "Partial Testers JSON (or @file) - overrides other flags")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "Partial Testers JSON (or @file, - for stdin) - overrides other flags")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
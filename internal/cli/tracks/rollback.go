@@ -0,0 +1,326 @@
+package tracks
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+const releaseStatePublished = "RELEASE_LIFECYCLE_STATE_PUBLISHED"
+
+func RollbackCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("tracks rollback", flag.ExitOnError)
+	packageName := fs.String("package", "", "Package name (applicationId)")
+	track := fs.String("track", "", "Track name (production, beta, alpha, internal, or custom track)")
+	versionCode := fs.Int64("version-code", 0, "Version code to restore (defaults to the previously live release)")
+	haltOnly := fs.Bool("halt-only", false, "Only halt the current staged rollout; do not re-promote a previous release")
+	changesNotSent := fs.Bool("changes-not-sent-for-review", false, "Commit without sending changes for review")
+	assumeYes := fs.Bool("assume-yes", false, "Skip the typed confirmation required for the production track")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "rollback",
+		ShortUsage: "gplay tracks rollback --package <name> --track <name> [--version-code <code>] [--halt-only]",
+		ShortHelp:  "Halt the current rollout and re-promote the previous release.",
+		LongHelp: `Roll a track back to the release that was live before the current one.
+
+The current staged (inProgress) or halted release is halted, and the
+previous release is set as the track's completed release. The previous
+version code is taken from, in order:
+  1. --version-code
+  2. the completed release still on the track next to a staged rollout
+  3. the newest published release older than the current one, from
+     "gplay tracks releases list"
+
+Play API constraints:
+  - Devices never downgrade. Users who already installed the current
+    version keep it; a rollback only changes what other users receive.
+  - Rolling back a staged rollout (halting it) is always possible. If the
+    current release was fully rolled out (completed), Play usually rejects
+    re-promoting a lower version code; ship a new build with a higher
+    version code instead.
+  - Releases that are no longer live are not returned by the release
+    history API; pass --version-code to restore one of those.
+
+Use --halt-only to stop a staged rollout without changing anything else.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*track) == "" {
+				return fmt.Errorf("--track is required")
+			}
+			if *versionCode < 0 {
+				return fmt.Errorf("--version-code must be positive")
+			}
+			if *haltOnly && *versionCode > 0 {
+				return fmt.Errorf("--halt-only cannot be combined with --version-code")
+			}
+			if err := shared.ConfirmProductionTrack(*track, "roll back", *assumeYes); err != nil {
+				return err
+			}
+			return rollbackTrack(ctx, rollbackOptions{
+				packageName:    *packageName,
+				track:          strings.TrimSpace(*track),
+				versionCode:    *versionCode,
+				haltOnly:       *haltOnly,
+				changesNotSent: *changesNotSent,
+			}, *outputFlag, *pretty)
+		},
+	}
+}
+
+type rollbackOptions struct {
+	packageName    string
+	track          string
+	versionCode    int64
+	haltOnly       bool
+	changesNotSent bool
+}
+
+// rollbackPlan is the track change a rollback commits.
+type rollbackPlan struct {
+	Releases      []*androidpublisher.TrackRelease
+	Restored      []int64
+	RestoredName  string
+	Halted        []int64
+	HaltedRelease bool
+}
+
+func rollbackTrack(ctx context.Context, opts rollbackOptions, outputFlag string, pretty bool) (err error) {
+	service, err := newPlayService(ctx)
+	if err != nil {
+		return err
+	}
+	pkg := shared.ResolvePackageName(opts.packageName, service.Cfg)
+	if strings.TrimSpace(pkg) == "" {
+		return fmt.Errorf("--package is required")
+	}
+
+	var history []*androidpublisher.ReleaseSummary
+	if !opts.haltOnly && opts.versionCode == 0 {
+		historyCtx, historyCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+		parent := fmt.Sprintf("applications/%s/tracks/%s", pkg, opts.track)
+		resp, err := service.API.Applications.Tracks.Releases.List(parent).Context(historyCtx).Do()
+		historyCancel()
+		if err != nil {
+			return shared.WrapGoogleAPIError("list track releases", err)
+		}
+		history = resp.Releases
+	}
+
+	fmt.Fprintf(os.Stderr, "Creating edit...\n")
+	editCtx, editCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	edit, err := service.API.Edits.Insert(pkg, &androidpublisher.AppEdit{}).Context(editCtx).Do()
+	editCancel()
+	if err != nil {
+		return fmt.Errorf("failed to create edit: %w", err)
+	}
+	done := false
+	defer func() {
+		if err != nil && !done {
+			err = discardEdit(ctx, service, pkg, edit.Id, err)
+		}
+	}()
+
+	getCtx, getCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	current, err := service.API.Edits.Tracks.Get(pkg, edit.Id, opts.track).Context(getCtx).Do()
+	getCancel()
+	if err != nil {
+		return fmt.Errorf("failed to get track: %w", err)
+	}
+
+	plan, err := planRollback(current.Releases, history, opts.versionCode, opts.haltOnly)
+	if err != nil {
+		return err
+	}
+
+	if plan.HaltedRelease {
+		fmt.Fprintf(os.Stderr, "Halting release %v\n", plan.Halted)
+	}
+	if len(plan.Restored) > 0 {
+		fmt.Fprintf(os.Stderr, "Restoring release %v\n", plan.Restored)
+	}
+	trackObj := &androidpublisher.Track{Track: opts.track, Releases: plan.Releases}
+	trackCtx, trackCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	_, err = service.API.Edits.Tracks.Update(pkg, edit.Id, opts.track, trackObj).Context(trackCtx).Do()
+	trackCancel()
+	if err != nil {
+		return shared.WrapGoogleAPIError("failed to update track", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Validating edit...\n")
+	validateCtx, validateCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	_, err = service.API.Edits.Validate(pkg, edit.Id).Context(validateCtx).Do()
+	validateCancel()
+	if err != nil {
+		return shared.WrapGoogleAPIError("validation failed", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Committing edit...\n")
+	commitCtx, commitCancel := shared.ContextWithTimeout(ctx, service.Cfg)
+	commitCall := service.API.Edits.Commit(pkg, edit.Id).Context(commitCtx)
+	if opts.changesNotSent {
+		commitCall = commitCall.ChangesNotSentForReview(true)
+	}
+	committed, err := commitCall.Do()
+	commitCancel()
+	if err != nil {
+		return shared.WrapGoogleAPIError("commit failed", err)
+	}
+	done = true
+	fmt.Fprintf(os.Stderr, "Rollback committed\n")
+
+	result := map[string]interface{}{
+		"editId":      committed.Id,
+		"packageName": pkg,
+		"track":       opts.track,
+	}
+	if len(plan.Restored) > 0 {
+		result["restoredVersionCodes"] = plan.Restored
+	}
+	if plan.RestoredName != "" {
+		result["restoredReleaseName"] = plan.RestoredName
+	}
+	if plan.HaltedRelease {
+		result["haltedVersionCodes"] = plan.Halted
+	}
+	return shared.PrintOutput(result, outputFlag, pretty)
+}
+
+// discardEdit deletes an edit a failed rollback left open and returns cause,
+// noting whether the edit is gone. The delete gets its own deadline so it
+// still runs when cause was a timeout.
+func discardEdit(ctx context.Context, service *playclient.Service, pkg, editID string, cause error) error {
+	deleteCtx, cancel := shared.ContextWithTimeout(context.WithoutCancel(ctx), service.Cfg)
+	defer cancel()
+	if err := service.API.Edits.Delete(pkg, editID).Context(deleteCtx).Do(); err != nil {
+		return fmt.Errorf("%w (rollback failed: %v; delete it with: gplay edits delete --package %s --edit %s)", cause, err, pkg, editID)
+	}
+	return fmt.Errorf("%w (edit %s deleted)", cause, editID)
+}
+
+// planRollback works out the track releases after a rollback. releases is
+// the track's current state in the edit and history the published release
+// summaries for the track.
+func planRollback(releases []*androidpublisher.TrackRelease, history []*androidpublisher.ReleaseSummary, versionCode int64, haltOnly bool) (*rollbackPlan, error) {
+	var staged, completed *androidpublisher.TrackRelease
+	for _, release := range releases {
+		switch release.Status {
+		case "inProgress", "halted":
+			if staged == nil {
+				staged = release
+			}
+		case "completed":
+			if completed == nil {
+				completed = release
+			}
+		}
+	}
+
+	plan := &rollbackPlan{}
+	if haltOnly {
+		if staged == nil || staged.Status != "inProgress" {
+			return nil, fmt.Errorf("no in-progress staged rollout to halt")
+		}
+		staged.Status = "halted"
+		plan.Halted = staged.VersionCodes
+		plan.HaltedRelease = true
+		plan.Releases = releases
+		return plan, nil
+	}
+
+	// The release users are moving to: a staged rollout if there is one,
+	// otherwise the fully rolled out release.
+	currentRelease := staged
+	if currentRelease == nil {
+		currentRelease = completed
+	}
+	if currentRelease == nil || len(currentRelease.VersionCodes) == 0 {
+		return nil, fmt.Errorf("track has no live release to roll back")
+	}
+
+	var restored *androidpublisher.TrackRelease
+	switch {
+	case versionCode > 0:
+		restored = &androidpublisher.TrackRelease{Status: "completed", VersionCodes: []int64{versionCode}}
+	case staged != nil && completed != nil:
+		restored = completed
+	default:
+		summary := previousPublished(history, minVersionCode(currentRelease.VersionCodes))
+		if summary == nil {
+			return nil, fmt.Errorf("no previous published release found in the track history; pass --version-code")
+		}
+		restored = &androidpublisher.TrackRelease{Status: "completed", Name: summary.ReleaseName}
+		for _, artifact := range summary.ActiveArtifacts {
+			restored.VersionCodes = append(restored.VersionCodes, artifact.VersionCode)
+		}
+	}
+	for _, code := range restored.VersionCodes {
+		for _, currentCode := range currentRelease.VersionCodes {
+			if code == currentCode {
+				return nil, fmt.Errorf("version code %d is the current release; nothing to roll back to", code)
+			}
+		}
+	}
+
+	restored.Status = "completed"
+	restored.UserFraction = 0
+	plan.Restored = restored.VersionCodes
+	plan.RestoredName = restored.Name
+	plan.Releases = []*androidpublisher.TrackRelease{restored}
+	if staged != nil {
+		staged.Status = "halted"
+		plan.Halted = staged.VersionCodes
+		plan.HaltedRelease = true
+		plan.Releases = append(plan.Releases, staged)
+	}
+	return plan, nil
+}
+
+// previousPublished returns the published release with the highest version
+// code that is still below before.
+func previousPublished(history []*androidpublisher.ReleaseSummary, before int64) *androidpublisher.ReleaseSummary {
+	var best *androidpublisher.ReleaseSummary
+	var bestCode int64
+	for _, summary := range history {
+		if summary.ReleaseLifecycleState != releaseStatePublished || len(summary.ActiveArtifacts) == 0 {
+			continue
+		}
+		var highest int64
+		for _, artifact := range summary.ActiveArtifacts {
+			if artifact.VersionCode > highest {
+				highest = artifact.VersionCode
+			}
+		}
+		if highest >= before {
+			continue
+		}
+		if best == nil || highest > bestCode {
+			best = summary
+			bestCode = highest
+		}
+	}
+	return best
+}
+
+func minVersionCode(codes []int64) int64 {
+	lowest := codes[0]
+	for _, code := range codes[1:] {
+		if code < lowest {
+			lowest = code
+		}
+	}
+	return lowest
+}
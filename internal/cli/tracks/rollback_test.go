@@ -0,0 +1,210 @@
+package tracks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+const rollbackHistoryJSON = `{"releases":[
+	{"releaseName":"40","releaseLifecycleState":"RELEASE_LIFECYCLE_STATE_PUBLISHED","activeArtifacts":[{"versionCode":40}]},
+	{"releaseName":"41","releaseLifecycleState":"RELEASE_LIFECYCLE_STATE_PUBLISHED","activeArtifacts":[{"versionCode":41}]},
+	{"releaseName":"45","releaseLifecycleState":"RELEASE_LIFECYCLE_STATE_NOT_APPROVED","activeArtifacts":[{"versionCode":45}]},
+	{"releaseName":"50","releaseLifecycleState":"RELEASE_LIFECYCLE_STATE_PUBLISHED","activeArtifacts":[{"versionCode":50}]}
+]}`
+
+// runRollback executes tracks rollback against a mock API whose track
+// currently holds trackJSON and returns the releases sent in the update.
+func runRollback(t *testing.T, trackJSON string, args ...string) (string, []map[string]interface{}) {
+	t.Helper()
+	var updated []map[string]interface{}
+	var committed bool
+	installMockTracksPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := r.URL.Path
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(path, "/tracks/production/releases"):
+			_, _ = io.WriteString(w, rollbackHistoryJSON)
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits"):
+			_, _ = io.WriteString(w, `{"id":"edit-1"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(path, "/edits/edit-1/tracks/production"):
+			_, _ = io.WriteString(w, trackJSON)
+		case r.Method == http.MethodPut && strings.HasSuffix(path, "/edits/edit-1/tracks/production"):
+			var body struct {
+				Releases []map[string]interface{} `json:"releases"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode track update: %v", err)
+			}
+			updated = body.Releases
+			_, _ = io.WriteString(w, `{"track":"production"}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits/edit-1:validate"):
+			_, _ = io.WriteString(w, `{"id":"edit-1"}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits/edit-1:commit"):
+			committed = true
+			_, _ = io.WriteString(w, `{"id":"edit-1"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cmd := RollbackCommand()
+	flags := append([]string{"--package", "com.example.app", "--track", "production", "--assume-yes"}, args...)
+	if err := cmd.FlagSet.Parse(flags); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	stdout, err := captureTracksStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !committed {
+		t.Fatal("expected the rollback edit to be committed")
+	}
+	return stdout, updated
+}
+
+func releaseCodes(release map[string]interface{}) []interface{} {
+	codes, _ := release["versionCodes"].([]interface{})
+	return codes
+}
+
+func TestTracksRollbackCommand_RepromotesPreviousFromHistory(t *testing.T) {
+	stdout, releases := runRollback(t, `{"track":"production","releases":[{"status":"completed","versionCodes":["50"]}]}`)
+
+	if len(releases) != 1 {
+		t.Fatalf("expected a single restored release, got %v", releases)
+	}
+	if releases[0]["status"] != "completed" || releases[0]["name"] != "41" {
+		t.Errorf("unexpected restored release: %v", releases[0])
+	}
+	if codes := releaseCodes(releases[0]); !reflect.DeepEqual(codes, []interface{}{"41"}) {
+		t.Errorf("restored versionCodes = %v, want [41]", codes)
+	}
+	if !strings.Contains(stdout, `"restoredVersionCodes":[41]`) {
+		t.Errorf("expected restored version codes in output, got %s", stdout)
+	}
+}
+
+func TestTracksRollbackCommand_HaltsStagedRolloutAndKeepsCompleted(t *testing.T) {
+	stdout, releases := runRollback(t, `{"track":"production","releases":[
+		{"status":"inProgress","userFraction":0.2,"versionCodes":["50"]},
+		{"status":"completed","versionCodes":["41"]}
+	]}`)
+
+	if len(releases) != 2 {
+		t.Fatalf("expected restored and halted releases, got %v", releases)
+	}
+	if releases[0]["status"] != "completed" || !reflect.DeepEqual(releaseCodes(releases[0]), []interface{}{"41"}) {
+		t.Errorf("unexpected restored release: %v", releases[0])
+	}
+	if releases[1]["status"] != "halted" || !reflect.DeepEqual(releaseCodes(releases[1]), []interface{}{"50"}) {
+		t.Errorf("unexpected halted release: %v", releases[1])
+	}
+	if !strings.Contains(stdout, `"haltedVersionCodes":[50]`) {
+		t.Errorf("expected halted version codes in output, got %s", stdout)
+	}
+}
+
+func TestPlanRollback_HaltOnly(t *testing.T) {
+	releases := []*androidpublisher.TrackRelease{
+		{Status: "inProgress", UserFraction: 0.1, VersionCodes: []int64{50}},
+		{Status: "completed", VersionCodes: []int64{41}},
+	}
+	plan, err := planRollback(releases, nil, 0, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Restored) != 0 || !reflect.DeepEqual(plan.Halted, []int64{50}) {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if plan.Releases[0].Status != "halted" || plan.Releases[1].Status != "completed" {
+		t.Fatalf("halt-only should keep the completed release untouched: %+v", plan.Releases)
+	}
+}
+
+func TestPlanRollback_Errors(t *testing.T) {
+	completedOnly := func() []*androidpublisher.TrackRelease {
+		return []*androidpublisher.TrackRelease{{Status: "completed", VersionCodes: []int64{50}}}
+	}
+	tests := []struct {
+		name        string
+		releases    []*androidpublisher.TrackRelease
+		versionCode int64
+		haltOnly    bool
+		want        string
+	}{
+		{"halt without staged rollout", completedOnly(), 0, true, "no in-progress staged rollout"},
+		{"empty track", nil, 0, false, "no live release"},
+		{"no history", completedOnly(), 0, false, "pass --version-code"},
+		{"explicit current version", completedOnly(), 50, false, "is the current release"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := planRollback(tt.releases, nil, tt.versionCode, tt.haltOnly)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestTracksRollbackCommand_ProductionRequiresConfirmation(t *testing.T) {
+	cmd := RollbackCommand()
+	if err := cmd.FlagSet.Parse([]string{"--track", "production"}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--assume-yes") {
+		t.Fatalf("expected confirmation error, got %v", err)
+	}
+}
+
+func TestTracksRollbackCommand_FailedValidationDeletesEdit(t *testing.T) {
+	var deleted bool
+	installMockTracksPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := r.URL.Path
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(path, "/tracks/production/releases"):
+			_, _ = io.WriteString(w, rollbackHistoryJSON)
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits"):
+			_, _ = io.WriteString(w, `{"id":"edit-1"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(path, "/edits/edit-1/tracks/production"):
+			_, _ = io.WriteString(w, `{"track":"production","releases":[{"status":"completed","versionCodes":["50"]}]}`)
+		case r.Method == http.MethodPut && strings.HasSuffix(path, "/edits/edit-1/tracks/production"):
+			_, _ = io.WriteString(w, `{"track":"production"}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/edits/edit-1:validate"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":{"code":400,"message":"invalid release"}}`)
+		case r.Method == http.MethodDelete && strings.HasSuffix(path, "/edits/edit-1"):
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cmd := RollbackCommand()
+	if err := cmd.FlagSet.Parse([]string{"--package", "com.example.app", "--track", "production", "--assume-yes"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	_, err := captureTracksStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "edit edit-1 deleted") {
+		t.Fatalf("expected validation error noting the deleted edit, got %v", err)
+	}
+	if !deleted {
+		t.Fatal("expected the failed rollback edit to be deleted")
+	}
+}
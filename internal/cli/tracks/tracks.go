@@ -4,11 +4,13 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 	"google.golang.org/api/androidpublisher/v3"
 
+	"github.com/tamtom/play-console-cli/internal/cli/bundles"
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
@@ -30,6 +32,7 @@ func TracksCommand() *ffcli.Command {
 			UpdateCommand(),
 			PatchCommand(),
 			ReleasesCommand(),
+			RollbackCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if len(args) == 0 {
@@ -64,7 +67,7 @@ func ReleasesListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("tracks releases list", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	track := fs.String("track", "", "Track name (production, beta, alpha, internal, or custom track)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -108,7 +111,7 @@ func ListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("tracks list", flag.ExitOnError)
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -151,7 +154,7 @@ func GetCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	track := fs.String("track", "", "Track name (production, beta, alpha, internal)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -194,7 +197,7 @@ func CreateCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	track := fs.String("track", "", "Track name to create")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -247,18 +250,38 @@ func UpdateCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	track := fs.String("track", "", "Track name")
-	releasesJSON := fs.String("releases", "", "JSON array of track releases (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	releasesJSON := fs.String("releases", "", "JSON array of track releases (or @file, - for stdin)")
+	fromBundle := fs.String("from-bundle", "", "Upload this .aab to the edit and use its version code in the release")
+	status := fs.String("status", "completed", "Release status when --from-bundle is used without --releases")
+	assumeYes := fs.Bool("assume-yes", false, "Skip the typed confirmation required for the production track")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "update",
-		ShortUsage: "gplay tracks update --package <name> --edit <id> --track <name> --releases <json>",
+		ShortUsage: "gplay tracks update --package <name> --edit <id> --track <name> (--releases <json> | --from-bundle <file>)",
 		ShortHelp:  "Update a track.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Update a track in an edit, replacing its releases.
+
+--from-bundle uploads an app bundle to the same edit and uses the version
+code Play assigns to it. Without --releases a single release with --status
+is created; with --releases the version code is added to every release
+that does not list versionCodes.
+
+Updating the production track requires typing the track name to confirm.
+Pass --assume-yes to skip the prompt in CI or other non-interactive use.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			return updateTrack(ctx, *packageName, *editID, *track, *releasesJSON, *outputFlag, *pretty, false)
+			return updateTrack(ctx, trackUpdate{
+				packageName:  *packageName,
+				editID:       *editID,
+				track:        *track,
+				releasesJSON: *releasesJSON,
+				fromBundle:   *fromBundle,
+				status:       *status,
+				assumeYes:    *assumeYes,
+			}, *outputFlag, *pretty)
 		},
 	}
 }
@@ -268,66 +291,171 @@ func PatchCommand() *ffcli.Command {
 	packageName := fs.String("package", "", "Package name (applicationId)")
 	editID := fs.String("edit", "", "Edit ID")
 	track := fs.String("track", "", "Track name")
-	releasesJSON := fs.String("releases", "", "JSON array of track releases (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	releasesJSON := fs.String("releases", "", "JSON array of track releases (or @file, - for stdin)")
+	fromBundle := fs.String("from-bundle", "", "Upload this .aab to the edit and use its version code in the release")
+	status := fs.String("status", "completed", "Release status when --from-bundle is used without --releases")
+	assumeYes := fs.Bool("assume-yes", false, "Skip the typed confirmation required for the production track")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "patch",
-		ShortUsage: "gplay tracks patch --package <name> --edit <id> --track <name> --releases <json>",
+		ShortUsage: "gplay tracks patch --package <name> --edit <id> --track <name> (--releases <json> | --from-bundle <file>)",
 		ShortHelp:  "Patch a track.",
-		FlagSet:    fs,
-		UsageFunc:  shared.DefaultUsageFunc,
+		LongHelp: `Patch a track in an edit.
+
+--from-bundle uploads an app bundle to the same edit and uses its version
+code, as described in "gplay tracks update --help".
+
+Patching the production track requires typing the track name to confirm.
+Pass --assume-yes to skip the prompt in CI or other non-interactive use.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			return updateTrack(ctx, *packageName, *editID, *track, *releasesJSON, *outputFlag, *pretty, true)
+			return updateTrack(ctx, trackUpdate{
+				packageName:  *packageName,
+				editID:       *editID,
+				track:        *track,
+				releasesJSON: *releasesJSON,
+				fromBundle:   *fromBundle,
+				status:       *status,
+				assumeYes:    *assumeYes,
+				patch:        true,
+			}, *outputFlag, *pretty)
 		},
 	}
 }
 
-func updateTrack(ctx context.Context, packageName, editID, track, releasesJSON, outputFlag string, pretty, patch bool) error {
+// trackUpdate holds the inputs shared by tracks update and tracks patch.
+type trackUpdate struct {
+	packageName  string
+	editID       string
+	track        string
+	releasesJSON string
+	fromBundle   string
+	status       string
+	assumeYes    bool
+	patch        bool
+}
+
+func updateTrack(ctx context.Context, opts trackUpdate, outputFlag string, pretty bool) error {
 	if err := shared.ValidateOutputFlags(outputFlag, pretty); err != nil {
 		return err
 	}
-	if strings.TrimSpace(track) == "" {
+	if strings.TrimSpace(opts.track) == "" {
 		return fmt.Errorf("--track is required")
 	}
-	if strings.TrimSpace(releasesJSON) == "" {
-		return fmt.Errorf("--releases is required")
+	releasesJSON := strings.TrimSpace(opts.releasesJSON)
+	fromBundle := strings.TrimSpace(opts.fromBundle)
+	if releasesJSON == "" && fromBundle == "" {
+		return fmt.Errorf("--releases or --from-bundle is required")
+	}
+	if fromBundle != "" {
+		if err := shared.CheckUploadFile(fromBundle, ".aab", os.Stderr); err != nil {
+			return err
+		}
+	}
+	if err := shared.ConfirmProductionTrack(opts.track, "update releases", opts.assumeYes); err != nil {
+		return err
+	}
+
+	var releases []*androidpublisher.TrackRelease
+	if releasesJSON != "" {
+		if err := shared.LoadJSONArg(releasesJSON, &releases); err != nil {
+			return fmt.Errorf("invalid releases JSON: %w", err)
+		}
+	}
+
+	if fromBundle != "" {
+		if err := validateBundleReleases(releases, opts.status); err != nil {
+			return err
+		}
 	}
 
-	service, err := playclient.NewService(ctx)
+	service, err := newPlayService(ctx)
 	if err != nil {
 		return err
 	}
-	pkg := shared.ResolvePackageName(packageName, service.Cfg)
+	pkg := shared.ResolvePackageName(opts.packageName, service.Cfg)
 	if strings.TrimSpace(pkg) == "" {
 		return fmt.Errorf("--package is required")
 	}
-	if strings.TrimSpace(editID) == "" {
+	if strings.TrimSpace(opts.editID) == "" {
 		return fmt.Errorf("--edit is required")
 	}
 
-	var releases []*androidpublisher.TrackRelease
-	if err := shared.LoadJSONArg(releasesJSON, &releases); err != nil {
-		return fmt.Errorf("invalid releases JSON: %w", err)
+	if fromBundle != "" {
+		bundle, err := bundles.UploadBundle(ctx, service, pkg, opts.editID, fromBundle)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Uploaded bundle version code %d\n", bundle.VersionCode)
+		releases, err = releasesWithVersionCode(releases, bundle.VersionCode, opts.status)
+		if err != nil {
+			return err
+		}
 	}
 
-	trackObj := &androidpublisher.Track{Track: track, Releases: releases}
+	trackObj := &androidpublisher.Track{Track: opts.track, Releases: releases}
 
 	ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 	defer cancel()
 
-	if patch {
-		resp, err := service.API.Edits.Tracks.Patch(pkg, editID, track, trackObj).Context(ctx).Do()
+	if opts.patch {
+		resp, err := service.API.Edits.Tracks.Patch(pkg, opts.editID, opts.track, trackObj).Context(ctx).Do()
 		if err != nil {
 			return err
 		}
 		return shared.PrintOutput(resp, outputFlag, pretty)
 	}
 
-	resp, err := service.API.Edits.Tracks.Update(pkg, editID, track, trackObj).Context(ctx).Do()
+	resp, err := service.API.Edits.Tracks.Update(pkg, opts.editID, opts.track, trackObj).Context(ctx).Do()
 	if err != nil {
 		return err
 	}
 	return shared.PrintOutput(resp, outputFlag, pretty)
 }
+
+// validateBundleReleases checks, before a --from-bundle upload, that the
+// uploaded version code can be attached: without releases --status must be
+// a valid release status, and otherwise some release must lack versionCodes.
+func validateBundleReleases(releases []*androidpublisher.TrackRelease, status string) error {
+	if len(releases) == 0 {
+		switch strings.TrimSpace(status) {
+		case "":
+			return fmt.Errorf("--status is required when --from-bundle is used without --releases")
+		case "draft", "inProgress", "halted", "completed":
+			return nil
+		default:
+			return fmt.Errorf("--status must be one of: draft, inProgress, halted, completed (got %q)", status)
+		}
+	}
+	for _, release := range releases {
+		if release != nil && len(release.VersionCodes) == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("--from-bundle: every release in --releases already lists versionCodes")
+}
+
+// releasesWithVersionCode attaches an uploaded bundle's version code to the
+// releases. With no releases a single release with status is created;
+// otherwise every release without versionCodes receives it.
+func releasesWithVersionCode(releases []*androidpublisher.TrackRelease, versionCode int64, status string) ([]*androidpublisher.TrackRelease, error) {
+	if err := validateBundleReleases(releases, status); err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return []*androidpublisher.TrackRelease{{
+			Status:       strings.TrimSpace(status),
+			VersionCodes: []int64{versionCode},
+		}}, nil
+	}
+	for _, release := range releases {
+		if release == nil || len(release.VersionCodes) > 0 {
+			continue
+		}
+		release.VersionCodes = []int64{versionCode}
+	}
+	return releases, nil
+}
@@ -3,16 +3,20 @@ package tracks
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 
+	"google.golang.org/api/androidpublisher/v3"
+
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
@@ -53,6 +57,7 @@ func TestTracksCommand_SubcommandNames(t *testing.T) {
 		"update":   false,
 		"patch":    false,
 		"releases": false,
+		"rollback": false,
 	}
 	for _, sub := range cmd.Subcommands {
 		if _, ok := expected[sub.Name]; ok {
@@ -346,6 +351,34 @@ func TestTracksUpdateCommand_PrettyWithTable(t *testing.T) {
 	}
 }
 
+func TestTracksUpdateCommand_ProductionRequiresConfirmation(t *testing.T) {
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--track", "production", "--releases", `[{"status":"completed"}]`}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for production update without confirmation")
+	}
+	if !strings.Contains(err.Error(), "--assume-yes") {
+		t.Errorf("error should mention --assume-yes, got: %s", err.Error())
+	}
+}
+
+func TestTracksPatchCommand_ProductionCaseInsensitive(t *testing.T) {
+	cmd := PatchCommand()
+	if err := cmd.FlagSet.Parse([]string{"--track", "Production", "--releases", `[{"status":"completed"}]`}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for production patch without confirmation")
+	}
+	if !strings.Contains(err.Error(), "production") {
+		t.Errorf("error should mention production, got: %s", err.Error())
+	}
+}
+
 // --- tracks patch ---
 
 func TestTracksPatchCommand_Name(t *testing.T) {
@@ -382,3 +415,136 @@ func TestTracksPatchCommand_MissingReleases(t *testing.T) {
 		t.Errorf("error should mention --releases, got: %s", err.Error())
 	}
 }
+
+// --- --from-bundle ---
+
+func TestReleasesWithVersionCode_CreatesReleaseWithStatus(t *testing.T) {
+	releases, err := releasesWithVersionCode(nil, 42, "draft")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(releases) != 1 || releases[0].Status != "draft" || len(releases[0].VersionCodes) != 1 || releases[0].VersionCodes[0] != 42 {
+		t.Fatalf("unexpected releases: %+v", releases)
+	}
+}
+
+func TestReleasesWithVersionCode_FillsOnlyEmptyReleases(t *testing.T) {
+	releases := []*androidpublisher.TrackRelease{
+		{Status: "completed", VersionCodes: []int64{7}},
+		{Status: "inProgress", UserFraction: 0.1},
+	}
+	got, err := releasesWithVersionCode(releases, 42, "completed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0].VersionCodes[0] != 7 {
+		t.Errorf("existing version codes should be kept, got %v", got[0].VersionCodes)
+	}
+	if len(got[1].VersionCodes) != 1 || got[1].VersionCodes[0] != 42 {
+		t.Errorf("expected version code 42 on second release, got %v", got[1].VersionCodes)
+	}
+}
+
+func TestReleasesWithVersionCode_ErrorsWhenNothingToFill(t *testing.T) {
+	releases := []*androidpublisher.TrackRelease{{Status: "completed", VersionCodes: []int64{7}}}
+	if _, err := releasesWithVersionCode(releases, 42, "completed"); err == nil {
+		t.Fatal("expected error when every release already has version codes")
+	}
+}
+
+func TestTracksUpdateCommand_FromBundleMissingFile(t *testing.T) {
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--track", "beta", "--from-bundle", filepath.Join(t.TempDir(), "missing.aab")}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "file not found") {
+		t.Fatalf("expected file not found error, got %v", err)
+	}
+}
+
+func TestTracksUpdateCommand_FromBundleValidatesBeforeUpload(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), "app.aab")
+	if err := os.WriteFile(bundlePath, []byte("bundle"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	installMockTracksPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for _, args := range [][]string{
+		{"--status", "live"},
+		{"--releases", `[{"status":"completed","versionCodes":["41"]}]`},
+	} {
+		cmd := UpdateCommand()
+		base := []string{"--package", "com.example.app", "--edit", "edit-1", "--track", "beta", "--from-bundle", bundlePath}
+		if err := cmd.FlagSet.Parse(append(base, args...)); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.Exec(context.Background(), nil); err == nil {
+			t.Errorf("%v: expected validation error before upload", args)
+		}
+	}
+}
+
+func TestTracksUpdateCommand_FromBundleUsesUploadedVersionCode(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), "app.aab")
+	if err := os.WriteFile(bundlePath, []byte("bundle"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var uploaded bool
+	var trackBody map[string]interface{}
+	installMockTracksPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/edits/edit-1/bundles"):
+			uploaded = true
+			_, _ = io.WriteString(w, `{"versionCode":42,"sha256":"abc"}`)
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/edits/edit-1/tracks/beta"):
+			if trackBody != nil {
+				t.Errorf("track updated twice")
+			}
+			if err := json.NewDecoder(r.Body).Decode(&trackBody); err != nil {
+				t.Errorf("decode track body: %v", err)
+			}
+			_, _ = io.WriteString(w, `{"track":"beta","releases":[{"status":"inProgress","versionCodes":["42"]}]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cmd := UpdateCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--package", "com.example.app",
+		"--edit", "edit-1",
+		"--track", "beta",
+		"--from-bundle", bundlePath,
+		"--status", "inProgress",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureTracksStdout(func() error {
+		return cmd.Exec(context.Background(), nil)
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !uploaded {
+		t.Fatal("expected bundle upload before track update")
+	}
+	releases, _ := trackBody["releases"].([]interface{})
+	if len(releases) != 1 {
+		t.Fatalf("expected one release, got %v", trackBody)
+	}
+	release := releases[0].(map[string]interface{})
+	if release["status"] != "inProgress" {
+		t.Errorf("status = %v, want inProgress", release["status"])
+	}
+	codes, _ := release["versionCodes"].([]interface{})
+	if len(codes) != 1 || codes[0] != "42" {
+		t.Errorf("versionCodes = %v, want [42]", release["versionCodes"])
+	}
+}
@@ -28,12 +28,46 @@ func UpdateCommand() *ffcli.Command {
 		FlagSet:    fs,
 		UsageFunc:  shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			return runUpdate(ctx, *check, *force)
+			return runUpdate(ctx, updateOptions{checkOnly: *check, force: *force, assumeYes: true})
 		},
 	}
 }
 
-func runUpdate(ctx context.Context, checkOnly bool, force bool) error {
+// SelfUpdateCommand returns the "gplay self-update" command.
+func SelfUpdateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("self-update", flag.ExitOnError)
+	check := fs.Bool("check", false, "Only check for updates, don't install")
+	yes := fs.Bool("yes", false, "Replace the running binary without prompting")
+
+	return &ffcli.Command{
+		Name:       "self-update",
+		ShortUsage: "gplay self-update [--check] [--yes]",
+		ShortHelp:  "Replace the gplay binary with the latest release.",
+		LongHelp: `Replace the gplay binary with the latest release.
+
+Checks the GitHub releases API for a newer version and downloads the
+binary for this OS and architecture. The download is verified against the
+release's checksums.txt before the running binary is replaced.
+
+Replacing the binary requires --yes, or answering a y/N prompt when stdin
+is a terminal. Homebrew and go install installations print the matching
+upgrade command instead.`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			return runUpdate(ctx, updateOptions{checkOnly: *check, assumeYes: *yes})
+		},
+	}
+}
+
+type updateOptions struct {
+	checkOnly bool
+	force     bool
+	// assumeYes skips the confirmation before the binary is replaced.
+	assumeYes bool
+}
+
+func runUpdate(ctx context.Context, opts updateOptions) error {
 	// Detect installation method
 	execPath, err := os.Executable()
 	if err != nil {
@@ -53,12 +87,12 @@ func runUpdate(ctx context.Context, checkOnly bool, force bool) error {
 	}
 
 	currentVersion := version.Version
-	if !info.IsNewer && !force {
+	if !info.IsNewer && !opts.force {
 		fmt.Fprintf(os.Stderr, "Already on latest version: %s\n", currentVersion)
 		return nil
 	}
 
-	if checkOnly {
+	if opts.checkOnly {
 		fmt.Fprintf(os.Stderr, "Current: %s\nLatest:  %s\n", currentVersion, info.LatestVersion)
 		if info.IsNewer {
 			fmt.Fprintf(os.Stderr, "Update available! Run 'gplay update' to install.\n")
@@ -75,6 +109,11 @@ func runUpdate(ctx context.Context, checkOnly bool, force bool) error {
 		fmt.Fprintf(os.Stderr, "Installed via go install. Run:\n  go install github.com/tamtom/play-console-cli@latest\n")
 		return nil
 	case "binary":
+		if !opts.assumeYes {
+			if err := shared.RequireConfirmation(ctx, fmt.Sprintf("Replace %s with gplay %s?", execPath, info.LatestVersion), "--yes"); err != nil {
+				return err
+			}
+		}
 		return selfUpdate(ctx, execPath, info)
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown installation method. Download the latest release from:\n  %s\n", info.ReleaseURL)
@@ -116,6 +155,7 @@ func selfUpdate(ctx context.Context, _ string, info *update.UpdateInfo) error {
 		return fmt.Errorf("downloading update: %w", err)
 	}
 	defer func() { _ = os.Remove(tmpPath) }() // clean up on failure
+	fmt.Fprintf(os.Stderr, "Checksum verified.\n")
 
 	// Apply the update (atomic rename)
 	if err := update.ApplyUpdate(tmpPath); err != nil {
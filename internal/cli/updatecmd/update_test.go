@@ -121,3 +121,19 @@ func TestDetectInstallMethodCustomGOPATH(t *testing.T) {
 		t.Errorf("expected %q for custom GOPATH, got %q", "goinstall", got)
 	}
 }
+
+func TestSelfUpdateCommandFlags(t *testing.T) {
+	cmd := SelfUpdateCommand()
+	if cmd.Name != "self-update" {
+		t.Errorf("expected command name %q, got %q", "self-update", cmd.Name)
+	}
+	for _, name := range []string{"check", "yes"} {
+		f := cmd.FlagSet.Lookup(name)
+		if f == nil {
+			t.Fatalf("expected --%s flag to be registered", name)
+		}
+		if f.DefValue != "false" {
+			t.Errorf("expected --%s default %q, got %q", name, "false", f.DefValue)
+		}
+	}
+}
@@ -0,0 +1,434 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/grants"
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+// User reconcile actions.
+const (
+	userActionCreate = "create"
+	userActionUpdate = "update"
+	userActionDelete = "delete"
+)
+
+// UsersImportResult reports the changes users import made or would make.
+type UsersImportResult struct {
+	Developer string       `json:"developer"`
+	DryRun    bool         `json:"dryRun,omitempty"`
+	InSync    bool         `json:"inSync"`
+	Changes   []UserChange `json:"changes"`
+	Skipped   []string     `json:"skipped,omitempty"`
+}
+
+// UserChange lists what import changes for one user.
+type UserChange struct {
+	Email             string                    `json:"email"`
+	Action            string                    `json:"action"`
+	AddPermissions    []string                  `json:"addPermissions,omitempty"`
+	RemovePermissions []string                  `json:"removePermissions,omitempty"`
+	ExpirationTime    *string                   `json:"expirationTime,omitempty"`
+	Grants            []grants.PackageGrantDiff `json:"grants,omitempty"`
+
+	// desired is the user from the import directory, nil for deletions.
+	desired *androidpublisher.User
+}
+
+// ExportCommand writes every user of a developer account to a directory.
+func ExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("users export", flag.ExitOnError)
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
+	outputDir := fs.String("dir", "./users", "Output directory for user JSON files")
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "gplay users export --developer <id> --dir <path>",
+		ShortHelp:  "Export users and their grants to JSON files.",
+		LongHelp: `Export the users of a developer account to a local directory.
+
+Each user is written to <dir>/<email>.json with their account permissions,
+expiration time, and app grants, in the format accepted by
+"gplay users import". Read-only fields such as name and accessState are
+left out.
+
+Examples:
+  gplay users export --developer 1234567890 --dir ./users`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
+				return fmt.Errorf("--developer is required")
+			}
+			if strings.TrimSpace(*outputDir) == "" {
+				return fmt.Errorf("--dir is required")
+			}
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			all, err := listAllUsers(ctx, service, developer)
+			if err != nil {
+				return fmt.Errorf("failed to list users: %w", err)
+			}
+
+			if err := os.MkdirAll(*outputDir, 0o755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+
+			for _, user := range all {
+				if user.Partial {
+					fmt.Fprintf(os.Stderr, "Warning: %s has permissions the API does not return; the export is partial\n", user.Email)
+				}
+				data, err := json.MarshalIndent(exportableUser(user), "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal %s: %w", user.Email, err)
+				}
+				path := filepath.Join(*outputDir, user.Email+".json")
+				if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", path, err)
+				}
+				fmt.Fprintf(os.Stderr, "Exported: %s\n", user.Email)
+			}
+
+			fmt.Fprintf(os.Stderr, "Exported %d users to %s\n", len(all), *outputDir)
+			return nil
+		},
+	}
+}
+
+// ImportCommand reconciles the users of a developer account with a
+// directory written by ExportCommand.
+func ImportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("users import", flag.ExitOnError)
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
+	inputDir := fs.String("dir", "./users", "Input directory with user JSON files")
+	dryRun := fs.Bool("dry-run", false, "Show the changes without applying them")
+	confirm := fs.Bool("confirm", false, "Apply the changes, including removing users missing from --dir")
+	includePartial := fs.Bool("include-partial", false, "Also update users whose permissions the API returns only in part")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "import",
+		ShortUsage: "gplay users import --developer <id> --dir <path> (--dry-run | --confirm)",
+		ShortHelp:  "Reconcile users and grants with JSON files.",
+		LongHelp: `Reconcile the users of a developer account with a local directory.
+
+Every <email>.json file in --dir is read as a user; the email comes from the
+file's "email", or from the file name when that is empty. The directory is
+authoritative:
+  - users missing from the account are created with their grants
+  - users whose account permissions, expiration time, or grants differ are
+    updated, and their grants created, patched, or deleted to match
+  - users in the account without a file are removed
+
+Users the API marks as partial have permissions it does not return, so
+updating them from a file could drop those permissions. Such users are
+skipped and listed under "skipped"; pass --include-partial to update them
+anyway.
+
+Pass --dry-run to print the changes, or --confirm to apply them.
+
+Examples:
+  gplay users import --developer 1234567890 --dir ./users --dry-run
+  gplay users import --developer 1234567890 --dir ./users --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
+				return fmt.Errorf("--developer is required")
+			}
+			if strings.TrimSpace(*inputDir) == "" {
+				return fmt.Errorf("--dir is required")
+			}
+			if *dryRun == *confirm {
+				return fmt.Errorf("pass exactly one of --dry-run or --confirm")
+			}
+			desired, err := readUserFiles(*inputDir)
+			if err != nil {
+				return err
+			}
+
+			service, err := newPlayService(ctx)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
+			defer cancel()
+
+			current, err := listAllUsers(ctx, service, developer)
+			if err != nil {
+				return fmt.Errorf("failed to list users: %w", err)
+			}
+
+			changes, skipped := planUserChanges(current, desired, *includePartial)
+			for _, email := range skipped {
+				fmt.Fprintf(os.Stderr, "Warning: skipping %s: it has permissions the API does not return; pass --include-partial to update it anyway\n", email)
+			}
+			result := &UsersImportResult{Developer: developer, DryRun: *dryRun, InSync: len(changes) == 0 && len(skipped) == 0, Changes: changes, Skipped: skipped}
+			if !*dryRun {
+				for i, change := range changes {
+					if err := applyUserChange(ctx, service, developer, change); err != nil {
+						return fmt.Errorf("failed to %s user %s after %d of %d changes: %w", change.Action, change.Email, i, len(changes), err)
+					}
+					fmt.Fprintf(os.Stderr, "%s: %s\n", actionPastTense(change.Action), change.Email)
+				}
+			}
+			return shared.PrintOutput(result, *outputFlag, *pretty)
+		},
+	}
+}
+
+// exportableUser copies the writable fields of user.
+func exportableUser(user *androidpublisher.User) *androidpublisher.User {
+	out := &androidpublisher.User{
+		Email:                       user.Email,
+		DeveloperAccountPermissions: user.DeveloperAccountPermissions,
+		ExpirationTime:              user.ExpirationTime,
+	}
+	for _, g := range user.Grants {
+		out.Grants = append(out.Grants, &androidpublisher.Grant{PackageName: g.PackageName, AppLevelPermissions: g.AppLevelPermissions})
+	}
+	return out
+}
+
+// readUserFiles parses every .json file in dir, in name order.
+func readUserFiles(dir string) ([]*androidpublisher.User, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input directory: %w", err)
+	}
+	var users []*androidpublisher.User
+	seen := map[string]string{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		var user androidpublisher.User
+		if err := shared.UnmarshalStrict(raw, &user); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if strings.TrimSpace(user.Email) == "" {
+			user.Email = strings.TrimSuffix(entry.Name(), ".json")
+		}
+		key := strings.ToLower(strings.TrimSpace(user.Email))
+		if other, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%s and %s both define user %s", other, entry.Name(), user.Email)
+		}
+		seen[key] = entry.Name()
+		users = append(users, &user)
+	}
+	return users, nil
+}
+
+// planUserChanges returns the changes that turn current into desired:
+// creations and updates in desired order, then deletions by email. Updates
+// to partial users are left out and their emails returned as skipped unless
+// includePartial is set.
+func planUserChanges(current, desired []*androidpublisher.User, includePartial bool) ([]UserChange, []string) {
+	byEmail := make(map[string]*androidpublisher.User, len(current))
+	for _, user := range current {
+		byEmail[strings.ToLower(strings.TrimSpace(user.Email))] = user
+	}
+
+	changes := []UserChange{}
+	var skipped []string
+	wanted := map[string]bool{}
+	for _, want := range desired {
+		key := strings.ToLower(strings.TrimSpace(want.Email))
+		wanted[key] = true
+		have, exists := byEmail[key]
+		if !exists {
+			changes = append(changes, UserChange{
+				Email:          want.Email,
+				Action:         userActionCreate,
+				AddPermissions: sortedPermissions(want.DeveloperAccountPermissions),
+				Grants:         grants.DiffGrants(nil, want.Grants),
+				desired:        want,
+			})
+			continue
+		}
+		change := UserChange{
+			Email:             have.Email,
+			Action:            userActionUpdate,
+			AddPermissions:    permissionsMissing(want.DeveloperAccountPermissions, have.DeveloperAccountPermissions),
+			RemovePermissions: permissionsMissing(have.DeveloperAccountPermissions, want.DeveloperAccountPermissions),
+			Grants:            grants.DiffGrants(have.Grants, want.Grants),
+			desired:           want,
+		}
+		if want.ExpirationTime != have.ExpirationTime {
+			expiration := want.ExpirationTime
+			change.ExpirationTime = &expiration
+		}
+		if len(change.AddPermissions) == 0 && len(change.RemovePermissions) == 0 && change.ExpirationTime == nil && len(change.Grants) == 0 {
+			continue
+		}
+		if have.Partial && !includePartial {
+			skipped = append(skipped, have.Email)
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	var removed []UserChange
+	for _, have := range current {
+		if wanted[strings.ToLower(strings.TrimSpace(have.Email))] {
+			continue
+		}
+		removed = append(removed, UserChange{
+			Email:             have.Email,
+			Action:            userActionDelete,
+			RemovePermissions: sortedPermissions(have.DeveloperAccountPermissions),
+			Grants:            grants.DiffGrants(have.Grants, nil),
+		})
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Email < removed[j].Email })
+	return append(changes, removed...), skipped
+}
+
+// applyUserChange makes the API calls for one planned change.
+func applyUserChange(ctx context.Context, service *playclient.Service, developer string, change UserChange) error {
+	name := fmt.Sprintf("developers/%s/users/%s", developer, change.Email)
+	switch change.Action {
+	case userActionCreate:
+		user := exportableUser(change.desired)
+		_, err := service.API.Users.Create(fmt.Sprintf("developers/%s", developer), user).Context(ctx).Do()
+		return err
+	case userActionDelete:
+		return service.API.Users.Delete(name).Context(ctx).Do()
+	}
+
+	var mask []string
+	if len(change.AddPermissions) > 0 || len(change.RemovePermissions) > 0 {
+		mask = append(mask, "developerAccountPermissions")
+	}
+	if change.ExpirationTime != nil {
+		mask = append(mask, "expirationTime")
+	}
+	if len(mask) > 0 {
+		patch := &androidpublisher.User{
+			DeveloperAccountPermissions: change.desired.DeveloperAccountPermissions,
+			ExpirationTime:              change.desired.ExpirationTime,
+			ForceSendFields:             []string{"DeveloperAccountPermissions"},
+		}
+		if _, err := service.API.Users.Patch(name, patch).Context(ctx).UpdateMask(strings.Join(mask, ",")).Do(); err != nil {
+			return err
+		}
+	}
+	for _, g := range change.Grants {
+		grantName := fmt.Sprintf("%s/grants/%s", name, g.PackageName)
+		grant := &androidpublisher.Grant{PackageName: g.PackageName, AppLevelPermissions: desiredGrantPermissions(change.desired, g.PackageName)}
+		var err error
+		switch g.Action {
+		case grants.DiffActionCreate:
+			_, err = service.API.Grants.Create(name, grant).Context(ctx).Do()
+		case grants.DiffActionUpdate:
+			_, err = service.API.Grants.Patch(grantName, grant).Context(ctx).UpdateMask("appLevelPermissions").Do()
+		case grants.DiffActionDelete:
+			err = service.API.Grants.Delete(grantName).Context(ctx).Do()
+		}
+		if err != nil {
+			return fmt.Errorf("%s grant on %s: %w", g.Action, g.PackageName, err)
+		}
+	}
+	return nil
+}
+
+func desiredGrantPermissions(user *androidpublisher.User, pkg string) []string {
+	for _, g := range user.Grants {
+		if g.PackageName == pkg {
+			return g.AppLevelPermissions
+		}
+	}
+	return nil
+}
+
+// listAllUsers fetches every user of developer across pages.
+func listAllUsers(ctx context.Context, service *playclient.Service, developer string) ([]*androidpublisher.User, error) {
+	parent := fmt.Sprintf("developers/%s", developer)
+	all, _, err := shared.FetchAllPages(ctx, 0, func(ctx context.Context, token string) ([]*androidpublisher.User, string, error) {
+		call := service.API.Users.List(parent).Context(ctx).PageSize(100)
+		if token != "" {
+			call.PageToken(token)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Users, resp.NextPageToken, nil
+	})
+	return all, err
+}
+
+// permissionsMissing returns the sorted permissions in a that b lacks.
+func permissionsMissing(a, b []string) []string {
+	have := map[string]bool{}
+	for _, p := range b {
+		have[strings.ToUpper(strings.TrimSpace(p))] = true
+	}
+	var out []string
+	for _, p := range sortedPermissions(a) {
+		if !have[p] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// sortedPermissions returns perms normalized, deduplicated, and sorted.
+func sortedPermissions(perms []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, p := range perms {
+		p = strings.ToUpper(strings.TrimSpace(p))
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func actionPastTense(action string) string {
+	switch action {
+	case userActionCreate:
+		return "Created"
+	case userActionDelete:
+		return "Removed"
+	default:
+		return "Updated"
+	}
+}
@@ -0,0 +1,264 @@
+package users
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/grants"
+	"github.com/tamtom/play-console-cli/internal/playclient"
+)
+
+const mockUsersList = `{"users":[
+	{"name":"developers/123/users/keep@example.com","email":"keep@example.com","accessState":"ACCESS_GRANTED","developerAccountPermissions":["CAN_SEE_ALL_APPS"],"grants":[{"name":"developers/123/users/keep@example.com/grants/com.example.app","packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP"]}]},
+	{"name":"developers/123/users/change@example.com","email":"change@example.com","developerAccountPermissions":["CAN_SEE_ALL_APPS","CAN_VIEW_FINANCIAL_DATA_GLOBAL"],"grants":[{"packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP"]},{"packageName":"com.example.old","appLevelPermissions":["CAN_ACCESS_APP"]}]},
+	{"name":"developers/123/users/gone@example.com","email":"gone@example.com","developerAccountPermissions":["CAN_REPLY_TO_REVIEWS_GLOBAL"]}
+]}`
+
+func TestPlanUserChanges(t *testing.T) {
+	var list androidpublisher.ListUsersResponse
+	if err := json.Unmarshal([]byte(mockUsersList), &list); err != nil {
+		t.Fatal(err)
+	}
+	desired := []*androidpublisher.User{
+		{Email: "keep@example.com", DeveloperAccountPermissions: []string{"CAN_SEE_ALL_APPS"}, Grants: []*androidpublisher.Grant{{PackageName: "com.example.app", AppLevelPermissions: []string{"CAN_ACCESS_APP"}}}},
+		{Email: "Change@example.com", DeveloperAccountPermissions: []string{"CAN_SEE_ALL_APPS", "CAN_MANAGE_ORDERS_GLOBAL"}, ExpirationTime: "2027-01-01T00:00:00Z", Grants: []*androidpublisher.Grant{{PackageName: "com.example.app", AppLevelPermissions: []string{"CAN_ACCESS_APP", "CAN_REPLY_TO_REVIEWS"}}}},
+		{Email: "new@example.com", DeveloperAccountPermissions: []string{"CAN_SEE_ALL_APPS"}},
+	}
+
+	changes, skipped := planUserChanges(list.Users, desired, false)
+	if len(skipped) != 0 {
+		t.Fatalf("expected no skipped users, got %v", skipped)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+
+	update := changes[0]
+	expiration := "2027-01-01T00:00:00Z"
+	wantGrants := []grants.PackageGrantDiff{
+		{PackageName: "com.example.app", Action: grants.DiffActionUpdate, Add: []string{"CAN_REPLY_TO_REVIEWS"}},
+		{PackageName: "com.example.old", Action: grants.DiffActionDelete, Remove: []string{"CAN_ACCESS_APP"}},
+	}
+	if update.Email != "change@example.com" || update.Action != userActionUpdate ||
+		!reflect.DeepEqual(update.AddPermissions, []string{"CAN_MANAGE_ORDERS_GLOBAL"}) ||
+		!reflect.DeepEqual(update.RemovePermissions, []string{"CAN_VIEW_FINANCIAL_DATA_GLOBAL"}) ||
+		update.ExpirationTime == nil || *update.ExpirationTime != expiration ||
+		!reflect.DeepEqual(update.Grants, wantGrants) {
+		t.Errorf("unexpected update: %+v", update)
+	}
+
+	create := changes[1]
+	if create.Email != "new@example.com" || create.Action != userActionCreate || !reflect.DeepEqual(create.AddPermissions, []string{"CAN_SEE_ALL_APPS"}) {
+		t.Errorf("unexpected create: %+v", create)
+	}
+
+	remove := changes[2]
+	if remove.Email != "gone@example.com" || remove.Action != userActionDelete || !reflect.DeepEqual(remove.RemovePermissions, []string{"CAN_REPLY_TO_REVIEWS_GLOBAL"}) {
+		t.Errorf("unexpected delete: %+v", remove)
+	}
+
+	if got, _ := planUserChanges(list.Users[:1], desired[:1], false); len(got) != 0 {
+		t.Errorf("expected no changes for a matching user, got %+v", got)
+	}
+}
+
+func TestPlanUserChanges_SkipsPartialUsers(t *testing.T) {
+	current := []*androidpublisher.User{
+		{Email: "partial@example.com", Partial: true, DeveloperAccountPermissions: []string{"CAN_SEE_ALL_APPS"}},
+	}
+	desired := []*androidpublisher.User{
+		{Email: "partial@example.com", DeveloperAccountPermissions: []string{"CAN_REPLY_TO_REVIEWS_GLOBAL"}},
+	}
+
+	changes, skipped := planUserChanges(current, desired, false)
+	if len(changes) != 0 || !reflect.DeepEqual(skipped, []string{"partial@example.com"}) {
+		t.Fatalf("expected partial user to be skipped, got changes %+v skipped %v", changes, skipped)
+	}
+
+	changes, skipped = planUserChanges(current, desired, true)
+	if len(changes) != 1 || changes[0].Action != userActionUpdate || len(skipped) != 0 {
+		t.Fatalf("expected --include-partial to plan the update, got changes %+v skipped %v", changes, skipped)
+	}
+}
+
+func TestExportCommand_WritesUserFiles(t *testing.T) {
+	installMockUsersPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, mockUsersList)
+	})
+	dir := t.TempDir()
+
+	cmd := ExportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123", "--dir", dir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Exec(context.Background(), nil); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "keep@example.com.json"))
+	if err != nil {
+		t.Fatalf("expected user file: %v", err)
+	}
+	if strings.Contains(string(data), "accessState") || strings.Contains(string(data), `"name"`) {
+		t.Errorf("read-only fields exported: %s", data)
+	}
+	users, err := readUserFiles(dir)
+	if err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("expected 3 users, got %d", len(users))
+	}
+}
+
+func TestImportCommand_DryRunAgainstMockedList(t *testing.T) {
+	var writes []string
+	var mu sync.Mutex
+	installMockUsersPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			mu.Lock()
+			writes = append(writes, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, mockUsersList)
+	})
+	dir := t.TempDir()
+	writeUserFile(t, dir, "keep@example.com.json", `{"developerAccountPermissions":["CAN_SEE_ALL_APPS"],"grants":[{"packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP"]}]}`)
+	writeUserFile(t, dir, "change@example.com.json", `{"email":"change@example.com","developerAccountPermissions":["CAN_SEE_ALL_APPS"],"grants":[{"packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP"]},{"packageName":"com.example.old","appLevelPermissions":["CAN_ACCESS_APP"]}]}`)
+	writeUserFile(t, dir, "new@example.com.json", `{"developerAccountPermissions":["CAN_SEE_ALL_APPS"]}`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123", "--dir", dir, "--dry-run"}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := captureUsersStdout(func() error { return cmd.Exec(context.Background(), nil) })
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(writes) != 0 {
+		t.Fatalf("dry run made write calls: %v", writes)
+	}
+
+	var result UsersImportResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal %q: %v", out, err)
+	}
+	actions := map[string]string{}
+	for _, c := range result.Changes {
+		actions[c.Email] = c.Action
+	}
+	want := map[string]string{"change@example.com": userActionUpdate, "new@example.com": userActionCreate, "gone@example.com": userActionDelete}
+	if !result.DryRun || !reflect.DeepEqual(actions, want) {
+		t.Fatalf("unexpected plan: %s", out)
+	}
+}
+
+func TestImportCommand_ConfirmApplies(t *testing.T) {
+	var writes []string
+	var mu sync.Mutex
+	installMockUsersPlayService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, mockUsersList)
+			return
+		}
+		mu.Lock()
+		writes = append(writes, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/androidpublisher/v3/")+" "+r.URL.Query().Get("updateMask"))
+		mu.Unlock()
+		_, _ = io.WriteString(w, `{}`)
+	})
+	dir := t.TempDir()
+	writeUserFile(t, dir, "keep@example.com.json", `{"developerAccountPermissions":["CAN_SEE_ALL_APPS"],"grants":[{"packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP"]}]}`)
+	writeUserFile(t, dir, "change@example.com.json", `{"developerAccountPermissions":["CAN_SEE_ALL_APPS"],"grants":[{"packageName":"com.example.app","appLevelPermissions":["CAN_ACCESS_APP","CAN_REPLY_TO_REVIEWS"]}]}`)
+
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123", "--dir", dir, "--confirm"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureUsersStdout(func() error { return cmd.Exec(context.Background(), nil) }); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	want := []string{
+		"PATCH developers/123/users/change@example.com developerAccountPermissions",
+		"PATCH developers/123/users/change@example.com/grants/com.example.app appLevelPermissions",
+		"DELETE developers/123/users/change@example.com/grants/com.example.old ",
+		"DELETE developers/123/users/gone@example.com ",
+	}
+	if !reflect.DeepEqual(writes, want) {
+		t.Fatalf("writes =\n%s\nwant\n%s", strings.Join(writes, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestImportCommand_RequiresDryRunOrConfirm(t *testing.T) {
+	cmd := ImportCommand()
+	if err := cmd.FlagSet.Parse([]string{"--developer", "123", "--dir", t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--dry-run or --confirm") {
+		t.Fatalf("expected --dry-run/--confirm error, got %v", err)
+	}
+}
+
+func writeUserFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func installMockUsersPlayService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := newPlayService
+	newPlayService = func(ctx context.Context) (*playclient.Service, error) {
+		return playclient.NewServiceWithClient(ctx, server.Client(), server.URL+"/")
+	}
+	t.Cleanup(func() {
+		newPlayService = original
+	})
+}
+
+func captureUsersStdout(fn func() error) (string, error) {
+	origStdout := os.Stdout
+	rOut, wOut, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	os.Stdout = wOut
+
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&buf, rOut)
+	}()
+
+	runErr := fn()
+
+	_ = wOut.Close()
+	os.Stdout = origStdout
+	wg.Wait()
+	_ = rOut.Close()
+
+	return buf.String(), runErr
+}
@@ -0,0 +1,36 @@
+package users
+
+import (
+	"strings"
+
+	"github.com/tamtom/play-console-cli/internal/output"
+)
+
+func init() {
+	output.RegisterType(&UsersImportResult{}, []string{"Email", "Action", "Account Permissions", "Expiration", "Grants"}, func(data any) [][]string {
+		result := data.(*UsersImportResult)
+		rows := make([][]string, 0, len(result.Changes))
+		for _, c := range result.Changes {
+			var perms []string
+			for _, p := range c.AddPermissions {
+				perms = append(perms, "+"+p)
+			}
+			for _, p := range c.RemovePermissions {
+				perms = append(perms, "-"+p)
+			}
+			expiration := ""
+			if c.ExpirationTime != nil {
+				expiration = *c.ExpirationTime
+				if expiration == "" {
+					expiration = "(cleared)"
+				}
+			}
+			var grants []string
+			for _, g := range c.Grants {
+				grants = append(grants, g.Action+" "+g.PackageName)
+			}
+			rows = append(rows, []string{c.Email, c.Action, strings.Join(perms, ", "), expiration, strings.Join(grants, ", ")})
+		}
+		return rows
+	})
+}
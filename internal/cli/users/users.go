@@ -10,9 +10,13 @@ import (
 	"google.golang.org/api/androidpublisher/v3"
 
 	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/config"
 	"github.com/tamtom/play-console-cli/internal/playclient"
 )
 
+// newPlayService is swapped out by tests.
+var newPlayService = playclient.NewService
+
 func UsersCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("users", flag.ExitOnError)
 	return &ffcli.Command{
@@ -30,6 +34,8 @@ developer account with various permission levels.`,
 			CreateCommand(),
 			UpdateCommand(),
 			DeleteCommand(),
+			ExportCommand(),
+			ImportCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if len(args) == 0 {
@@ -42,10 +48,10 @@ developer account with various permission levels.`,
 
 func ListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("users list", flag.ExitOnError)
-	developerID := fs.String("developer", "", "Developer ID (from Play Console URL)")
+	developerID := fs.String("developer", "", "Developer ID from the Play Console URL (defaults to the profile's default_developer or developer_id in config)")
 	pageSize := fs.Int("page-size", 100, "Page size")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -58,7 +64,9 @@ func ListCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*developerID) == "" {
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
 				return fmt.Errorf("--developer is required")
 			}
 			service, err := playclient.NewService(ctx)
@@ -69,7 +77,7 @@ func ListCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			parent := fmt.Sprintf("developers/%s", *developerID)
+			parent := fmt.Sprintf("developers/%s", developer)
 			var all []*androidpublisher.User
 			pageToken := ""
 			for {
@@ -98,10 +106,10 @@ func ListCommand() *ffcli.Command {
 
 func CreateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("users create", flag.ExitOnError)
-	developerID := fs.String("developer", "", "Developer ID")
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
 	email := fs.String("email", "", "User email address")
-	jsonFlag := fs.String("json", "", "User permissions JSON (or @file)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	jsonFlag := fs.String("json", "", "User permissions JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -140,7 +148,9 @@ Available account permissions:
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*developerID) == "" {
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
 				return fmt.Errorf("--developer is required")
 			}
 			if strings.TrimSpace(*email) == "" {
@@ -163,7 +173,7 @@ Available account permissions:
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			parent := fmt.Sprintf("developers/%s", *developerID)
+			parent := fmt.Sprintf("developers/%s", developer)
 			resp, err := service.API.Users.Create(parent, &user).Context(ctx).Do()
 			if err != nil {
 				return err
@@ -175,11 +185,11 @@ Available account permissions:
 
 func UpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("users update", flag.ExitOnError)
-	developerID := fs.String("developer", "", "Developer ID")
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
 	email := fs.String("email", "", "User email address")
-	jsonFlag := fs.String("json", "", "Updated user permissions JSON (or @file)")
+	jsonFlag := fs.String("json", "", "Updated user permissions JSON (or @file, - for stdin)")
 	updateMask := fs.String("update-mask", "", "Fields to update (comma-separated)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -220,7 +230,9 @@ fields in the request body are applied.`,
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*developerID) == "" {
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
 				return fmt.Errorf("--developer is required")
 			}
 			if strings.TrimSpace(*email) == "" {
@@ -229,6 +241,11 @@ fields in the request body are applied.`,
 			if strings.TrimSpace(*jsonFlag) == "" {
 				return fmt.Errorf("--json is required")
 			}
+			if strings.TrimSpace(*updateMask) != "" {
+				if err := shared.ValidateUpdateMask(*updateMask, &androidpublisher.User{}); err != nil {
+					return err
+				}
+			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
 				return err
@@ -242,7 +259,7 @@ fields in the request body are applied.`,
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			name := fmt.Sprintf("developers/%s/users/%s", *developerID, *email)
+			name := fmt.Sprintf("developers/%s/users/%s", developer, *email)
 			call := service.API.Users.Patch(name, &user).Context(ctx)
 			if strings.TrimSpace(*updateMask) != "" {
 				call.UpdateMask(*updateMask)
@@ -258,10 +275,10 @@ fields in the request body are applied.`,
 
 func DeleteCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("users delete", flag.ExitOnError)
-	developerID := fs.String("developer", "", "Developer ID")
+	developerID := fs.String("developer", "", "Developer ID (defaults to the profile's default_developer or developer_id in config)")
 	email := fs.String("email", "", "User email address")
-	confirm := fs.Bool("confirm", false, "Confirm deletion")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	confirm := fs.Bool("confirm", false, "Confirm deletion (prompts when omitted on a terminal)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -274,14 +291,18 @@ func DeleteCommand() *ffcli.Command {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
 				return err
 			}
-			if strings.TrimSpace(*developerID) == "" {
+			cfg, _ := config.Load()
+			developer := shared.ResolveDeveloperID(*developerID, cfg)
+			if developer == "" {
 				return fmt.Errorf("--developer is required")
 			}
 			if strings.TrimSpace(*email) == "" {
 				return fmt.Errorf("--email is required")
 			}
 			if !*confirm {
-				return fmt.Errorf("--confirm is required")
+				if err := shared.RequireConfirmation(ctx, fmt.Sprintf("Remove user %s?", *email), "--confirm"); err != nil {
+					return err
+				}
 			}
 			service, err := playclient.NewService(ctx)
 			if err != nil {
@@ -291,7 +312,7 @@ func DeleteCommand() *ffcli.Command {
 			ctx, cancel := shared.ContextWithTimeout(ctx, service.Cfg)
 			defer cancel()
 
-			name := fmt.Sprintf("developers/%s/users/%s", *developerID, *email)
+			name := fmt.Sprintf("developers/%s/users/%s", developer, *email)
 			err = service.API.Users.Delete(name).Context(ctx).Do()
 			if err != nil {
 				return err
@@ -17,13 +17,13 @@ func TestUsersCommand_Name(t *testing.T) {
 	}
 }
 
-func TestUsersCommand_HasFourSubcommands(t *testing.T) {
+func TestUsersCommand_HasSixSubcommands(t *testing.T) {
 	cmd := UsersCommand()
-	if len(cmd.Subcommands) != 4 {
-		t.Fatalf("expected 4 subcommands, got %d", len(cmd.Subcommands))
+	if len(cmd.Subcommands) != 6 {
+		t.Fatalf("expected 6 subcommands, got %d", len(cmd.Subcommands))
 	}
 
-	want := map[string]bool{"list": true, "create": true, "update": true, "delete": true}
+	want := map[string]bool{"list": true, "create": true, "update": true, "delete": true, "export": true, "import": true}
 	for _, sub := range cmd.Subcommands {
 		if !want[sub.Name] {
 			t.Errorf("unexpected subcommand %q", sub.Name)
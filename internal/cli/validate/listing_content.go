@@ -0,0 +1,104 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// allowedDescriptionTags are the HTML tags Google Play renders in a full
+// description. Any other tag is rejected when the listing is saved.
+var allowedDescriptionTags = map[string]bool{
+	"b":      true,
+	"strong": true,
+	"i":      true,
+	"em":     true,
+	"u":      true,
+	"br":     true,
+	"p":      true,
+	"ul":     true,
+	"ol":     true,
+	"li":     true,
+}
+
+var (
+	htmlTagPattern         = regexp.MustCompile(`<\s*/?\s*([a-zA-Z][a-zA-Z0-9]*)[^>]*>`)
+	repeatedBlanksPattern  = regexp.MustCompile(`\n[ \t]*\n[ \t]*\n`)
+	allowedDescriptionList = sortedAllowedTags()
+)
+
+func sortedAllowedTags() string {
+	tags := make([]string, 0, len(allowedDescriptionTags))
+	for tag := range allowedDescriptionTags {
+		tags = append(tags, "<"+tag+">")
+	}
+	sort.Strings(tags)
+	return strings.Join(tags, ", ")
+}
+
+// disallowedTags returns the distinct tags in text that Play does not allow,
+// lowercased and in order of first appearance.
+func disallowedTags(text string) []string {
+	var found []string
+	seen := map[string]bool{}
+	for _, m := range htmlTagPattern.FindAllStringSubmatch(text, -1) {
+		tag := strings.ToLower(m[1])
+		if allowedDescriptionTags[tag] || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		found = append(found, tag)
+	}
+	return found
+}
+
+// hasControlChars reports whether text contains control characters other
+// than tabs and line breaks.
+func hasControlChars(text string) bool {
+	return strings.IndexFunc(text, func(r rune) bool {
+		return unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t'
+	}) >= 0
+}
+
+// checkSingleLineField validates a title or short description, which must
+// not contain line breaks or control characters.
+func checkSingleLineField(result *LocaleValidationResult, label, value string) {
+	if strings.ContainsAny(value, "\r\n") {
+		result.Valid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("%s must be a single line", label))
+	}
+	if hasControlChars(value) {
+		result.Valid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("%s contains control characters", label))
+	}
+}
+
+// checkFullDescriptionContent validates the formatting of a full description.
+// raw is the text as written, before surrounding whitespace is trimmed.
+func checkFullDescriptionContent(result *LocaleValidationResult, raw string) {
+	if tags := disallowedTags(raw); len(tags) > 0 {
+		result.Valid = false
+		for _, tag := range tags {
+			result.Errors = append(result.Errors, fmt.Sprintf("Full description contains disallowed HTML tag <%s> (allowed: %s)", tag, allowedDescriptionList))
+		}
+	}
+	if hasControlChars(raw) {
+		result.Valid = false
+		result.Errors = append(result.Errors, "Full description contains control characters")
+	}
+	if raw != strings.TrimSpace(raw) {
+		result.Warnings = append(result.Warnings, "Full description has leading or trailing whitespace")
+	}
+	if repeatedBlanksPattern.MatchString(strings.ReplaceAll(raw, "\r\n", "\n")) {
+		result.Warnings = append(result.Warnings, "Full description has repeated blank lines")
+	}
+}
+
+// trimFinalNewline drops the single line ending editors add at the end of a
+// text file, so it is not reported as trailing whitespace.
+func trimFinalNewline(s string) string {
+	s = strings.TrimSuffix(s, "\n")
+	return strings.TrimSuffix(s, "\r")
+}
@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDisallowedTags(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"plain text", "Just text, 5 < 6 and 7 > 3", nil},
+		{"allowed tags", "<b>Bold</b> <i>it</i> <u>u</u><br><br/><strong>s</strong> <em>e</em>", nil},
+		{"allowed list", "<p>Features:</p><ul><li>One</li></ul><ol><li>Two</li></ol>", nil},
+		{"uppercase allowed", "<B>Bold</B><BR />", nil},
+		{"script", "Hi <script>alert(1)</script>", []string{"script"}},
+		{"link and image", `<a href="https://example.com">x</a><img src="x.png">`, []string{"a", "img"}},
+		{"mixed reports once", "<b>ok</b><div>one</div><div>two</div><font color=red>f</font>", []string{"div", "font"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := disallowedTags(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("disallowedTags(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckFullDescriptionContent(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantValid    bool
+		wantError    string
+		wantWarnings []string
+	}{
+		{name: "clean", text: "<b>Great</b> app.\n\nTry it.", wantValid: true},
+		{name: "disallowed tag", text: "<h1>Title</h1>", wantError: "disallowed HTML tag <h1>"},
+		{name: "control character", text: "Bell\x07", wantError: "control characters"},
+		{name: "surrounding whitespace", text: "  Text\n", wantValid: true, wantWarnings: []string{"Full description has leading or trailing whitespace"}},
+		{name: "repeated blank lines", text: "One\n\n\nTwo", wantValid: true, wantWarnings: []string{"Full description has repeated blank lines"}},
+		{name: "crlf blank lines", text: "One\r\n\r\n\r\nTwo", wantValid: true, wantWarnings: []string{"Full description has repeated blank lines"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &LocaleValidationResult{Valid: true}
+			checkFullDescriptionContent(result, tt.text)
+			if tt.wantError != "" {
+				if result.Valid || len(result.Errors) == 0 || !strings.Contains(result.Errors[0], tt.wantError) {
+					t.Fatalf("expected error containing %q, got valid=%v errors=%v", tt.wantError, result.Valid, result.Errors)
+				}
+				return
+			}
+			if result.Valid != tt.wantValid || len(result.Errors) != 0 {
+				t.Fatalf("valid=%v errors=%v", result.Valid, result.Errors)
+			}
+			if !reflect.DeepEqual(result.Warnings, tt.wantWarnings) {
+				t.Fatalf("warnings = %v, want %v", result.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestValidateLocaleListing_ContentChecks(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"title.txt":             "My App\n",
+		"short_description.txt": "Line one\nLine two\n",
+		"full_description.txt":  "<b>Bold</b> and <marquee>moving</marquee>\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result := validateLocaleListing(dir, "fastlane")
+	if result.Valid {
+		t.Fatal("expected listing to be invalid")
+	}
+	joined := strings.Join(result.Errors, "\n")
+	if !strings.Contains(joined, "Short description must be a single line") {
+		t.Errorf("expected short description newline error, got %v", result.Errors)
+	}
+	if !strings.Contains(joined, "<marquee>") {
+		t.Errorf("expected disallowed tag error, got %v", result.Errors)
+	}
+	if strings.Contains(joined, "Title") {
+		t.Errorf("title with a final newline should pass, got %v", result.Errors)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("final newline should not warn, got %v", result.Warnings)
+	}
+}
+
+func TestValidateLocaleListing_JSONTitleNewline(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "listing.json"), []byte(`{"title":"My\nApp"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result := validateLocaleListing(dir, "json")
+	if result.Valid || !strings.Contains(strings.Join(result.Errors, "\n"), "Title must be a single line") {
+		t.Fatalf("expected title newline error, got valid=%v errors=%v", result.Valid, result.Errors)
+	}
+}
@@ -0,0 +1,204 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+)
+
+// maxOfferPhases is the most phases Play accepts on a subscription offer.
+const maxOfferPhases = 2
+
+// PhasesCommand returns the "validate phases" subcommand, a local check of
+// subscription offer phases.
+func PhasesCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("validate phases", flag.ExitOnError)
+	jsonFlag := fs.String("json", "", "Offer or phases JSON (or @file, - for stdin)")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "phases",
+		ShortUsage: "gplay validate phases --json <json>",
+		ShortHelp:  "Validate subscription offer phases locally.",
+		LongHelp: `Validate the phases of a subscription offer without calling the API.
+
+--json accepts an offer (as used by gplay offers create) or a bare array
+of phases.
+
+Checks:
+- At least one and at most 2 phases
+- Each phase has an ISO 8601 duration and a recurrenceCount of 1 or more
+- A free trial is a single period, comes first, and appears at most once
+- Each regional config sets exactly one of free, price, relativeDiscount,
+  or absoluteDiscount, and relativeDiscount is between 0 and 1
+- No region is configured twice in a phase, and a phase is not part free
+  and part paid
+- Every phase covers the same regions (warning)
+
+Examples:
+  gplay validate phases --json @offer.json
+  gplay validate phases --json '[{"duration":"P7D","recurrenceCount":1,"regionalConfigs":[{"regionCode":"US","free":{}}]}]'`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
+				return err
+			}
+			if strings.TrimSpace(*jsonFlag) == "" {
+				return fmt.Errorf("--json is required")
+			}
+			raw, err := shared.LoadJSONArgRaw(*jsonFlag)
+			if err != nil {
+				return fmt.Errorf("invalid JSON: %w", err)
+			}
+			phases, err := parseOfferPhases(raw)
+			if err != nil {
+				return err
+			}
+			return shared.PrintOutput(validatePhases(phases), *outputFlag, *pretty)
+		},
+	}
+}
+
+// parseOfferPhases reads either a SubscriptionOffer or an array of phases.
+func parseOfferPhases(raw []byte) ([]*androidpublisher.SubscriptionOfferPhase, error) {
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "[") {
+		var phases []*androidpublisher.SubscriptionOfferPhase
+		if err := json.Unmarshal(raw, &phases); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return phases, nil
+	}
+	var offer androidpublisher.SubscriptionOffer
+	if err := json.Unmarshal(raw, &offer); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return offer.Phases, nil
+}
+
+func validatePhases(phases []*androidpublisher.SubscriptionOfferPhase) *ValidationResult {
+	result := newValidationResult()
+	result.Details["phaseCount"] = len(phases)
+
+	fail := func(format string, args ...interface{}) {
+		result.Valid = false
+		result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+	}
+
+	if len(phases) == 0 {
+		fail("Offer has no phases")
+		return result
+	}
+	if len(phases) > maxOfferPhases {
+		fail("Offer has %d phases, the maximum is %d", len(phases), maxOfferPhases)
+	}
+
+	var firstRegions []string
+	seenPaid := false
+	freeCount := 0
+	for i, phase := range phases {
+		n := i + 1
+		if phase == nil {
+			fail("Phase %d is empty", n)
+			continue
+		}
+		if !shared.IsISODuration(phase.Duration) {
+			fail("Phase %d: duration %q is not an ISO 8601 duration (e.g. P7D, P1M)", n, phase.Duration)
+		}
+		if phase.RecurrenceCount < 1 {
+			fail("Phase %d: recurrenceCount must be 1 or more, got %d", n, phase.RecurrenceCount)
+		}
+
+		free, paid, regions := checkPhaseRegions(phase, n, fail)
+		switch {
+		case free && paid:
+			fail("Phase %d mixes free and paid regional configs", n)
+		case free:
+			freeCount++
+			if seenPaid {
+				fail("Phase %d: a free trial must come before paid phases", n)
+			}
+			if phase.RecurrenceCount > 1 {
+				fail("Phase %d: a free trial must have recurrenceCount 1, got %d", n, phase.RecurrenceCount)
+			}
+		case paid:
+			seenPaid = true
+		}
+
+		if i == 0 {
+			firstRegions = regions
+			result.Details["regions"] = regions
+		} else if strings.Join(regions, ",") != strings.Join(firstRegions, ",") {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Phase %d covers regions %s, phase 1 covers %s", n, describeRegions(regions), describeRegions(firstRegions)))
+		}
+	}
+	if freeCount > 1 {
+		fail("Offer has %d free trial phases, only one is allowed", freeCount)
+	}
+	return result
+}
+
+// checkPhaseRegions validates the regional configs of one phase and reports
+// whether any region is free or paid, along with the sorted region codes.
+func checkPhaseRegions(phase *androidpublisher.SubscriptionOfferPhase, n int, fail func(string, ...interface{})) (free, paid bool, regions []string) {
+	seen := map[string]bool{}
+	for _, cfg := range phase.RegionalConfigs {
+		if cfg == nil {
+			continue
+		}
+		region := strings.ToUpper(strings.TrimSpace(cfg.RegionCode))
+		if region == "" {
+			fail("Phase %d: regional config without regionCode", n)
+			continue
+		}
+		if seen[region] {
+			fail("Phase %d: region %s is configured more than once", n, region)
+			continue
+		}
+		seen[region] = true
+		regions = append(regions, region)
+
+		modes := 0
+		if cfg.Free != nil {
+			modes++
+			free = true
+		}
+		if cfg.Price != nil {
+			modes++
+			paid = true
+		}
+		if cfg.AbsoluteDiscount != nil {
+			modes++
+			paid = true
+		}
+		if cfg.RelativeDiscount != 0 {
+			modes++
+			paid = true
+			if cfg.RelativeDiscount <= 0 || cfg.RelativeDiscount >= 1 {
+				fail("Phase %d, region %s: relativeDiscount must be between 0 and 1, got %g", n, region, cfg.RelativeDiscount)
+			}
+		}
+		if modes != 1 {
+			fail("Phase %d, region %s: set exactly one of free, price, relativeDiscount, or absoluteDiscount", n, region)
+		}
+	}
+	sort.Strings(regions)
+	return free, paid, regions
+}
+
+func describeRegions(regions []string) string {
+	if len(regions) == 0 {
+		return "(none)"
+	}
+	return strings.Join(regions, ",")
+}
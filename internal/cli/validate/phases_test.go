@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func phasesResult(t *testing.T, raw string) *ValidationResult {
+	t.Helper()
+	phases, err := parseOfferPhases([]byte(raw))
+	if err != nil {
+		t.Fatalf("parseOfferPhases: %v", err)
+	}
+	return validatePhases(phases)
+}
+
+func TestValidatePhases_ValidTrialThenIntroPrice(t *testing.T) {
+	result := phasesResult(t, `{"offerId":"intro","phases":[
+		{"duration":"P7D","recurrenceCount":1,"regionalConfigs":[{"regionCode":"US","free":{}},{"regionCode":"DE","free":{}}]},
+		{"duration":"P1M","recurrenceCount":3,"regionalConfigs":[{"regionCode":"US","price":{"currencyCode":"USD","units":"1"}},{"regionCode":"DE","relativeDiscount":0.5}]}
+	]}`)
+	if !result.Valid || len(result.Errors) != 0 || len(result.Warnings) != 0 {
+		t.Fatalf("expected valid phases, got %+v", result)
+	}
+	if result.Details["phaseCount"] != 2 {
+		t.Errorf("phaseCount = %v", result.Details["phaseCount"])
+	}
+}
+
+func TestValidatePhases_FreeTrialAfterPaidPhase(t *testing.T) {
+	result := phasesResult(t, `[
+		{"duration":"P1M","recurrenceCount":1,"regionalConfigs":[{"regionCode":"US","relativeDiscount":0.5}]},
+		{"duration":"P7D","recurrenceCount":1,"regionalConfigs":[{"regionCode":"US","free":{}}]}
+	]`)
+	if result.Valid {
+		t.Fatal("expected misordered phases to be invalid")
+	}
+	if !strings.Contains(strings.Join(result.Errors, "\n"), "Phase 2: a free trial must come before paid phases") {
+		t.Errorf("unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestValidatePhases_ReportsRegionAndRecurrenceErrors(t *testing.T) {
+	result := phasesResult(t, `[
+		{"duration":"7 days","recurrenceCount":2,"regionalConfigs":[
+			{"regionCode":"US","free":{}},
+			{"regionCode":"us","free":{}},
+			{"regionCode":"DE","free":{},"price":{"currencyCode":"EUR","units":"1"}}
+		]}
+	]`)
+	errs := strings.Join(result.Errors, "\n")
+	for _, want := range []string{
+		`duration "7 days" is not an ISO 8601 duration`,
+		"region US is configured more than once",
+		"region DE: set exactly one of",
+		"Phase 1 mixes free and paid regional configs",
+	} {
+		if !strings.Contains(errs, want) {
+			t.Errorf("missing error %q in:\n%s", want, errs)
+		}
+	}
+}
+
+func TestValidatePhases_LimitsAndRegionCoverage(t *testing.T) {
+	result := phasesResult(t, `[
+		{"duration":"P3D","recurrenceCount":2,"regionalConfigs":[{"regionCode":"US","free":{}}]},
+		{"duration":"P1M","recurrenceCount":0,"regionalConfigs":[{"regionCode":"US","relativeDiscount":1.5},{"regionCode":"FR","relativeDiscount":0.2}]},
+		{"duration":"P1M","recurrenceCount":1,"regionalConfigs":[{"regionCode":"US","relativeDiscount":0.2}]}
+	]`)
+	errs := strings.Join(result.Errors, "\n")
+	for _, want := range []string{
+		"Offer has 3 phases, the maximum is 2",
+		"a free trial must have recurrenceCount 1, got 2",
+		"Phase 2: recurrenceCount must be 1 or more",
+		"relativeDiscount must be between 0 and 1",
+	} {
+		if !strings.Contains(errs, want) {
+			t.Errorf("missing error %q in:\n%s", want, errs)
+		}
+	}
+	if !strings.Contains(strings.Join(result.Warnings, "\n"), "Phase 2 covers regions FR,US, phase 1 covers US") {
+		t.Errorf("expected region coverage warning, got %v", result.Warnings)
+	}
+
+	if empty := phasesResult(t, `{"phases":[]}`); empty.Valid {
+		t.Error("expected an offer without phases to be invalid")
+	}
+}
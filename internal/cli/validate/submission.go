@@ -18,7 +18,7 @@ func SubmissionCommand() *ffcli.Command {
 	track := fs.String("track", "production", "Target track to validate")
 	releaseNotes := fs.String("release-notes", "", "Release notes input: plain text, JSON array, or @file")
 	format := fs.String("format", "fastlane", "Metadata format: fastlane (default), json")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 	strict := fs.Bool("strict", false, "Treat warnings as failures")
 
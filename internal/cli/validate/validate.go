@@ -39,7 +39,7 @@ func ValidateCommand() *ffcli.Command {
 	screenshotsDir := fs.String("screenshots-dir", "", "Directory containing screenshots grouped by locale/device type")
 	releaseNotes := fs.String("release-notes", "", "Release notes input: plain text, JSON array, or @file")
 	strict := fs.Bool("strict", false, "Treat warnings as failures")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -59,7 +59,10 @@ Legacy local-only validators remain available as subcommands:
   gplay validate bundle
   gplay validate listing
   gplay validate screenshots
-  gplay validate submission`,
+  gplay validate submission
+
+Subscription offer phases can be checked locally before submission:
+  gplay validate phases`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
@@ -67,6 +70,7 @@ Legacy local-only validators remain available as subcommands:
 			ListingCommand(),
 			ScreenshotsCommand(),
 			SubmissionCommand(),
+			PhasesCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if err := shared.ValidateOutputFlags(*outputFlag, *pretty); err != nil {
@@ -113,7 +117,7 @@ Legacy local-only validators remain available as subcommands:
 func BundleCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("validate bundle", flag.ExitOnError)
 	filePath := fs.String("file", "", "Path to .aab bundle file")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -148,7 +152,7 @@ func ListingCommand() *ffcli.Command {
 	dir := fs.String("dir", "./metadata", "Directory containing listing metadata")
 	locale := fs.String("locale", "", "Specific locale to validate (optional)")
 	format := fs.String("format", "fastlane", "Metadata format: fastlane (default), json")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -162,7 +166,12 @@ Checks:
 - Short description length (max 80 characters)
 - Full description length (max 4000 characters)
 - Required fields present
-- Valid UTF-8 encoding`,
+- Valid UTF-8 encoding
+- Title and short description on a single line, without control characters
+- Full description uses only HTML tags Play allows (<b>, <strong>, <i>,
+  <em>, <u>, <br>, <p>, <ul>, <ol>, <li>) and no control characters
+- Warnings for leading/trailing whitespace and repeated blank lines in the
+  full description`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -180,7 +189,7 @@ func ScreenshotsCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("validate screenshots", flag.ExitOnError)
 	dir := fs.String("dir", "./metadata", "Directory containing screenshots")
 	locale := fs.String("locale", "", "Specific locale to validate (optional)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -207,18 +216,35 @@ Checks:
 	}
 }
 
+// ValidationSchemaVersion is the version of the ValidationResult JSON shape.
+// Bump it whenever a field is added, removed, or changes meaning so CI
+// parsers can adapt.
+const ValidationSchemaVersion = 1
+
+// ValidationResult is the output of validate bundle, listing, and screenshots.
 type ValidationResult struct {
-	Valid    bool                   `json:"valid"`
-	Errors   []string               `json:"errors,omitempty"`
-	Warnings []string               `json:"warnings,omitempty"`
-	Details  map[string]interface{} `json:"details,omitempty"`
+	SchemaVersion int                    `json:"schemaVersion"`
+	Valid         bool                   `json:"valid"`
+	Errors        []string               `json:"errors,omitempty"`
+	Warnings      []string               `json:"warnings,omitempty"`
+	Details       map[string]interface{} `json:"details,omitempty"`
 }
 
-func validateBundle(filePath string) *ValidationResult {
-	result := &ValidationResult{
-		Valid:   true,
-		Details: make(map[string]interface{}),
+func newValidationResult() *ValidationResult {
+	return &ValidationResult{
+		SchemaVersion: ValidationSchemaVersion,
+		Valid:         true,
+		Details:       make(map[string]interface{}),
 	}
+}
+
+// ValidateBundleFile runs the local .aab checks behind "gplay validate bundle".
+func ValidateBundleFile(filePath string) *ValidationResult {
+	return validateBundle(filePath)
+}
+
+func validateBundle(filePath string) *ValidationResult {
+	result := newValidationResult()
 
 	// Check file exists
 	info, err := os.Stat(filePath)
@@ -273,10 +299,7 @@ func validateBundle(filePath string) *ValidationResult {
 }
 
 func validateListings(dir, locale, format string) *ValidationResult {
-	result := &ValidationResult{
-		Valid:   true,
-		Details: make(map[string]interface{}),
-	}
+	result := newValidationResult()
 
 	localeResults := make(map[string]interface{})
 
@@ -363,6 +386,7 @@ func validateLocaleListing(localeDir, format string) *LocaleValidationResult {
 				result.Valid = false
 				result.Errors = append(result.Errors, fmt.Sprintf("Title too long: %d/%d characters", result.Title.Length, maxTitleLength))
 			}
+			checkSingleLineField(result, "Title", title)
 		}
 
 		if shortDesc, ok := listing["shortDescription"].(string); ok {
@@ -371,6 +395,7 @@ func validateLocaleListing(localeDir, format string) *LocaleValidationResult {
 				result.Valid = false
 				result.Errors = append(result.Errors, fmt.Sprintf("Short description too long: %d/%d characters", result.ShortDescription.Length, maxShortDescriptionLength))
 			}
+			checkSingleLineField(result, "Short description", shortDesc)
 		}
 
 		if fullDesc, ok := listing["fullDescription"].(string); ok {
@@ -379,6 +404,7 @@ func validateLocaleListing(localeDir, format string) *LocaleValidationResult {
 				result.Valid = false
 				result.Errors = append(result.Errors, fmt.Sprintf("Full description too long: %d/%d characters", result.FullDescription.Length, maxFullDescriptionLength))
 			}
+			checkFullDescriptionContent(result, fullDesc)
 		}
 
 		return result
@@ -401,6 +427,7 @@ func validateLocaleListing(localeDir, format string) *LocaleValidationResult {
 			result.Valid = false
 			result.Errors = append(result.Errors, fmt.Sprintf("Title too long: %d/%d characters", result.Title.Length, maxTitleLength))
 		}
+		checkSingleLineField(result, "Title", title)
 	} else if !os.IsNotExist(err) {
 		result.Warnings = append(result.Warnings, "Cannot read title.txt")
 	}
@@ -417,6 +444,7 @@ func validateLocaleListing(localeDir, format string) *LocaleValidationResult {
 			result.Valid = false
 			result.Errors = append(result.Errors, fmt.Sprintf("Short description too long: %d/%d characters", result.ShortDescription.Length, maxShortDescriptionLength))
 		}
+		checkSingleLineField(result, "Short description", shortDesc)
 	} else if !os.IsNotExist(err) {
 		result.Warnings = append(result.Warnings, "Cannot read short_description.txt")
 	}
@@ -433,6 +461,7 @@ func validateLocaleListing(localeDir, format string) *LocaleValidationResult {
 			result.Valid = false
 			result.Errors = append(result.Errors, fmt.Sprintf("Full description too long: %d/%d characters", result.FullDescription.Length, maxFullDescriptionLength))
 		}
+		checkFullDescriptionContent(result, trimFinalNewline(string(fullData)))
 	} else if !os.IsNotExist(err) {
 		result.Warnings = append(result.Warnings, "Cannot read full_description.txt")
 	}
@@ -451,10 +480,7 @@ func validateField(content string, maxLength int, required bool) *FieldValidatio
 }
 
 func validateScreenshots(dir, locale string) *ValidationResult {
-	result := &ValidationResult{
-		Valid:   true,
-		Details: make(map[string]interface{}),
-	}
+	result := newValidationResult()
 
 	var locales []string
 	if locale != "" {
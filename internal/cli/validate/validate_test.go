@@ -2,6 +2,7 @@ package validate
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"strings"
@@ -45,6 +46,7 @@ func TestValidateCommand_SubcommandNames(t *testing.T) {
 		"listing":     false,
 		"screenshots": false,
 		"submission":  false,
+		"phases":      false,
 	}
 	for _, sub := range cmd.Subcommands {
 		if _, ok := expected[sub.Name]; ok {
@@ -177,3 +179,27 @@ func TestScreenshotsCommand_Name(t *testing.T) {
 		t.Errorf("expected name %q, got %q", "screenshots", cmd.Name)
 	}
 }
+
+func TestValidationResult_IncludesSchemaVersion(t *testing.T) {
+	results := map[string]*ValidationResult{
+		"bundle":      validateBundle(t.TempDir() + "/missing.aab"),
+		"listing":     validateListings(t.TempDir(), "", "fastlane"),
+		"screenshots": validateScreenshots(t.TempDir(), ""),
+	}
+	for name, result := range results {
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("%s: marshal: %v", name, err)
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s: unmarshal: %v", name, err)
+		}
+		if got, ok := decoded["schemaVersion"].(float64); !ok || int(got) != ValidationSchemaVersion {
+			t.Errorf("%s: schemaVersion = %v, want %d", name, decoded["schemaVersion"], ValidationSchemaVersion)
+		}
+	}
+	if ValidationSchemaVersion != 1 {
+		t.Errorf("ValidationSchemaVersion = %d; update this test when bumping it", ValidationSchemaVersion)
+	}
+}
@@ -32,7 +32,7 @@ func AnomaliesCommand() *ffcli.Command {
 	from := fs.String("from", "", "Start date (YYYY-MM-DD); defaults to 7d ago")
 	to := fs.String("to", "", "End date (YYYY-MM-DD); defaults to today")
 	limit := fs.Int("limit", 50, "Maximum anomalies to return (1-1000)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
@@ -47,7 +47,7 @@ func CrashesQueryCommand() *ffcli.Command {
 	to := fs.String("to", "", "End date (ISO 8601, e.g. 2025-01-31)")
 	dimension := fs.String("dimension", "", "Dimension to group by (versionCode, deviceModel, etc.)")
 	metricType := fs.String("type", "crash", "Metric type: crash (default) or anr")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
 
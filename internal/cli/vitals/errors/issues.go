@@ -21,7 +21,7 @@ func IssuesCommand() *ffcli.Command {
 	orderBy := fs.String("order-by", "", "Order results (e.g. 'errorReportCount desc')")
 	pageSize := fs.Int64("page-size", 50, "Max results per page (1-1000)")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
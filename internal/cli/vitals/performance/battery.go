@@ -19,7 +19,7 @@ func BatteryCommand() *ffcli.Command {
 	to := fs.String("to", "", "End date (YYYY-MM-DD)")
 	dimension := fs.String("dimension", "", "Breakdown dimension (e.g. apiLevel, deviceModel, country)")
 	metricType := fs.String("type", "", "Battery metric type: wakeup or wakelock")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
 
@@ -18,7 +18,7 @@ func RenderingCommand() *ffcli.Command {
 	from := fs.String("from", "", "Start date (YYYY-MM-DD)")
 	to := fs.String("to", "", "End date (YYYY-MM-DD)")
 	dimension := fs.String("dimension", "", "Breakdown dimension (e.g. apiLevel, deviceModel, country)")
-	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown")
+	outputFlag := fs.String("output", "json", "Output format: json (default), table, markdown, yaml, template")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 	paginate := fs.Bool("paginate", false, "Fetch all pages")
 
@@ -379,6 +379,8 @@ func TestWorkflowRunCommand_MultiWorkflowRequiresSelection(t *testing.T) {
 
 func TestWorkflowRunCommand_ExplicitWorkflowSelection(t *testing.T) {
 	dir := t.TempDir()
+	// Resume state goes to .gplay/ under the working directory.
+	t.Chdir(dir)
 	workflowJSON := `{
 		"workflows": {
 			"publish": {
@@ -0,0 +1,27 @@
+package cmdtest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCompletion_BashCoversRegisteredCommands(t *testing.T) {
+	root := RootCommand("test")
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"completion", "bash"}); err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	})
+	if stderr != "" {
+		t.Errorf("expected no stderr, got %q", stderr)
+	}
+	for _, want := range []string{"offers", "subscriptions", "auth", "--package", "--list-scopes"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("bash completion should contain %q", want)
+		}
+	}
+}
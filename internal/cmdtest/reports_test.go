@@ -16,7 +16,7 @@ func TestReports_Help(t *testing.T) {
 	if !strings.Contains(combined, "reports") {
 		t.Errorf("help should mention reports, got: %q", combined)
 	}
-	for _, sub := range []string{"financial", "stats"} {
+	for _, sub := range []string{"financial", "stats", "download-url"} {
 		if !strings.Contains(combined, sub) {
 			t.Errorf("help should list %q subcommand, got: %q", sub, combined)
 		}
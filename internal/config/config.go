@@ -108,14 +108,18 @@ func parseDurationValue(raw string) (time.Duration, error) {
 	return time.Duration(seconds) * time.Second, nil
 }
 
-// Profile stores a named auth profile in config.json.
+// Profile stores a named auth profile in config.json. DefaultPackage and
+// DefaultDeveloper take precedence over the global defaults while the profile
+// is active.
 type Profile struct {
-	Name         string `json:"name"`
-	Type         string `json:"type"`
-	KeyPath      string `json:"key_path,omitempty"`
-	TokenPath    string `json:"token_path,omitempty"`
-	ClientID     string `json:"client_id,omitempty"`
-	ClientSecret string `json:"client_secret,omitempty"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	KeyPath          string `json:"key_path,omitempty"`
+	TokenPath        string `json:"token_path,omitempty"`
+	ClientID         string `json:"client_id,omitempty"`
+	ClientSecret     string `json:"client_secret,omitempty"`
+	DefaultPackage   string `json:"default_package,omitempty"`
+	DefaultDeveloper string `json:"default_developer,omitempty"`
 }
 
 // Config holds the application configuration.
@@ -123,12 +127,16 @@ type Config struct {
 	DefaultProfile       string        `json:"default_profile"`
 	Profiles             []Profile     `json:"profiles,omitempty"`
 	PackageName          string        `json:"package_name,omitempty"`
+	DeveloperID          string        `json:"developer_id,omitempty"`
 	Timeout              DurationValue `json:"timeout"`
 	TimeoutSeconds       DurationValue `json:"timeout_seconds"`
 	UploadTimeout        DurationValue `json:"upload_timeout"`
 	UploadTimeoutSeconds DurationValue `json:"upload_timeout_seconds"`
 	MaxRetries           int           `json:"max_retries,omitempty"`
 	RetryDelay           string        `json:"retry_delay,omitempty"`
+	MaxQPS               float64       `json:"max_qps,omitempty"`
+	DefaultOutput        string        `json:"default_output,omitempty"`
+	DefaultPretty        bool          `json:"default_pretty,omitempty"`
 	Debug                string        `json:"debug"`
 }
 
@@ -171,6 +179,14 @@ func (c *Config) Validate() error {
 	if c.MaxRetries < 0 || c.MaxRetries > maxConfigRetries {
 		return fmt.Errorf("max_retries must be between 0 and %d, got %d", maxConfigRetries, c.MaxRetries)
 	}
+	if c.MaxQPS < 0 {
+		return fmt.Errorf("max_qps must be 0 (unlimited) or greater, got %g", c.MaxQPS)
+	}
+	switch strings.ToLower(strings.TrimSpace(c.DefaultOutput)) {
+	case "", "json", "table", "markdown", "md", "yaml", "yml":
+	default:
+		return fmt.Errorf("default_output must be one of json, table, markdown, yaml, got %q", c.DefaultOutput)
+	}
 
 	return nil
 }
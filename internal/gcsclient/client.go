@@ -37,6 +37,23 @@ type Service struct {
 	Cfg *config.Config
 }
 
+// ParseBucket resolves a Play Console report bucket ID or URI into a GCS
+// bucket name. Accepts:
+//   - Full GCS URI: "gs://pubsite_prod_rev_12345/earnings/" → "pubsite_prod_rev_12345"
+//   - Full GCS URI: "gs://pubsite_prod_12345/" → "pubsite_prod_12345"
+//   - Plain numeric ID: "12345" → "pubsite_prod_rev_12345" (default prefix)
+func ParseBucket(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "gs://") {
+		raw = strings.TrimPrefix(raw, "gs://")
+		if idx := strings.Index(raw, "/"); idx >= 0 {
+			raw = raw[:idx]
+		}
+		return raw
+	}
+	return "pubsite_prod_rev_" + raw
+}
+
 // NewService creates an authenticated GCS service.
 func NewService(ctx context.Context) (*Service, error) {
 	cfg, err := config.Load()
@@ -109,6 +126,7 @@ func (s *Service) DownloadObject(ctx context.Context, bucket, object string) (io
 }
 
 func newHTTPClient(ctx context.Context, cfg *config.Config) (*http.Client, error) {
+	ctx = withHTTPTransport(ctx)
 	creds, err := resolveCredentials(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -312,3 +330,10 @@ func redirectURIFromEnv() string {
 	}
 	return "urn:ietf:wg:oauth:2.0:oob"
 }
+
+// withHTTPTransport makes oauth2 build its client, including token refreshes,
+// on a transport that honors --http-timeout and --insecure-skip-verify.
+func withHTTPTransport(ctx context.Context) context.Context {
+	base := &http.Client{Transport: shared.NewAPITransport()}
+	return context.WithValue(ctx, oauth2.HTTPClient, base)
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PrintYAML renders v as YAML on stdout.
+func PrintYAML(v interface{}) error {
+	return RenderYAML(os.Stdout, v)
+}
+
+// RenderYAML writes v as YAML. The value is first round-tripped through
+// encoding/json so that keys follow the JSON tags of the Google API structs
+// (e.g. "productId") instead of Go field names.
+func RenderYAML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(yamlValue(generic))
+	if err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// yamlValue converts json.Number leaves into int64/float64 so they are not
+// emitted as quoted strings.
+func yamlValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = yamlValue(val)
+		}
+		return t
+	case []interface{}:
+		for i, val := range t {
+			t[i] = yamlValue(val)
+		}
+		return t
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
+		return t.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/androidpublisher/v3"
+)
+
+func TestRenderYAML_UsesJSONFieldNames(t *testing.T) {
+	var buf bytes.Buffer
+	sub := &androidpublisher.Subscription{
+		PackageName: "com.example.app",
+		ProductId:   "premium",
+		Listings: []*androidpublisher.SubscriptionListing{
+			{LanguageCode: "en-US", Title: "Premium"},
+		},
+	}
+	if err := RenderYAML(&buf, sub); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"packageName: com.example.app", "productId: premium", "languageCode: en-US", "title: Premium"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"PackageName", "ProductId", "LanguageCode"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output should not contain Go field name %q, got:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestRenderYAML_Slice(t *testing.T) {
+	var buf bytes.Buffer
+	items := []map[string]string{{"sku": "coins_100"}, {"sku": "coins_500"}}
+	if err := RenderYAML(&buf, items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "- sku: coins_100\n- sku: coins_500\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRenderYAML_EmptySlice(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderYAML(&buf, []string{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("expected [], got %q", buf.String())
+	}
+}
+
+func TestRenderYAML_NumbersUnquoted(t *testing.T) {
+	var buf bytes.Buffer
+	data := map[string]interface{}{"versionCode": 1000000, "ratio": 0.5, "id": "123"}
+	if err := RenderYAML(&buf, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"versionCode: 1000000", "ratio: 0.5", `id: "123"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
@@ -24,6 +24,8 @@ type Service struct {
 
 // NewService creates an authenticated Android Publisher service.
 func NewService(ctx context.Context) (*Service, error) {
+	defer shared.StartSpan(ctx, "auth")()
+
 	cfg, err := config.Load()
 	if err != nil && !errors.Is(err, config.ErrNotFound) {
 		return nil, shared.NewActionableError(
@@ -37,6 +39,8 @@ func NewService(ctx context.Context) (*Service, error) {
 		return nil, err
 	}
 
+	shared.WithRateLimit(client, shared.ResolveQPS(cfg))
+
 	// Wrap transport with DryRunTransport when dry-run is active.
 	if shared.IsDryRun(ctx) {
 		client.Transport = &shared.DryRunTransport{
@@ -49,6 +53,9 @@ func NewService(ctx context.Context) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	if base := shared.APIBaseURL(); base != "" {
+		api.BasePath = base
+	}
 	return &Service{API: api, Cfg: cfg}, nil
 }
 
@@ -66,9 +73,17 @@ func NewServiceWithClient(ctx context.Context, client *http.Client, basePath str
 }
 
 func newHTTPClient(ctx context.Context, cfg *config.Config) (*http.Client, error) {
+	ctx = withHTTPTransport(ctx)
 	creds, err := resolveCredentials(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 	return oauth2.NewClient(ctx, creds.TokenSource), nil
 }
+
+// withHTTPTransport makes oauth2 build its client, including token refreshes,
+// on a transport that honors --http-timeout and --insecure-skip-verify.
+func withHTTPTransport(ctx context.Context) context.Context {
+	base := &http.Client{Transport: shared.NewAPITransport()}
+	return context.WithValue(ctx, oauth2.HTTPClient, base)
+}
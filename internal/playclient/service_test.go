@@ -0,0 +1,27 @@
+package playclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestWithHTTPTransport_AppliesHTTPTimeout(t *testing.T) {
+	t.Setenv("GPLAY_HTTP_TIMEOUT", "5s")
+
+	client := oauth2.NewClient(withHTTPTransport(context.Background()), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}))
+	oauthTransport, ok := client.Transport.(*oauth2.Transport)
+	if !ok {
+		t.Fatalf("expected *oauth2.Transport, got %T", client.Transport)
+	}
+	base, ok := oauthTransport.Base.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport base, got %T", oauthTransport.Base)
+	}
+	if base.ResponseHeaderTimeout != 5*time.Second || base.TLSHandshakeTimeout != 5*time.Second {
+		t.Fatalf("transport timeouts = %v/%v, want 5s", base.ResponseHeaderTimeout, base.TLSHandshakeTimeout)
+	}
+}
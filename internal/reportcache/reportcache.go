@@ -0,0 +1,125 @@
+// Package reportcache stores GCS report bucket listings on disk so repeated
+// `gplay reports ... list` runs can skip re-listing the bucket.
+package reportcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/cli/shared"
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+)
+
+const (
+	// DirEnvVar overrides the cache directory.
+	DirEnvVar = "GPLAY_CACHE_DIR"
+
+	defaultDirName = ".gplay"
+	cacheDirName   = "cache"
+	reportsDirName = "reports"
+)
+
+// Cache is an on-disk store of report listings, one file per bucket and
+// prefix. The bucket identifies the developer account and the prefix the
+// report type.
+type Cache struct {
+	Dir string
+	Now func() time.Time
+}
+
+// entry is the file format of one cached listing.
+type entry struct {
+	Bucket    string                 `json:"bucket"`
+	Prefix    string                 `json:"prefix"`
+	FetchedAt time.Time              `json:"fetchedAt"`
+	Objects   []gcsclient.ObjectInfo `json:"objects"`
+}
+
+// Dir returns the cache directory, honoring GPLAY_CACHE_DIR.
+func Dir() (string, error) {
+	if env := strings.TrimSpace(os.Getenv(DirEnvVar)); env != "" {
+		return filepath.Clean(env), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, defaultDirName, cacheDirName), nil
+}
+
+// Open returns the cache at Dir using the wall clock.
+func Open() (*Cache, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: dir, Now: time.Now}, nil
+}
+
+// Get returns the listing cached for bucket and prefix when it is younger
+// than ttl. A missing, unreadable, or expired entry is a miss.
+func (c *Cache) Get(bucket, prefix string, ttl time.Duration) ([]gcsclient.ObjectInfo, bool) {
+	data, err := os.ReadFile(c.entryPath(bucket, prefix))
+	if err != nil {
+		return nil, false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+	if e.Bucket != bucket || e.Prefix != prefix || c.now().Sub(e.FetchedAt) >= ttl {
+		return nil, false
+	}
+	return e.Objects, true
+}
+
+// Put stores the listing for bucket and prefix, replacing any earlier one.
+func (c *Cache) Put(bucket, prefix string, objects []gcsclient.ObjectInfo) error {
+	data, err := json.Marshal(entry{Bucket: bucket, Prefix: prefix, FetchedAt: c.now().UTC(), Objects: objects})
+	if err != nil {
+		return err
+	}
+	return shared.AtomicWrite(c.entryPath(bucket, prefix), data, 0o600)
+}
+
+// Clear removes every cached listing and returns how many were removed.
+func (c *Cache) Clear() (int, error) {
+	dir := filepath.Join(c.Dir, reportsDirName)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("read cache directory: %w", err)
+	}
+	removed := 0
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			return removed, fmt.Errorf("remove cache entry: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (c *Cache) entryPath(bucket, prefix string) string {
+	sum := sha256.Sum256([]byte(bucket + "\x00" + prefix))
+	return filepath.Join(c.Dir, reportsDirName, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *Cache) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
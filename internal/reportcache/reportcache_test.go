@@ -0,0 +1,53 @@
+package reportcache
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tamtom/play-console-cli/internal/gcsclient"
+)
+
+func TestCache_GetPutAndExpiry(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := &Cache{Dir: t.TempDir(), Now: func() time.Time { return now }}
+	objects := []gcsclient.ObjectInfo{{Name: "stats/installs/a.csv", Size: 10, Updated: "2025-02-01T00:00:00Z"}}
+
+	if _, ok := cache.Get("bucket", "stats/installs/", time.Hour); ok {
+		t.Fatal("expected a miss before Put")
+	}
+	if err := cache.Put("bucket", "stats/installs/", objects); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := cache.Get("bucket", "stats/installs/", time.Hour)
+	if !ok || !reflect.DeepEqual(got, objects) {
+		t.Fatalf("Get = %v, %v; want %v, true", got, ok, objects)
+	}
+	if _, ok := cache.Get("bucket", "stats/ratings/", time.Hour); ok {
+		t.Fatal("expected a miss for another prefix")
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := cache.Get("bucket", "stats/installs/", time.Hour); ok {
+		t.Fatal("expected a miss once the entry reaches the TTL")
+	}
+}
+
+func TestCache_Clear(t *testing.T) {
+	cache := &Cache{Dir: t.TempDir()}
+	if removed, err := cache.Clear(); err != nil || removed != 0 {
+		t.Fatalf("Clear on empty cache = %d, %v", removed, err)
+	}
+	for _, prefix := range []string{"earnings/", "sales/"} {
+		if err := cache.Put("bucket", prefix, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	removed, err := cache.Clear()
+	if err != nil || removed != 2 {
+		t.Fatalf("Clear = %d, %v; want 2, nil", removed, err)
+	}
+	if _, ok := cache.Get("bucket", "earnings/", time.Hour); ok {
+		t.Fatal("expected a miss after Clear")
+	}
+}
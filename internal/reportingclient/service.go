@@ -37,6 +37,7 @@ func NewService(ctx context.Context) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	shared.WithRateLimit(client, shared.ResolveQPS(cfg))
 	api, err := playdeveloperreporting.NewService(ctx, option.WithHTTPClient(client))
 	if err != nil {
 		return nil, err
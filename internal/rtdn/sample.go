@@ -0,0 +1,152 @@
+package rtdn
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DeveloperNotification is the JSON Play publishes as message.data, before
+// base64 encoding.
+type DeveloperNotification struct {
+	Version                    string                      `json:"version"`
+	PackageName                string                      `json:"packageName"`
+	EventTimeMillis            string                      `json:"eventTimeMillis"`
+	SubscriptionNotification   *SubscriptionNotification   `json:"subscriptionNotification,omitempty"`
+	OneTimeProductNotification *OneTimeProductNotification `json:"oneTimeProductNotification,omitempty"`
+	VoidedPurchaseNotification *VoidedPurchaseNotification `json:"voidedPurchaseNotification,omitempty"`
+}
+
+// SubscriptionNotification is the subscriptionNotification field.
+type SubscriptionNotification struct {
+	Version          string `json:"version"`
+	NotificationType int    `json:"notificationType"`
+	PurchaseToken    string `json:"purchaseToken"`
+	SubscriptionID   string `json:"subscriptionId"`
+}
+
+// OneTimeProductNotification is the oneTimeProductNotification field.
+type OneTimeProductNotification struct {
+	Version          string `json:"version"`
+	NotificationType int    `json:"notificationType"`
+	PurchaseToken    string `json:"purchaseToken"`
+	SKU              string `json:"sku"`
+}
+
+// VoidedPurchaseNotification is the voidedPurchaseNotification field.
+type VoidedPurchaseNotification struct {
+	PurchaseToken string `json:"purchaseToken"`
+	OrderID       string `json:"orderId"`
+	ProductType   int    `json:"productType"`
+	RefundType    int    `json:"refundType"`
+}
+
+// Voided purchase product and refund types, per Play Billing docs.
+const (
+	VoidedProductTypeSubscription = 1
+	VoidedProductTypeOneTime      = 2
+	VoidedRefundTypeFull          = 1
+)
+
+// SampleOptions describes a sample notification. Zero values get defaults.
+type SampleOptions struct {
+	Kind             NotificationKind
+	PackageName      string
+	ProductID        string
+	PurchaseToken    string
+	NotificationType int
+	Time             time.Time
+}
+
+// NewSampleNotification builds a DeveloperNotification of the given kind
+// (subscription, one-time product, or voided purchase). NotificationType
+// defaults to SUBSCRIPTION_PURCHASED or ONE_TIME_PRODUCT_PURCHASED and must
+// be a documented type; voided purchases have none.
+func NewSampleNotification(opts SampleOptions) (*DeveloperNotification, error) {
+	if opts.PackageName == "" {
+		opts.PackageName = "com.example.app"
+	}
+	if opts.ProductID == "" {
+		opts.ProductID = "sample_product"
+	}
+	if opts.PurchaseToken == "" {
+		opts.PurchaseToken = "sample-purchase-token"
+	}
+	if opts.Time.IsZero() {
+		opts.Time = time.Now()
+	}
+	n := &DeveloperNotification{
+		Version:         "1.0",
+		PackageName:     opts.PackageName,
+		EventTimeMillis: strconv.FormatInt(opts.Time.UnixMilli(), 10),
+	}
+	switch opts.Kind {
+	case KindSubscription:
+		typ, err := sampleNotificationType(opts.NotificationType, 4, subscriptionNames)
+		if err != nil {
+			return nil, err
+		}
+		n.SubscriptionNotification = &SubscriptionNotification{
+			Version:          "1.0",
+			NotificationType: typ,
+			PurchaseToken:    opts.PurchaseToken,
+			SubscriptionID:   opts.ProductID,
+		}
+	case KindOneTime:
+		typ, err := sampleNotificationType(opts.NotificationType, 1, oneTimeNames)
+		if err != nil {
+			return nil, err
+		}
+		n.OneTimeProductNotification = &OneTimeProductNotification{
+			Version:          "1.0",
+			NotificationType: typ,
+			PurchaseToken:    opts.PurchaseToken,
+			SKU:              opts.ProductID,
+		}
+	case KindVoided:
+		if opts.NotificationType != 0 {
+			return nil, fmt.Errorf("voided purchase notifications have no notification type")
+		}
+		n.VoidedPurchaseNotification = &VoidedPurchaseNotification{
+			PurchaseToken: opts.PurchaseToken,
+			OrderID:       "GPA.0000-0000-0000-00000",
+			ProductType:   VoidedProductTypeSubscription,
+			RefundType:    VoidedRefundTypeFull,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported notification kind %q", opts.Kind)
+	}
+	return n, nil
+}
+
+func sampleNotificationType(value, fallback int, names map[int]string) (int, error) {
+	if value == 0 {
+		return fallback, nil
+	}
+	if _, ok := names[value]; !ok {
+		return 0, fmt.Errorf("unknown notification type %d", value)
+	}
+	return value, nil
+}
+
+// NewEnvelope wraps base64-encoded notification data in the envelope Pub/Sub
+// POSTs to a push endpoint.
+func NewEnvelope(data string, publishTime time.Time) *PubsubEnvelope {
+	env := &PubsubEnvelope{Subscription: "projects/sample-project/subscriptions/sample-subscription"}
+	env.Message.Data = data
+	env.Message.MessageID = strconv.FormatInt(publishTime.UnixNano(), 10)
+	env.Message.PublishTime = publishTime.UTC().Format(time.RFC3339Nano)
+	return env
+}
+
+// EncodeNotification returns n as base64-encoded JSON, the form it takes in
+// message.data.
+func EncodeNotification(n *DeveloperNotification) (string, error) {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
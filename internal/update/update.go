@@ -1,7 +1,11 @@
 package update
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -24,6 +28,10 @@ const (
 
 	// CheckInterval is how often to check for updates
 	CheckInterval = 24 * time.Hour
+
+	// ChecksumsAssetName is the release asset listing the SHA-256 of every
+	// binary, in sha256sum format.
+	ChecksumsAssetName = "checksums.txt"
 )
 
 // Release represents a GitHub release
@@ -50,6 +58,8 @@ type UpdateInfo struct {
 	LatestVersion  string
 	ReleaseURL     string
 	DownloadURL    string
+	ChecksumURL    string
+	AssetName      string
 	IsNewer        bool
 }
 
@@ -137,11 +147,13 @@ func CheckForUpdate(ctx context.Context, opts Options) (*UpdateInfo, error) {
 	}
 
 	// Find the appropriate asset for this platform
-	assetName := getBinaryName()
+	info.AssetName = getBinaryName()
 	for _, asset := range release.Assets {
-		if asset.Name == assetName {
+		switch asset.Name {
+		case info.AssetName:
 			info.DownloadURL = asset.BrowserDownloadURL
-			break
+		case ChecksumsAssetName:
+			info.ChecksumURL = asset.BrowserDownloadURL
 		}
 	}
 
@@ -188,9 +200,12 @@ func getBinaryName() string {
 
 // compareVersions compares two semver versions
 // Returns: 1 if a > b, -1 if a < b, 0 if equal
+// A pre-release sorts before the release it precedes (1.2.0-rc.1 < 1.2.0).
 func compareVersions(a, b string) int {
-	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
-	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	coreA, preA, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	coreB, preB, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+	partsA := strings.Split(coreA, ".")
+	partsB := strings.Split(coreB, ".")
 
 	for i := 0; i < 3; i++ {
 		var numA, numB int
@@ -209,14 +224,29 @@ func compareVersions(a, b string) int {
 		}
 	}
 
-	return 0
+	switch {
+	case preA == "" && preB != "":
+		return 1
+	case preA != "" && preB == "":
+		return -1
+	}
+	return strings.Compare(preA, preB)
 }
 
-// DownloadUpdate downloads the latest binary
+// DownloadUpdate downloads the latest binary and verifies it against the
+// release's checksums.txt. The binary is rejected if the release has no
+// checksums or they don't match.
 func DownloadUpdate(ctx context.Context, info *UpdateInfo) (string, error) {
 	if info.DownloadURL == "" {
 		return "", fmt.Errorf("no download URL available for this platform")
 	}
+	if info.ChecksumURL == "" {
+		return "", fmt.Errorf("release has no %s; refusing to install an unverified binary", ChecksumsAssetName)
+	}
+	checksums, err := fetch(ctx, info.ChecksumURL, 30*time.Second)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", ChecksumsAssetName, err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", info.DownloadURL, nil)
 	if err != nil {
@@ -247,9 +277,73 @@ func DownloadUpdate(ctx context.Context, info *UpdateInfo) (string, error) {
 	}
 
 	tmpFile.Close()
+
+	assetName := info.AssetName
+	if assetName == "" {
+		assetName = getBinaryName()
+	}
+	if err := VerifyChecksum(tmpFile.Name(), checksums, assetName); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return "", err
+	}
 	return tmpFile.Name(), nil
 }
 
+// fetch returns the body of a GET request to url.
+func fetch(ctx context.Context, url string, timeout time.Duration) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// VerifyChecksum checks that the SHA-256 of the file at path matches the
+// entry for assetName in checksums, which uses the sha256sum format
+// ("<hex>  <name>", or "<hex> *<name>" for binary mode).
+func VerifyChecksum(path string, checksums []byte, assetName string) error {
+	var expected string
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == assetName {
+			expected = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading %s: %w", ChecksumsAssetName, err)
+	}
+	if expected == "" {
+		return fmt.Errorf("%s has no entry for %s", ChecksumsAssetName, assetName)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", assetName, expected, actual)
+	}
+	return nil
+}
+
 // ApplyUpdate replaces the current binary with the new one
 func ApplyUpdate(newBinaryPath string) error {
 	currentBinary, err := os.Executable()
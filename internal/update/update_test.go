@@ -0,0 +1,139 @@
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.3.0", "1.2.9", 1},
+		{"1.2.9", "1.3.0", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.2", "1.2.0", 0},
+		{"1.2.0", "1.2.0-rc.1", 1},
+		{"1.2.0-rc.1", "1.2.0", -1},
+		{"1.2.0-rc.2", "1.2.0-rc.1", 1},
+		{"0.1.0", "dev", 1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func writeAsset(t *testing.T, content string) (path, sum string) {
+	t.Helper()
+	path = filepath.Join(t.TempDir(), "gplay-linux-amd64")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256([]byte(content))
+	return path, hex.EncodeToString(h[:])
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	path, sum := writeAsset(t, "binary contents")
+	other := strings.Repeat("0", 64)
+
+	tests := []struct {
+		name      string
+		checksums string
+		wantErr   string
+	}{
+		{"text mode", other + "  gplay-darwin-arm64\n" + sum + "  gplay-linux-amd64\n", ""},
+		{"binary mode", sum + " *gplay-linux-amd64\n", ""},
+		{"uppercase hex", strings.ToUpper(sum) + "  gplay-linux-amd64\n", ""},
+		{"mismatch", other + "  gplay-linux-amd64\n", "checksum mismatch for gplay-linux-amd64"},
+		{"missing entry", sum + "  gplay-linux-arm64\n", "checksums.txt has no entry for gplay-linux-amd64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyChecksum(path, []byte(tt.checksums), "gplay-linux-amd64")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDownloadUpdate_VerifiesChecksum(t *testing.T) {
+	const binary = "new gplay binary"
+	h := sha256.Sum256([]byte(binary))
+	sum := hex.EncodeToString(h[:])
+
+	tests := []struct {
+		name      string
+		checksums string
+		wantErr   string
+	}{
+		{"match", sum + "  gplay-test\n", ""},
+		{"mismatch", strings.Repeat("a", 64) + "  gplay-test\n", "checksum mismatch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/gplay-test":
+					_, _ = w.Write([]byte(binary))
+				case "/checksums.txt":
+					_, _ = w.Write([]byte(tt.checksums))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			info := &UpdateInfo{
+				AssetName:   "gplay-test",
+				DownloadURL: server.URL + "/gplay-test",
+				ChecksumURL: server.URL + "/checksums.txt",
+			}
+			path, err := DownloadUpdate(context.Background(), info)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer os.Remove(path)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != binary {
+				t.Errorf("downloaded %q, want %q", data, binary)
+			}
+		})
+	}
+}
+
+func TestDownloadUpdate_RequiresChecksums(t *testing.T) {
+	_, err := DownloadUpdate(context.Background(), &UpdateInfo{DownloadURL: "http://127.0.0.1:0/gplay"})
+	if err == nil || !strings.Contains(err.Error(), "refusing to install an unverified binary") {
+		t.Fatalf("expected missing checksums error, got %v", err)
+	}
+}
@@ -16,7 +16,7 @@ func TestExecute_SimpleEchoStep(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, nil, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, nil, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -41,7 +41,7 @@ func TestExecute_FailingStep(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, nil, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, nil, testExecuteOptions(t))
 	if err == nil {
 		t.Fatal("expected error for failing step")
 	}
@@ -63,7 +63,7 @@ func TestExecute_ContinueOnError(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, nil, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, nil, testExecuteOptions(t))
 	// ContinueOn=error means the workflow continues but still reports failure.
 	if err == nil {
 		t.Fatal("expected error from failing step")
@@ -90,8 +90,9 @@ func TestExecute_DryRun(t *testing.T) {
 	}
 
 	result, err := Execute(context.Background(), w, nil, ExecuteOptions{
-		DryRun: true,
-		Stderr: &stderr,
+		DryRun:    true,
+		Stderr:    &stderr,
+		StatePath: testStatePath(t),
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -119,7 +120,7 @@ func TestExecute_ConditionSkipped(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, map[string]string{}, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, map[string]string{}, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -139,7 +140,7 @@ func TestExecute_ConditionMet(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, map[string]string{"DEPLOY_ENABLED": "true"}, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, map[string]string{"DEPLOY_ENABLED": "true"}, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -160,7 +161,7 @@ func TestExecute_VariableInterpolation(t *testing.T) {
 	}
 
 	params := map[string]string{"GREETING": "hello-world"}
-	result, err := Execute(context.Background(), w, params, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, params, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -180,7 +181,7 @@ func TestExecute_BeforeAll(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, nil, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, nil, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -203,7 +204,7 @@ func TestExecute_AfterAllRunsOnFailure(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, nil, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, nil, testExecuteOptions(t))
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -233,7 +234,7 @@ func TestExecute_OnErrorRunsOnFailure(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, nil, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, nil, testExecuteOptions(t))
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -262,7 +263,7 @@ func TestExecute_RequiredParamMissing(t *testing.T) {
 		},
 	}
 
-	_, err := Execute(context.Background(), w, map[string]string{}, ExecuteOptions{})
+	_, err := Execute(context.Background(), w, map[string]string{}, testExecuteOptions(t))
 	if err == nil {
 		t.Fatal("expected error for missing required param")
 	}
@@ -282,7 +283,7 @@ func TestExecute_ParamDefaults(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, map[string]string{}, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, map[string]string{}, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -302,7 +303,7 @@ func TestExecute_ParamOverridesDefault(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, map[string]string{"ENV": "production"}, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, map[string]string{"ENV": "production"}, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -320,7 +321,7 @@ func TestExecute_EnvMerge(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(context.Background(), w, nil, ExecuteOptions{})
+	result, err := Execute(context.Background(), w, nil, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -340,7 +341,7 @@ func TestExecute_ContextCancelled(t *testing.T) {
 		},
 	}
 
-	result, err := Execute(ctx, w, nil, ExecuteOptions{})
+	result, err := Execute(ctx, w, nil, testExecuteOptions(t))
 	if err == nil {
 		t.Fatal("expected error for canceled context")
 	}
@@ -372,7 +373,7 @@ func TestExecuteDefinition_ReferencedWorkflowWithOutputs(t *testing.T) {
 		},
 	}
 
-	result, err := ExecuteDefinition(context.Background(), def, "deploy", nil, ExecuteOptions{})
+	result, err := ExecuteDefinition(context.Background(), def, "deploy", nil, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -419,7 +420,7 @@ func TestExecuteDefinition_WorkflowWithInterpolatedParams(t *testing.T) {
 		},
 	}
 
-	result, err := ExecuteDefinition(context.Background(), def, "deploy", nil, ExecuteOptions{})
+	result, err := ExecuteDefinition(context.Background(), def, "deploy", nil, testExecuteOptions(t))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -480,3 +481,14 @@ func TestExecuteDefinition_ResumeUsesNestedPaths(t *testing.T) {
 		t.Fatalf("expected nested resume skips, got %#v", skipped)
 	}
 }
+
+// testStatePath keeps resume state out of the source tree.
+func testStatePath(t *testing.T) string {
+	t.Helper()
+	return filepath.Join(t.TempDir(), "workflow-state.json")
+}
+
+func testExecuteOptions(t *testing.T) ExecuteOptions {
+	t.Helper()
+	return ExecuteOptions{StatePath: testStatePath(t)}
+}